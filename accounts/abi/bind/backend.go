@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 )
 
 var (
@@ -36,6 +37,10 @@ var (
 	// on a backend that doesn't implement PendingContractCaller.
 	ErrNoPendingState = errors.New("backend does not support pending state")
 
+	// ErrNoBlobTransactor is raised when attempting to send a blob transaction
+	// via a backend that doesn't implement BlobTransactor.
+	ErrNoBlobTransactor = errors.New("backend does not support blob transactions")
+
 	// ErrNoCodeAfterDeploy is returned by WaitDeployed if contract creation leaves
 	// an empty contract behind.
 	ErrNoCodeAfterDeploy = errors.New("no contract code after deployment")
@@ -98,6 +103,15 @@ type ContractTransactor interface {
 	SendTransaction(ctx context.Context, tx *types.Transaction) error
 }
 
+// BlobTransactor defines the methods needed to send blob transactions. Transact will
+// try to discover this interface when blobs are attached to the transaction. If the
+// backend does not support blob transactions, Transact returns ErrNoBlobTransactor.
+type BlobTransactor interface {
+	// SendBlobTransaction injects the blob transaction into the pending pool for
+	// execution, together with the blobs it references.
+	SendBlobTransaction(ctx context.Context, tx *types.Transaction, blobs []kzg.Blob) error
+}
+
 // ContractFilterer defines the methods needed to access log events using one-off
 // queries or continuous event subscriptions.
 type ContractFilterer interface {
@@ -27,8 +27,10 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/event"
 )
 
@@ -57,6 +59,9 @@ type TransactOpts struct {
 	GasTipCap *big.Int // Gas priority fee cap to use for the 1559 transaction execution (nil = gas price oracle)
 	GasLimit  uint64   // Gas limit to set for the transaction execution (0 = estimate)
 
+	Blobs            []kzg.Blob // Blobs to attach, turning the transaction into a blob transaction (nil = no blobs)
+	MaxFeePerDataGas *big.Int   // Data gas fee cap to use for the blob transaction execution (nil = derived from the head)
+
 	Context context.Context // Network context to support cancellation and timeouts (nil = no timeout)
 
 	NoSend bool // Do all transact steps but do not send the transaction
@@ -283,6 +288,37 @@ func (c *BoundContract) createDynamicTx(opts *TransactOpts, contract *common.Add
 	return types.NewTx(baseTx), nil
 }
 
+func (c *BoundContract) createBlobTx(opts *TransactOpts, contract *common.Address, input []byte, head *types.Header) (*types.Transaction, error) {
+	// Fill in the execution fields the same way as for dynamic fee transactions
+	dynamicTx, err := c.createDynamicTx(opts, contract, input, head)
+	if err != nil {
+		return nil, err
+	}
+	// Estimate DataGasFeeCap
+	dataGasFeeCap := opts.MaxFeePerDataGas
+	if dataGasFeeCap == nil {
+		// The chain config of the remote node is unknown, assume the default blob parameters
+		dataGasFeeCap = new(big.Int).Mul(misc.GetDataGasPrice(nil, head.ExcessDataGas), big.NewInt(2))
+	}
+	// Compute the commitments to the blobs, which the transaction references
+	sidecar, err := types.NewBlobTxSidecar(opts.Blobs)
+	if err != nil {
+		return nil, err
+	}
+	baseTx := &types.BlobTx{
+		To:                  contract,
+		Nonce:               dynamicTx.Nonce(),
+		GasFeeCap:           dynamicTx.GasFeeCap(),
+		GasTipCap:           dynamicTx.GasTipCap(),
+		Gas:                 dynamicTx.Gas(),
+		Value:               dynamicTx.Value(),
+		Data:                input,
+		MaxFeePerDataGas:    dataGasFeeCap,
+		BlobVersionedHashes: sidecar.BlobHashes(),
+	}
+	return types.NewTx(baseTx).WithBlobTxSidecar(sidecar), nil
+}
+
 func (c *BoundContract) createLegacyTx(opts *TransactOpts, contract *common.Address, input []byte) (*types.Transaction, error) {
 	if opts.GasFeeCap != nil || opts.GasTipCap != nil {
 		return nil, errors.New("maxFeePerGas or maxPriorityFeePerGas specified but london is not active yet")
@@ -366,7 +402,18 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 		rawTx *types.Transaction
 		err   error
 	)
-	if opts.GasPrice != nil {
+	if len(opts.Blobs) > 0 {
+		if opts.GasPrice != nil {
+			return nil, errors.New("gasPrice specified for blob transaction")
+		}
+		if head, errHead := c.transactor.HeaderByNumber(ensureContext(opts.Context), nil); errHead != nil {
+			return nil, errHead
+		} else if head.BaseFee != nil {
+			rawTx, err = c.createBlobTx(opts, contract, input, head)
+		} else {
+			return nil, errors.New("blobs specified but london is not active yet")
+		}
+	} else if opts.GasPrice != nil {
 		rawTx, err = c.createLegacyTx(opts, contract, input)
 	} else {
 		// Only query for basefee if gasPrice not specified
@@ -393,6 +440,16 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 	if opts.NoSend {
 		return signedTx, nil
 	}
+	if len(opts.Blobs) > 0 {
+		transactor, ok := c.transactor.(BlobTransactor)
+		if !ok {
+			return nil, ErrNoBlobTransactor
+		}
+		if err := transactor.SendBlobTransaction(ensureContext(opts.Context), signedTx, opts.Blobs); err != nil {
+			return nil, err
+		}
+		return signedTx, nil
+	}
 	if err := c.transactor.SendTransaction(ensureContext(opts.Context), signedTx); err != nil {
 		return nil, err
 	}
@@ -28,8 +28,11 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 )
@@ -38,6 +41,7 @@ func mockSign(addr common.Address, tx *types.Transaction) (*types.Transaction, e
 
 type mockTransactor struct {
 	baseFee                *big.Int
+	excessDataGas          *big.Int
 	gasTipCap              *big.Int
 	gasPrice               *big.Int
 	suggestGasTipCapCalled bool
@@ -45,7 +49,7 @@ type mockTransactor struct {
 }
 
 func (mt *mockTransactor) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	return &types.Header{BaseFee: mt.baseFee}, nil
+	return &types.Header{BaseFee: mt.baseFee, ExcessDataGas: mt.excessDataGas}, nil
 }
 
 func (mt *mockTransactor) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
@@ -74,6 +78,17 @@ func (mt *mockTransactor) SendTransaction(ctx context.Context, tx *types.Transac
 	return nil
 }
 
+type mockBlobTransactor struct {
+	mockTransactor
+	sentTx    *types.Transaction
+	sentBlobs []kzg.Blob
+}
+
+func (mt *mockBlobTransactor) SendBlobTransaction(ctx context.Context, tx *types.Transaction, blobs []kzg.Blob) error {
+	mt.sentTx, mt.sentBlobs = tx, blobs
+	return nil
+}
+
 type mockCaller struct {
 	codeAtBlockNumber         *big.Int
 	callContractBlockNumber   *big.Int
@@ -312,6 +327,48 @@ func TestTransactGasFee(t *testing.T) {
 	assert.True(mt.suggestGasPriceCalled)
 }
 
+func TestTransactBlobs(t *testing.T) {
+	assert := assert.New(t)
+
+	blobs := []kzg.Blob{{}}
+	sidecar, err := types.NewBlobTxSidecar(blobs)
+	assert.Nil(err)
+
+	// Backends without blob support must be rejected
+	mt := &mockTransactor{baseFee: big.NewInt(100), gasTipCap: big.NewInt(5)}
+	bc := bind.NewBoundContract(common.Address{}, abi.ABI{}, nil, mt, nil)
+	_, err = bc.Transact(&bind.TransactOpts{Signer: mockSign, Blobs: blobs}, "")
+	assert.Equal(bind.ErrNoBlobTransactor, err)
+
+	// Blob transactions can't be priced the legacy way
+	_, err = bc.Transact(&bind.TransactOpts{Signer: mockSign, Blobs: blobs, GasPrice: big.NewInt(5)}, "")
+	assert.NotNil(err)
+
+	// MaxFeePerDataGas
+	// When opts.MaxFeePerDataGas is nil
+	excess := big.NewInt(10 * params.DataGasPriceUpdateFraction)
+	bt := &mockBlobTransactor{mockTransactor: mockTransactor{baseFee: big.NewInt(100), gasTipCap: big.NewInt(5), excessDataGas: excess}}
+	bc = bind.NewBoundContract(common.Address{}, abi.ABI{}, nil, bt, nil)
+	opts := &bind.TransactOpts{Signer: mockSign, Blobs: blobs}
+	tx, err := bc.Transact(opts, "")
+	assert.Nil(err)
+	assert.Equal(uint8(types.BlobTxType), tx.Type())
+	assert.Equal(big.NewInt(5), tx.GasTipCap())
+	assert.Equal(big.NewInt(205), tx.GasFeeCap())
+	assert.Equal(new(big.Int).Mul(misc.GetDataGasPrice(nil, excess), big.NewInt(2)), tx.MaxFeePerDataGas())
+	assert.Equal(sidecar.BlobHashes(), tx.DataHashes())
+	assert.Equal(sidecar, tx.BlobTxSidecar())
+	assert.Nil(opts.MaxFeePerDataGas)
+	assert.Equal(tx, bt.sentTx)
+	assert.Equal(blobs, bt.sentBlobs)
+
+	// When opts.MaxFeePerDataGas is set
+	opts.MaxFeePerDataGas = big.NewInt(42)
+	tx, err = bc.Transact(opts, "")
+	assert.Nil(err)
+	assert.Equal(big.NewInt(42), tx.MaxFeePerDataGas())
+}
+
 func unpackAndCheck(t *testing.T, bc *bind.BoundContract, expected map[string]interface{}, mockLog types.Log) {
 	received := make(map[string]interface{})
 	if err := bc.UnpackLogIntoMap(received, "received", mockLog); err != nil {
@@ -281,6 +281,7 @@ func doTest(cmdline []string) {
 		coverage = flag.Bool("coverage", false, "Whether to record code coverage")
 		verbose  = flag.Bool("v", false, "Whether to log verbosely")
 		race     = flag.Bool("race", false, "Execute the race detector")
+		tags     = flag.String("tags", "", "Comma separated build tags to test with")
 	)
 	flag.CommandLine.Parse(cmdline)
 
@@ -304,6 +305,9 @@ func doTest(cmdline []string) {
 	if *race {
 		gotest.Args = append(gotest.Args, "-race")
 	}
+	if *tags != "" {
+		gotest.Args = append(gotest.Args, "-tags", *tags)
+	}
 
 	packages := []string{"./..."}
 	if len(flag.CommandLine.Args()) > 0 {
@@ -0,0 +1,286 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	rpcFlag = cli.StringFlag{
+		Name:  "rpc",
+		Usage: "RPC endpoint of the node to send the transaction to",
+		Value: "http://localhost:8545",
+	}
+	keyfileFlag = cli.StringFlag{
+		Name:  "keyfile",
+		Usage: "the keyfile of the account signing the transaction",
+	}
+	privateKeyFlag = cli.StringFlag{
+		Name:  "privatekey",
+		Usage: "file containing a raw hex private key to sign the transaction with",
+	}
+	passphraseFlag = cli.StringFlag{
+		Name:  "passwordfile",
+		Usage: "the file that contains the password for the keyfile",
+	}
+	toFlag = cli.StringFlag{
+		Name:  "to",
+		Usage: "recipient of the transaction (defaults to the sender)",
+	}
+	gasLimitFlag = cli.Uint64Flag{
+		Name:  "gaslimit",
+		Usage: "gas limit of the transaction",
+		Value: params.TxGas,
+	}
+	tipFlag = cli.StringFlag{
+		Name:  "tip",
+		Usage: "maximum priority fee per gas in wei (defaults to the node's suggestion)",
+	}
+	feeCapFlag = cli.StringFlag{
+		Name:  "maxfee",
+		Usage: "maximum fee per gas in wei (defaults to twice the base fee plus the tip)",
+	}
+	dataFeeCapFlag = cli.StringFlag{
+		Name:  "maxdatafee",
+		Usage: "maximum fee per data gas in wei (defaults to twice the current data gas price)",
+	}
+	jsonFlag = cli.BoolFlag{
+		Name:  "json",
+		Usage: "output JSON instead of human-readable format",
+	}
+)
+
+var commandSend = cli.Command{
+	Name:      "send",
+	Usage:     "send a file as blobs",
+	ArgsUsage: "<file>",
+	Description: `
+Split the contents of a file into blobs, wrap them into a blob transaction
+signed with the given key and send it to the node at --rpc.
+
+The versioned hashes of the blobs are printed once the transaction has been
+accepted by the node.`,
+	Flags: []cli.Flag{
+		rpcFlag,
+		keyfileFlag,
+		privateKeyFlag,
+		passphraseFlag,
+		toFlag,
+		gasLimitFlag,
+		tipFlag,
+		feeCapFlag,
+		dataFeeCapFlag,
+		jsonFlag,
+	},
+	Action: send,
+}
+
+type outputSend struct {
+	Hash                common.Hash
+	BlobVersionedHashes []common.Hash
+}
+
+func send(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		utils.Fatalf("Usage: blobtool send [options] <file>")
+	}
+	data, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Failed to read the file: %v", err)
+	}
+	blobs, err := encodeBlobs(data)
+	if err != nil {
+		utils.Fatalf("Failed to encode blobs: %v", err)
+	}
+	sidecar, err := types.NewBlobTxSidecar(blobs)
+	if err != nil {
+		utils.Fatalf("Failed to commit to blobs: %v", err)
+	}
+	key := getKey(ctx)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := from
+	if ctx.IsSet(toFlag.Name) {
+		if !common.IsHexAddress(ctx.String(toFlag.Name)) {
+			utils.Fatalf("Invalid recipient: %s", ctx.String(toFlag.Name))
+		}
+		to = common.HexToAddress(ctx.String(toFlag.Name))
+	}
+	// Gather the chain state needed to build the transaction
+	client, err := ethclient.Dial(ctx.String(rpcFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to connect to %s: %v", ctx.String(rpcFlag.Name), err)
+	}
+	defer client.Close()
+
+	background := context.Background()
+	chainID, err := client.ChainID(background)
+	if err != nil {
+		utils.Fatalf("Failed to retrieve chain id: %v", err)
+	}
+	nonce, err := client.PendingNonceAt(background, from)
+	if err != nil {
+		utils.Fatalf("Failed to retrieve nonce: %v", err)
+	}
+	head, err := client.HeaderByNumber(background, nil)
+	if err != nil {
+		utils.Fatalf("Failed to retrieve head header: %v", err)
+	}
+	if head.BaseFee == nil {
+		utils.Fatalf("Chain does not support EIP-1559 transactions")
+	}
+	tip := getBig(ctx, tipFlag.Name)
+	if tip == nil {
+		if tip, err = client.SuggestGasTipCap(background); err != nil {
+			utils.Fatalf("Failed to suggest gas tip: %v", err)
+		}
+	}
+	feeCap := getBig(ctx, feeCapFlag.Name)
+	if feeCap == nil {
+		feeCap = new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+	}
+	dataFeeCap := getBig(ctx, dataFeeCapFlag.Name)
+	if dataFeeCap == nil {
+		// The chain config of the node is unknown, assume the default blob parameters
+		dataFeeCap = new(big.Int).Mul(misc.GetDataGasPrice(nil, head.ExcessDataGas), big.NewInt(2))
+	}
+	// Sign and send the transaction along with its blobs
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.BlobTx{
+		ChainID:             chainID,
+		Nonce:               nonce,
+		GasTipCap:           tip,
+		GasFeeCap:           feeCap,
+		Gas:                 ctx.Uint64(gasLimitFlag.Name),
+		To:                  &to,
+		Value:               new(big.Int),
+		MaxFeePerDataGas:    dataFeeCap,
+		BlobVersionedHashes: sidecar.BlobHashes(),
+	})
+	if err != nil {
+		utils.Fatalf("Failed to sign transaction: %v", err)
+	}
+	if err := client.SendBlobTransaction(background, tx, blobs); err != nil {
+		utils.Fatalf("Failed to send transaction: %v", err)
+	}
+	out := outputSend{Hash: tx.Hash(), BlobVersionedHashes: tx.DataHashes()}
+	if ctx.Bool(jsonFlag.Name) {
+		str, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			utils.Fatalf("Failed to marshal JSON object: %v", err)
+		}
+		fmt.Println(string(str))
+	} else {
+		fmt.Println("Transaction:", out.Hash.Hex())
+		for i, hash := range out.BlobVersionedHashes {
+			fmt.Printf("Blob %d:      %s\n", i, hash.Hex())
+		}
+	}
+	return nil
+}
+
+// encodeBlobs splits a payload into chunks fitting into a single blob each, and
+// packs them into as many blobs as needed.
+func encodeBlobs(data []byte) ([]kzg.Blob, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty payload")
+	}
+	count := (len(data) + kzg.MaxBlobDataSize - 1) / kzg.MaxBlobDataSize
+	if count > params.MaxBlobsPerBlock {
+		return nil, fmt.Errorf("payload too large: %d bytes needs %d blobs, max %d", len(data), count, params.MaxBlobsPerBlock)
+	}
+	blobs := make([]kzg.Blob, count)
+	for i := range blobs {
+		end := (i + 1) * kzg.MaxBlobDataSize
+		if end > len(data) {
+			end = len(data)
+		}
+		if err := blobs[i].EncodeData(data[i*kzg.MaxBlobDataSize : end]); err != nil {
+			return nil, err
+		}
+	}
+	return blobs, nil
+}
+
+// getKey loads the private key to sign with, either from an encrypted keyfile
+// or from a file holding a raw private key.
+func getKey(ctx *cli.Context) *ecdsa.PrivateKey {
+	switch {
+	case ctx.IsSet(keyfileFlag.Name) && ctx.IsSet(privateKeyFlag.Name):
+		utils.Fatalf("Only one of --%s and --%s may be given", keyfileFlag.Name, privateKeyFlag.Name)
+	case ctx.IsSet(privateKeyFlag.Name):
+		privateKey, err := crypto.LoadECDSA(ctx.String(privateKeyFlag.Name))
+		if err != nil {
+			utils.Fatalf("Failed to load the private key: %v", err)
+		}
+		return privateKey
+	case ctx.IsSet(keyfileFlag.Name):
+		keyjson, err := ioutil.ReadFile(ctx.String(keyfileFlag.Name))
+		if err != nil {
+			utils.Fatalf("Failed to read the keyfile: %v", err)
+		}
+		key, err := keystore.DecryptKey(keyjson, getPassphrase(ctx))
+		if err != nil {
+			utils.Fatalf("Error decrypting key: %v", err)
+		}
+		return key.PrivateKey
+	}
+	utils.Fatalf("One of --%s and --%s is required", keyfileFlag.Name, privateKeyFlag.Name)
+	return nil
+}
+
+// getPassphrase obtains the passphrase of the keyfile, either from the file given
+// by --passwordfile or by prompting the user.
+func getPassphrase(ctx *cli.Context) string {
+	if file := ctx.String(passphraseFlag.Name); file != "" {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			utils.Fatalf("Failed to read password file '%s': %v", file, err)
+		}
+		return strings.TrimRight(string(content), "\r\n")
+	}
+	return utils.GetPassPhrase("", false)
+}
+
+// getBig parses an optional decimal wei amount given on the command line.
+func getBig(ctx *cli.Context, name string) *big.Int {
+	if !ctx.IsSet(name) {
+		return nil
+	}
+	value, ok := new(big.Int).SetString(ctx.String(name), 10)
+	if !ok || value.Sign() < 0 {
+		utils.Fatalf("Invalid --%s value: %s", name, ctx.String(name))
+	}
+	return value
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestEncodeBlobs(t *testing.T) {
+	tests := []struct {
+		size  int
+		blobs int
+		fail  bool
+	}{
+		{size: 0, fail: true},
+		{size: 1, blobs: 1},
+		{size: kzg.MaxBlobDataSize, blobs: 1},
+		{size: kzg.MaxBlobDataSize + 1, blobs: 2},
+		{size: params.MaxBlobsPerBlock * kzg.MaxBlobDataSize, blobs: params.MaxBlobsPerBlock},
+		{size: params.MaxBlobsPerBlock*kzg.MaxBlobDataSize + 1, fail: true},
+	}
+	for i, tt := range tests {
+		data := make([]byte, tt.size)
+		for j := range data {
+			data[j] = byte(j)
+		}
+		blobs, err := encodeBlobs(data)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("test %d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test %d: failed to encode: %v", i, err)
+		}
+		if len(blobs) != tt.blobs {
+			t.Errorf("test %d: blob count mismatch: have %d, want %d", i, len(blobs), tt.blobs)
+		}
+		var decoded []byte
+		for j := range blobs {
+			chunk, err := blobs[j].DecodeData()
+			if err != nil {
+				t.Fatalf("test %d: failed to decode blob %d: %v", i, j, err)
+			}
+			decoded = append(decoded, chunk...)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("test %d: payload mismatch after round trip", i)
+		}
+	}
+}
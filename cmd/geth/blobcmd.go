@@ -0,0 +1,256 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/olekukonko/tablewriter"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	blobStatsFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "First block of the range (default = genesis)",
+	}
+	blobStatsToFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Last block of the range (default = head block)",
+	}
+	blobStatsTopFlag = cli.IntFlag{
+		Name:  "top",
+		Usage: "Number of top blob senders to list",
+		Value: 10,
+	}
+
+	blobCommand = cli.Command{
+		Name:        "blob",
+		Usage:       "A set of commands inspecting blob transactions",
+		Category:    "MISCELLANEOUS COMMANDS",
+		Description: "",
+		Subcommands: []cli.Command{
+			{
+				Name:     "stats",
+				Usage:    "Compute blob usage statistics over a range of blocks",
+				Action:   utils.MigrateFlags(blobStats),
+				Category: "MISCELLANEOUS COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.AncientFlag,
+					utils.SyncModeFlag,
+					utils.MainnetFlag,
+					utils.RopstenFlag,
+					utils.SepoliaFlag,
+					utils.RinkebyFlag,
+					utils.GoerliFlag,
+					blobStatsFromFlag,
+					blobStatsToFlag,
+					blobStatsTopFlag,
+				},
+				Description: `
+geth blob stats --from N --to M
+computes the blob usage of the canonical blocks N to M (both included) from
+the local chain data: the blobs per block, the data gas used against the data
+gas targeted by the fee market, the data fees burned and the senders posting
+the most blobs.
+`,
+			},
+		},
+	}
+)
+
+// blobSender is the blob usage of a single sender.
+type blobSender struct {
+	address common.Address
+	txs     int
+	blobs   int
+}
+
+// blobUsage is the blob usage of a range of blocks.
+type blobUsage struct {
+	from, to uint64 // Block range covered, both included
+	blocks   int    // Number of blocks with blobs enabled
+
+	txs      int    // Number of blob transactions
+	blobs    int    // Number of blobs
+	maxBlobs int    // Most blobs included in a single block
+	dataGas  uint64 // Data gas used by the blobs
+	target   uint64 // Data gas targeted by the fee market
+
+	burnt   *big.Int                       // Data fees burned
+	senders map[common.Address]*blobSender // Blob usage per sender
+}
+
+// topSenders returns the given number of senders posting the most blobs.
+func (u *blobUsage) topSenders(n int) []*blobSender {
+	senders := make([]*blobSender, 0, len(u.senders))
+	for _, sender := range u.senders {
+		senders = append(senders, sender)
+	}
+	sort.Slice(senders, func(i, j int) bool {
+		if senders[i].blobs != senders[j].blobs {
+			return senders[i].blobs > senders[j].blobs
+		}
+		return bytes.Compare(senders[i].address[:], senders[j].address[:]) < 0
+	})
+	if len(senders) > n {
+		senders = senders[:n]
+	}
+	return senders
+}
+
+// gatherBlobUsage computes the blob usage of the canonical blocks from the given
+// range, both included. The data fees are charged at the price set by the
+// excess data gas of the parent, like block processing does.
+func gatherBlobUsage(db ethdb.Reader, config *params.ChainConfig, from, to uint64) (*blobUsage, error) {
+	usage := &blobUsage{
+		from:    from,
+		to:      to,
+		burnt:   new(big.Int),
+		senders: make(map[common.Address]*blobSender),
+	}
+	var parent *types.Header
+	if from > 0 {
+		if parent = rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, from-1), from-1); parent == nil {
+			return nil, fmt.Errorf("block #%d not found", from-1)
+		}
+	}
+	for number := from; number <= to; number++ {
+		hash := rawdb.ReadCanonicalHash(db, number)
+		block := rawdb.ReadBlock(db, hash, number)
+		if block == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		// The genesis block carries no transactions, so it has no blob target either
+		if number > 0 && config.IsSharding(block.Number(), block.Time()) {
+			usage.blocks++
+			usage.target += config.ShardingParamsAt(block.Time()).TargetDataGasPerBlock()
+
+			var excess *big.Int
+			if parent != nil {
+				excess = parent.ExcessDataGas
+			}
+			var (
+				price  = misc.GetDataGasPrice(config, excess)
+				signer = types.MakeSigner(config, block.Number(), block.Time())
+				blobs  int
+			)
+			for _, tx := range block.Transactions() {
+				if tx.Type() != types.BlobTxType {
+					continue
+				}
+				addr, err := types.Sender(signer, tx)
+				if err != nil {
+					return nil, fmt.Errorf("block #%d: invalid sender of tx %#x: %v", number, tx.Hash(), err)
+				}
+				sender := usage.senders[addr]
+				if sender == nil {
+					sender = &blobSender{address: addr}
+					usage.senders[addr] = sender
+				}
+				sender.txs++
+				sender.blobs += len(tx.DataHashes())
+
+				usage.txs++
+				blobs += len(tx.DataHashes())
+			}
+			dataGas := uint64(blobs) * params.DataGasPerBlob
+			usage.burnt.Add(usage.burnt, new(big.Int).Mul(price, new(big.Int).SetUint64(dataGas)))
+			usage.dataGas += dataGas
+			usage.blobs += blobs
+			if blobs > usage.maxBlobs {
+				usage.maxBlobs = blobs
+			}
+		}
+		parent = block.Header()
+	}
+	return usage, nil
+}
+
+func blobStats(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	config := rawdb.ReadChainConfig(db, rawdb.ReadCanonicalHash(db, 0))
+	if config == nil {
+		return errors.New("failed to load chain config")
+	}
+	head := rawdb.ReadHeadBlock(db)
+	if head == nil {
+		return errors.New("failed to load head block")
+	}
+	from, to := ctx.Uint64(blobStatsFromFlag.Name), head.NumberU64()
+	if ctx.IsSet(blobStatsToFlag.Name) {
+		to = ctx.Uint64(blobStatsToFlag.Name)
+	}
+	if from > to {
+		return fmt.Errorf("invalid block range: from %d > to %d", from, to)
+	}
+	if to > head.NumberU64() {
+		return fmt.Errorf("block #%d beyond head block #%d", to, head.NumberU64())
+	}
+	usage, err := gatherBlobUsage(db, config, from, to)
+	if err != nil {
+		return err
+	}
+	var perBlock, utilization string
+	if usage.blocks > 0 {
+		perBlock = fmt.Sprintf("%.2f", float64(usage.blobs)/float64(usage.blocks))
+	}
+	if usage.target > 0 {
+		utilization = fmt.Sprintf("%.2f%%", 100*float64(usage.dataGas)/float64(usage.target))
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Statistic", "Value"})
+	table.AppendBulk([][]string{
+		{"Blocks", fmt.Sprintf("#%d - #%d", usage.from, usage.to)},
+		{"Blocks with blobs enabled", fmt.Sprintf("%d", usage.blocks)},
+		{"Blob transactions", fmt.Sprintf("%d", usage.txs)},
+		{"Blobs", fmt.Sprintf("%d", usage.blobs)},
+		{"Blobs per block", perBlock},
+		{"Most blobs in a block", fmt.Sprintf("%d", usage.maxBlobs)},
+		{"Data gas used", fmt.Sprintf("%d", usage.dataGas)},
+		{"Data gas target", fmt.Sprintf("%d", usage.target)},
+		{"Data gas used vs target", utilization},
+		{"Data fees burned (wei)", usage.burnt.String()},
+	})
+	table.Render()
+
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Sender", "Transactions", "Blobs"})
+	for _, sender := range usage.topSenders(ctx.Int(blobStatsTopFlag.Name)) {
+		table.Append([]string{sender.address.Hex(), fmt.Sprintf("%d", sender.txs), fmt.Sprintf("%d", sender.blobs)})
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestGatherBlobUsage(t *testing.T) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		config  = params.TestShardingChainConfig
+		signer  = types.LatestSigner(config)
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		excess  = big.NewInt(3 * params.DataGasPriceUpdateFraction)
+	)
+	blobTx := func(key *ecdsa.PrivateKey, nonce uint64, blobs int) *types.Transaction {
+		hashes := make([]common.Hash, blobs)
+		for i := range hashes {
+			hashes[i] = common.Hash{0x01, byte(nonce), byte(i)}
+		}
+		return types.MustSignNewTx(key, signer, &types.BlobTx{
+			ChainID:             config.ChainID,
+			Nonce:               nonce,
+			GasTipCap:           new(big.Int),
+			GasFeeCap:           new(big.Int),
+			MaxFeePerDataGas:    new(big.Int),
+			BlobVersionedHashes: hashes,
+		})
+	}
+	// Store a chain with blobs in the first two blocks after genesis
+	bodies := [][]*types.Transaction{
+		nil,
+		{blobTx(key1, 0, 2)},
+		{blobTx(key2, 0, 1), types.NewTx(&types.LegacyTx{}), blobTx(key1, 1, 1)},
+		nil,
+	}
+	for i, txs := range bodies {
+		header := &types.Header{Number: big.NewInt(int64(i)), ExcessDataGas: new(big.Int)}
+		if i == 1 {
+			header.ExcessDataGas = excess
+		}
+		block := types.NewBlock(header, txs, nil, nil, trie.NewStackTrie(nil))
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+	}
+	perBlob := new(big.Int).SetUint64(params.DataGasPerBlob)
+	minPrice := misc.GetDataGasPrice(config, nil)
+	excessPrice := misc.GetDataGasPrice(config, excess)
+
+	// The whole chain pays the first blobs at the minimum price, the later ones
+	// at the price set by the excess data gas of their parent
+	usage, err := gatherBlobUsage(db, config, 0, 3)
+	if err != nil {
+		t.Fatalf("failed to gather blob usage: %v", err)
+	}
+	if usage.blocks != 3 || usage.txs != 3 || usage.blobs != 4 || usage.maxBlobs != 2 {
+		t.Errorf("blob counts mismatch: have %d blocks, %d txs, %d blobs, %d max", usage.blocks, usage.txs, usage.blobs, usage.maxBlobs)
+	}
+	if usage.dataGas != 4*params.DataGasPerBlob || usage.target != 3*config.ShardingParams().TargetDataGasPerBlock() {
+		t.Errorf("data gas mismatch: have %d used, %d target", usage.dataGas, usage.target)
+	}
+	burnt := new(big.Int).Mul(new(big.Int).Mul(big.NewInt(2), perBlob), new(big.Int).Add(minPrice, excessPrice))
+	if usage.burnt.Cmp(burnt) != 0 {
+		t.Errorf("burnt fees mismatch: have %d, want %d", usage.burnt, burnt)
+	}
+	top := usage.topSenders(1)
+	if len(top) != 1 || top[0].address != addr1 || top[0].txs != 2 || top[0].blobs != 3 {
+		t.Errorf("top sender mismatch: have %+v, want %x with 2 txs and 3 blobs", top[0], addr1)
+	}
+	// Ranges not starting at genesis are priced from the parent before them
+	if usage, err = gatherBlobUsage(db, config, 2, 2); err != nil {
+		t.Fatalf("failed to gather blob usage: %v", err)
+	}
+	if burnt := new(big.Int).Mul(new(big.Int).Mul(big.NewInt(2), perBlob), excessPrice); usage.burnt.Cmp(burnt) != 0 {
+		t.Errorf("burnt fees mismatch: have %d, want %d", usage.burnt, burnt)
+	}
+	if senders := usage.topSenders(10); len(senders) != 2 || senders[0].blobs != 1 || senders[1].blobs != 1 {
+		t.Errorf("senders mismatch: have %d senders", len(senders))
+	}
+	if _, ok := usage.senders[addr2]; !ok {
+		t.Errorf("sender %x missing", addr2)
+	}
+	// Missing blocks are reported
+	if _, err := gatherBlobUsage(db, config, 2, 4); err == nil {
+		t.Errorf("missing block not reported")
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// kzgsetup generates, converts and verifies KZG trusted setups.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Git SHA1 commit hash of the release (set via linker flags)
+var gitCommit = ""
+var gitDate = ""
+
+var app *cli.App
+
+func init() {
+	app = flags.NewApp(gitCommit, gitDate, "a KZG trusted setup tool")
+	app.Commands = []cli.Command{
+		commandGenerate,
+		commandConvert,
+		commandVerify,
+	}
+	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
+}
+
+var (
+	g1PowersFlag = cli.IntFlag{
+		Name:  "g1",
+		Usage: "number of G1 powers, the size of the evaluation domain (power of two)",
+		Value: kzg.FieldElementsPerBlob,
+	}
+	g2PowersFlag = cli.IntFlag{
+		Name:  "g2",
+		Usage: "number of G2 powers",
+		Value: 65,
+	}
+	secretFlag = cli.StringFlag{
+		Name:  "secret",
+		Usage: "decimal setup secret (defaults to a random one, which is discarded)",
+	}
+	outputFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "file to write the setup to (defaults to stdout)",
+	}
+	samplesFlag = cli.IntFlag{
+		Name:  "samples",
+		Usage: "number of indices to run consistency checks on (0 checks all)",
+		Value: 16,
+	}
+)
+
+var commandGenerate = cli.Command{
+	Name:  "generate",
+	Usage: "generate an insecure development setup",
+	Description: `
+Generate a trusted setup from a secret known to this tool. Anyone knowing the
+secret can forge KZG proofs against the setup, so it must only ever be used for
+testing and devnets.`,
+	Flags: []cli.Flag{
+		g1PowersFlag,
+		g2PowersFlag,
+		secretFlag,
+		outputFlag,
+	},
+	Action: generate,
+}
+
+var commandConvert = cli.Command{
+	Name:      "convert",
+	Usage:     "convert ceremony output into the node's setup format",
+	ArgsUsage: "<transcript.json>",
+	Description: `
+Convert the powers of tau of a ceremony transcript into the node's setup format,
+deriving the Lagrange basis the node commits to blobs with. The transcript with
+as many G1 powers as given by --g1 is used. The setup is verified before being
+written.`,
+	Flags: []cli.Flag{
+		g1PowersFlag,
+		samplesFlag,
+		outputFlag,
+	},
+	Action: convert,
+}
+
+var commandVerify = cli.Command{
+	Name:      "verify",
+	Usage:     "verify a setup in the node's or a standard format",
+	ArgsUsage: "<setup>",
+	Description: `
+Verify that all points of a setup are in the correct subgroups, that the powers
+of the secret are consistent with each other and that the Lagrange basis matches
+them. Besides the node's format, the JSON format of the consensus specs and the
+text format of c-kzg are accepted. The consistency checks are run on --samples
+random indices.`,
+	Flags: []cli.Flag{
+		samplesFlag,
+	},
+	Action: verify,
+}
+
+// ceremonyOutput is the transcript format of the KZG ceremony.
+type ceremonyOutput struct {
+	Transcripts []struct {
+		NumG1Powers int `json:"numG1Powers"`
+		NumG2Powers int `json:"numG2Powers"`
+		PowersOfTau struct {
+			G1Powers []hexutil.Bytes `json:"G1Powers"`
+			G2Powers []hexutil.Bytes `json:"G2Powers"`
+		} `json:"powersOfTau"`
+	} `json:"transcripts"`
+}
+
+func generate(ctx *cli.Context) error {
+	secret, err := getSecret(ctx)
+	if err != nil {
+		return err
+	}
+	setup, err := kzg.NewInsecureTrustedSetup(secret, ctx.Int(g1PowersFlag.Name), ctx.Int(g2PowersFlag.Name))
+	if err != nil {
+		return err
+	}
+	return writeSetup(ctx, setup)
+}
+
+func convert(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("need transcript file as argument")
+	}
+	blob, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	var ceremony ceremonyOutput
+	if err := json.Unmarshal(blob, &ceremony); err != nil {
+		return fmt.Errorf("invalid transcript: %v", err)
+	}
+	for _, transcript := range ceremony.Transcripts {
+		if transcript.NumG1Powers != ctx.Int(g1PowersFlag.Name) {
+			continue
+		}
+		powers := transcript.PowersOfTau
+		if len(powers.G1Powers) != transcript.NumG1Powers || len(powers.G2Powers) != transcript.NumG2Powers {
+			return fmt.Errorf("transcript holds %d G1 and %d G2 powers, want %d and %d",
+				len(powers.G1Powers), len(powers.G2Powers), transcript.NumG1Powers, transcript.NumG2Powers)
+		}
+		setup, err := kzg.NewTrustedSetup(powers.G1Powers, powers.G2Powers)
+		if err != nil {
+			return err
+		}
+		if err := setup.Verify(ctx.Int(samplesFlag.Name)); err != nil {
+			return err
+		}
+		return writeSetup(ctx, setup)
+	}
+	return fmt.Errorf("no transcript with %d G1 powers", ctx.Int(g1PowersFlag.Name))
+}
+
+func verify(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("need setup file as argument")
+	}
+	setup, err := kzg.ReadTrustedSetupFile(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid setup: %v", err)
+	}
+	if err := setup.Verify(ctx.Int(samplesFlag.Name)); err != nil {
+		return err
+	}
+	fmt.Printf("Setup with %d G1 and %d G2 points is valid\n", len(setup.G1Lagrange), len(setup.G2Monomial))
+	return nil
+}
+
+// getSecret returns the secret given on the command line, or a random one.
+func getSecret(ctx *cli.Context) (*big.Int, error) {
+	if !ctx.IsSet(secretFlag.Name) {
+		return rand.Int(rand.Reader, kzg.BLSModulus)
+	}
+	secret, ok := new(big.Int).SetString(ctx.String(secretFlag.Name), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid secret: %s", ctx.String(secretFlag.Name))
+	}
+	return secret, nil
+}
+
+// writeSetup writes the JSON encoding of the setup to the output file, or to
+// stdout if none was given.
+func writeSetup(ctx *cli.Context, setup *kzg.TrustedSetup) error {
+	out, err := json.MarshalIndent(setup, "", "  ")
+	if err != nil {
+		return err
+	}
+	if file := ctx.String(outputFlag.Name); file != "" {
+		return ioutil.WriteFile(file, out, 0644)
+	}
+	_, err = fmt.Println(string(out))
+	return err
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/eth"
 	ethcatalyst "github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -233,6 +234,32 @@ var (
 		Usage: "Number of recent blocks to maintain transactions index for (default = about one year, 0 = entire chain)",
 		Value: ethconfig.Defaults.TxLookupLimit,
 	}
+	BlobRetentionFlag = cli.Uint64Flag{
+		Name:  "blobretention",
+		Usage: "Number of recent blocks to retain blob sidecars for (default = about 18 days, 0 = entire chain)",
+		Value: ethconfig.Defaults.BlobRetention,
+	}
+	BlobHistoryFlag = cli.BoolFlag{
+		Name:  "blobhistory",
+		Usage: "Serve retained blob sidecars over discovery v5 and retrieve pruned ones from other nodes",
+	}
+	BlobForwardFlag = cli.StringFlag{
+		Name:  "blobforward",
+		Usage: "Comma separated RPC endpoints of trusted nodes to forward blob transactions to, instead of announcing them to peers",
+	}
+	KZGSetupFlag = cli.StringFlag{
+		Name:  "kzg.setup",
+		Usage: "KZG trusted setup file to use instead of the insecure development setup (JSON or c-kzg text format)",
+	}
+	KZGVerifyOnlyFlag = cli.BoolFlag{
+		Name:  "kzg.verifyonly",
+		Usage: "Only keep the verification part of the KZG trusted setup, disabling local blob commitments",
+	}
+	KZGBackendFlag = cli.StringFlag{
+		Name:  "kzg.backend",
+		Usage: "BLS12-381 implementation for KZG commitments and proofs (" + strings.Join(kzg.Backends(), ", ") + ")",
+		Value: kzg.BackendName(),
+	}
 	LightKDFFlag = cli.BoolFlag{
 		Name:  "lightkdf",
 		Usage: "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
@@ -354,6 +381,11 @@ var (
 		Usage: "Time interval to regenerate the local transaction journal",
 		Value: core.DefaultTxPoolConfig.Rejournal,
 	}
+	TxPoolBlobStoreFlag = cli.StringFlag{
+		Name:  "txpool.blobstore",
+		Usage: "Disk store for the blobs of pooled transactions to survive node restarts",
+		Value: core.DefaultTxPoolConfig.BlobStore,
+	}
 	TxPoolPriceLimitFlag = cli.Uint64Flag{
 		Name:  "txpool.pricelimit",
 		Usage: "Minimum gas price limit to enforce for acceptance into the pool",
@@ -364,6 +396,11 @@ var (
 		Usage: "Price bump percentage to replace an already existing transaction",
 		Value: ethconfig.Defaults.TxPool.PriceBump,
 	}
+	TxPoolBlobPriceBumpFlag = cli.Uint64Flag{
+		Name:  "txpool.blobpricebump",
+		Usage: "Price bump percentage to replace an already existing blob transaction",
+		Value: ethconfig.Defaults.TxPool.BlobPriceBump,
+	}
 	TxPoolAccountSlotsFlag = cli.Uint64Flag{
 		Name:  "txpool.accountslots",
 		Usage: "Minimum number of executable transaction slots guaranteed per account",
@@ -384,6 +421,16 @@ var (
 		Usage: "Maximum number of non-executable transaction slots for all accounts",
 		Value: ethconfig.Defaults.TxPool.GlobalQueue,
 	}
+	TxPoolAccountBlobsFlag = cli.Uint64Flag{
+		Name:  "txpool.accountblobs",
+		Usage: "Maximum number of blobs permitted per account",
+		Value: ethconfig.Defaults.TxPool.AccountBlobs,
+	}
+	TxPoolGlobalBlobsFlag = cli.Uint64Flag{
+		Name:  "txpool.globalblobs",
+		Usage: "Maximum number of blobs for all accounts",
+		Value: ethconfig.Defaults.TxPool.GlobalBlobs,
+	}
 	TxPoolLifetimeFlag = cli.DurationFlag{
 		Name:  "txpool.lifetime",
 		Usage: "Maximum amount of time non-executable transaction are queued",
@@ -518,6 +565,10 @@ var (
 		Usage: "Sets a cap on transaction fee (in ether) that can be sent via the RPC APIs (0 = no cap)",
 		Value: ethconfig.Defaults.RPCTxFeeCap,
 	}
+	RPCBeaconBlobsFlag = cli.BoolFlag{
+		Name:  "rpc.beaconblobs",
+		Usage: "Return blob sidecars over RPC in the beacon API format (snake_case fields, decimal string indices)",
+	}
 	// Logging and debug settings
 	EthStatsURLFlag = cli.StringFlag{
 		Name:  "ethstats",
@@ -574,6 +625,26 @@ var (
 		Usage: "HTTP path path prefix on which JSON-RPC is served. Use '/' to serve on all paths.",
 		Value: "",
 	}
+	AuthListenFlag = cli.StringFlag{
+		Name:  "authrpc.addr",
+		Usage: "Listening address for authenticated APIs",
+		Value: node.DefaultConfig.AuthAddr,
+	}
+	AuthPortFlag = cli.IntFlag{
+		Name:  "authrpc.port",
+		Usage: "Listening port for authenticated APIs",
+		Value: node.DefaultConfig.AuthPort,
+	}
+	AuthVirtualHostsFlag = cli.StringFlag{
+		Name:  "authrpc.vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value: strings.Join(node.DefaultConfig.AuthVirtualHosts, ","),
+	}
+	JWTSecretFlag = cli.StringFlag{
+		Name:  "authrpc.jwtsecret",
+		Usage: "Path to a JWT secret to enable the authenticated engine and blob APIs (generated if missing)",
+		Value: "",
+	}
 	GraphQLEnabledFlag = cli.BoolFlag{
 		Name:  "graphql",
 		Usage: "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
@@ -969,6 +1040,19 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.GlobalIsSet(AllowUnprotectedTxs.Name) {
 		cfg.AllowUnprotectedTxs = ctx.GlobalBool(AllowUnprotectedTxs.Name)
 	}
+
+	if ctx.GlobalIsSet(AuthListenFlag.Name) {
+		cfg.AuthAddr = ctx.GlobalString(AuthListenFlag.Name)
+	}
+	if ctx.GlobalIsSet(AuthPortFlag.Name) {
+		cfg.AuthPort = ctx.GlobalInt(AuthPortFlag.Name)
+	}
+	if ctx.GlobalIsSet(AuthVirtualHostsFlag.Name) {
+		cfg.AuthVirtualHosts = SplitAndTrim(ctx.GlobalString(AuthVirtualHostsFlag.Name))
+	}
+	if ctx.GlobalIsSet(JWTSecretFlag.Name) {
+		cfg.JWTSecret = ctx.GlobalString(JWTSecretFlag.Name)
+	}
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1329,12 +1413,18 @@ func setTxPool(ctx *cli.Context, cfg *core.TxPoolConfig) {
 	if ctx.GlobalIsSet(TxPoolRejournalFlag.Name) {
 		cfg.Rejournal = ctx.GlobalDuration(TxPoolRejournalFlag.Name)
 	}
+	if ctx.GlobalIsSet(TxPoolBlobStoreFlag.Name) {
+		cfg.BlobStore = ctx.GlobalString(TxPoolBlobStoreFlag.Name)
+	}
 	if ctx.GlobalIsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.GlobalUint64(TxPoolPriceLimitFlag.Name)
 	}
 	if ctx.GlobalIsSet(TxPoolPriceBumpFlag.Name) {
 		cfg.PriceBump = ctx.GlobalUint64(TxPoolPriceBumpFlag.Name)
 	}
+	if ctx.GlobalIsSet(TxPoolBlobPriceBumpFlag.Name) {
+		cfg.BlobPriceBump = ctx.GlobalUint64(TxPoolBlobPriceBumpFlag.Name)
+	}
 	if ctx.GlobalIsSet(TxPoolAccountSlotsFlag.Name) {
 		cfg.AccountSlots = ctx.GlobalUint64(TxPoolAccountSlotsFlag.Name)
 	}
@@ -1347,6 +1437,12 @@ func setTxPool(ctx *cli.Context, cfg *core.TxPoolConfig) {
 	if ctx.GlobalIsSet(TxPoolGlobalQueueFlag.Name) {
 		cfg.GlobalQueue = ctx.GlobalUint64(TxPoolGlobalQueueFlag.Name)
 	}
+	if ctx.GlobalIsSet(TxPoolAccountBlobsFlag.Name) {
+		cfg.AccountBlobs = ctx.GlobalUint64(TxPoolAccountBlobsFlag.Name)
+	}
+	if ctx.GlobalIsSet(TxPoolGlobalBlobsFlag.Name) {
+		cfg.GlobalBlobs = ctx.GlobalUint64(TxPoolGlobalBlobsFlag.Name)
+	}
 	if ctx.GlobalIsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.GlobalDuration(TxPoolLifetimeFlag.Name)
 	}
@@ -1545,6 +1641,27 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.GlobalIsSet(TxLookupLimitFlag.Name) {
 		cfg.TxLookupLimit = ctx.GlobalUint64(TxLookupLimitFlag.Name)
 	}
+	if ctx.GlobalIsSet(BlobRetentionFlag.Name) {
+		cfg.BlobRetention = ctx.GlobalUint64(BlobRetentionFlag.Name)
+	}
+	if ctx.GlobalIsSet(BlobHistoryFlag.Name) {
+		cfg.BlobHistory = ctx.GlobalBool(BlobHistoryFlag.Name)
+	}
+	if ctx.GlobalIsSet(BlobForwardFlag.Name) {
+		cfg.BlobForwardURLs = SplitAndTrim(ctx.GlobalString(BlobForwardFlag.Name))
+	}
+	if ctx.GlobalIsSet(KZGBackendFlag.Name) {
+		if err := kzg.SetBackend(ctx.GlobalString(KZGBackendFlag.Name)); err != nil {
+			Fatalf("%v", err)
+		}
+	}
+	if ctx.GlobalIsSet(KZGSetupFlag.Name) {
+		setKZGSetup(ctx.GlobalString(KZGSetupFlag.Name), ctx.GlobalBool(KZGVerifyOnlyFlag.Name))
+	}
+	if ctx.GlobalBool(KZGVerifyOnlyFlag.Name) {
+		kzg.EnableVerifyOnly()
+		log.Info("Enabled verify-only KZG mode, blob commitments can't be computed locally")
+	}
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheTrieFlag.Name) / 100
 	}
@@ -1591,6 +1708,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.GlobalIsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.GlobalFloat64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.GlobalIsSet(RPCBeaconBlobsFlag.Name) {
+		cfg.RPCBeaconBlobs = ctx.GlobalBool(RPCBeaconBlobsFlag.Name)
+	}
 	if ctx.GlobalIsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs, cfg.SnapDiscoveryURLs = []string{}, []string{}
 	} else if ctx.GlobalIsSet(DNSDiscoveryFlag.Name) {
@@ -1693,6 +1813,33 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	}
 }
 
+// kzgSetupSamples is the number of indices the consistency of a KZG trusted
+// setup loaded on startup is checked on.
+const kzgSetupSamples = 16
+
+// setKZGSetup loads the KZG trusted setup from the given file, keeping only its
+// verification part in verify-only mode. Full setups are checked for
+// consistency first, as committing against a broken one produces commitments
+// no other node accepts.
+func setKZGSetup(path string, verifyOnly bool) {
+	setup, err := kzg.ReadTrustedSetupFile(path)
+	if err != nil {
+		Fatalf("Failed to read KZG trusted setup: %v", err)
+	}
+	if verifyOnly {
+		err = kzg.LoadVerificationSetup(setup)
+	} else {
+		if err := setup.Verify(kzgSetupSamples); err != nil {
+			Fatalf("Invalid KZG trusted setup: %v", err)
+		}
+		err = kzg.LoadTrustedSetup(setup)
+	}
+	if err != nil {
+		Fatalf("Failed to load KZG trusted setup: %v", err)
+	}
+	log.Info("Loaded KZG trusted setup", "file", path, "g1", len(setup.G1Lagrange), "g2", len(setup.G2Monomial))
+}
+
 // SetDNSDiscoveryDefaults configures DNS discovery with the given URL if
 // no URLs are set.
 func SetDNSDiscoveryDefaults(cfg *ethconfig.Config, genesis common.Hash) {
@@ -205,7 +205,11 @@ func (beacon *Beacon) verifyHeader(chain consensus.ChainHeaderReader, header, pa
 		return consensus.ErrInvalidNumber
 	}
 	// Verify the header's EIP-1559 attributes.
-	return misc.VerifyEip1559Header(chain.Config(), parent, header)
+	if err := misc.VerifyEip1559Header(chain.Config(), parent, header); err != nil {
+		return err
+	}
+	// Verify the header's EIP-4844 attributes, as far as possible without the body.
+	return misc.VerifyExcessDataGas(chain.Config(), parent, header)
 }
 
 // verifyHeaders is similar to verifyHeader, but verifies a batch of headers
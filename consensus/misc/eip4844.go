@@ -0,0 +1,194 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package misc
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var dataGasPerBlob = big.NewInt(params.DataGasPerBlob)
+
+// VerifyEip4844Header verifies the data gas accounting of a header, which was
+// added in EIP-4844, given the number of blobs carried by its block.
+// - blob count check
+// - excessDataGas check
+func VerifyEip4844Header(config *params.ChainConfig, parent, header *types.Header, blobs int) error {
+	// Verify the block does not consume more data gas than allowed
+	if max := config.ShardingParamsAt(header.Time).MaxBlobsPerBlock; uint64(blobs) > max {
+		return fmt.Errorf("too many blobs in block: have %d, max %d", blobs, max)
+	}
+	// Headers without excessDataGas may not carry blobs, nor follow ones that do
+	if header.ExcessDataGas == nil {
+		if parent.ExcessDataGas != nil {
+			return fmt.Errorf("header is missing excessDataGas")
+		}
+		if blobs > 0 {
+			return fmt.Errorf("blobs in block without excessDataGas: have %d", blobs)
+		}
+		return nil
+	}
+	// Verify the excessDataGas is correct based on the parent header.
+	expectedExcessDataGas := CalcExcessDataGas(config, parent, header.Time, blobs)
+	if header.ExcessDataGas.Cmp(expectedExcessDataGas) != 0 {
+		return fmt.Errorf("invalid excessDataGas: have %s, want %s, parentExcessDataGas %s, blobs %d",
+			header.ExcessDataGas, expectedExcessDataGas, parent.ExcessDataGas, blobs)
+	}
+	return nil
+}
+
+// VerifyExcessDataGas verifies the excess data gas of a header without knowing
+// the number of blobs carried by its block, as during header-only sync. It checks
+// that the header keeps tracking data gas once its parent does, and that the
+// excess can be reached from the parent with an allowed number of blobs. Bodies
+// have to be checked with VerifyEip4844Header once available.
+func VerifyExcessDataGas(config *params.ChainConfig, parent, header *types.Header) error {
+	// Verify the header carries excessDataGas iff the sharding fork is active
+	if !config.IsSharding(header.Number, header.Time) {
+		if header.ExcessDataGas != nil {
+			return fmt.Errorf("invalid excessDataGas before fork: have %v, want <nil>", header.ExcessDataGas)
+		}
+		return nil
+	}
+	if header.ExcessDataGas == nil {
+		return fmt.Errorf("header is missing excessDataGas")
+	}
+	if header.ExcessDataGas.Sign() < 0 {
+		return fmt.Errorf("invalid excessDataGas: have %s, want non-negative", header.ExcessDataGas)
+	}
+	// A zero excess is reachable iff an empty block does not leave any excess
+	if header.ExcessDataGas.Sign() == 0 {
+		if min := CalcExcessDataGas(config, parent, header.Time, 0); min.Sign() != 0 {
+			return fmt.Errorf("invalid excessDataGas: have 0, want at least %s, parentExcessDataGas %s", min, parent.ExcessDataGas)
+		}
+		return nil
+	}
+	// Otherwise the excess determines the data gas consumed by the block exactly
+	sharding := config.ShardingParamsAt(header.Time)
+	consumed := new(big.Int).Add(header.ExcessDataGas, new(big.Int).SetUint64(sharding.TargetDataGasPerBlock()))
+	if parent.ExcessDataGas != nil {
+		consumed.Sub(consumed, parent.ExcessDataGas)
+	}
+	blobs, rem := new(big.Int).QuoRem(consumed, dataGasPerBlob, new(big.Int))
+	if consumed.Sign() < 0 || rem.Sign() != 0 || blobs.Cmp(new(big.Int).SetUint64(sharding.MaxBlobsPerBlock)) > 0 {
+		return fmt.Errorf("invalid excessDataGas: have %s, unreachable from parentExcessDataGas %s", header.ExcessDataGas, parent.ExcessDataGas)
+	}
+	return nil
+}
+
+// CalcExcessDataGas calculates the excess data gas of a header with the given
+// timestamp carrying the given number of blobs. A parent without excess data gas
+// counts as zero.
+func CalcExcessDataGas(config *params.ChainConfig, parent *types.Header, time uint64, blobs int) *big.Int {
+	excessDataGas := new(big.Int)
+	if parent.ExcessDataGas != nil {
+		excessDataGas.Set(parent.ExcessDataGas)
+	}
+	consumedDataGas := new(big.Int).Mul(big.NewInt(int64(blobs)), dataGasPerBlob)
+	excessDataGas.Add(excessDataGas, consumedDataGas)
+
+	targetDataGas := new(big.Int).SetUint64(config.ShardingParamsAt(time).TargetDataGasPerBlock())
+	if excessDataGas.Cmp(targetDataGas) < 0 {
+		return new(big.Int)
+	}
+	return excessDataGas.Sub(excessDataGas, targetDataGas)
+}
+
+// GetDataGasPrice calculates the price of a unit of data gas given the excess
+// data gas of a header. A nil excess data gas counts as zero, a nil config uses
+// the default blob parameters.
+func GetDataGasPrice(config *params.ChainConfig, excessDataGas *big.Int) *big.Int {
+	if excessDataGas == nil {
+		excessDataGas = new(big.Int)
+	}
+	sharding := config.ShardingParams()
+	return fakeExponential(
+		new(big.Int).SetUint64(sharding.MinDataGasPrice),
+		excessDataGas,
+		new(big.Int).SetUint64(sharding.DataGasPriceUpdateFraction),
+	)
+}
+
+// PostingCost is the cost of posting a payload on chain, either as the calldata
+// of a transaction or in the blobs of blob transactions.
+type PostingCost struct {
+	Transactions uint64   // Number of transactions needed to post the payload
+	Blobs        uint64   // Number of blobs needed to post the payload, zero for calldata
+	Gas          uint64   // Execution gas used by the transactions
+	DataGas      uint64   // Data gas used by the blobs, zero for calldata
+	Fee          *big.Int // Total fee paid by the transactions
+}
+
+// CalldataPostingCost calculates the cost of posting a payload of the given size
+// as the calldata of a single transaction at the given base fee and tip. The
+// payload is assumed not to contain zero bytes.
+func CalldataPostingCost(size uint64, baseFee, tip *big.Int) *PostingCost {
+	gas := params.TxGas + size*params.TxDataNonZeroGasEIP2028
+	return &PostingCost{
+		Transactions: 1,
+		Gas:          gas,
+		Fee:          new(big.Int).Mul(new(big.Int).Add(baseFee, tip), new(big.Int).SetUint64(gas)),
+	}
+}
+
+// BlobPostingCost calculates the cost of posting a payload of the given size in
+// blobs at the given base fee, tip and data gas price, spread over as few blob
+// transactions as the blob limit per transaction at the given time allows.
+func BlobPostingCost(config *params.ChainConfig, time uint64, size uint64, baseFee, tip, dataGasPrice *big.Int) *PostingCost {
+	var (
+		blobs   = (size + kzg.MaxBlobDataSize - 1) / kzg.MaxBlobDataSize
+		perTx   = config.ShardingParamsAt(time).MaxBlobsPerTx
+		txs     = (blobs + perTx - 1) / perTx
+		gas     = txs * params.TxGas
+		dataGas = blobs * params.DataGasPerBlob
+	)
+	if blobs == 0 {
+		// An empty payload still needs a transaction with a blob
+		blobs, txs, gas, dataGas = 1, 1, params.TxGas, params.DataGasPerBlob
+	}
+	fee := new(big.Int).Mul(new(big.Int).Add(baseFee, tip), new(big.Int).SetUint64(gas))
+	fee.Add(fee, new(big.Int).Mul(dataGasPrice, new(big.Int).SetUint64(dataGas)))
+
+	return &PostingCost{
+		Transactions: txs,
+		Blobs:        blobs,
+		Gas:          gas,
+		DataGas:      dataGas,
+		Fee:          fee,
+	}
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) using
+// Taylor expansion.
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	var (
+		output = new(big.Int)
+		accum  = new(big.Int).Mul(factor, denominator)
+	)
+	for i := 1; accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(int64(i)))
+	}
+	return output.Div(output, denominator)
+}
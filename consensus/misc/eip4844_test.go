@@ -0,0 +1,313 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package misc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestFakeExponential(t *testing.T) {
+	tests := []struct {
+		factor      int64
+		numerator   int64
+		denominator int64
+		want        int64
+	}{
+		// When numerator == 0 the return value should always equal the value of factor
+		{1, 0, 1, 1},
+		{38493, 0, 1000, 38493},
+		{0, 1234, 2345, 0}, // should be 0
+		{1, 2, 1, 6},       // approximate 7.389
+		{1, 4, 2, 6},
+		{1, 3, 1, 16}, // approximate 20.09
+		{1, 6, 2, 18},
+		{1, 4, 1, 49}, // approximate 54.60
+		{1, 8, 2, 50},
+		{10, 8, 2, 542}, // approximate 540.598
+		{11, 8, 2, 596}, // approximate 600.58
+		{1, 5, 1, 136},  // approximate 148.4
+		{1, 5, 2, 11},   // approximate 12.18
+		{2, 5, 2, 23},   // approximate 24.36
+		{1, 50000000, 2225652, 5709098764},
+	}
+	for i, tt := range tests {
+		have := fakeExponential(big.NewInt(tt.factor), big.NewInt(tt.numerator), big.NewInt(tt.denominator))
+		if have.Int64() != tt.want {
+			t.Errorf("test %d: fake exponential mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+func TestCalcExcessDataGas(t *testing.T) {
+	tests := []struct {
+		parent int64
+		blobs  int
+		want   int64
+	}{
+		// The excess data gas should not increase from zero if the used data gas
+		// is lower than or equal to the target
+		{0, 0, 0},
+		{0, 1, 0},
+		{0, params.TargetDataGasPerBlock / params.DataGasPerBlob, 0},
+
+		// If the target data gas is exceeded, the excessDataGas should increase
+		// by however much it was overshot
+		{0, (params.TargetDataGasPerBlock / params.DataGasPerBlob) + 1, params.DataGasPerBlob},
+		{1, (params.TargetDataGasPerBlock / params.DataGasPerBlob) + 1, params.DataGasPerBlob + 1},
+		{1, (params.TargetDataGasPerBlock / params.DataGasPerBlob) + 2, 2*params.DataGasPerBlob + 1},
+
+		// The excess data gas should decrease by however much the target was
+		// under-shot, capped at zero
+		{params.TargetDataGasPerBlock, params.TargetDataGasPerBlock / params.DataGasPerBlob, params.TargetDataGasPerBlock},
+		{params.TargetDataGasPerBlock, (params.TargetDataGasPerBlock / params.DataGasPerBlob) - 1, params.TargetDataGasPerBlock - params.DataGasPerBlob},
+		{params.TargetDataGasPerBlock, 0, 0},
+	}
+	for i, tt := range tests {
+		parent := &types.Header{ExcessDataGas: big.NewInt(tt.parent)}
+		if have := CalcExcessDataGas(params.TestShardingChainConfig, parent, 0, tt.blobs); have.Int64() != tt.want {
+			t.Errorf("test %d: excess data gas mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+	// Parents predating EIP-4844 count as having zero excess data gas
+	if have := CalcExcessDataGas(params.TestShardingChainConfig, &types.Header{}, 0, params.MaxBlobsPerBlock); have.Int64() != params.MaxDataGasPerBlock-params.TargetDataGasPerBlock {
+		t.Errorf("pre-4844 parent: excess data gas mismatch: have %v, want %v", have, params.MaxDataGasPerBlock-params.TargetDataGasPerBlock)
+	}
+}
+
+func TestGetDataGasPrice(t *testing.T) {
+	tests := []struct {
+		excessDataGas int64
+		want          int64
+	}{
+		{0, 1},
+		{1542706, 1},
+		{1542707, 2},
+		{10 * 1024 * 1024, 111},
+	}
+	for i, tt := range tests {
+		if have := GetDataGasPrice(params.TestShardingChainConfig, big.NewInt(tt.excessDataGas)); have.Int64() != tt.want {
+			t.Errorf("test %d: data gas price mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+	if have := GetDataGasPrice(params.TestShardingChainConfig, nil); have.Int64() != params.MinDataGasPrice {
+		t.Errorf("nil excess data gas: data gas price mismatch: have %v, want %v", have, params.MinDataGasPrice)
+	}
+}
+
+// TestVerifyEip4844Header tests the blob limit and excess data gas checks on
+// headers both before and after the introduction of excessDataGas.
+func TestPostingCost(t *testing.T) {
+	var (
+		baseFee      = big.NewInt(10)
+		tip          = big.NewInt(2)
+		dataGasPrice = big.NewInt(3)
+		perTx        = params.TestShardingChainConfig.ShardingParams().MaxBlobsPerTx
+	)
+	tests := []struct {
+		size        uint64
+		txs         uint64
+		blobs       uint64
+		calldataFee int64
+	}{
+		{0, 1, 1, 12 * 21000},
+		{1, 1, 1, 12 * (21000 + 16)},
+		{kzg.MaxBlobDataSize, 1, 1, 12 * (21000 + 16*kzg.MaxBlobDataSize)},
+		{kzg.MaxBlobDataSize + 1, 1, 2, 12 * (21000 + 16*(kzg.MaxBlobDataSize+1))},
+		{perTx * kzg.MaxBlobDataSize, 1, perTx, 12 * (21000 + 16*int64(perTx)*kzg.MaxBlobDataSize)},
+		{perTx*kzg.MaxBlobDataSize + 1, 2, perTx + 1, 12 * (21000 + 16*(int64(perTx)*kzg.MaxBlobDataSize+1))},
+	}
+	for i, tt := range tests {
+		calldata := CalldataPostingCost(tt.size, baseFee, tip)
+		if calldata.Transactions != 1 || calldata.Blobs != 0 || calldata.DataGas != 0 {
+			t.Errorf("test %d: calldata posting shape mismatch: %+v", i, calldata)
+		}
+		if calldata.Fee.Int64() != tt.calldataFee {
+			t.Errorf("test %d: calldata fee mismatch: have %v, want %v", i, calldata.Fee, tt.calldataFee)
+		}
+		blobs := BlobPostingCost(params.TestShardingChainConfig, 0, tt.size, baseFee, tip, dataGasPrice)
+		if blobs.Transactions != tt.txs || blobs.Blobs != tt.blobs {
+			t.Errorf("test %d: blob posting shape mismatch: have %d txs %d blobs, want %d txs %d blobs", i, blobs.Transactions, blobs.Blobs, tt.txs, tt.blobs)
+		}
+		want := int64(12*21000*tt.txs + 3*params.DataGasPerBlob*tt.blobs)
+		if blobs.Gas != 21000*tt.txs || blobs.DataGas != params.DataGasPerBlob*tt.blobs || blobs.Fee.Int64() != want {
+			t.Errorf("test %d: blob posting cost mismatch: have %d gas %d data gas %v fee, want %d fee", i, blobs.Gas, blobs.DataGas, blobs.Fee, want)
+		}
+	}
+}
+
+func TestVerifyEip4844Header(t *testing.T) {
+	for i, tc := range []struct {
+		parent *big.Int
+		header *big.Int
+		blobs  int
+		ok     bool
+	}{
+		// Legacy headers without blobs
+		{nil, nil, 0, true},
+		// Legacy headers may not carry blobs
+		{nil, nil, 1, false},
+		// Transitions into EIP-4844 headers
+		{nil, big.NewInt(0), 0, true},
+		{nil, big.NewInt(0), params.TargetDataGasPerBlock / params.DataGasPerBlob, true},
+		{nil, big.NewInt(params.DataGasPerBlob), params.MaxBlobsPerBlock - 1, true},
+		{nil, big.NewInt(0), params.MaxBlobsPerBlock - 1, false},
+		// EIP-4844 headers may not drop excessDataGas
+		{big.NewInt(0), nil, 0, false},
+		// EIP-4844 headers must follow the parent excess
+		{big.NewInt(params.DataGasPerBlob), big.NewInt(0), 0, true},
+		{big.NewInt(params.DataGasPerBlob), big.NewInt(params.DataGasPerBlob), 0, false},
+		{big.NewInt(params.DataGasPerBlob), big.NewInt(params.DataGasPerBlob), params.TargetDataGasPerBlock / params.DataGasPerBlob, true},
+		// Blocks may not consume more than the maximum data gas
+		{big.NewInt(0), big.NewInt(params.MaxDataGasPerBlock - params.TargetDataGasPerBlock), params.MaxBlobsPerBlock, true},
+		{big.NewInt(0), big.NewInt(params.MaxDataGasPerBlock + params.DataGasPerBlob - params.TargetDataGasPerBlock), params.MaxBlobsPerBlock + 1, false},
+	} {
+		parent := &types.Header{ExcessDataGas: tc.parent}
+		header := &types.Header{ExcessDataGas: tc.header}
+		err := VerifyEip4844Header(params.TestShardingChainConfig, parent, header, tc.blobs)
+		if tc.ok && err != nil {
+			t.Errorf("test %d: Expected valid header: %s", i, err)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("test %d: Expected invalid header", i)
+		}
+	}
+}
+
+// TestVerifyExcessDataGas tests the header-only excess data gas checks, which
+// have to accept every excess reachable with some allowed number of blobs.
+func TestVerifyExcessDataGas(t *testing.T) {
+	for i, tc := range []struct {
+		parent *big.Int
+		header *big.Int
+		ok     bool
+	}{
+		// EIP-4844 headers may not drop excessDataGas
+		{nil, nil, false},
+		{big.NewInt(0), nil, false},
+		// Zero excess is reachable unless an empty block leaves some excess
+		{nil, big.NewInt(0), true},
+		{big.NewInt(params.TargetDataGasPerBlock), big.NewInt(0), true},
+		{big.NewInt(params.TargetDataGasPerBlock + params.DataGasPerBlob), big.NewInt(0), false},
+		// Non-zero excess must be a whole number of blobs away from the parent
+		{big.NewInt(0), big.NewInt(params.DataGasPerBlob), true},
+		{big.NewInt(0), big.NewInt(params.DataGasPerBlob + 1), false},
+		{big.NewInt(params.TargetDataGasPerBlock + params.DataGasPerBlob), big.NewInt(params.DataGasPerBlob), true},
+		{big.NewInt(4 * params.DataGasPerBlob), big.NewInt(params.DataGasPerBlob), false},
+		// Blocks may not consume more than the maximum data gas
+		{big.NewInt(0), big.NewInt(params.MaxDataGasPerBlock - params.TargetDataGasPerBlock), true},
+		{big.NewInt(0), big.NewInt(params.MaxDataGasPerBlock + params.DataGasPerBlob - params.TargetDataGasPerBlock), false},
+		// Negative excess is invalid
+		{big.NewInt(0), big.NewInt(-params.DataGasPerBlob), false},
+	} {
+		parent := &types.Header{Number: big.NewInt(1), ExcessDataGas: tc.parent}
+		header := &types.Header{Number: big.NewInt(2), ExcessDataGas: tc.header}
+		err := VerifyExcessDataGas(params.TestShardingChainConfig, parent, header)
+		if tc.ok && err != nil {
+			t.Errorf("test %d: Expected valid header: %s", i, err)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("test %d: Expected invalid header", i)
+		}
+	}
+	// Headers before the fork may not carry excessDataGas
+	parent := &types.Header{Number: big.NewInt(1)}
+	if err := VerifyExcessDataGas(params.TestChainConfig, parent, &types.Header{Number: big.NewInt(2)}); err != nil {
+		t.Errorf("legacy header rejected: %v", err)
+	}
+	if err := VerifyExcessDataGas(params.TestChainConfig, parent, &types.Header{Number: big.NewInt(2), ExcessDataGas: new(big.Int)}); err == nil {
+		t.Errorf("legacy header with excessDataGas accepted")
+	}
+}
+
+// TestShardingConfig tests that the data gas accounting follows the blob
+// parameters configured for the chain.
+func TestShardingConfig(t *testing.T) {
+	config := *params.TestShardingChainConfig
+	config.Sharding = &params.ShardingConfig{
+		MaxBlobsPerBlock:           8,
+		TargetBlobsPerBlock:        4,
+		MinDataGasPrice:            10,
+		DataGasPriceUpdateFraction: params.DataGasPriceUpdateFraction / 2,
+	}
+	// The excess is measured against the configured target
+	parent := &types.Header{Number: big.NewInt(1), ExcessDataGas: new(big.Int)}
+	if have := CalcExcessDataGas(&config, parent, 0, 4); have.Sign() != 0 {
+		t.Errorf("excess data gas at target mismatch: have %v, want 0", have)
+	}
+	excess := CalcExcessDataGas(&config, parent, 0, 8)
+	if want := int64(4 * params.DataGasPerBlob); excess.Int64() != want {
+		t.Errorf("excess data gas at max mismatch: have %v, want %v", excess, want)
+	}
+	// Blocks may carry up to the configured number of blobs
+	header := &types.Header{Number: big.NewInt(2), ExcessDataGas: excess}
+	if err := VerifyEip4844Header(&config, parent, header, 8); err != nil {
+		t.Errorf("block at configured blob limit rejected: %v", err)
+	}
+	if err := VerifyEip4844Header(&config, parent, header, 9); err == nil {
+		t.Errorf("block above configured blob limit accepted")
+	}
+	if err := VerifyExcessDataGas(&config, parent, header); err != nil {
+		t.Errorf("excess data gas at configured blob limit rejected: %v", err)
+	}
+	if err := VerifyExcessDataGas(params.TestShardingChainConfig, parent, header); err == nil {
+		t.Errorf("excess data gas above default blob limit accepted")
+	}
+	// The price starts at the configured minimum and moves at the configured rate
+	if have := GetDataGasPrice(&config, nil); have.Int64() != 10 {
+		t.Errorf("minimum data gas price mismatch: have %v, want 10", have)
+	}
+	excess = big.NewInt(1542707)
+	if have, base := GetDataGasPrice(&config, excess), GetDataGasPrice(params.TestShardingChainConfig, excess); have.Cmp(new(big.Int).Mul(base, big.NewInt(10))) <= 0 {
+		t.Errorf("data gas price update fraction ignored: have %v, default %v", have, base)
+	}
+}
+
+// TestShardingSchedule tests that the data gas accounting follows the blob
+// parameters scheduled for the timestamp of the block.
+func TestShardingSchedule(t *testing.T) {
+	config := *params.TestShardingChainConfig
+	config.ShardingSchedule = []*params.ShardingUpdate{{Time: 100, MaxBlobsPerBlock: 8, TargetBlobsPerBlock: 4}}
+
+	// The excess is measured against the target active at the block
+	parent := &types.Header{Number: big.NewInt(1), ExcessDataGas: new(big.Int)}
+	if have, want := CalcExcessDataGas(&config, parent, 99, 4), int64(2*params.DataGasPerBlob); have.Int64() != want {
+		t.Errorf("excess data gas before update mismatch: have %v, want %v", have, want)
+	}
+	if have := CalcExcessDataGas(&config, parent, 100, 4); have.Sign() != 0 {
+		t.Errorf("excess data gas after update mismatch: have %v, want 0", have)
+	}
+	// Blocks may only carry the raised number of blobs once the update is active
+	before := &types.Header{Number: big.NewInt(2), Time: 99, ExcessDataGas: CalcExcessDataGas(&config, parent, 99, 8)}
+	if err := VerifyEip4844Header(&config, parent, before, 8); err == nil {
+		t.Errorf("block above blob limit before update accepted")
+	}
+	if err := VerifyExcessDataGas(&config, parent, before); err == nil {
+		t.Errorf("excess data gas above blob limit before update accepted")
+	}
+	after := &types.Header{Number: big.NewInt(2), Time: 100, ExcessDataGas: CalcExcessDataGas(&config, parent, 100, 8)}
+	if err := VerifyEip4844Header(&config, parent, after, 8); err != nil {
+		t.Errorf("block at raised blob limit rejected: %v", err)
+	}
+	if err := VerifyExcessDataGas(&config, parent, after); err != nil {
+		t.Errorf("excess data gas at raised blob limit rejected: %v", err)
+	}
+}
@@ -26,4 +26,5 @@ var (
 	GenericServerError = rpc.CustomError{Code: -32000, ValidationError: "Server error"}
 	UnknownPayload     = rpc.CustomError{Code: -32001, ValidationError: "Unknown payload"}
 	InvalidTB          = rpc.CustomError{Code: -32002, ValidationError: "Invalid terminal block"}
+	EvictedBundle      = rpc.CustomError{Code: -32003, ValidationError: "Blobs bundle evicted"}
 )
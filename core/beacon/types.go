@@ -23,6 +23,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/trie"
 )
 
@@ -58,6 +59,7 @@ type ExecutableDataV1 struct {
 	BaseFeePerGas *big.Int       `json:"baseFeePerGas" gencodec:"required"`
 	BlockHash     common.Hash    `json:"blockHash"     gencodec:"required"`
 	Transactions  [][]byte       `json:"transactions"  gencodec:"required"`
+	ExcessDataGas *big.Int       `json:"excessDataGas,omitempty"`
 }
 
 // JSON type overrides for executableData.
@@ -67,11 +69,27 @@ type executableDataMarshaling struct {
 	GasUsed       hexutil.Uint64
 	Timestamp     hexutil.Uint64
 	BaseFeePerGas *hexutil.Big
+	ExcessDataGas *hexutil.Big
 	ExtraData     hexutil.Bytes
 	LogsBloom     hexutil.Bytes
 	Transactions  []hexutil.Bytes
 }
 
+// BlobsBundleV1 holds the blobs of a built payload, along with their
+// commitments, so the beacon chain can construct the blob sidecars.
+type BlobsBundleV1 struct {
+	BlockHash common.Hash         `json:"blockHash"`
+	KZGs      []kzg.KZGCommitment `json:"kzgs"`
+	Blobs     []kzg.Blob          `json:"blobs"`
+}
+
+// ExecutionPayloadBlobsBundleV1 is the response of getPayloadV3, bundling the
+// execution payload with the blobs of the transactions it includes.
+type ExecutionPayloadBlobsBundleV1 struct {
+	ExecutionPayload *ExecutableDataV1 `json:"executionPayload"`
+	BlobsBundle      *BlobsBundleV1    `json:"blobsBundle"`
+}
+
 type NewBlockResponse struct {
 	Valid bool `json:"valid"`
 }
@@ -159,21 +177,22 @@ func ExecutableDataToBlock(params ExecutableDataV1) (*types.Block, error) {
 		return nil, fmt.Errorf("invalid extradata length: %v", len(params.ExtraData))
 	}
 	header := &types.Header{
-		ParentHash:  params.ParentHash,
-		UncleHash:   types.EmptyUncleHash,
-		Coinbase:    params.FeeRecipient,
-		Root:        params.StateRoot,
-		TxHash:      types.DeriveSha(types.Transactions(txs), trie.NewStackTrie(nil)),
-		ReceiptHash: params.ReceiptsRoot,
-		Bloom:       types.BytesToBloom(params.LogsBloom),
-		Difficulty:  common.Big0,
-		Number:      new(big.Int).SetUint64(params.Number),
-		GasLimit:    params.GasLimit,
-		GasUsed:     params.GasUsed,
-		Time:        params.Timestamp,
-		BaseFee:     params.BaseFeePerGas,
-		Extra:       params.ExtraData,
-		MixDigest:   params.Random,
+		ParentHash:    params.ParentHash,
+		UncleHash:     types.EmptyUncleHash,
+		Coinbase:      params.FeeRecipient,
+		Root:          params.StateRoot,
+		TxHash:        types.DeriveSha(types.Transactions(txs), trie.NewStackTrie(nil)),
+		ReceiptHash:   params.ReceiptsRoot,
+		Bloom:         types.BytesToBloom(params.LogsBloom),
+		Difficulty:    common.Big0,
+		Number:        new(big.Int).SetUint64(params.Number),
+		GasLimit:      params.GasLimit,
+		GasUsed:       params.GasUsed,
+		Time:          params.Timestamp,
+		BaseFee:       params.BaseFeePerGas,
+		Extra:         params.ExtraData,
+		MixDigest:     params.Random,
+		ExcessDataGas: params.ExcessDataGas,
 	}
 	block := types.NewBlockWithHeader(header).WithBody(txs, nil /* uncles */)
 	if block.Hash() != params.BlockHash {
@@ -200,5 +219,29 @@ func BlockToExecutableData(block *types.Block) *ExecutableDataV1 {
 		Transactions:  encodeTransactions(block.Transactions()),
 		Random:        block.MixDigest(),
 		ExtraData:     block.Extra(),
+		ExcessDataGas: block.ExcessDataGas(),
+	}
+}
+
+// BlockToBlobsBundle collects the blobs and commitments of all the blob
+// transactions in the given block. The transactions are expected to carry
+// their sidecars, as the ones of locally built blocks do.
+func BlockToBlobsBundle(block *types.Block) (*BlobsBundleV1, error) {
+	bundle := &BlobsBundleV1{
+		BlockHash: block.Hash(),
+		KZGs:      []kzg.KZGCommitment{},
+		Blobs:     []kzg.Blob{},
+	}
+	for i, tx := range block.Transactions() {
+		if len(tx.DataHashes()) == 0 {
+			continue
+		}
+		sidecar := tx.BlobTxSidecar()
+		if sidecar == nil {
+			return nil, fmt.Errorf("missing blobs of transaction %d (%x)", i, tx.Hash())
+		}
+		bundle.KZGs = append(bundle.KZGs, sidecar.Commitments...)
+		bundle.Blobs = append(bundle.Blobs, sidecar.Blobs...)
 	}
+	return bundle, nil
 }
@@ -20,6 +20,7 @@ import (
 	"crypto/ecdsa"
 	"io/ioutil"
 	"math/big"
+	"math/rand"
 	"os"
 	"testing"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
 )
@@ -86,7 +88,7 @@ func genValueTx(nbytes int) func(int, *BlockGen) {
 		toaddr := common.Address{}
 		data := make([]byte, nbytes)
 		gas, _ := IntrinsicGas(data, nil, false, false, false)
-		signer := types.MakeSigner(gen.config, big.NewInt(int64(i)))
+		signer := types.MakeSigner(gen.config, big.NewInt(int64(i)), gen.Time())
 		gasPrice := big.NewInt(0)
 		if gen.header.BaseFee != nil {
 			gasPrice = gen.header.BaseFee
@@ -130,7 +132,7 @@ func genTxRing(naccounts int) func(int, *BlockGen) {
 		if gen.header.BaseFee != nil {
 			gasPrice = gen.header.BaseFee
 		}
-		signer := types.MakeSigner(gen.config, big.NewInt(int64(i)))
+		signer := types.MakeSigner(gen.config, big.NewInt(int64(i)), gen.Time())
 		for {
 			gas -= params.TxGas
 			if gas < params.TxGas {
@@ -249,6 +251,111 @@ func BenchmarkChainWrite_full_500k(b *testing.B) {
 
 // makeChainForBench writes a given number of headers or empty blocks/receipts
 // into a database.
+func BenchmarkBlobPipeline_1blob(b *testing.B)    { benchBlobPipeline(b, 1) }
+func BenchmarkBlobPipeline_2blobs(b *testing.B)   { benchBlobPipeline(b, 2) }
+func BenchmarkBlobPipeline_maxblobs(b *testing.B) { benchBlobPipeline(b, params.MaxBlobsPerBlock) }
+
+// benchBlobPipeline measures the lifecycle of a blob transaction carrying the
+// given number of blobs: submitting it to the pool, which verifies the blobs,
+// building a block with it, importing the block and storing its sidecar.
+func benchBlobPipeline(b *testing.B, blobs int) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		config = params.TestShardingChainConfig
+		gspec  = Genesis{
+			Config:  config,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Alloc:   GenesisAlloc{benchRootAddr: {Balance: benchRootFunds}},
+		}
+	)
+	gspec.MustCommit(db)
+
+	chain, _ := NewBlockChain(db, nil, config, ethash.NewFaker(), vm.Config{}, nil, nil)
+	defer chain.Stop()
+
+	pool := NewTxPool(testTxPoolConfig, config, chain)
+	defer pool.Stop()
+	<-pool.initDoneCh
+
+	// Create random blobs up front, committing to them is not part of the pipeline
+	sidecar := new(types.BlobTxSidecar)
+	for i := 0; i < blobs; i++ {
+		var (
+			blob kzg.Blob
+			data = make([]byte, kzg.MaxBlobDataSize)
+		)
+		rand.Read(data)
+		if err := blob.EncodeData(data); err != nil {
+			b.Fatalf("failed to encode blob: %v", err)
+		}
+		commitment, err := kzg.BlobToKZGCommitment(&blob)
+		if err != nil {
+			b.Fatalf("failed to commit to blob: %v", err)
+		}
+		proof, err := kzg.ComputeBlobKZGProof(&blob, commitment)
+		if err != nil {
+			b.Fatalf("failed to compute blob proof: %v", err)
+		}
+		sidecar.Blobs = append(sidecar.Blobs, blob)
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+	}
+	hashes := sidecar.BlobHashes()
+
+	// insert imports a single block with the given transactions, waiting for the
+	// pool to catch up with the new head
+	insert := func(txs ...*types.Transaction) *types.Block {
+		parent := chain.CurrentBlock()
+		blocks, _ := GenerateChain(config, parent, ethash.NewFaker(), db, 1, func(i int, gen *BlockGen) {
+			for _, tx := range txs {
+				gen.AddTxWithChain(chain, tx)
+			}
+		})
+		if _, err := chain.InsertChain(blocks); err != nil {
+			b.Fatalf("failed to import block: %v", err)
+		}
+		<-pool.requestReset(parent.Header(), blocks[0].Header())
+		return blocks[0]
+	}
+	signer := types.LatestSignerForChainID(config.ChainID)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tx, err := types.SignNewTx(benchRootKey, signer, &types.BlobTx{
+			ChainID:             config.ChainID,
+			Nonce:               uint64(i),
+			GasTipCap:           big.NewInt(1),
+			GasFeeCap:           big.NewInt(2 * params.InitialBaseFee),
+			Gas:                 params.TxGas,
+			To:                  &common.Address{},
+			MaxFeePerDataGas:    big.NewInt(100),
+			BlobVersionedHashes: hashes,
+		})
+		if err != nil {
+			b.Fatalf("failed to sign transaction: %v", err)
+		}
+		if errs := pool.AddRemotesSync([]*types.Transaction{tx.WithBlobTxSidecar(sidecar)}); errs[0] != nil {
+			b.Fatalf("failed to pool transaction: %v", errs[0])
+		}
+		pending := pool.Pending(false)[benchRootAddr]
+		if len(pending) != 1 {
+			b.Fatalf("pending transaction count mismatch: have %d, want 1", len(pending))
+		}
+		block := insert(pool.GetWithBlobs(pending[0].Hash()))
+		if stored := rawdb.ReadBlobSidecars(db, block.Hash(), block.NumberU64()); len(stored) != 1 {
+			b.Fatalf("stored sidecar count mismatch: have %d, want 1", len(stored))
+		}
+		// Blocks above the data gas target raise its price, bring it back down
+		// with an empty block to keep the iterations comparable
+		if block.ExcessDataGas().Sign() > 0 {
+			b.StopTimer()
+			insert()
+			b.StartTimer()
+		}
+	}
+}
+
 func makeChainForBench(db ethdb.Database, full bool, count uint64) {
 	var hash common.Hash
 	for n := uint64(0); n < count; n++ {
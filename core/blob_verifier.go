@@ -0,0 +1,130 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+)
+
+// sidecarVerifier is a concurrent verifier of the blob sidecars attached to the
+// transactions of imported blocks.
+var sidecarVerifier = newBlobSidecarVerifier(runtime.NumCPU())
+
+// blobSidecarVerifierRequest is a request for verifying the sidecars attached
+// to the blob transactions of a block.
+type blobSidecarVerifierRequest struct {
+	txs    []*types.Transaction
+	result chan<- error
+}
+
+// blobSidecarVerifier is a helper structure to concurrently run the KZG proof
+// verification of blob sidecars on background threads, separate from the ones
+// recovering the transaction senders.
+type blobSidecarVerifier struct {
+	tasks chan *blobSidecarVerifierRequest
+}
+
+// newBlobSidecarVerifier creates a new sidecar verifier and starts the given
+// number of processing goroutines.
+func newBlobSidecarVerifier(threads int) *blobSidecarVerifier {
+	verifier := &blobSidecarVerifier{
+		tasks: make(chan *blobSidecarVerifierRequest, threads),
+	}
+	for i := 0; i < threads; i++ {
+		go verifier.verify()
+	}
+	return verifier
+}
+
+// verify is an infinite loop, verifying the sidecars of the scheduled blocks.
+func (verifier *blobSidecarVerifier) verify() {
+	for task := range verifier.tasks {
+		task.result <- verifyBlockSidecars(task.txs)
+	}
+}
+
+// verifyFromBlocks schedules the verification of the sidecars attached to a
+// batch of blocks, returning a channel per block which delivers the first
+// verification failure of the block, or nil if all its sidecars are valid.
+// Blocks without sidecars deliver nil immediately.
+func (verifier *blobSidecarVerifier) verifyFromBlocks(blocks []*types.Block) []<-chan error {
+	var (
+		results = make([]<-chan error, len(blocks))
+		tasks   []*blobSidecarVerifierRequest
+	)
+	for i, block := range blocks {
+		var txs []*types.Transaction
+		for _, tx := range block.Transactions() {
+			if tx.Type() == types.BlobTxType && tx.BlobTxSidecar() != nil {
+				txs = append(txs, tx)
+			}
+		}
+		// The channels are buffered so abandoned results don't block the verifier
+		result := make(chan error, 1)
+		results[i] = result
+
+		if len(txs) == 0 {
+			result <- nil
+			continue
+		}
+		tasks = append(tasks, &blobSidecarVerifierRequest{txs: txs, result: result})
+	}
+	// Schedule the blocks in order, so the first ones are ready first
+	go func() {
+		for _, task := range tasks {
+			verifier.tasks <- task
+		}
+	}()
+	return results
+}
+
+// verifyBlockSidecars checks the sidecars of the blob transactions of a block
+// against their versioned hashes, verifying the KZG proofs of all the blobs of
+// the block in a single batch.
+func verifyBlockSidecars(txs []*types.Transaction) error {
+	var (
+		blobs       []kzg.Blob
+		commitments []kzg.KZGCommitment
+		proofs      []kzg.KZGProof
+		owners      []*types.Transaction // Transaction carrying each blob
+	)
+	for _, tx := range txs {
+		sidecar := tx.BlobTxSidecar()
+		if err := sidecar.VerifyHashes(tx.DataHashes()); err != nil {
+			return fmt.Errorf("tx %#x: %w", tx.Hash(), err)
+		}
+		blobs = append(blobs, sidecar.Blobs...)
+		commitments = append(commitments, sidecar.Commitments...)
+		proofs = append(proofs, sidecar.Proofs...)
+		for range sidecar.Blobs {
+			owners = append(owners, tx)
+		}
+	}
+	if err := kzg.VerifyBlobKZGProofBatch(blobs, commitments, proofs); err != nil {
+		var blobErr *kzg.BlobError
+		if errors.As(err, &blobErr) {
+			return fmt.Errorf("tx %#x: %w", owners[blobErr.Index].Hash(), blobErr.Err)
+		}
+		return err
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
@@ -65,6 +66,18 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 	if hash := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); hash != header.TxHash {
 		return fmt.Errorf("transaction root hash mismatch: have %x, want %x", hash, header.TxHash)
 	}
+	// Blobs may not exceed the data gas limit and must be accounted for in the
+	// excess data gas carried over from the parent. Headers don't carry the data
+	// gas used, the excess data gas commits to it instead.
+	dataGasUsed := block.DataGasUsed()
+	if max := v.config.ShardingParamsAt(header.Time).MaxDataGasPerBlock(); dataGasUsed > max {
+		return fmt.Errorf("%w: have %d, max %d", ErrDataGasLimitReached, dataGasUsed, max)
+	}
+	if parent := v.bc.GetHeader(block.ParentHash(), block.NumberU64()-1); parent != nil {
+		if err := misc.VerifyEip4844Header(v.config, parent, header, int(dataGasUsed/params.DataGasPerBlob)); err != nil {
+			return err
+		}
+	}
 	if !v.bc.HasBlockAndState(block.ParentHash(), block.NumberU64()-1) {
 		if !v.bc.HasBlock(block.ParentHash(), block.NumberU64()-1) {
 			return consensus.ErrUnknownAncestor
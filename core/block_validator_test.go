@@ -18,6 +18,7 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
 	"math/big"
 	"runtime"
 	"testing"
@@ -28,11 +29,13 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
 )
 
 // Tests that simple header verification works, for both good and bad blocks.
@@ -371,6 +374,65 @@ func testHeaderConcurrentAbortion(t *testing.T, threads int) {
 	}
 }
 
+// Tests that blocks carrying more blobs than allowed are rejected, as well as
+// blocks whose data gas usage doesn't match the one derived during processing.
+func TestBlockBlobLimits(t *testing.T) {
+	var (
+		testdb    = rawdb.NewMemoryDatabase()
+		gspec     = &Genesis{Config: params.TestShardingChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+		genesis   = gspec.MustCommit(testdb)
+		blocks, _ = GenerateChain(params.TestShardingChainConfig, genesis, ethash.NewFaker(), testdb, 1, nil)
+	)
+	chain, _ := NewBlockChain(testdb, nil, params.TestShardingChainConfig, ethash.NewFaker(), vm.Config{}, nil, nil)
+	defer chain.Stop()
+
+	// makeBlock assembles a child of the genesis with blob transactions carrying
+	// the given number of blobs each
+	makeBlock := func(blobs ...int) *types.Block {
+		var txs []*types.Transaction
+		for i, n := range blobs {
+			txs = append(txs, types.NewTx(&types.BlobTx{
+				ChainID:             params.TestShardingChainConfig.ChainID,
+				Nonce:               uint64(i),
+				GasTipCap:           common.Big0,
+				GasFeeCap:           big.NewInt(params.InitialBaseFee),
+				Gas:                 params.TxGas,
+				Value:               common.Big0,
+				MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+				BlobVersionedHashes: make([]common.Hash, n),
+			}))
+		}
+		header := blocks[0].Header()
+		var total int
+		for _, n := range blobs {
+			total += n
+		}
+		header.ExcessDataGas = misc.CalcExcessDataGas(params.TestShardingChainConfig, genesis.Header(), header.Time, total)
+		return types.NewBlock(header, txs, nil, nil, trie.NewStackTrie(nil))
+	}
+	validator := chain.Validator()
+
+	// Blocks within the limit are accepted, regardless of how the blobs are split
+	for _, blobs := range [][]int{{params.MaxBlobsPerBlock}, {1, params.MaxBlobsPerBlock - 1}} {
+		if err := validator.ValidateBody(makeBlock(blobs...)); err != nil {
+			t.Errorf("blobs %v: valid block rejected: %v", blobs, err)
+		}
+	}
+	// Blocks above the limit are rejected, even if every transaction fits
+	for _, blobs := range [][]int{{params.MaxBlobsPerBlock + 1}, {1, params.MaxBlobsPerBlock}} {
+		if err := validator.ValidateBody(makeBlock(blobs...)); !errors.Is(err, ErrDataGasLimitReached) {
+			t.Errorf("blobs %v: error mismatch: have %v, want %v", blobs, err, ErrDataGasLimitReached)
+		}
+	}
+	// Blocks whose excess data gas doesn't account for their blobs are rejected
+	block := makeBlock(params.MaxBlobsPerBlock)
+	header := block.Header()
+	header.ExcessDataGas = misc.CalcExcessDataGas(params.TestShardingChainConfig, genesis.Header(), header.Time, params.MaxBlobsPerBlock-1)
+	if err := validator.ValidateBody(block.WithSeal(header)); err == nil {
+		t.Errorf("block with mismatching excess data gas accepted")
+	}
+}
+
 func TestCalcGasLimit(t *testing.T) {
 	for i, tc := range []struct {
 		pGasLimit uint64
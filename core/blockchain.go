@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/common/prque"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
@@ -78,6 +79,12 @@ var (
 	blockPrefetchExecuteTimer   = metrics.NewRegisteredTimer("chain/prefetch/executes", nil)
 	blockPrefetchInterruptMeter = metrics.NewRegisteredMeter("chain/prefetch/interrupts", nil)
 
+	blobTailGauge      = metrics.NewRegisteredGauge("chain/blobs/tail", nil)      // Oldest block whose sidecars are retained
+	blobRetainedGauge  = metrics.NewRegisteredGauge("chain/blobs/retained", nil)  // Number of blocks whose sidecars are retained
+	blobBacklogGauge   = metrics.NewRegisteredGauge("chain/blobs/backlog", nil)   // Number of blocks whose sidecars are due for pruning
+	blobReclaimedMeter = metrics.NewRegisteredMeter("chain/blobs/reclaimed", nil) // Bytes of sidecars deleted by the pruner
+	blobPruneTimer     = metrics.NewRegisteredTimer("chain/blobs/prune", nil)     // Duration of the pruning passes
+
 	errInsertionInterrupted = errors.New("insertion is interrupted")
 	errChainStopped         = errors.New("blockchain is stopped")
 )
@@ -129,6 +136,7 @@ type CacheConfig struct {
 	TrieTimeLimit       time.Duration // Time limit after which to flush the current in-memory trie to disk
 	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
 	Preimages           bool          // Whether to store preimage of trie key to the disk
+	BlobRetention       uint64        // Number of recent blocks to retain blob sidecars for (0 = entire chain)
 
 	SnapshotWait bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
 }
@@ -390,6 +398,15 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 		go bc.maintainTxIndex(txIndexBlock)
 	}
 
+	// Start blob sidecar pruner, or archive them all into the freezer if the
+	// entire chain is to be retained.
+	if bc.cacheConfig.BlobRetention != 0 {
+		bc.wg.Add(1)
+		go bc.maintainBlobSidecars()
+	} else {
+		rawdb.EnableBlobArchive(bc.db)
+	}
+
 	// If periodic cache journal is required, spin it up.
 	if bc.cacheConfig.TrieCleanRejournal > 0 {
 		if bc.cacheConfig.TrieCleanRejournal < time.Minute {
@@ -598,11 +615,12 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, root common.Hash, repair bo
 			// Remove the hash <-> number mapping from the active store.
 			rawdb.DeleteHeaderNumber(db, hash)
 		} else {
-			// Remove relative body and receipts from the active store.
+			// Remove relative body, receipts and blobs from the active store.
 			// The header, total difficulty and canonical hash will be
 			// removed in the hc.SetHead function.
 			rawdb.DeleteBody(db, hash, num)
 			rawdb.DeleteReceipts(db, hash, num)
+			rawdb.DeleteBlobSidecars(db, hash, num)
 		}
 		// Todo(rjl493456442) txlookup, bloombits, etc
 	}
@@ -742,6 +760,9 @@ func (bc *BlockChain) writeHeadBlock(block *types.Block) {
 	rawdb.WriteHeadFastBlockHash(batch, block.Hash())
 	rawdb.WriteCanonicalHash(batch, block.Hash(), block.NumberU64())
 	rawdb.WriteTxLookupEntriesByBlock(batch, block)
+	if rawdb.HasBlobSidecars(bc.db, block.Hash(), block.NumberU64()) {
+		rawdb.WriteBlobLookupEntriesByBlock(batch, block)
+	}
 	rawdb.WriteHeadBlockHash(batch, block.Hash())
 
 	// Flush the whole batch into the disk, exit the node if failed
@@ -891,6 +912,24 @@ func (bc *BlockChain) InsertReceiptChain(blockChain types.Blocks, receiptChain [
 					blockChain[i-1].Hash().Bytes()[:4], i, blockChain[i].NumberU64(), blockChain[i].Hash().Bytes()[:4], blockChain[i].ParentHash().Bytes()[:4])
 			}
 		}
+		// Header verification could only bound the excess data gas without the
+		// bodies, check that it accounts for the blobs exactly
+		var parent *types.Header
+		if i != 0 {
+			parent = blockChain[i-1].Header()
+		} else {
+			parent = bc.GetHeader(blockChain[i].ParentHash(), blockChain[i].NumberU64()-1)
+		}
+		if parent != nil {
+			var blobs int
+			for _, tx := range blockChain[i].Transactions() {
+				blobs += len(tx.DataHashes())
+			}
+			if err := misc.VerifyEip4844Header(bc.chainConfig, parent, blockChain[i].Header(), blobs); err != nil {
+				log.Error("Invalid data gas accounting in receipt insert", "number", blockChain[i].Number(), "hash", blockChain[i].Hash(), "err", err)
+				return 0, fmt.Errorf("invalid data gas accounting: item %d is #%d [%x..]: %w", i, blockChain[i].NumberU64(), blockChain[i].Hash().Bytes()[:4], err)
+			}
+		}
 		if blockChain[i].NumberU64() <= ancientLimit {
 			ancientBlocks, ancientReceipts = append(ancientBlocks, blockChain[i]), append(ancientReceipts, receiptChain[i])
 		} else {
@@ -1130,6 +1169,15 @@ func (bc *BlockChain) InsertReceiptChain(blockChain types.Blocks, receiptChain [
 		"number", head.Number(), "hash", head.Hash(), "age", common.PrettyAge(time.Unix(int64(head.Time()), 0)),
 		"size", common.StorageSize(size),
 	}
+	var blobs int
+	for _, block := range blockChain {
+		for _, tx := range block.Transactions() {
+			blobs += len(tx.DataHashes())
+		}
+	}
+	if blobs > 0 {
+		context = append(context, []interface{}{"blobs", blobs}...)
+	}
 	if stats.ignored > 0 {
 		context = append(context, []interface{}{"ignored", stats.ignored}...)
 	}
@@ -1170,6 +1218,26 @@ func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
 	return nil
 }
 
+// blockBlobSidecars collects the sidecars of the blob transactions in a block,
+// in transaction order. Sidecars are not part of the block encoding, so they
+// are only available if every blob transaction was attached its sidecar before
+// import (i.e. for locally built blocks and engine API payloads whose blobs are
+// known to the transaction pool); nil is returned otherwise.
+func blockBlobSidecars(block *types.Block) []*types.BlobTxSidecar {
+	var sidecars []*types.BlobTxSidecar
+	for _, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		sidecar := tx.BlobTxSidecar()
+		if sidecar == nil {
+			return nil
+		}
+		sidecars = append(sidecars, sidecar)
+	}
+	return sidecars
+}
+
 // writeBlockWithState writes block, metadata and corresponding state data to the
 // database.
 func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB) error {
@@ -1189,6 +1257,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	rawdb.WriteTd(blockBatch, block.Hash(), block.NumberU64(), externTd)
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
+	if sidecars := blockBlobSidecars(block); sidecars != nil {
+		rawdb.WriteBlobSidecars(blockBatch, block.Hash(), block.NumberU64(), sidecars)
+	}
 	rawdb.WritePreimages(blockBatch, state.Preimages())
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
@@ -1380,7 +1451,11 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 	}
 
 	// Start a parallel signature recovery (signer will fluke on fork transition, minimal perf loss)
-	senderCacher.recoverFromBlocks(types.MakeSigner(bc.chainConfig, chain[0].Number()), chain)
+	senderCacher.recoverFromBlocks(types.MakeSigner(bc.chainConfig, chain[0].Number(), chain[0].Time()), chain)
+
+	// Start a parallel verification of the attached blob sidecars on a separate
+	// thread pool, overlapping the KZG proof checks with block execution
+	sidecarResults := sidecarVerifier.verifyFromBlocks(chain)
 
 	var (
 		stats     = insertStats{startTime: mclock.Now()}
@@ -1618,6 +1693,12 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 			atomic.StoreUint32(&followupInterrupt, 1)
 			return it.index, err
 		}
+		if err := <-sidecarResults[it.index]; err != nil {
+			err = fmt.Errorf("%w: %v", ErrInvalidBlobSidecar, err)
+			bc.reportBlock(block, receipts, err)
+			atomic.StoreUint32(&followupInterrupt, 1)
+			return it.index, err
+		}
 		proctime := time.Since(start)
 
 		// Update the metrics touched during block validation
@@ -1656,7 +1737,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 		case CanonStatTy:
 			log.Debug("Inserted new block", "number", block.Number(), "hash", block.Hash(),
 				"uncles", len(block.Uncles()), "txs", len(block.Transactions()), "gas", block.GasUsed(),
-				"elapsed", common.PrettyDuration(time.Since(start)),
+				"datagas", block.DataGasUsed(), "elapsed", common.PrettyDuration(time.Since(start)),
 				"root", block.Root())
 
 			lastCanon = block
@@ -1680,6 +1761,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 		}
 		stats.processed++
 		stats.usedGas += usedGas
+		stats.usedDataGas += block.DataGasUsed()
 
 		dirty, _ := bc.stateCache.TrieDB().Size()
 		stats.report(chain, it.index, dirty)
@@ -2030,6 +2112,21 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 	for _, tx := range types.TxDifference(deletedTxs, addedTxs) {
 		rawdb.DeleteTxLookupEntry(indexesBatch, tx.Hash())
 	}
+	// Blobs may be reincluded by different transactions, only drop the lookups
+	// of the ones not carried by the new chain
+	addedBlobs := make(map[common.Hash]struct{})
+	for _, tx := range addedTxs {
+		for _, hash := range tx.DataHashes() {
+			addedBlobs[hash] = struct{}{}
+		}
+	}
+	for _, tx := range deletedTxs {
+		for _, hash := range tx.DataHashes() {
+			if _, ok := addedBlobs[hash]; !ok {
+				rawdb.DeleteBlobLookupEntry(indexesBatch, hash)
+			}
+		}
+	}
 	// Delete any canonical number assignments above the new head
 	number := bc.CurrentBlock().NumberU64()
 	for i := number + 1; ; i++ {
@@ -2254,6 +2351,77 @@ func (bc *BlockChain) maintainTxIndex(ancients uint64) {
 	}
 }
 
+// maintainBlobSidecars is responsible for pruning the blob sidecars of blocks
+// that fell out of the retention window.
+//
+// User can use flag `blobretention` to specify the number of recent blocks to
+// retain blob sidecars for. Sidecars are large and only needed until the data
+// availability period expires, so keeping them forever would grow the database
+// unboundedly.
+func (bc *BlockChain) maintainBlobSidecars() {
+	defer bc.wg.Done()
+
+	// pruneBlocks removes the sidecars between the tail and the retention window
+	pruneBlocks := func(tail *uint64, head uint64, done chan struct{}) {
+		defer func() { done <- struct{}{} }()
+
+		var from, to uint64
+		if tail != nil {
+			from = *tail
+		}
+		if head+1 > bc.cacheConfig.BlobRetention {
+			to = head + 1 - bc.cacheConfig.BlobRetention
+		}
+		if from < to {
+			blobBacklogGauge.Update(int64(to - from))
+
+			start := time.Now()
+			blobReclaimedMeter.Mark(int64(rawdb.PruneBlobSidecars(bc.db, from, to, bc.quit)))
+			blobPruneTimer.UpdateSince(start)
+
+			if tail := rawdb.ReadBlobSidecarsTail(bc.db); tail != nil {
+				from = *tail
+			}
+		}
+		// Report the retention window actually maintained, which falls behind
+		// the configured one if pruning was interrupted
+		var backlog uint64
+		if from < to {
+			backlog = to - from
+		}
+		blobBacklogGauge.Update(int64(backlog))
+		blobTailGauge.Update(int64(from))
+		blobRetainedGauge.Update(int64(head + 1 - from))
+	}
+	var (
+		done   chan struct{}                  // Non-nil if background pruning routine is active.
+		headCh = make(chan ChainHeadEvent, 1) // Buffered to avoid locking up the event feed
+	)
+	sub := bc.SubscribeChainHeadEvent(headCh)
+	if sub == nil {
+		return
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case head := <-headCh:
+			if done == nil {
+				done = make(chan struct{})
+				go pruneBlocks(rawdb.ReadBlobSidecarsTail(bc.db), head.Block.NumberU64(), done)
+			}
+		case <-done:
+			done = nil
+		case <-bc.quit:
+			if done != nil {
+				log.Info("Waiting background blob sidecar pruner to exit")
+				<-done
+			}
+			return
+		}
+	}
+}
+
 // reportBlock logs a bad block error.
 func (bc *BlockChain) reportBlock(block *types.Block, receipts types.Receipts, err error) {
 	rawdb.WriteBadBlock(bc.db, block)
@@ -28,7 +28,7 @@ import (
 // insertStats tracks and reports on block insertion.
 type insertStats struct {
 	queued, processed, ignored int
-	usedGas                    uint64
+	usedGas, usedDataGas       uint64
 	lastIndex                  int
 	startTime                  mclock.AbsTime
 }
@@ -47,10 +47,15 @@ func (st *insertStats) report(chain []*types.Block, index int, dirty common.Stor
 	)
 	// If we're at the last block of the batch or report period reached, log
 	if index == len(chain)-1 || elapsed >= statsReportLimit {
-		// Count the number of transactions in this segment
-		var txs int
+		// Count the number of transactions and blobs in this segment
+		var txs, blobTxs, blobs int
 		for _, block := range chain[st.lastIndex : index+1] {
 			txs += len(block.Transactions())
+			for _, tx := range block.Transactions() {
+				if n := len(tx.DataHashes()); n > 0 {
+					blobTxs, blobs = blobTxs+1, blobs+n
+				}
+			}
 		}
 		end := chain[index]
 
@@ -63,6 +68,9 @@ func (st *insertStats) report(chain []*types.Block, index int, dirty common.Stor
 		if timestamp := time.Unix(int64(end.Time()), 0); time.Since(timestamp) > time.Minute {
 			context = append(context, []interface{}{"age", common.PrettyAge(timestamp)}...)
 		}
+		if blobs > 0 {
+			context = append(context, []interface{}{"blobtxs", blobTxs, "blobs", blobs, "datagas", st.usedDataGas}...)
+		}
 		context = append(context, []interface{}{"dirty", dirty}...)
 
 		if st.queued > 0 {
@@ -210,6 +210,16 @@ func (bc *BlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
 	return receipts
 }
 
+// GetBlobSidecarsByHash retrieves the sidecars of the blob transactions in a
+// given block, if they are still retained.
+func (bc *BlockChain) GetBlobSidecarsByHash(hash common.Hash) []*types.BlobTxSidecar {
+	number := rawdb.ReadHeaderNumber(bc.db, hash)
+	if number == nil {
+		return nil
+	}
+	return rawdb.ReadBlobSidecars(bc.db, hash, *number)
+}
+
 // GetUnclesInChain retrieves all the uncles from a given block backwards until
 // a specific distance is reached.
 func (bc *BlockChain) GetUnclesInChain(block *types.Block, length int) []*types.Header {
@@ -251,6 +261,17 @@ func (bc *BlockChain) GetTransactionLookup(hash common.Hash) *rawdb.LegacyTxLook
 	return lookup
 }
 
+// GetBlobBlockHash retrieves the hash of the canonical block carrying the blob
+// with the given versioned hash, as long as its sidecar is retained. The block
+// might not carry the blob anymore if it was reorged out after the lookup.
+func (bc *BlockChain) GetBlobBlockHash(versionedHash common.Hash) common.Hash {
+	number := rawdb.ReadBlobLookupEntry(bc.db, versionedHash)
+	if number == nil {
+		return common.Hash{}
+	}
+	return rawdb.ReadCanonicalHash(bc.db, *number)
+}
+
 // GetTd retrieves a block's total difficulty in the canonical chain from the
 // database by hash and number, caching it if found.
 func (bc *BlockChain) GetTd(hash common.Hash, number uint64) *big.Int {
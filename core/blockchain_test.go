@@ -37,6 +37,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
@@ -3552,6 +3553,132 @@ func TestEIP2718Transition(t *testing.T) {
 	}
 }
 
+// TestBlobTxAccessList tests that the access list of a blob transaction is
+// charged for and warms up the listed slots the same way as the access lists
+// of EIP-2930 and EIP-1559 transactions do.
+func TestBlobTxAccessList(t *testing.T) {
+	var (
+		aa = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+
+		// Generate a canonical chain to act as the main dataset
+		engine = ethash.NewFaker()
+		db     = rawdb.NewMemoryDatabase()
+
+		// A sender who makes transactions, has some funds
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000000000)
+		gspec   = &Genesis{
+			Config: params.TestShardingChainConfig,
+			Alloc: GenesisAlloc{
+				address: {Balance: funds},
+				// The address 0xAAAA sloads 0x00 and 0x01
+				aa: {
+					Code: []byte{
+						byte(vm.PC),
+						byte(vm.PC),
+						byte(vm.SLOAD),
+						byte(vm.SLOAD),
+					},
+					Nonce:   0,
+					Balance: big.NewInt(0),
+				},
+			},
+		}
+		genesis = gspec.MustCommit(db)
+
+		accesses = types.AccessList{{
+			Address:     aa,
+			StorageKeys: []common.Hash{{0}},
+		}}
+		hashes = []common.Hash{kzg.KZGCommitment{0xc0}.ComputeVersionedHash()}
+	)
+	blocks, _ := GenerateChain(gspec.Config, genesis, engine, db, 1, func(i int, b *BlockGen) {
+		b.SetCoinbase(common.Address{1})
+
+		// Transactions to 0xAAAA of every type accepting an access list, and a
+		// blob transaction without one
+		signer := types.LatestSigner(gspec.Config)
+		for _, inner := range []types.TxData{
+			&types.AccessListTx{
+				ChainID:    gspec.Config.ChainID,
+				Nonce:      0,
+				To:         &aa,
+				Gas:        30000,
+				GasPrice:   b.header.BaseFee,
+				AccessList: accesses,
+			},
+			&types.DynamicFeeTx{
+				ChainID:    gspec.Config.ChainID,
+				Nonce:      1,
+				To:         &aa,
+				Gas:        30000,
+				GasFeeCap:  b.header.BaseFee,
+				GasTipCap:  common.Big0,
+				AccessList: accesses,
+			},
+			&types.BlobTx{
+				ChainID:             gspec.Config.ChainID,
+				Nonce:               2,
+				To:                  &aa,
+				Gas:                 30000,
+				GasFeeCap:           b.header.BaseFee,
+				GasTipCap:           common.Big0,
+				AccessList:          accesses,
+				MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+				BlobVersionedHashes: hashes,
+			},
+			&types.BlobTx{
+				ChainID:             gspec.Config.ChainID,
+				Nonce:               3,
+				To:                  &aa,
+				Gas:                 30000,
+				GasFeeCap:           b.header.BaseFee,
+				GasTipCap:           common.Big0,
+				MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+				BlobVersionedHashes: hashes,
+			},
+		} {
+			tx, err := types.SignNewTx(key, signer, inner)
+			if err != nil {
+				t.Fatalf("failed to sign transaction: %v", err)
+			}
+			b.AddTx(tx)
+		}
+	})
+
+	// Import the canonical chain
+	diskdb := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(diskdb)
+
+	chain, err := NewBlockChain(diskdb, nil, gspec.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	receipts := chain.GetReceiptsByHash(chain.GetBlockByNumber(1).Hash())
+	if len(receipts) != 4 {
+		t.Fatalf("receipt count mismatch: have %d, want %d", len(receipts), 4)
+	}
+	// Expected gas is intrinsic + 2 * pc + hot load + cold load, since only one load is in the access list
+	expected := params.TxGas + params.TxAccessListAddressGas + params.TxAccessListStorageKeyGas +
+		vm.GasQuickStep*2 + params.WarmStorageReadCostEIP2929 + params.ColdSloadCostEIP2929
+	for i, name := range []string{"access list", "dynamic fee", "blob"} {
+		if receipts[i].GasUsed != expected {
+			t.Errorf("%s transaction: incorrect amount of gas spent: expected %d, got %d", name, expected, receipts[i].GasUsed)
+		}
+	}
+	// Without an access list, both loads are cold
+	expected = params.TxGas + vm.GasQuickStep*2 + params.ColdSloadCostEIP2929*2
+	if receipts[3].GasUsed != expected {
+		t.Errorf("blob transaction without access list: incorrect amount of gas spent: expected %d, got %d", expected, receipts[3].GasUsed)
+	}
+}
+
 // TestEIP1559Transition tests the following:
 //
 // 1. A transaction whose gasFeeCap is greater than the baseFee is valid.
@@ -3704,3 +3831,156 @@ func TestEIP1559Transition(t *testing.T) {
 		t.Fatalf("sender balance incorrect: expected %d, got %d", expected, actual)
 	}
 }
+
+// Tests that the excess data gas of headers is bounded during header sync, and
+// checked against the blobs of the bodies once those are imported with receipts.
+func TestInsertReceiptChainExcessDataGas(t *testing.T) {
+	var (
+		gendb   = rawdb.NewMemoryDatabase()
+		gspec   = &Genesis{Config: params.TestShardingChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+		genesis = gspec.MustCommit(gendb)
+	)
+	generate := func(excess int64) ([]*types.Block, []types.Receipts) {
+		return GenerateChain(gspec.Config, genesis, ethash.NewFaker(), gendb, 3, func(i int, block *BlockGen) {
+			block.header.ExcessDataGas = new(big.Int)
+			if i == 2 {
+				block.header.ExcessDataGas.SetInt64(excess)
+			}
+		})
+	}
+	newChain := func() *BlockChain {
+		db := rawdb.NewMemoryDatabase()
+		gspec.MustCommit(db)
+		chain, _ := NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
+		return chain
+	}
+	headersOf := func(blocks []*types.Block) []*types.Header {
+		headers := make([]*types.Header, len(blocks))
+		for i, block := range blocks {
+			headers[i] = block.Header()
+		}
+		return headers
+	}
+	// An excess not reachable with any number of blobs must be rejected by header sync
+	blocks, _ := generate(params.DataGasPerBlob + 1)
+	chain := newChain()
+	if _, err := chain.InsertHeaderChain(headersOf(blocks), 1); err == nil {
+		t.Fatalf("unreachable excess data gas accepted during header sync")
+	}
+	chain.Stop()
+
+	// An excess claiming blobs the body does not carry passes header sync, but
+	// must be rejected once the body is imported
+	blocks, receipts := generate(params.DataGasPerBlob)
+	chain = newChain()
+	defer chain.Stop()
+	if n, err := chain.InsertHeaderChain(headersOf(blocks), 1); err != nil {
+		t.Fatalf("failed to insert header %d: %v", n, err)
+	}
+	if _, err := chain.InsertReceiptChain(blocks, receipts, 0); err == nil {
+		t.Fatalf("mismatching excess data gas accepted during receipt import")
+	}
+	if head := chain.CurrentFastBlock().NumberU64(); head != 0 {
+		t.Fatalf("fast head mismatch: have %d, want %d", head, 0)
+	}
+	// Importing only the valid prefix must succeed
+	if n, err := chain.InsertReceiptChain(blocks[:2], receipts[:2], 0); err != nil {
+		t.Fatalf("failed to insert receipt %d: %v", n, err)
+	}
+	if head := chain.CurrentFastBlock().NumberU64(); head != 2 {
+		t.Fatalf("fast head mismatch: have %d, want %d", head, 2)
+	}
+}
+
+// Tests that the blob sidecars attached to the transactions of imported blocks
+// are verified, rejecting blocks whose sidecars don't match their transactions.
+func TestInsertChainBlobSidecars(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		gendb   = rawdb.NewMemoryDatabase()
+		gspec   = &Genesis{
+			Config:  params.TestShardingChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Alloc:   GenesisAlloc{address: {Balance: big.NewInt(1000000000000000000)}},
+		}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSigner(gspec.Config)
+
+		// The point at infinity commits to the empty blob
+		sidecar = &types.BlobTxSidecar{
+			Blobs:       []kzg.Blob{{}},
+			Commitments: []kzg.KZGCommitment{{0xc0}},
+			Proofs:      []kzg.KZGProof{{0xc0}},
+		}
+	)
+	blocks, _ := GenerateChain(gspec.Config, genesis, ethash.NewFaker(), gendb, 1, func(i int, b *BlockGen) {
+		tx := types.MustSignNewTx(key, signer, &types.BlobTx{
+			ChainID:             gspec.Config.ChainID,
+			Nonce:               0,
+			To:                  &common.Address{},
+			Gas:                 params.TxGas,
+			GasFeeCap:           b.header.BaseFee,
+			GasTipCap:           common.Big0,
+			MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+			BlobVersionedHashes: sidecar.BlobHashes(),
+		})
+		b.AddTx(tx.WithBlobTxSidecar(sidecar))
+	})
+	tx := blocks[0].Transactions()[0]
+
+	newChain := func() (*BlockChain, ethdb.Database) {
+		db := rawdb.NewMemoryDatabase()
+		gspec.MustCommit(db)
+		chain, _ := NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
+		return chain, db
+	}
+	// A sidecar whose blob doesn't match the commitment must be rejected
+	corrupted := *sidecar
+	corrupted.Blobs = []kzg.Blob{{}}
+	corrupted.Blobs[0][31] = 0x01
+
+	chain, _ := newChain()
+	block := blocks[0].WithBody(types.Transactions{tx.WithBlobTxSidecar(&corrupted)}, nil)
+	if _, err := chain.InsertChain(types.Blocks{block}); !errors.Is(err, ErrInvalidBlobSidecar) {
+		t.Fatalf("corrupted sidecar error mismatch: have %v, want %v", err, ErrInvalidBlobSidecar)
+	}
+	chain.Stop()
+
+	// Blocks without sidecars are imported, as blobs are not part of blocks
+	chain, db := newChain()
+	block = blocks[0].WithBody(types.Transactions{tx.WithoutBlobTxSidecar()}, nil)
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatalf("failed to import block without sidecars: %v", err)
+	}
+	if sidecars := rawdb.ReadBlobSidecars(db, block.Hash(), block.NumberU64()); sidecars != nil {
+		t.Fatalf("sidecars stored for block imported without them")
+	}
+	if hash := chain.GetBlobBlockHash(tx.DataHashes()[0]); hash != (common.Hash{}) {
+		t.Fatalf("blob indexed without its sidecar: have %x", hash)
+	}
+	chain.Stop()
+
+	// Valid sidecars are imported along with the block
+	chain, db = newChain()
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to import block with sidecars: %v", err)
+	}
+	if sidecars := rawdb.ReadBlobSidecars(db, blocks[0].Hash(), blocks[0].NumberU64()); len(sidecars) != 1 {
+		t.Fatalf("sidecars not stored along with the block")
+	}
+	if hash := chain.GetBlobBlockHash(tx.DataHashes()[0]); hash != blocks[0].Hash() {
+		t.Fatalf("blob lookup mismatch: have %x, want %x", hash, blocks[0].Hash())
+	}
+	// Reorging the block out must drop the lookup of its blob
+	fork, _ := GenerateChain(gspec.Config, genesis, ethash.NewFaker(), gendb, 2, func(i int, b *BlockGen) {
+		b.SetCoinbase(common.Address{0x01})
+	})
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to import fork: %v", err)
+	}
+	if entry := rawdb.ReadBlobLookupEntry(db, tx.DataHashes()[0]); entry != nil {
+		t.Fatalf("reorged blob still indexed at block %d", *entry)
+	}
+}
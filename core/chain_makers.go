@@ -85,7 +85,7 @@ func (b *BlockGen) SetDifficulty(diff *big.Int) {
 // the protocol-imposed limitations (gas limit, etc.), there are some
 // further limitations on the content of transactions that can be
 // added. Notably, contract code relying on the BLOCKHASH instruction
-// will panic during execution.
+// only sees the blocks generated so far.
 func (b *BlockGen) AddTx(tx *types.Transaction) {
 	b.AddTxWithChain(nil, tx)
 }
@@ -102,13 +102,26 @@ func (b *BlockGen) AddTxWithChain(bc *BlockChain, tx *types.Transaction) {
 	if b.gasPool == nil {
 		b.SetCoinbase(common.Address{})
 	}
+	var chain ChainContext = bc
+	if bc == nil {
+		chain = &generatedChain{b}
+	}
 	b.statedb.Prepare(tx.Hash(), len(b.txs))
-	receipt, err := ApplyTransaction(b.config, bc, &b.header.Coinbase, b.gasPool, b.statedb, b.header, tx, &b.header.GasUsed, vm.Config{})
+	receipt, err := ApplyTransaction(b.config, chain, &b.header.Coinbase, b.gasPool, b.statedb, b.header, tx, &b.header.GasUsed, vm.Config{})
 	if err != nil {
 		panic(err)
 	}
 	b.txs = append(b.txs, tx)
 	b.receipts = append(b.receipts, receipt)
+
+	// Account for the blobs of the transaction in the excess data gas
+	if b.header.ExcessDataGas != nil && len(tx.DataHashes()) > 0 {
+		var blobs int
+		for _, tx := range b.txs {
+			blobs += len(tx.DataHashes())
+		}
+		b.header.ExcessDataGas = misc.CalcExcessDataGas(b.config, b.parent.Header(), b.header.Time, blobs)
+	}
 }
 
 // GetBalance returns the balance of the given address at the generated block.
@@ -130,6 +143,11 @@ func (b *BlockGen) Number() *big.Int {
 	return new(big.Int).Set(b.header.Number)
 }
 
+// Time returns the timestamp of the block being generated.
+func (b *BlockGen) Time() uint64 {
+	return b.header.Time
+}
+
 // BaseFee returns the EIP-1559 base fee of the block being generated.
 func (b *BlockGen) BaseFee() *big.Int {
 	return new(big.Int).Set(b.header.BaseFee)
@@ -312,6 +330,11 @@ func makeHeader(chain consensus.ChainReader, parent *types.Block, state *state.S
 			header.GasLimit = CalcGasLimit(parentGasLimit, parentGasLimit)
 		}
 	}
+	// Track data gas after the sharding fork, blobs are accounted for as
+	// they are added
+	if chain.Config().IsSharding(header.Number, header.Time) {
+		header.ExcessDataGas = misc.CalcExcessDataGas(chain.Config(), parent.Header(), header.Time, 0)
+	}
 	return header
 }
 
@@ -348,3 +371,33 @@ func (cr *fakeChainReader) GetHeaderByHash(hash common.Hash) *types.Header
 func (cr *fakeChainReader) GetHeader(hash common.Hash, number uint64) *types.Header { return nil }
 func (cr *fakeChainReader) GetBlock(hash common.Hash, number uint64) *types.Block   { return nil }
 func (cr *fakeChainReader) GetTd(hash common.Hash, number uint64) *big.Int          { return nil }
+
+// generatedChain is the chain context of blocks generated without a backing
+// blockchain, resolving headers from the blocks generated so far.
+type generatedChain struct {
+	b *BlockGen
+}
+
+// Engine retrieves the consensus engine of the generated chain.
+func (c *generatedChain) Engine() consensus.Engine {
+	return c.b.engine
+}
+
+// Config retrieves the chain config of the generated chain.
+func (c *generatedChain) Config() *params.ChainConfig {
+	return c.b.config
+}
+
+// GetHeader retrieves the header of the parent or of a previously generated
+// block.
+func (c *generatedChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if parent := c.b.parent; parent.Hash() == hash {
+		return parent.Header()
+	}
+	for _, block := range c.b.chain {
+		if block != nil && block.NumberU64() == number && block.Hash() == hash {
+			return block.Header()
+		}
+	}
+	return nil
+}
@@ -32,6 +32,10 @@ var (
 	// ErrNoGenesis is returned when there is no Genesis Block.
 	ErrNoGenesis = errors.New("genesis not found in chain")
 
+	// ErrInvalidBlobSidecar is returned if a blob sidecar attached to a transaction
+	// of a block to import doesn't match the transaction's versioned hashes.
+	ErrInvalidBlobSidecar = errors.New("invalid blob sidecar")
+
 	errSideChainReceipts = errors.New("side blocks can't be accepted as ancient chain data")
 )
 
@@ -59,6 +63,10 @@ var (
 	// by a transaction is higher than what's left in the block.
 	ErrGasLimitReached = errors.New("gas limit reached")
 
+	// ErrDataGasLimitReached is returned if the blobs of a transaction need more
+	// data gas than what's left in the block.
+	ErrDataGasLimitReached = errors.New("data gas limit reached")
+
 	// ErrInsufficientFundsForTransfer is returned if the transaction sender doesn't
 	// have enough funds for transfer(topmost call only).
 	ErrInsufficientFundsForTransfer = errors.New("insufficient funds for transfer")
@@ -94,6 +102,18 @@ var (
 	// the base fee of the block.
 	ErrFeeCapTooLow = errors.New("max fee per gas less than block base fee")
 
+	// ErrDataFeeCapVeryHigh is a sanity error to avoid extremely big numbers
+	// specified in the data gas fee cap field.
+	ErrDataFeeCapVeryHigh = errors.New("max fee per data gas higher than 2^256-1")
+
+	// ErrDataFeeCapTooLow is returned if the transaction data gas fee cap is less
+	// than the data gas price of the block.
+	ErrDataFeeCapTooLow = errors.New("max fee per data gas less than block data gas price")
+
+	// ErrDataGasPriceUnknown is returned if a blob transaction is executed in a
+	// block whose data gas price cannot be derived, e.g. as its parent is unknown.
+	ErrDataGasPriceUnknown = errors.New("data gas price of block unknown")
+
 	// ErrSenderNoEOA is returned if the sender of a transaction is a contract.
 	ErrSenderNoEOA = errors.New("sender not an eoa")
 )
@@ -21,8 +21,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 // ChainContext supports retrieving headers and consensus parameters from the
@@ -33,6 +35,9 @@ type ChainContext interface {
 
 	// GetHeader returns the hash corresponding to their hash.
 	GetHeader(common.Hash, uint64) *types.Header
+
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
 }
 
 // NewEVMBlockContext creates a new context for use in the EVM.
@@ -66,14 +71,33 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		BaseFee:     baseFee,
 		GasLimit:    header.GasLimit,
 		Random:      random,
+
+		DataGasPrice: blockDataGasPrice(chain, header),
+	}
+}
+
+// blockDataGasPrice returns the price of data gas in the block with the given
+// header, which is set by the excess data gas of its parent. It returns nil for
+// blocks without data gas accounting, and for blocks whose parent is unknown, in
+// which case the state transition rejects any blob transaction.
+func blockDataGasPrice(chain ChainContext, header *types.Header) *big.Int {
+	if header.ExcessDataGas == nil || chain == nil {
+		return nil
+	}
+	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return nil
 	}
+	return misc.GetDataGasPrice(chain.Config(), parent.ExcessDataGas)
 }
 
 // NewEVMTxContext creates a new transaction context for a single transaction.
 func NewEVMTxContext(msg Message) vm.TxContext {
 	return vm.TxContext{
-		Origin:   msg.From(),
-		GasPrice: new(big.Int).Set(msg.GasPrice()),
+		Origin:     msg.From(),
+		GasPrice:   new(big.Int).Set(msg.GasPrice()),
+		DataHashes: msg.DataHashes(),
+		AccessList: msg.AccessList(),
 	}
 }
 
@@ -171,7 +171,7 @@ func TestCreation(t *testing.T) {
 	}
 	for i, tt := range tests {
 		for j, ttt := range tt.cases {
-			if have := NewID(tt.config, tt.genesis, ttt.head); have != ttt.want {
+			if have := NewID(tt.config, tt.genesis, ttt.head, 0); have != ttt.want {
 				t.Errorf("test %d, case %d: fork ID mismatch: have %x, want %x", i, j, have, ttt.want)
 			}
 		}
@@ -253,13 +253,71 @@ func TestValidation(t *testing.T) {
 		{7279999, ID{Hash: checksumToBytes(0xa00bc324), Next: 7279999}, ErrLocalIncompatibleOrStale},
 	}
 	for i, tt := range tests {
-		filter := newFilter(params.MainnetChainConfig, params.MainnetGenesisHash, func() uint64 { return tt.head })
+		filter := newFilter(params.MainnetChainConfig, params.MainnetGenesisHash, func() (uint64, uint64) { return tt.head, 0 })
 		if err := filter(tt.id); err != tt.err {
 			t.Errorf("test %d: validation error mismatch: have %v, want %v", i, err, tt.err)
 		}
 	}
 }
 
+// TestTimeForks tests that forks scheduled by block timestamp are checksummed
+// after the block based ones and validated against the local head timestamp.
+func TestTimeForks(t *testing.T) {
+	shardingTime := uint64(1668000000)
+
+	config := *params.MainnetChainConfig
+	config.MergeForkBlock = big.NewInt(15000000)
+	config.ShardingForkTime = &shardingTime
+
+	var (
+		mergeSum    = checksumToBytes(0xe3abe201)
+		shardingSum = checksumToBytes(checksumUpdate(0xe3abe201, shardingTime))
+	)
+	creations := []struct {
+		head, time uint64
+		want       ID
+	}{
+		{14999999, shardingTime, ID{Hash: checksumToBytes(0x20c327fc), Next: 15000000}}, // Block forks come first
+		{15000000, shardingTime - 1, ID{Hash: mergeSum, Next: shardingTime}},            // Last pre-sharding timestamp
+		{15000000, shardingTime, ID{Hash: shardingSum, Next: 0}},                        // First sharding timestamp
+		{20000000, shardingTime + 1, ID{Hash: shardingSum, Next: 0}},                    // Future sharding timestamp
+	}
+	for i, tt := range creations {
+		if have := NewID(&config, params.MainnetGenesisHash, tt.head, tt.time); have != tt.want {
+			t.Errorf("creation %d: fork ID mismatch: have %x, want %x", i, have, tt.want)
+		}
+	}
+	validations := []struct {
+		head, time uint64
+		id         ID
+		err        error
+	}{
+		// Local is before sharding, remote announces the same merge state and the sharding fork.
+		{15000000, shardingTime - 1, ID{Hash: mergeSum, Next: shardingTime}, nil},
+
+		// Local is before sharding, remote already passed it. Local is out of sync, accept.
+		{15000000, shardingTime - 1, ID{Hash: shardingSum, Next: 0}, nil},
+
+		// Local passed sharding, remote announces merge and knowledge about sharding. Remote is
+		// simply out of sync, accept.
+		{15000000, shardingTime, ID{Hash: mergeSum, Next: shardingTime}, nil},
+
+		// Local passed sharding, remote announces merge but is not aware of sharding. Remote
+		// needs software update.
+		{15000000, shardingTime, ID{Hash: mergeSum, Next: 0}, ErrRemoteStale},
+
+		// Local is before sharding, remote announces a time based fork at a timestamp which is
+		// already passed locally. Local is incompatible.
+		{15000000, shardingTime - 1, ID{Hash: mergeSum, Next: shardingTime - 100}, ErrLocalIncompatibleOrStale},
+	}
+	for i, tt := range validations {
+		filter := newFilter(&config, params.MainnetGenesisHash, func() (uint64, uint64) { return tt.head, tt.time })
+		if err := filter(tt.id); err != tt.err {
+			t.Errorf("validation %d: validation error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
 // Tests that IDs are properly RLP encoded (specifically important because we
 // use uint32 to store the hash, but we need to encode it as [4]byte).
 func TestEncoding(t *testing.T) {
@@ -48,8 +48,12 @@ func TestSetupGenesis(t *testing.T) {
 			},
 		}
 		oldcustomg = customg
+		timedg     = customg
 	)
 	oldcustomg.Config = &params.ChainConfig{HomesteadBlock: big.NewInt(2)}
+
+	shardingTime := uint64(25)
+	timedg.Config = &params.ChainConfig{HomesteadBlock: big.NewInt(2), ShardingForkTime: &shardingTime}
 	tests := []struct {
 		name       string
 		fn         func(ethdb.Database) (*params.ChainConfig, common.Hash, error)
@@ -135,6 +139,31 @@ func TestSetupGenesis(t *testing.T) {
 				RewindTo:     1,
 			},
 		},
+		{
+			name: "incompatible time based config in DB",
+			fn: func(db ethdb.Database) (*params.ChainConfig, common.Hash, error) {
+				// Commit the 'old' genesis block without the sharding fork and advance
+				// to block #4 at time 40, past the sharding fork time of timedg.
+				genesis := oldcustomg.MustCommit(db)
+
+				bc, _ := NewBlockChain(db, nil, oldcustomg.Config, ethash.NewFullFaker(), vm.Config{}, nil, nil)
+				defer bc.Stop()
+
+				blocks, _ := GenerateChain(oldcustomg.Config, genesis, ethash.NewFaker(), db, 4, nil)
+				bc.InsertChain(blocks)
+
+				// This should return a compatibility error rewinding to block #2 at time 20.
+				return SetupGenesisBlock(db, &timedg)
+			},
+			wantHash:   customghash,
+			wantConfig: timedg.Config,
+			wantErr: &params.ConfigCompatError{
+				What:         "Sharding fork timestamp",
+				NewTime:      &shardingTime,
+				RewindTo:     2,
+				RewindToTime: 24,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -262,6 +262,26 @@ func WriteTxIndexTail(db ethdb.KeyValueWriter, number uint64) {
 	}
 }
 
+// ReadBlobSidecarsTail retrieves the number of the oldest block whose blob
+// sidecars are retained. If the entry is non-existent in the database, no
+// sidecars have been pruned yet.
+func ReadBlobSidecarsTail(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(blobSidecarsTailKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteBlobSidecarsTail stores the number of the oldest block whose blob
+// sidecars are retained into database.
+func WriteBlobSidecarsTail(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(blobSidecarsTailKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the blob sidecars tail", "err", err)
+	}
+}
+
 // ReadFastTxLookupLimit retrieves the tx lookup limit used in fast sync.
 func ReadFastTxLookupLimit(db ethdb.KeyValueReader) *uint64 {
 	data, _ := db.Get(fastTxLookupLimitKey)
@@ -608,7 +628,7 @@ func ReadRawReceipts(db ethdb.Reader, hash common.Hash, number uint64) types.Rec
 // fields then nil is returned.
 //
 // The current implementation populates these metadata fields by reading the receipts'
-// corresponding block body, so if the block body is not found it will return nil even
+// corresponding block header and body, so if either is not found it will return nil even
 // if the receipt itself is stored.
 func ReadReceipts(db ethdb.Reader, hash common.Hash, number uint64, config *params.ChainConfig) types.Receipts {
 	// We're deriving many fields from the block body, retrieve beside the receipt
@@ -621,7 +641,13 @@ func ReadReceipts(db ethdb.Reader, hash common.Hash, number uint64, config *para
 		log.Error("Missing body but have receipt", "hash", hash, "number", number)
 		return nil
 	}
-	if err := receipts.DeriveFields(config, hash, number, body.Transactions); err != nil {
+	// The signer of the block depends on its timestamp
+	header := ReadHeader(db, hash, number)
+	if header == nil {
+		log.Error("Missing header but have receipt", "hash", hash, "number", number)
+		return nil
+	}
+	if err := receipts.DeriveFields(config, hash, number, header.Time, body.Transactions); err != nil {
 		log.Error("Failed to derive block receipts fields", "hash", hash, "number", number, "err", err)
 		return nil
 	}
@@ -652,6 +678,68 @@ func DeleteReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	}
 }
 
+// HasBlobSidecars verifies the existence of the blob sidecars of a block in the
+// key-value store, where the sidecars of recent blocks are kept.
+func HasBlobSidecars(db ethdb.Reader, hash common.Hash, number uint64) bool {
+	if has, err := db.Has(blockBlobsKey(number, hash)); !has || err != nil {
+		return false
+	}
+	return true
+}
+
+// ReadBlobSidecarsRLP retrieves the sidecars of the blob transactions included
+// in a block, in RLP encoding.
+func ReadBlobSidecarsRLP(db ethdb.Reader, hash common.Hash, number uint64) rlp.RawValue {
+	// First try to look up the data in ancient database. Unlike the rest of the
+	// block data, sidecars are only moved there if blob archiving is enabled,
+	// so fall back to leveldb if the ancient item is empty.
+	var data []byte
+	db.ReadAncients(func(reader ethdb.AncientReader) error {
+		if isCanon(reader, number, hash) {
+			data, _ = reader.Ancient(freezerBlobsTable, number)
+		}
+		if len(data) == 0 {
+			data, _ = db.Get(blockBlobsKey(number, hash))
+		}
+		return nil
+	})
+	return data
+}
+
+// ReadBlobSidecars retrieves the sidecars of the blob transactions included in
+// a block, in transaction order. Nil is returned once they have been pruned.
+func ReadBlobSidecars(db ethdb.Reader, hash common.Hash, number uint64) []*types.BlobTxSidecar {
+	data := ReadBlobSidecarsRLP(db, hash, number)
+	if len(data) == 0 {
+		return nil
+	}
+	var sidecars []*types.BlobTxSidecar
+	if err := rlp.DecodeBytes(data, &sidecars); err != nil {
+		log.Error("Invalid blob sidecar array RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return sidecars
+}
+
+// WriteBlobSidecars stores the sidecars of the blob transactions included in a
+// block.
+func WriteBlobSidecars(db ethdb.KeyValueWriter, hash common.Hash, number uint64, sidecars []*types.BlobTxSidecar) {
+	data, err := rlp.EncodeToBytes(sidecars)
+	if err != nil {
+		log.Crit("Failed to encode block blob sidecars", "err", err)
+	}
+	if err := db.Put(blockBlobsKey(number, hash), data); err != nil {
+		log.Crit("Failed to store block blob sidecars", "err", err)
+	}
+}
+
+// DeleteBlobSidecars removes all blob sidecar data associated with a block hash.
+func DeleteBlobSidecars(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockBlobsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete block blob sidecars", "err", err)
+	}
+}
+
 // storedReceiptRLP is the storage encoding of a receipt.
 // Re-definition in core/types/receipt.go.
 type storedReceiptRLP struct {
@@ -818,19 +906,24 @@ func writeAncientBlock(op ethdb.AncientWriteOp, block *types.Block, header *type
 	if err := op.Append(freezerDifficultyTable, num, td); err != nil {
 		return fmt.Errorf("can't append block %d total difficulty: %v", num, err)
 	}
+	if err := op.AppendRaw(freezerBlobsTable, num, nil); err != nil {
+		return fmt.Errorf("can't append block %d blob sidecars: %v", num, err)
+	}
 	return nil
 }
 
 // DeleteBlock removes all block data associated with a hash.
 func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
+	DeleteBlobSidecars(db, hash, number)
 	DeleteHeader(db, hash, number)
 	DeleteBody(db, hash, number)
 	DeleteTd(db, hash, number)
 }
 
 // DeleteBlockWithoutNumber removes all block data associated with a hash, except
-// the hash to number mapping.
+// the hash to number mapping. Blob sidecars are left in place for the retention
+// policy to prune.
 func DeleteBlockWithoutNumber(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
 	deleteHeaderWithoutNumber(db, hash, number)
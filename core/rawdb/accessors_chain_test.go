@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"golang.org/x/crypto/sha3"
@@ -379,15 +380,21 @@ func TestBlockReceiptStorage(t *testing.T) {
 	receipts := []*types.Receipt{receipt1, receipt2}
 
 	// Check that no receipt entries are in a pristine database
-	hash := common.BytesToHash([]byte{0x03, 0x14})
+	header := &types.Header{Number: big.NewInt(0), Extra: []byte("test header")}
+	hash := header.Hash()
 	if rs := ReadReceipts(db, hash, 0, params.TestChainConfig); len(rs) != 0 {
 		t.Fatalf("non existent receipts returned: %v", rs)
 	}
 	// Insert the body that corresponds to the receipts
 	WriteBody(db, hash, 0, body)
 
-	// Insert the receipt slice into the database and check presence
+	// Insert the receipt slice into the database, the receipts need the header
+	// too for their metadata
 	WriteReceipts(db, hash, 0, receipts)
+	if rs := ReadReceipts(db, hash, 0, params.TestChainConfig); rs != nil {
+		t.Fatalf("receipts returned without header: %v", rs)
+	}
+	WriteHeader(db, header)
 	if rs := ReadReceipts(db, hash, 0, params.TestChainConfig); len(rs) == 0 {
 		t.Fatalf("no receipts returned")
 	} else {
@@ -413,6 +420,40 @@ func TestBlockReceiptStorage(t *testing.T) {
 	}
 }
 
+func TestBlobSidecarStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	sidecar1 := &types.BlobTxSidecar{
+		Blobs:       make([]kzg.Blob, 1),
+		Commitments: []kzg.KZGCommitment{{0x01}},
+		Proofs:      []kzg.KZGProof{{0x02}},
+	}
+	sidecar1.Blobs[0][31] = 0x03
+
+	sidecar2 := &types.BlobTxSidecar{
+		Blobs:       make([]kzg.Blob, 2),
+		Commitments: []kzg.KZGCommitment{{0x04}, {0x05}},
+		Proofs:      []kzg.KZGProof{{0x06}, {0x07}},
+	}
+	sidecars := []*types.BlobTxSidecar{sidecar1, sidecar2}
+
+	// Check that no sidecars entries are in a pristine database
+	hash := common.BytesToHash([]byte{0x03, 0x14})
+	if sc := ReadBlobSidecars(db, hash, 0); sc != nil {
+		t.Fatalf("non existent sidecars returned: %v", sc)
+	}
+	// Insert the sidecars slice into the database and check presence
+	WriteBlobSidecars(db, hash, 0, sidecars)
+	if sc := ReadBlobSidecars(db, hash, 0); !reflect.DeepEqual(sc, sidecars) {
+		t.Fatalf("retrieved sidecars mismatch: have %v, want %v", sc, sidecars)
+	}
+	// Delete the block and ensure that the sidecars are purged too
+	DeleteBlock(db, hash, 0)
+	if sc := ReadBlobSidecars(db, hash, 0); sc != nil {
+		t.Fatalf("deleted sidecars returned: %v", sc)
+	}
+}
+
 func checkReceiptsRLP(have, want types.Receipts) error {
 	if len(have) != len(want) {
 		return fmt.Errorf("receipts sizes mismatch: have %d, want %d", len(have), len(want))
@@ -501,6 +542,71 @@ func TestAncientStorage(t *testing.T) {
 	}
 }
 
+func TestAncientBlobSidecarStorage(t *testing.T) {
+	for _, archive := range []bool{false, true} {
+		frdir, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatalf("failed to create temp freezer dir: %v", err)
+		}
+		defer os.RemoveAll(frdir)
+
+		db, err := NewDatabaseWithFreezer(NewMemoryDatabase(), frdir, "", false)
+		if err != nil {
+			t.Fatalf("failed to create database with ancient backend")
+		}
+		defer db.Close()
+		if archive {
+			EnableBlobArchive(db)
+		}
+		// Create a test block with some sidecars in the key-value store
+		block := types.NewBlockWithHeader(&types.Header{
+			Number:      big.NewInt(0),
+			Extra:       []byte("test block"),
+			UncleHash:   types.EmptyUncleHash,
+			TxHash:      types.EmptyRootHash,
+			ReceiptHash: types.EmptyRootHash,
+		})
+		sidecars := []*types.BlobTxSidecar{{
+			Blobs:       make([]kzg.Blob, 1),
+			Commitments: []kzg.KZGCommitment{{0x01}},
+			Proofs:      []kzg.KZGProof{{0x02}},
+		}}
+		hash, number := block.Hash(), block.NumberU64()
+
+		WriteBlock(db, block)
+		WriteReceipts(db, hash, number, nil)
+		WriteTd(db, hash, number, big.NewInt(100))
+		WriteCanonicalHash(db, hash, number)
+		WriteBlobSidecars(db, hash, number, sidecars)
+
+		// Freeze the block and ensure the sidecars are only archived on request
+		f := db.(*freezerdb).AncientStore.(*freezer)
+		if _, err := f.freezeRange(&nofreezedb{KeyValueStore: db}, 0, 0); err != nil {
+			t.Fatalf("failed to freeze block: %v", err)
+		}
+		blob, err := db.Ancient(freezerBlobsTable, number)
+		if err != nil {
+			t.Fatalf("failed to retrieve ancient sidecars: %v", err)
+		}
+		if archived := len(blob) > 0; archived != archive {
+			t.Fatalf("archive %v: sidecars archived mismatch: have %v", archive, archived)
+		}
+		// Sidecars should be retrievable regardless of where they are stored
+		if sc := ReadBlobSidecars(db, hash, number); !reflect.DeepEqual(sc, sidecars) {
+			t.Fatalf("archive %v: retrieved sidecars mismatch: have %v, want %v", archive, sc, sidecars)
+		}
+		DeleteBlobSidecars(db, hash, number)
+		if sc := ReadBlobSidecars(db, hash, number); archive != (sc != nil) {
+			t.Fatalf("archive %v: sidecars availability mismatch: have %v", archive, sc)
+		}
+		// Use a fake hash for data retrieval, nothing should be returned.
+		fakeHash := common.BytesToHash([]byte{0x01, 0x02, 0x03})
+		if sc := ReadBlobSidecars(db, fakeHash, number); sc != nil {
+			t.Fatalf("invalid sidecars returned")
+		}
+	}
+}
+
 func TestCanonicalHashIteration(t *testing.T) {
 	var cases = []struct {
 		from, to uint64
@@ -759,7 +865,7 @@ func TestReadLogs(t *testing.T) {
 	}
 
 	// Fill in log fields so we can compare their rlp encoding
-	if err := types.Receipts(receipts).DeriveFields(params.TestChainConfig, hash, 0, body.Transactions); err != nil {
+	if err := types.Receipts(receipts).DeriveFields(params.TestChainConfig, hash, 0, 0, body.Transactions); err != nil {
 		t.Fatal(err)
 	}
 	for i, pr := range receipts {
@@ -93,6 +93,37 @@ func DeleteTxLookupEntries(db ethdb.KeyValueWriter, hashes []common.Hash) {
 	}
 }
 
+// ReadBlobLookupEntry retrieves the number of the block carrying the blob with
+// the given versioned hash, if its sidecar is retained.
+func ReadBlobLookupEntry(db ethdb.Reader, hash common.Hash) *uint64 {
+	data, _ := db.Get(blobLookupKey(hash))
+	if len(data) == 0 {
+		return nil
+	}
+	number := new(big.Int).SetBytes(data).Uint64()
+	return &number
+}
+
+// WriteBlobLookupEntriesByBlock stores the block number for every blob referenced
+// by the transactions of a block, enabling versioned hash based blob lookups.
+func WriteBlobLookupEntriesByBlock(db ethdb.KeyValueWriter, block *types.Block) {
+	numberBytes := block.Number().Bytes()
+	for _, tx := range block.Transactions() {
+		for _, hash := range tx.DataHashes() {
+			if err := db.Put(blobLookupKey(hash), numberBytes); err != nil {
+				log.Crit("Failed to store blob lookup entry", "err", err)
+			}
+		}
+	}
+}
+
+// DeleteBlobLookupEntry removes the lookup metadata associated with a versioned hash.
+func DeleteBlobLookupEntry(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(blobLookupKey(hash)); err != nil {
+		log.Crit("Failed to delete blob lookup entry", "err", err)
+	}
+}
+
 // ReadTransaction retrieves a specific transaction from the database, along with
 // its added positional metadata.
 func ReadTransaction(db ethdb.Reader, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64) {
@@ -17,6 +17,7 @@
 package rawdb
 
 import (
+	"encoding/binary"
 	"runtime"
 	"sync/atomic"
 	"time"
@@ -352,3 +353,88 @@ func UnindexTransactions(db ethdb.Database, from uint64, to uint64, interrupt ch
 func unindexTransactionsForTesting(db ethdb.Database, from uint64, to uint64, interrupt chan struct{}, hook func(uint64) bool) {
 	unindexTransactions(db, from, to, interrupt, hook)
 }
+
+// PruneBlobSidecars removes the blob sidecars of all blocks, canonical or not,
+// in the specified range, along with the blob lookup entries pointing at them.
+// The from is included while to is excluded. The blob sidecars tail is moved
+// forward as the pruning progresses. The number of bytes of sidecar entries
+// deleted is returned.
+//
+// There is a passed channel, the whole procedure will be interrupted if any
+// signal received.
+func PruneBlobSidecars(db ethdb.Database, from uint64, to uint64, interrupt chan struct{}) uint64 {
+	// short circuit for invalid range
+	if from >= to {
+		return 0
+	}
+	var (
+		it        = db.NewIterator(blockBlobsPrefix, encodeBlockNumber(from))
+		batch     = db.NewBatch()
+		start     = time.Now()
+		logged    = start.Add(-7 * time.Second)
+		blocks    = 0
+		reclaimed uint64
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(blockBlobsPrefix)+8+common.HashLength {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(blockBlobsPrefix) : len(blockBlobsPrefix)+8])
+		if number >= to {
+			break
+		}
+		if err := batch.Delete(key); err != nil {
+			log.Crit("Failed to delete blob sidecars", "err", err)
+		}
+		// Drop the lookups of the blobs, unless they were reindexed to another block
+		var sidecars []*types.BlobTxSidecar
+		if err := rlp.DecodeBytes(it.Value(), &sidecars); err != nil {
+			log.Error("Invalid blob sidecar array RLP", "number", number, "err", err)
+		}
+		for _, sidecar := range sidecars {
+			for _, hash := range sidecar.BlobHashes() {
+				if entry := ReadBlobLookupEntry(db, hash); entry != nil && *entry == number {
+					DeleteBlobLookupEntry(batch, hash)
+				}
+			}
+		}
+		blocks++
+		reclaimed += uint64(len(key) + len(it.Value()))
+
+		// If enough deletions were accumulated in memory, dump them to disk
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			WriteBlobSidecarsTail(batch, number)
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed writing batch to db", "error", err)
+				return reclaimed
+			}
+			batch.Reset()
+		}
+		// If we've spent too much time already, notify the user of what we're doing
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Pruning blob sidecars", "blocks", blocks, "number", number, "total", to-from, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+		select {
+		case <-interrupt:
+			log.Debug("Blob sidecar pruning interrupted", "blocks", blocks, "tail", number, "elapsed", common.PrettyDuration(time.Since(start)))
+			WriteBlobSidecarsTail(batch, number)
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed writing batch to db", "error", err)
+			}
+			return reclaimed
+		default:
+		}
+	}
+	// Flush the new tail and the last deletions
+	WriteBlobSidecarsTail(batch, to)
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed writing batch to db", "error", err)
+		return reclaimed
+	}
+	log.Debug("Pruned blob sidecars", "blocks", blocks, "tail", to, "elapsed", common.PrettyDuration(time.Since(start)))
+	return reclaimed
+}
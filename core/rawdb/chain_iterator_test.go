@@ -25,6 +25,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 )
 
 func TestChainIterator(t *testing.T) {
@@ -206,3 +207,92 @@ func TestIndexTransactions(t *testing.T) {
 	verify(8, 11, true, 8)
 	verify(0, 8, false, 8)
 }
+
+func TestPruneBlobSidecars(t *testing.T) {
+	chainDb := NewMemoryDatabase()
+
+	// Store sidecars for a few blocks, with a side block at every height
+	sidecars := []*types.BlobTxSidecar{{
+		Blobs:       make([]kzg.Blob, 1),
+		Commitments: []kzg.KZGCommitment{{0x01}},
+		Proofs:      []kzg.KZGProof{{0x02}},
+	}}
+	for i := uint64(0); i < 10; i++ {
+		WriteBlobSidecars(chainDb, common.Hash{byte(i)}, i, sidecars)
+		WriteBlobSidecars(chainDb, common.Hash{byte(i), 0x01}, i, sidecars)
+	}
+	if tail := ReadBlobSidecarsTail(chainDb); tail != nil {
+		t.Fatalf("blob sidecars tail mismatch: have %d, want nil", *tail)
+	}
+	verify := func(tail uint64) {
+		t.Helper()
+		if stored := ReadBlobSidecarsTail(chainDb); stored == nil || *stored != tail {
+			t.Fatalf("blob sidecars tail mismatch: have %v, want %d", stored, tail)
+		}
+		for i := uint64(0); i < 10; i++ {
+			for _, hash := range []common.Hash{{byte(i)}, {byte(i), 0x01}} {
+				have := ReadBlobSidecars(chainDb, hash, i) != nil
+				if want := i >= tail; have != want {
+					t.Fatalf("block %d: sidecars presence mismatch: have %v, want %v", i, have, want)
+				}
+			}
+		}
+	}
+	// Every pruned block reclaims the entries of both of its sidecar sets
+	entry := uint64(len(blockBlobsKey(0, common.Hash{})) + len(ReadBlobSidecarsRLP(chainDb, common.Hash{0x00}, 0)))
+
+	if reclaimed := PruneBlobSidecars(chainDb, 0, 4, nil); reclaimed != 8*entry {
+		t.Errorf("reclaimed bytes mismatch: have %d, want %d", reclaimed, 8*entry)
+	}
+	verify(4)
+
+	if reclaimed := PruneBlobSidecars(chainDb, 4, 7, nil); reclaimed != 6*entry {
+		t.Errorf("reclaimed bytes mismatch: have %d, want %d", reclaimed, 6*entry)
+	}
+	verify(7)
+
+	// Pruning an empty range must not move the tail
+	if reclaimed := PruneBlobSidecars(chainDb, 7, 7, nil); reclaimed != 0 {
+		t.Errorf("reclaimed bytes mismatch: have %d, want 0", reclaimed)
+	}
+	verify(7)
+}
+
+func TestPruneBlobLookups(t *testing.T) {
+	chainDb := NewMemoryDatabase()
+
+	// Store the sidecars of two blocks, indexing their blobs
+	sidecars := make([][]*types.BlobTxSidecar, 3)
+	for i := range sidecars {
+		sidecars[i] = []*types.BlobTxSidecar{{
+			Blobs:       make([]kzg.Blob, 1),
+			Commitments: []kzg.KZGCommitment{{byte(i)}},
+			Proofs:      []kzg.KZGProof{{0x02}},
+		}}
+	}
+	for i := uint64(1); i <= 2; i++ {
+		tx := types.NewTx(&types.BlobTx{BlobVersionedHashes: sidecars[i][0].BlobHashes()})
+		block := types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(i)}).WithBody(types.Transactions{tx}, nil)
+
+		WriteBlobSidecars(chainDb, block.Hash(), i, sidecars[i])
+		WriteBlobLookupEntriesByBlock(chainDb, block)
+	}
+	// Store a side block at the first height with a blob reindexed to a later block
+	reincluded := sidecars[0][0].BlobHashes()[0]
+	WriteBlobSidecars(chainDb, common.Hash{0x01}, 1, sidecars[0])
+	WriteBlobLookupEntriesByBlock(chainDb, types.NewBlockWithHeader(&types.Header{Number: big.NewInt(5)}).WithBody(types.Transactions{
+		types.NewTx(&types.BlobTx{BlobVersionedHashes: []common.Hash{reincluded}}),
+	}, nil))
+
+	PruneBlobSidecars(chainDb, 0, 2, nil)
+
+	if entry := ReadBlobLookupEntry(chainDb, sidecars[1][0].BlobHashes()[0]); entry != nil {
+		t.Errorf("pruned blob still indexed at block %d", *entry)
+	}
+	if entry := ReadBlobLookupEntry(chainDb, sidecars[2][0].BlobHashes()[0]); entry == nil || *entry != 2 {
+		t.Errorf("retained blob lookup mismatch: have %v, want 2", entry)
+	}
+	if entry := ReadBlobLookupEntry(chainDb, reincluded); entry == nil || *entry != 5 {
+		t.Errorf("reincluded blob lookup mismatch: have %v, want 5", entry)
+	}
+}
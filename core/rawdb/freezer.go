@@ -85,6 +85,8 @@ type freezer struct {
 	writeLock  sync.RWMutex
 	writeBatch *freezerBatch
 
+	archiveBlobs uint32 // Whether to move blob sidecars into the ancient store (atomic)
+
 	readonly     bool
 	tables       map[string]*freezerTable // Data tables for storing everything
 	instanceLock fileutil.Releaser        // File-system lock to prevent double opens
@@ -327,6 +329,9 @@ func (f *freezer) validate() error {
 	)
 	// Hack to get length of any table
 	for kind, table := range f.tables {
+		if kind == freezerBlobsTable && len(f.tables) > 1 {
+			continue
+		}
 		length = atomic.LoadUint64(&table.items)
 		name = kind
 		break
@@ -334,6 +339,10 @@ func (f *freezer) validate() error {
 	// Now check every table against that length
 	for kind, table := range f.tables {
 		items := atomic.LoadUint64(&table.items)
+		if kind == freezerBlobsTable && items < length {
+			// Blob sidecars table not yet padded, see repair
+			continue
+		}
 		if length != items {
 			return fmt.Errorf("freezer tables %s and %s have differing lengths: %d != %d", kind, name, items, length)
 		}
@@ -345,12 +354,33 @@ func (f *freezer) validate() error {
 // repair truncates all data tables to the same length.
 func (f *freezer) repair() error {
 	min := uint64(math.MaxUint64)
-	for _, table := range f.tables {
+	for kind, table := range f.tables {
+		if kind == freezerBlobsTable && len(f.tables) > 1 {
+			continue
+		}
 		items := atomic.LoadUint64(&table.items)
 		if min > items {
 			min = items
 		}
 	}
+	// The blob sidecars table was added after the others, so freezers created
+	// before it have a shorter one. Pad it with empty items instead of dropping
+	// all the other ancient data.
+	if table, ok := f.tables[freezerBlobsTable]; ok {
+		if items := atomic.LoadUint64(&table.items); items < min {
+			log.Info("Padding ancient blob sidecars table", "items", items, "target", min)
+
+			batch := table.newBatch()
+			for ; items < min; items++ {
+				if err := batch.AppendRaw(items, nil); err != nil {
+					return err
+				}
+			}
+			if err := batch.commit(); err != nil {
+				return err
+			}
+		}
+	}
 	for _, table := range f.tables {
 		if err := table.truncate(min); err != nil {
 			return err
@@ -455,6 +485,11 @@ func (f *freezer) freeze(db ethdb.KeyValueStore) {
 			if first+uint64(i) != 0 {
 				DeleteBlockWithoutNumber(batch, ancients[i], first+uint64(i))
 				DeleteCanonicalHash(batch, first+uint64(i))
+
+				// Archived blob sidecars are not needed in leveldb any more
+				if atomic.LoadUint32(&f.archiveBlobs) == 1 {
+					DeleteBlobSidecars(batch, ancients[i], first+uint64(i))
+				}
 			}
 		}
 		if err := batch.Write(); err != nil {
@@ -555,6 +590,12 @@ func (f *freezer) freezeRange(nfdb *nofreezedb, number, limit uint64) (hashes []
 			if len(td) == 0 {
 				return fmt.Errorf("total difficulty missing, can't freeze block %d", number)
 			}
+			// Blob sidecars are only archived on request, they are left for the
+			// pruner otherwise. Either way, they may be missing.
+			var blobs []byte
+			if atomic.LoadUint32(&f.archiveBlobs) == 1 {
+				blobs = ReadBlobSidecarsRLP(nfdb, hash, number)
+			}
 
 			// Write to the batch.
 			if err := op.AppendRaw(freezerHashTable, number, hash[:]); err != nil {
@@ -572,6 +613,9 @@ func (f *freezer) freezeRange(nfdb *nofreezedb, number, limit uint64) (hashes []
 			if err := op.AppendRaw(freezerDifficultyTable, number, td); err != nil {
 				return fmt.Errorf("can't write td to freezer: %v", err)
 			}
+			if err := op.AppendRaw(freezerBlobsTable, number, blobs); err != nil {
+				return fmt.Errorf("can't write blob sidecars to freezer: %v", err)
+			}
 
 			hashes = append(hashes, hash)
 		}
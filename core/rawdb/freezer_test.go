@@ -291,6 +291,41 @@ func TestFreezerReadonlyValidate(t *testing.T) {
 	}
 }
 
+func TestFreezerPadBlobsTable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Fill a freezer predating the blob sidecars table.
+	f, err := newFreezer(dir, "", false, 2049, map[string]bool{"a": true})
+	if err != nil {
+		t.Fatal("can't open freezer", err)
+	}
+	var item = make([]byte, 1024)
+	aBatch := f.tables["a"].newBatch()
+	require.NoError(t, aBatch.AppendRaw(0, item))
+	require.NoError(t, aBatch.AppendRaw(1, item))
+	require.NoError(t, aBatch.AppendRaw(2, item))
+	require.NoError(t, aBatch.commit())
+	require.NoError(t, f.Close())
+
+	// Reopening it with the blob sidecars table should pad it instead of
+	// truncating the existing data.
+	f, err = newFreezer(dir, "", false, 2049, map[string]bool{"a": true, freezerBlobsTable: false})
+	if err != nil {
+		t.Fatal("can't open freezer", err)
+	}
+	defer f.Close()
+
+	checkAncientCount(t, f, "a", 3)
+	checkAncientCount(t, f, freezerBlobsTable, 3)
+	if blob, err := f.Ancient(freezerBlobsTable, 2); err != nil || len(blob) != 0 {
+		t.Fatalf("unexpected padding item: %x, %v", blob, err)
+	}
+}
+
 func newFreezerForTesting(t *testing.T, tables map[string]bool) (*freezer, string) {
 	t.Helper()
 
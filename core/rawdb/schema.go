@@ -66,6 +66,9 @@ var (
 	// txIndexTailKey tracks the oldest block whose transactions have been indexed.
 	txIndexTailKey = []byte("TransactionIndexTail")
 
+	// blobSidecarsTailKey tracks the oldest block whose blob sidecars have been retained.
+	blobSidecarsTailKey = []byte("BlobSidecarsTail")
+
 	// fastTxLookupLimitKey tracks the transaction lookup limit during fast sync.
 	fastTxLookupLimitKey = []byte("FastTransactionLookupLimit")
 
@@ -86,8 +89,10 @@ var (
 
 	blockBodyPrefix     = []byte("b") // blockBodyPrefix + num (uint64 big endian) + hash -> block body
 	blockReceiptsPrefix = []byte("r") // blockReceiptsPrefix + num (uint64 big endian) + hash -> block receipts
+	blockBlobsPrefix    = []byte("x") // blockBlobsPrefix + num (uint64 big endian) + hash -> block blob sidecars
 
 	txLookupPrefix        = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
+	blobLookupPrefix      = []byte("v") // blobLookupPrefix + versioned hash -> blob lookup metadata
 	bloomBitsPrefix       = []byte("B") // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
 	SnapshotAccountPrefix = []byte("a") // SnapshotAccountPrefix + account hash -> account trie value
 	SnapshotStoragePrefix = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
@@ -118,6 +123,11 @@ const (
 
 	// freezerDifficultyTable indicates the name of the freezer total difficulty table.
 	freezerDifficultyTable = "diffs"
+
+	// freezerBlobsTable indicates the name of the freezer blob sidecars table.
+	// It is only populated if blob archiving is enabled, otherwise it holds an
+	// empty item for every block.
+	freezerBlobsTable = "blobs"
 )
 
 // FreezerNoSnappy configures whether compression is disabled for the ancient-tables.
@@ -128,6 +138,7 @@ var FreezerNoSnappy = map[string]bool{
 	freezerBodiesTable:     false,
 	freezerReceiptTable:    false,
 	freezerDifficultyTable: true,
+	freezerBlobsTable:      false,
 }
 
 // LegacyTxLookupEntry is the legacy TxLookupEntry definition with some unnecessary
@@ -180,11 +191,21 @@ func blockReceiptsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockReceiptsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// blockBlobsKey = blockBlobsPrefix + num (uint64 big endian) + hash
+func blockBlobsKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockBlobsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
 }
 
+// blobLookupKey = blobLookupPrefix + versioned hash
+func blobLookupKey(hash common.Hash) []byte {
+	return append(blobLookupPrefix, hash.Bytes()...)
+}
+
 // accountSnapshotKey = SnapshotAccountPrefix + hash
 func accountSnapshotKey(hash common.Hash) []byte {
 	return append(SnapshotAccountPrefix, hash.Bytes()...)
@@ -18,8 +18,11 @@ package core
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"math/big"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -27,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -299,6 +303,130 @@ func TestStateProcessorErrors(t *testing.T) {
 	}
 }
 
+// TestDataGasCharge tests that blob transactions are charged for their data gas
+// at the block's data gas price, and rejected if their data fee cap is too low
+// or if the data gas price is not available.
+func TestDataGasCharge(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000000000000)
+		signer  = types.LatestSignerForChainID(params.TestShardingChainConfig.ChainID)
+		baseFee = big.NewInt(1)
+	)
+	for i, tt := range []struct {
+		dataGasPrice *big.Int
+		err          error
+	}{
+		{dataGasPrice: big.NewInt(1)},
+		{dataGasPrice: big.NewInt(10)},
+		{dataGasPrice: big.NewInt(11), err: ErrDataFeeCapTooLow},
+		{dataGasPrice: nil, err: ErrDataGasPriceUnknown},
+	} {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.SetBalance(addr, funds)
+
+		blockCtx := vm.BlockContext{
+			CanTransfer:  CanTransfer,
+			Transfer:     Transfer,
+			BlockNumber:  big.NewInt(1),
+			Time:         big.NewInt(0),
+			Difficulty:   big.NewInt(0),
+			GasLimit:     params.GenesisGasLimit,
+			BaseFee:      baseFee,
+			DataGasPrice: tt.dataGasPrice,
+		}
+		tx := blobTx(0, params.TxGas, baseFee, big.NewInt(0), big.NewInt(10), 2, key)
+		msg, err := tx.AsMessage(signer, baseFee)
+		if err != nil {
+			t.Fatalf("test %d: failed to create message: %v", i, err)
+		}
+		evm := vm.NewEVM(blockCtx, NewEVMTxContext(msg), statedb, params.TestShardingChainConfig, vm.Config{})
+		if _, err := ApplyMessage(evm, msg, new(GasPool).AddGas(params.GenesisGasLimit)); !errors.Is(err, tt.err) {
+			t.Fatalf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if tt.err != nil {
+			continue
+		}
+		cost := new(big.Int).SetUint64(tx.DataGas())
+		cost.Mul(cost, blockCtx.DataGasPrice)
+		cost.Add(cost, new(big.Int).Mul(big.NewInt(int64(params.TxGas)), baseFee))
+		cost.Add(cost, tx.Value())
+
+		if have, want := statedb.GetBalance(addr), new(big.Int).Sub(funds, cost); have.Cmp(want) != 0 {
+			t.Errorf("test %d: balance mismatch: have %v, want %v", i, have, want)
+		}
+	}
+}
+
+// blobTxTracer records the blob transaction hooks invoked by the state transition.
+type blobTxTracer struct {
+	vm.EVMLogger
+
+	hashes       []common.Hash
+	dataGas      uint64
+	dataGasPrice *big.Int
+	starts, ends int
+}
+
+func (t *blobTxTracer) CaptureBlobTxStart(hashes []common.Hash, dataGas uint64, dataGasPrice *big.Int) {
+	t.hashes, t.dataGas, t.dataGasPrice = hashes, dataGas, dataGasPrice
+	t.starts++
+}
+
+func (t *blobTxTracer) CaptureBlobTxEnd(err error) { t.ends++ }
+
+func (t *blobTxTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+}
+
+func (t *blobTxTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {}
+
+// TestBlobTxTracerHooks tests that tracers implementing vm.BlobTxLogger are told
+// about the blobs of the messages they trace.
+func TestBlobTxTracerHooks(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		signer  = types.LatestSignerForChainID(params.TestShardingChainConfig.ChainID)
+		baseFee = big.NewInt(1)
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetBalance(addr, big.NewInt(1000000000000000000))
+
+	blockCtx := vm.BlockContext{
+		CanTransfer:  CanTransfer,
+		Transfer:     Transfer,
+		BlockNumber:  big.NewInt(1),
+		Time:         big.NewInt(0),
+		Difficulty:   big.NewInt(0),
+		GasLimit:     params.GenesisGasLimit,
+		BaseFee:      baseFee,
+		DataGasPrice: big.NewInt(3),
+	}
+	tx := blobTx(0, params.TxGas, baseFee, big.NewInt(0), big.NewInt(10), 2, key)
+	msg, err := tx.AsMessage(signer, baseFee)
+	if err != nil {
+		t.Fatalf("failed to create message: %v", err)
+	}
+	tracer := new(blobTxTracer)
+	evm := vm.NewEVM(blockCtx, NewEVMTxContext(msg), statedb, params.TestShardingChainConfig, vm.Config{Debug: true, Tracer: tracer})
+	if _, err := ApplyMessage(evm, msg, new(GasPool).AddGas(params.GenesisGasLimit)); err != nil {
+		t.Fatalf("failed to apply message: %v", err)
+	}
+	if tracer.starts != 1 || tracer.ends != 1 {
+		t.Fatalf("hook invocation mismatch: have %d starts and %d ends, want 1 each", tracer.starts, tracer.ends)
+	}
+	if !reflect.DeepEqual(tracer.hashes, tx.DataHashes()) {
+		t.Errorf("versioned hash mismatch: have %v, want %v", tracer.hashes, tx.DataHashes())
+	}
+	if tracer.dataGas != tx.DataGas() {
+		t.Errorf("data gas mismatch: have %d, want %d", tracer.dataGas, tx.DataGas())
+	}
+	if tracer.dataGasPrice == nil || tracer.dataGasPrice.Cmp(blockCtx.DataGasPrice) != 0 {
+		t.Errorf("data gas price mismatch: have %v, want %v", tracer.dataGasPrice, blockCtx.DataGasPrice)
+	}
+}
+
 // GenerateBadBlock constructs a "block" which contains the transactions. The transactions are not expected to be
 // valid, and no proper post-state can be made. But from the perspective of the blockchain, the block is sufficiently
 // valid to be considered for import:
@@ -60,6 +60,8 @@ type StateTransition struct {
 	data       []byte
 	state      vm.StateDB
 	evm        *vm.EVM
+
+	dataGasPrice *big.Int // Price paid for the data gas of blobs, nil if none is bought
 }
 
 // Message represents a message sent to a contract.
@@ -77,14 +79,18 @@ type Message interface {
 	IsFake() bool
 	Data() []byte
 	AccessList() types.AccessList
+	DataHashes() []common.Hash
+	MaxFeePerDataGas() *big.Int
 }
 
 // ExecutionResult includes all output after executing given evm
 // message no matter the execution itself is successful or not.
 type ExecutionResult struct {
-	UsedGas    uint64 // Total used gas but include the refunded gas
-	Err        error  // Any error encountered during the execution(listed in core/vm/errors.go)
-	ReturnData []byte // Returned data from evm(function result or data supplied with revert opcode)
+	UsedGas      uint64   // Total used gas but include the refunded gas
+	DataGasUsed  uint64   // Data gas bought for the blobs of the message, zero if none were paid for
+	DataGasPrice *big.Int // Price paid for the data gas, nil if no data gas was bought
+	Err          error    // Any error encountered during the execution(listed in core/vm/errors.go)
+	ReturnData   []byte   // Returned data from evm(function result or data supplied with revert opcode)
 }
 
 // Unwrap returns the internal evm error which allows us for further
@@ -198,6 +204,19 @@ func (st *StateTransition) buyGas() error {
 		balanceCheck = balanceCheck.Mul(balanceCheck, st.gasFeeCap)
 		balanceCheck.Add(balanceCheck, st.value)
 	}
+	// Blob transactions additionally buy the data gas of their blobs upfront,
+	// which is burned at the block's data gas price.
+	if st.dataGasPrice != nil {
+		dgval := new(big.Int).SetUint64(st.dataGas())
+		dgval.Mul(dgval, st.dataGasPrice)
+		mgval.Add(mgval, dgval) // balanceCheck aliases mgval without a fee cap
+
+		if st.gasFeeCap != nil {
+			dataCheck := new(big.Int).SetUint64(st.dataGas())
+			dataCheck.Mul(dataCheck, st.msg.MaxFeePerDataGas())
+			balanceCheck.Add(balanceCheck, dataCheck)
+		}
+	}
 	if have, want := st.state.GetBalance(st.msg.From()), balanceCheck; have.Cmp(want) < 0 {
 		return fmt.Errorf("%w: address %v have %v want %v", ErrInsufficientFunds, st.msg.From().Hex(), have, want)
 	}
@@ -256,9 +275,38 @@ func (st *StateTransition) preCheck() error {
 			}
 		}
 	}
+	// Make sure that the transaction data fee cap covers the block's data gas price
+	if st.dataGas() > 0 {
+		dataFeeCap := st.msg.MaxFeePerDataGas()
+		if dataFeeCap == nil {
+			dataFeeCap = new(big.Int)
+		}
+		// Skip the checks and the charge if the cap is zero and baseFee was
+		// explicitly disabled (eth_call)
+		if !st.evm.Config.NoBaseFee || dataFeeCap.BitLen() > 0 {
+			// Blobs must never be free, refuse them if the price is unavailable
+			if st.evm.Context.DataGasPrice == nil {
+				return fmt.Errorf("%w: address %v", ErrDataGasPriceUnknown, st.msg.From().Hex())
+			}
+			if l := dataFeeCap.BitLen(); l > 256 {
+				return fmt.Errorf("%w: address %v, maxFeePerDataGas bit length: %d", ErrDataFeeCapVeryHigh,
+					st.msg.From().Hex(), l)
+			}
+			if dataFeeCap.Cmp(st.evm.Context.DataGasPrice) < 0 {
+				return fmt.Errorf("%w: address %v, maxFeePerDataGas: %s dataGasPrice: %s", ErrDataFeeCapTooLow,
+					st.msg.From().Hex(), dataFeeCap, st.evm.Context.DataGasPrice)
+			}
+			st.dataGasPrice = st.evm.Context.DataGasPrice
+		}
+	}
 	return st.buyGas()
 }
 
+// dataGas returns the amount of data gas consumed by the blobs of the message.
+func (st *StateTransition) dataGas() uint64 {
+	return uint64(len(st.msg.DataHashes())) * params.DataGasPerBlob
+}
+
 // TransitionDb will transition the state by applying the current message and
 // returning the evm execution result with following fields.
 //
@@ -310,9 +358,17 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	}
 
 	// Set up the initial access list.
-	if rules := st.evm.ChainConfig().Rules(st.evm.Context.BlockNumber, st.evm.Context.Random != nil); rules.IsBerlin {
+	if rules := st.evm.ChainRules(); rules.IsBerlin {
 		st.state.PrepareAccessList(msg.From(), msg.To(), vm.ActivePrecompiles(rules), msg.AccessList())
 	}
+	// Let the tracer account for the blobs of the message, if it cares about them
+	var blobTracer vm.BlobTxLogger
+	if st.evm.Config.Debug && len(msg.DataHashes()) > 0 {
+		blobTracer, _ = st.evm.Config.Tracer.(vm.BlobTxLogger)
+	}
+	if blobTracer != nil {
+		blobTracer.CaptureBlobTxStart(msg.DataHashes(), st.dataGas(), st.dataGasPrice)
+	}
 	var (
 		ret   []byte
 		vmerr error // vm errors do not effect consensus and are therefore not assigned to err
@@ -324,6 +380,9 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 		st.state.SetNonce(msg.From(), st.state.GetNonce(sender.Address())+1)
 		ret, st.gas, vmerr = st.evm.Call(sender, st.to(), st.data, st.gas, st.value)
 	}
+	if blobTracer != nil {
+		blobTracer.CaptureBlobTxEnd(vmerr)
+	}
 
 	if !london {
 		// Before EIP-3529: refunds were capped to gasUsed / 2
@@ -338,11 +397,15 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	}
 	st.state.AddBalance(st.evm.Context.Coinbase, new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), effectiveTip))
 
-	return &ExecutionResult{
+	result := &ExecutionResult{
 		UsedGas:    st.gasUsed(),
 		Err:        vmerr,
 		ReturnData: ret,
-	}, nil
+	}
+	if st.dataGasPrice != nil {
+		result.DataGasUsed, result.DataGasPrice = st.dataGas(), st.dataGasPrice
+	}
+	return result, nil
 }
 
 func (st *StateTransition) refundGas(refundQuotient uint64) {
@@ -0,0 +1,203 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// blobBytesGauge tracks the size of the blob transactions held by the store.
+var blobBytesGauge = metrics.NewRegisteredGauge("txpool/blobs/bytes", nil)
+
+// blobRetentionDepth is the number of blocks the included blob transactions are
+// retained for, allowing them to be reinjected along with their blobs if their
+// block gets reorged out. It matches the deepest reorg the pool reinjects from.
+const blobRetentionDepth = 64
+
+// txBlobStore keeps the blobs of pooled blob transactions on disk, so that the
+// pool only needs to hold on to the bare transactions in memory. Transactions
+// are stored in their network encoding, allowing them to be reinjected into the
+// pool after a restart, or after a reorg if they were already included.
+type txBlobStore struct {
+	db ethdb.KeyValueStore // Database holding the wrapped transactions, keyed by hash
+
+	sizes    map[common.Hash]int    // Encoded sizes of the stored transactions
+	size     int                    // Total size of the stored transactions
+	retained map[common.Hash]uint64 // Included transactions kept for reorgs, mapped to the head they were included at
+	lock     sync.Mutex             // Protects the size and retention tracking
+}
+
+// newTxBlobStore opens the blob store at the given path, or an in-memory one if
+// no path is given.
+func newTxBlobStore(path string) (*txBlobStore, error) {
+	if path == "" {
+		return newTxBlobStoreWithDB(rawdb.NewMemoryDatabase()), nil
+	}
+	db, err := rawdb.NewLevelDBDatabase(path, 16, 16, "txpool/blobstore/", false)
+	if err != nil {
+		return nil, err
+	}
+	return newTxBlobStoreWithDB(db), nil
+}
+
+// newTxBlobStoreWithDB creates a blob store on top of the given database.
+func newTxBlobStoreWithDB(db ethdb.KeyValueStore) *txBlobStore {
+	return &txBlobStore{
+		db:       db,
+		sizes:    make(map[common.Hash]int),
+		retained: make(map[common.Hash]uint64),
+	}
+}
+
+// load reinjects all the stored transactions into the pool. The store is wiped
+// beforehand, the pool adding back the transactions it accepts.
+func (store *txBlobStore) load(add func([]*types.Transaction) []error) error {
+	var txs []*types.Transaction
+
+	it := store.db.NewIterator(nil, nil)
+	for it.Next() {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalNetwork(it.Value()); err != nil {
+			log.Debug("Failed to decode stored blob transaction", "hash", common.BytesToHash(it.Key()), "err", err)
+		} else {
+			txs = append(txs, tx)
+		}
+		if err := store.db.Delete(it.Key()); err != nil {
+			it.Release()
+			return err
+		}
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+	dropped := 0
+	for i, err := range add(txs) {
+		// Transactions already pooled from the local journal keep their blobs
+		if err == ErrAlreadyKnown {
+			store.put(txs[i])
+			continue
+		}
+		if err != nil {
+			log.Debug("Failed to add stored blob transaction", "err", err)
+			dropped++
+		}
+	}
+	log.Info("Loaded stored blob transactions", "transactions", len(txs), "dropped", dropped)
+	return nil
+}
+
+// put stores a blob transaction along with its sidecar.
+func (store *txBlobStore) put(tx *types.Transaction) {
+	blob, err := tx.MarshalNetwork()
+	if err != nil {
+		log.Error("Failed to encode blob transaction", "hash", tx.Hash(), "err", err)
+		return
+	}
+	if err := store.db.Put(tx.Hash().Bytes(), blob); err != nil {
+		log.Error("Failed to store blob transaction", "hash", tx.Hash(), "err", err)
+		return
+	}
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	store.size += len(blob) - store.sizes[tx.Hash()]
+	store.sizes[tx.Hash()] = len(blob)
+	delete(store.retained, tx.Hash())
+	blobBytesGauge.Update(int64(store.size))
+}
+
+// get retrieves the sidecar of a stored blob transaction, or nil if it's not
+// found.
+func (store *txBlobStore) get(hash common.Hash) *types.BlobTxSidecar {
+	blob, err := store.db.Get(hash.Bytes())
+	if err != nil {
+		return nil
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalNetwork(blob); err != nil {
+		log.Error("Failed to decode stored blob transaction", "hash", hash, "err", err)
+		return nil
+	}
+	return tx.BlobTxSidecar()
+}
+
+// retain marks a stored blob transaction as included in the chain at the given
+// head, keeping it around until it's deeper than blobRetentionDepth instead of
+// deleting it when it leaves the pool. Unknown transactions are ignored.
+func (store *txBlobStore) retain(hash common.Hash, head uint64) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if _, ok := store.sizes[hash]; ok {
+		store.retained[hash] = head
+	}
+}
+
+// prune deletes all the retained blob transactions which were included too deep
+// below the given head to be reorged out any more.
+func (store *txBlobStore) prune(head uint64) {
+	if head < blobRetentionDepth {
+		return
+	}
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	for hash, number := range store.retained {
+		if number < head-blobRetentionDepth {
+			store.drop(hash)
+		}
+	}
+}
+
+// delete removes a blob transaction from the store, unless it's retained for
+// reinjection after a reorg.
+func (store *txBlobStore) delete(hash common.Hash) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if _, ok := store.retained[hash]; ok {
+		return
+	}
+	store.drop(hash)
+}
+
+// drop removes a blob transaction from the store.
+//
+// Note, this method assumes the store lock is held!
+func (store *txBlobStore) drop(hash common.Hash) {
+	if err := store.db.Delete(hash.Bytes()); err != nil {
+		log.Error("Failed to delete blob transaction", "hash", hash, "err", err)
+		return
+	}
+	store.size -= store.sizes[hash]
+	delete(store.sizes, hash)
+	delete(store.retained, hash)
+	blobBytesGauge.Update(int64(store.size))
+}
+
+// close flushes the blob store contents to disk and closes it.
+func (store *txBlobStore) close() error {
+	return store.db.Close()
+}
@@ -42,6 +42,11 @@ func (*devNull) Close() error                      { return nil }
 
 // txJournal is a rotating log of transactions with the aim of storing locally
 // created transactions to allow non-executed ones to survive node restarts.
+//
+// Transactions are stored in their network encoding, so blob transactions keep
+// their blobs and can be announced again after a restart. Apart from wrapped
+// blob transactions, the network encoding is the canonical one, keeping older
+// journals readable.
 type txJournal struct {
 	path   string         // Filesystem path to store the transactions at
 	writer io.WriteCloser // Output stream to write new transactions into
@@ -94,7 +99,7 @@ func (journal *txJournal) load(add func([]*types.Transaction) []error) error {
 	for {
 		// Parse the next transaction and terminate on error
 		tx := new(types.Transaction)
-		if err = stream.Decode(tx); err != nil {
+		if err = decodeJournalTx(stream, tx); err != nil {
 			if err != io.EOF {
 				failure = err
 			}
@@ -121,7 +126,7 @@ func (journal *txJournal) insert(tx *types.Transaction) error {
 	if journal.writer == nil {
 		return errNoActiveJournal
 	}
-	if err := rlp.Encode(journal.writer, tx); err != nil {
+	if err := encodeJournalTx(journal.writer, tx); err != nil {
 		return err
 	}
 	return nil
@@ -145,7 +150,7 @@ func (journal *txJournal) rotate(all map[common.Address]types.Transactions) erro
 	journaled := 0
 	for _, txs := range all {
 		for _, tx := range txs {
-			if err = rlp.Encode(replacement, tx); err != nil {
+			if err = encodeJournalTx(replacement, tx); err != nil {
 				replacement.Close()
 				return err
 			}
@@ -168,6 +173,37 @@ func (journal *txJournal) rotate(all map[common.Address]types.Transactions) erro
 	return nil
 }
 
+// encodeJournalTx writes a transaction into the journal in its network encoding.
+// Like in blocks, legacy transactions are written as a list and typed ones as a
+// string, which holds the blobs too for wrapped blob transactions.
+func encodeJournalTx(w io.Writer, tx *types.Transaction) error {
+	if tx.Type() == types.LegacyTxType {
+		return rlp.Encode(w, tx)
+	}
+	enc, err := tx.MarshalNetwork()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
+}
+
+// decodeJournalTx reads the next transaction written by encodeJournalTx from
+// the journal.
+func decodeJournalTx(stream *rlp.Stream, tx *types.Transaction) error {
+	kind, _, err := stream.Kind()
+	if err != nil {
+		return err
+	}
+	if kind == rlp.List {
+		return stream.Decode(tx)
+	}
+	enc, err := stream.Bytes()
+	if err != nil {
+		return err
+	}
+	return tx.UnmarshalNetwork(enc)
+}
+
 // close flushes the transaction journal contents to disk and closes the file.
 func (journal *txJournal) close() error {
 	var err error
@@ -278,29 +278,16 @@ func (l *txList) Overlaps(tx *types.Transaction) bool {
 //
 // If the new transaction is accepted into the list, the lists' cost and gas
 // thresholds are also potentially updated.
-func (l *txList) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Transaction) {
+//
+// Replacing a blob transaction requires bumping its data fee cap too by at least
+// blobPriceBump, whereas replacing it with a non-blob transaction requires the
+// fee cap and tip to be bumped by blobPriceBump instead of priceBump, so that
+// sidecars cannot be churned through the network for free.
+func (l *txList) Add(tx *types.Transaction, priceBump uint64, blobPriceBump uint64) (bool, *types.Transaction) {
 	// If there's an older better transaction, abort
 	old := l.txs.Get(tx.Nonce())
-	if old != nil {
-		if old.GasFeeCapCmp(tx) >= 0 || old.GasTipCapCmp(tx) >= 0 {
-			return false, nil
-		}
-		// thresholdFeeCap = oldFC  * (100 + priceBump) / 100
-		a := big.NewInt(100 + int64(priceBump))
-		aFeeCap := new(big.Int).Mul(a, old.GasFeeCap())
-		aTip := a.Mul(a, old.GasTipCap())
-
-		// thresholdTip    = oldTip * (100 + priceBump) / 100
-		b := big.NewInt(100)
-		thresholdFeeCap := aFeeCap.Div(aFeeCap, b)
-		thresholdTip := aTip.Div(aTip, b)
-
-		// We have to ensure that both the new fee cap and tip are higher than the
-		// old ones as well as checking the percentage threshold to ensure that
-		// this is accurate for low (Wei-level) gas price replacements.
-		if tx.GasFeeCapIntCmp(thresholdFeeCap) < 0 || tx.GasTipCapIntCmp(thresholdTip) < 0 {
-			return false, nil
-		}
+	if old != nil && !replaces(old, tx, priceBump, blobPriceBump) {
+		return false, nil
 	}
 	// Otherwise overwrite the old transaction with the current one
 	l.txs.Put(tx)
@@ -313,6 +300,57 @@ func (l *txList) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Tran
 	return true, old
 }
 
+// Replaceable returns whether tx could be inserted into the list, i.e. its
+// nonce is either free or the transaction holding it is cheap enough to be
+// replaced by tx.
+func (l *txList) Replaceable(tx *types.Transaction, priceBump uint64, blobPriceBump uint64) bool {
+	old := l.txs.Get(tx.Nonce())
+	return old == nil || replaces(old, tx, priceBump, blobPriceBump)
+}
+
+// replaces returns whether tx pays enough more than old to replace it.
+func replaces(old, tx *types.Transaction, priceBump uint64, blobPriceBump uint64) bool {
+	if old.GasFeeCapCmp(tx) >= 0 || old.GasTipCapCmp(tx) >= 0 {
+		return false
+	}
+	if old.Type() == types.BlobTxType {
+		if tx.Type() == types.BlobTxType {
+			// thresholdDataFeeCap = oldDFC * (100 + blobPriceBump) / 100
+			if !bumped(tx.MaxFeePerDataGas(), old.MaxFeePerDataGas(), blobPriceBump) {
+				return false
+			}
+		} else {
+			priceBump = blobPriceBump
+		}
+	}
+	// thresholdFeeCap = oldFC  * (100 + priceBump) / 100
+	a := big.NewInt(100 + int64(priceBump))
+	aFeeCap := new(big.Int).Mul(a, old.GasFeeCap())
+	aTip := a.Mul(a, old.GasTipCap())
+
+	// thresholdTip    = oldTip * (100 + priceBump) / 100
+	b := big.NewInt(100)
+	thresholdFeeCap := aFeeCap.Div(aFeeCap, b)
+	thresholdTip := aTip.Div(aTip, b)
+
+	// We have to ensure that both the new fee cap and tip are higher than the
+	// old ones as well as checking the percentage threshold to ensure that
+	// this is accurate for low (Wei-level) gas price replacements.
+	return tx.GasFeeCapIntCmp(thresholdFeeCap) >= 0 && tx.GasTipCapIntCmp(thresholdTip) >= 0
+}
+
+// bumped returns whether the new price is strictly higher than the old one and
+// also at least priceBump percent above it.
+func bumped(price, old *big.Int, priceBump uint64) bool {
+	if price.Cmp(old) <= 0 {
+		return false
+	}
+	threshold := new(big.Int).Mul(old, big.NewInt(100+int64(priceBump)))
+	threshold.Div(threshold, big.NewInt(100))
+
+	return price.Cmp(threshold) >= 0
+}
+
 // Forward removes all transactions from the list with a nonce lower than the
 // provided threshold. Every removed transaction is returned for any post-removal
 // maintenance.
@@ -85,6 +85,18 @@ var (
 	// than some meaningful limit a user might use. This is not a consensus error
 	// making the transaction invalid, rather a DOS protection.
 	ErrOversizedData = errors.New("oversized data")
+
+	// ErrMissingBlobSidecar is returned if a blob transaction is added to the pool
+	// without the blobs it references, which are needed to include it in a block.
+	ErrMissingBlobSidecar = errors.New("missing blob sidecar")
+
+	// ErrTooManyBlobs is returned if a blob transaction references more blobs
+	// than fit into a single block.
+	ErrTooManyBlobs = errors.New("too many blobs")
+
+	// ErrAccountBlobLimit is returned if accepting a blob transaction would make
+	// its sender exceed the number of blobs allowed per account.
+	ErrAccountBlobLimit = errors.New("account blob limit exceeded")
 )
 
 var (
@@ -125,6 +137,14 @@ var (
 	queuedGauge  = metrics.NewRegisteredGauge("txpool/queued", nil)
 	localGauge   = metrics.NewRegisteredGauge("txpool/local", nil)
 	slotsGauge   = metrics.NewRegisteredGauge("txpool/slots", nil)
+	blobsGauge   = metrics.NewRegisteredGauge("txpool/blobs", nil)
+
+	// Blob transaction rejection metrics
+	blobMissingMeter      = metrics.NewRegisteredMeter("txpool/blobs/missing", nil)      // Sent without their sidecar
+	blobInvalidMeter      = metrics.NewRegisteredMeter("txpool/blobs/invalid", nil)      // Sidecar not matching the referenced blobs
+	blobTooManyMeter      = metrics.NewRegisteredMeter("txpool/blobs/toomany", nil)      // More blobs than fit into a block
+	blobUnderpricedMeter  = metrics.NewRegisteredMeter("txpool/blobs/underpriced", nil)  // Data gas fee cap below the current price
+	blobAccountLimitMeter = metrics.NewRegisteredMeter("txpool/blobs/accountlimit", nil) // Sender out of blob allowance
 
 	reheapTimer = metrics.NewRegisteredTimer("txpool/reheap", nil)
 )
@@ -155,15 +175,21 @@ type TxPoolConfig struct {
 	NoLocals  bool             // Whether local transaction handling should be disabled
 	Journal   string           // Journal of local transactions to survive node restarts
 	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
+	BlobStore string           // Disk store of pooled blobs to survive node restarts
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
 
+	BlobPriceBump uint64 // Minimum price bump percentage to replace an already existing blob transaction (nonce)
+
 	AccountSlots uint64 // Number of executable transaction slots guaranteed per account
 	GlobalSlots  uint64 // Maximum number of executable transaction slots for all accounts
 	AccountQueue uint64 // Maximum number of non-executable transaction slots permitted per account
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
+	AccountBlobs uint64 // Maximum number of blobs permitted per account
+	GlobalBlobs  uint64 // Maximum number of blobs for all accounts
+
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
 }
 
@@ -172,15 +198,21 @@ type TxPoolConfig struct {
 var DefaultTxPoolConfig = TxPoolConfig{
 	Journal:   "transactions.rlp",
 	Rejournal: time.Hour,
+	BlobStore: "blobpool",
 
 	PriceLimit: 1,
 	PriceBump:  10,
 
+	BlobPriceBump: 100,
+
 	AccountSlots: 16,
 	GlobalSlots:  4096 + 1024, // urgent + floating queue capacity with 4:1 ratio
 	AccountQueue: 64,
 	GlobalQueue:  1024,
 
+	AccountBlobs: 16,
+	GlobalBlobs:  256, // 32MB of blobs at most
+
 	Lifetime: 3 * time.Hour,
 }
 
@@ -200,6 +232,10 @@ func (config *TxPoolConfig) sanitize() TxPoolConfig {
 		log.Warn("Sanitizing invalid txpool price bump", "provided", conf.PriceBump, "updated", DefaultTxPoolConfig.PriceBump)
 		conf.PriceBump = DefaultTxPoolConfig.PriceBump
 	}
+	if conf.BlobPriceBump < conf.PriceBump {
+		log.Warn("Sanitizing invalid txpool blob price bump", "provided", conf.BlobPriceBump, "updated", conf.PriceBump)
+		conf.BlobPriceBump = conf.PriceBump
+	}
 	if conf.AccountSlots < 1 {
 		log.Warn("Sanitizing invalid txpool account slots", "provided", conf.AccountSlots, "updated", DefaultTxPoolConfig.AccountSlots)
 		conf.AccountSlots = DefaultTxPoolConfig.AccountSlots
@@ -216,6 +252,14 @@ func (config *TxPoolConfig) sanitize() TxPoolConfig {
 		log.Warn("Sanitizing invalid txpool global queue", "provided", conf.GlobalQueue, "updated", DefaultTxPoolConfig.GlobalQueue)
 		conf.GlobalQueue = DefaultTxPoolConfig.GlobalQueue
 	}
+	if conf.AccountBlobs < 1 {
+		log.Warn("Sanitizing invalid txpool account blobs", "provided", conf.AccountBlobs, "updated", DefaultTxPoolConfig.AccountBlobs)
+		conf.AccountBlobs = DefaultTxPoolConfig.AccountBlobs
+	}
+	if conf.GlobalBlobs < 1 {
+		log.Warn("Sanitizing invalid txpool global blobs", "provided", conf.GlobalBlobs, "updated", DefaultTxPoolConfig.GlobalBlobs)
+		conf.GlobalBlobs = DefaultTxPoolConfig.GlobalBlobs
+	}
 	if conf.Lifetime < 1 {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultTxPoolConfig.Lifetime)
 		conf.Lifetime = DefaultTxPoolConfig.Lifetime
@@ -243,10 +287,14 @@ type TxPool struct {
 	istanbul bool // Fork indicator whether we are in the istanbul stage.
 	eip2718  bool // Fork indicator whether we are using EIP-2718 type transactions.
 	eip1559  bool // Fork indicator whether we are using EIP-1559 type transactions.
+	sharding bool // Fork indicator whether we are using EIP-4844 type transactions.
 
+	currentHead   *types.Header  // Current head of the blockchain
 	currentState  *state.StateDB // Current state in the blockchain head
 	pendingNonces *txNoncer      // Pending state tracking virtual nonces
 	currentMaxGas uint64         // Current gas limit for transaction caps
+	dataGasPrice  *big.Int       // Data gas price of the pending block
+	maxBlobsPerTx uint64         // Blobs a transaction may carry in the pending block
 
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *txJournal  // Journal of local transaction to back up to disk
@@ -305,20 +353,36 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 		pool.locals.add(addr)
 	}
 	pool.priced = newTxPricedList(pool.all)
+
+	// Keep the blobs of pooled transactions on disk, or in memory as a fallback
+	store, err := newTxBlobStore(config.BlobStore)
+	if err != nil {
+		log.Warn("Failed to open blob store, keeping blobs in memory", "err", err)
+		store, _ = newTxBlobStore("")
+	}
+	pool.all.store = store
 	pool.reset(nil, chain.CurrentBlock().Header())
 
 	// Start the reorg loop early so it can handle requests generated during journal loading.
 	pool.wg.Add(1)
 	go pool.scheduleReorgLoop()
 
-	// If local transactions and journaling is enabled, load from disk
+	// If local transactions and journaling is enabled, load from disk. Do it
+	// before reinjecting the stored blob transactions, so that local ones are
+	// restored as such.
 	if !config.NoLocals && config.Journal != "" {
 		pool.journal = newTxJournal(config.Journal)
 
 		if err := pool.journal.load(pool.AddLocals); err != nil {
 			log.Warn("Failed to load transaction journal", "err", err)
 		}
-		if err := pool.journal.rotate(pool.local()); err != nil {
+	}
+	// Reinject the blob transactions stored before a restart
+	if err := store.load(pool.AddRemotes); err != nil {
+		log.Warn("Failed to load stored blob transactions", "err", err)
+	}
+	if pool.journal != nil {
+		if err := pool.journal.rotate(pool.journalLocals()); err != nil {
 			log.Warn("Failed to rotate transaction journal", "err", err)
 		}
 	}
@@ -401,7 +465,7 @@ func (pool *TxPool) loop() {
 		case <-journal.C:
 			if pool.journal != nil {
 				pool.mu.Lock()
-				if err := pool.journal.rotate(pool.local()); err != nil {
+				if err := pool.journal.rotate(pool.journalLocals()); err != nil {
 					log.Warn("Failed to rotate local tx journal", "err", err)
 				}
 				pool.mu.Unlock()
@@ -422,6 +486,9 @@ func (pool *TxPool) Stop() {
 	if pool.journal != nil {
 		pool.journal.close()
 	}
+	if err := pool.all.store.close(); err != nil {
+		log.Error("Failed to close blob store", "err", err)
+	}
 	log.Info("Transaction pool stopped")
 }
 
@@ -581,6 +648,23 @@ func (pool *TxPool) local() map[common.Address]types.Transactions {
 	return txs
 }
 
+// journalLocals retrieves all currently known local transactions to journal,
+// grouped by origin account. Blob transactions are reattached to their blobs.
+func (pool *TxPool) journalLocals() map[common.Address]types.Transactions {
+	txs := pool.local()
+	for _, list := range txs {
+		for i, tx := range list {
+			if tx.Type() != types.BlobTxType {
+				continue
+			}
+			if sidecar := pool.all.store.get(tx.Hash()); sidecar != nil {
+				list[i] = tx.WithBlobTxSidecar(sidecar)
+			}
+		}
+	}
+	return txs
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
@@ -592,6 +676,10 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if !pool.eip1559 && tx.Type() == types.DynamicFeeTxType {
 		return ErrTxTypeNotSupported
 	}
+	// Reject blob transactions until EIP-4844 activates.
+	if !pool.sharding && tx.Type() == types.BlobTxType {
+		return ErrTxTypeNotSupported
+	}
 	// Reject transactions over defined size to prevent DOS attacks
 	if uint64(tx.Size()) > txMaxSize {
 		return ErrOversizedData
@@ -616,6 +704,26 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if tx.GasFeeCapIntCmp(tx.GasTipCap()) < 0 {
 		return ErrTipAboveFeeCap
 	}
+	// Blob transactions need their blobs to be includable, and may not carry
+	// more of them than fit into a block.
+	if tx.Type() == types.BlobTxType {
+		if tx.BlobTxSidecar() == nil {
+			blobMissingMeter.Mark(1)
+			return ErrMissingBlobSidecar
+		}
+		if uint64(len(tx.DataHashes())) > pool.maxBlobsPerTx {
+			blobTooManyMeter.Mark(1)
+			return ErrTooManyBlobs
+		}
+		if tx.MaxFeePerDataGas().BitLen() > 256 {
+			return ErrDataFeeCapVeryHigh
+		}
+		// Drop non-local blob transactions unable to pay for the current data gas
+		if !local && tx.MaxFeePerDataGas().Cmp(pool.dataGasPrice) < 0 {
+			blobUnderpricedMeter.Mark(1)
+			return ErrDataFeeCapTooLow
+		}
+	}
 	// Make sure the transaction is signed properly.
 	from, err := types.Sender(pool.signer, tx)
 	if err != nil {
@@ -670,6 +778,25 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 		invalidTxMeter.Mark(1)
 		return false, err
 	}
+	// If the transaction carries blobs, make sure there's room for them. Any
+	// blob transactions that need to go are only evicted once the new one is
+	// known to be accepted.
+	var blobDrops types.Transactions
+	if tx.Type() == types.BlobTxType {
+		if blobDrops, err = pool.makeBlobRoom(tx, isLocal); err != nil {
+			log.Trace("Discarding blob transaction", "hash", hash, "blobs", len(tx.DataHashes()), "err", err)
+			return false, err
+		}
+	}
+	// Blobs are kept on disk, only pool the transaction without them. Local
+	// transactions are journaled along with their blobs though.
+	var (
+		wrapped   *types.Transaction
+		journaled = tx
+	)
+	if tx.BlobTxSidecar() != nil {
+		wrapped, tx = tx, tx.WithoutBlobTxSidecar()
+	}
 	// If the transaction pool is full, discard underpriced transactions
 	if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.GlobalSlots+pool.config.GlobalQueue {
 		// If the new transaction is underpriced, don't accept it
@@ -707,11 +834,29 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 			pool.removeTx(tx.Hash(), false)
 		}
 	}
-	// Try to replace an existing transaction in the pending pool
 	from, _ := types.Sender(pool.signer, tx) // already validated
+
+	// Make room for the blobs of the new transaction, unless it's an underpriced
+	// replacement that would be rejected anyway
+	if len(blobDrops) > 0 {
+		if list := pool.pending[from]; list != nil && !list.Replaceable(tx, pool.config.PriceBump, pool.config.BlobPriceBump) {
+			pendingDiscardMeter.Mark(1)
+			return false, ErrReplaceUnderpriced
+		}
+		if list := pool.queue[from]; list != nil && !list.Replaceable(tx, pool.config.PriceBump, pool.config.BlobPriceBump) {
+			queuedDiscardMeter.Mark(1)
+			return false, ErrReplaceUnderpriced
+		}
+		for _, drop := range blobDrops {
+			log.Trace("Discarding freshly underpriced blob transaction", "hash", drop.Hash(), "maxFeePerDataGas", drop.MaxFeePerDataGas())
+			underpricedTxMeter.Mark(1)
+			pool.removeTx(drop.Hash(), false)
+		}
+	}
+	// Try to replace an existing transaction in the pending pool
 	if list := pool.pending[from]; list != nil && list.Overlaps(tx) {
 		// Nonce already pending, check if required price bump is met
-		inserted, old := list.Add(tx, pool.config.PriceBump)
+		inserted, old := list.Add(tx, pool.config.PriceBump, pool.config.BlobPriceBump)
 		if !inserted {
 			pendingDiscardMeter.Mark(1)
 			return false, ErrReplaceUnderpriced
@@ -724,7 +869,10 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 		}
 		pool.all.Add(tx, isLocal)
 		pool.priced.Put(tx, isLocal)
-		pool.journalTx(from, tx)
+		if wrapped != nil {
+			pool.all.store.put(wrapped)
+		}
+		pool.journalTx(from, journaled)
 		pool.queueTxEvent(tx)
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
@@ -737,6 +885,9 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 	if err != nil {
 		return false, err
 	}
+	if wrapped != nil {
+		pool.all.store.put(wrapped)
+	}
 	// Mark local addresses and journal local transactions
 	if local && !pool.locals.contains(from) {
 		log.Info("Setting new local account", "address", from)
@@ -746,7 +897,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 	if isLocal {
 		localGauge.Inc(1)
 	}
-	pool.journalTx(from, tx)
+	pool.journalTx(from, journaled)
 
 	log.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replaced, nil
@@ -761,7 +912,7 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction, local boo
 	if pool.queue[from] == nil {
 		pool.queue[from] = newTxList(false)
 	}
-	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump)
+	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump, pool.config.BlobPriceBump)
 	if !inserted {
 		// An older transaction was better, discard this
 		queuedDiscardMeter.Mark(1)
@@ -815,7 +966,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	}
 	list := pool.pending[addr]
 
-	inserted, old := list.Add(tx, pool.config.PriceBump)
+	inserted, old := list.Add(tx, pool.config.PriceBump, pool.config.BlobPriceBump)
 	if !inserted {
 		// An older transaction was better, discard this
 		pool.all.Remove(hash)
@@ -908,6 +1059,16 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 			invalidTxMeter.Mark(1)
 			continue
 		}
+		// Verifying the blobs is expensive, do it before obtaining the lock too.
+		// Missing sidecars are rejected during validation.
+		if sidecar := tx.BlobTxSidecar(); sidecar != nil {
+			if err := sidecar.Verify(tx.DataHashes()); err != nil {
+				errs[i] = err
+				invalidTxMeter.Mark(1)
+				blobInvalidMeter.Mark(1)
+				continue
+			}
+		}
 		// Accumulate all unknown transactions for deeper processing
 		news = append(news, tx)
 	}
@@ -957,7 +1118,7 @@ func (pool *TxPool) addTxsLocked(txs []*types.Transaction, local bool) ([]error,
 func (pool *TxPool) Status(hashes []common.Hash) []TxStatus {
 	status := make([]TxStatus, len(hashes))
 	for i, hash := range hashes {
-		tx := pool.Get(hash)
+		tx := pool.all.Get(hash)
 		if tx == nil {
 			continue
 		}
@@ -976,16 +1137,110 @@ func (pool *TxPool) Status(hashes []common.Hash) []TxStatus {
 }
 
 // Get returns a transaction if it is contained in the pool and nil otherwise.
+// Blob transactions are returned without their sidecar, use GetWithBlobs to
+// retrieve them along with their blobs.
 func (pool *TxPool) Get(hash common.Hash) *types.Transaction {
 	return pool.all.Get(hash)
 }
 
+// GetWithBlobs returns a transaction if it is contained in the pool and nil
+// otherwise. Blob transactions are returned along with their sidecar, which is
+// loaded from disk.
+func (pool *TxPool) GetWithBlobs(hash common.Hash) *types.Transaction {
+	tx := pool.all.Get(hash)
+	if tx == nil || tx.Type() != types.BlobTxType {
+		return tx
+	}
+	sidecar := pool.all.store.get(hash)
+	if sidecar == nil {
+		// The transaction may have been removed in the meantime
+		return nil
+	}
+	return tx.WithBlobTxSidecar(sidecar)
+}
+
 // Has returns an indicator whether txpool has a transaction cached with the
 // given hash.
 func (pool *TxPool) Has(hash common.Hash) bool {
 	return pool.all.Get(hash) != nil
 }
 
+// makeBlobRoom ensures that accepting a blob transaction keeps both its sender
+// and the pool within their blob allowances. If the pool is out of blob space,
+// the cheapest remote blob transactions of other accounts that need to be
+// evicted to make room are returned, provided the new transaction pays more
+// than them or is local. The caller is responsible for dropping them.
+func (pool *TxPool) makeBlobRoom(tx *types.Transaction, local bool) (types.Transactions, error) {
+	from, _ := types.Sender(pool.signer, tx) // already validated
+
+	// Count the blobs of the sender, releasing those of a replaced transaction
+	var (
+		blobs    = len(tx.DataHashes())
+		owned    = blobs
+		replaced int
+	)
+	for _, list := range []*txList{pool.pending[from], pool.queue[from]} {
+		if list == nil {
+			continue
+		}
+		for nonce, old := range list.txs.items {
+			if nonce == tx.Nonce() {
+				replaced += len(old.DataHashes())
+			} else {
+				owned += len(old.DataHashes())
+			}
+		}
+	}
+	if uint64(owned) > pool.config.AccountBlobs {
+		blobAccountLimitMeter.Mark(1)
+		return nil, ErrAccountBlobLimit
+	}
+	overflow := pool.all.Blobs() - replaced + blobs - int(pool.config.GlobalBlobs)
+	if overflow <= 0 {
+		return nil, nil
+	}
+	// The pool is out of blob space, gather the cheapest blob transactions that
+	// need to go to make room for the new one
+	var candidates types.Transactions
+	pool.all.Range(func(hash common.Hash, other *types.Transaction, _ bool) bool {
+		if len(other.DataHashes()) > 0 {
+			if sender, _ := types.Sender(pool.signer, other); sender != from {
+				candidates = append(candidates, other)
+			}
+		}
+		return true
+	}, false, true)
+	sort.Slice(candidates, func(i, j int) bool {
+		return pool.blobTxCmp(candidates[i], candidates[j]) < 0
+	})
+	var drops types.Transactions
+	for _, candidate := range candidates {
+		if overflow <= 0 {
+			break
+		}
+		if !local && pool.blobTxCmp(candidate, tx) >= 0 {
+			underpricedTxMeter.Mark(1)
+			return nil, ErrUnderpriced
+		}
+		drops = append(drops, candidate)
+		overflow -= len(candidate.DataHashes())
+	}
+	if overflow > 0 {
+		overflowedTxMeter.Mark(1)
+		return nil, ErrTxPoolOverflow
+	}
+	return drops, nil
+}
+
+// blobTxCmp orders blob transactions by their data gas fee cap, using their
+// effective tip at the pending base fee to break ties.
+func (pool *TxPool) blobTxCmp(a, b *types.Transaction) int {
+	if c := a.MaxFeePerDataGas().Cmp(b.MaxFeePerDataGas()); c != 0 {
+		return c
+	}
+	return a.EffectiveGasTipCmp(b, pool.priced.urgent.baseFee)
+}
+
 // removeTx removes a single transaction from the queue, moving all subsequent
 // transactions back to the future queue.
 func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) {
@@ -1290,20 +1545,37 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 		log.Error("Failed to reset txpool state", "err", err)
 		return
 	}
+	pool.currentHead = newHead
 	pool.currentState = statedb
 	pool.pendingNonces = newTxNoncer(statedb)
 	pool.currentMaxGas = newHead.GasLimit
+	pool.dataGasPrice = misc.GetDataGasPrice(pool.chainconfig, newHead.ExcessDataGas)
 
+	// Blocks only carry the bare blob transactions, reattach the blobs retained
+	// since their inclusion so they can be reinjected too
+	for i, tx := range reinject {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		if sidecar := pool.all.store.get(tx.Hash()); sidecar != nil {
+			reinject[i] = tx.WithBlobTxSidecar(sidecar)
+		}
+	}
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	senderCacher.recover(pool.signer, reinject)
 	pool.addTxsLocked(reinject, false)
 
+	// Drop the retained blobs of transactions which cannot be reorged out any more
+	pool.all.store.prune(newHead.Number.Uint64())
+
 	// Update all fork indicator by next pending block number.
 	next := new(big.Int).Add(newHead.Number, big.NewInt(1))
 	pool.istanbul = pool.chainconfig.IsIstanbul(next)
 	pool.eip2718 = pool.chainconfig.IsBerlin(next)
 	pool.eip1559 = pool.chainconfig.IsLondon(next)
+	pool.sharding = pool.chainconfig.IsSharding(next, uint64(time.Now().Unix()))
+	pool.maxBlobsPerTx = pool.chainconfig.ShardingParamsAt(uint64(time.Now().Unix())).MaxBlobsPerTx
 }
 
 // promoteExecutables moves transactions that have become processable from the
@@ -1323,6 +1595,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 		forwards := list.Forward(pool.currentState.GetNonce(addr))
 		for _, tx := range forwards {
 			hash := tx.Hash()
+			pool.all.store.retain(hash, pool.currentHead.Number.Uint64())
 			pool.all.Remove(hash)
 		}
 		log.Trace("Removed old queued transactions", "count", len(forwards))
@@ -1520,6 +1793,7 @@ func (pool *TxPool) demoteUnexecutables() {
 		olds := list.Forward(nonce)
 		for _, tx := range olds {
 			hash := tx.Hash()
+			pool.all.store.retain(hash, pool.currentHead.Number.Uint64())
 			pool.all.Remove(hash)
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
@@ -1664,9 +1938,11 @@ func (as *accountSet) merge(other *accountSet) {
 // to build upper-level structure.
 type txLookup struct {
 	slots   int
+	blobs   int
 	lock    sync.RWMutex
 	locals  map[common.Hash]*types.Transaction
 	remotes map[common.Hash]*types.Transaction
+	store   *txBlobStore // Disk store of the blobs, cleaned up on removal
 }
 
 // newTxLookup returns a new txLookup structure.
@@ -1759,6 +2035,15 @@ func (t *txLookup) Slots() int {
 	return t.slots
 }
 
+// Blobs returns the current number of blobs referenced by the transactions in
+// the lookup.
+func (t *txLookup) Blobs() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.blobs
+}
+
 // Add adds a transaction to the lookup.
 func (t *txLookup) Add(tx *types.Transaction, local bool) {
 	t.lock.Lock()
@@ -1767,6 +2052,9 @@ func (t *txLookup) Add(tx *types.Transaction, local bool) {
 	t.slots += numSlots(tx)
 	slotsGauge.Update(int64(t.slots))
 
+	t.blobs += len(tx.DataHashes())
+	blobsGauge.Update(int64(t.blobs))
+
 	if local {
 		t.locals[tx.Hash()] = tx
 	} else {
@@ -1790,6 +2078,13 @@ func (t *txLookup) Remove(hash common.Hash) {
 	t.slots -= numSlots(tx)
 	slotsGauge.Update(int64(t.slots))
 
+	t.blobs -= len(tx.DataHashes())
+	blobsGauge.Update(int64(t.blobs))
+
+	if t.store != nil && tx.Type() == types.BlobTxType {
+		t.store.delete(hash)
+	}
+
 	delete(t.locals, hash)
 	delete(t.remotes, hash)
 }
@@ -19,11 +19,14 @@ package core
 import (
 	"crypto/ecdsa"
 	"errors"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"math/rand"
 	"os"
+	"reflect"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -33,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
@@ -50,6 +54,7 @@ var (
 func init() {
 	testTxPoolConfig = DefaultTxPoolConfig
 	testTxPoolConfig.Journal = ""
+	testTxPoolConfig.BlobStore = ""
 
 	cpy := *params.TestChainConfig
 	eip1559Config = &cpy
@@ -113,6 +118,36 @@ func dynamicFeeTx(nonce uint64, gaslimit uint64, gasFee *big.Int, tip *big.Int,
 	return tx
 }
 
+// blobTx creates a blob transaction carrying the given number of empty blobs,
+// along with their sidecar.
+func blobTx(nonce uint64, gaslimit uint64, gasFee *big.Int, tip *big.Int, dataFee *big.Int, blobs int, key *ecdsa.PrivateKey) *types.Transaction {
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0 // point at infinity, committing to the empty blob
+
+	var (
+		hashes  []common.Hash
+		sidecar = new(types.BlobTxSidecar)
+	)
+	for i := 0; i < blobs; i++ {
+		hashes = append(hashes, commitment.ComputeVersionedHash())
+		sidecar.Blobs = append(sidecar.Blobs, kzg.Blob{})
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, kzg.KZGProof{0xc0})
+	}
+	tx, _ := types.SignNewTx(key, types.LatestSignerForChainID(params.TestChainConfig.ChainID), &types.BlobTx{
+		ChainID:             params.TestChainConfig.ChainID,
+		Nonce:               nonce,
+		GasTipCap:           tip,
+		GasFeeCap:           gasFee,
+		Gas:                 gaslimit,
+		To:                  &common.Address{},
+		Value:               big.NewInt(100),
+		MaxFeePerDataGas:    dataFee,
+		BlobVersionedHashes: hashes,
+	})
+	return tx.WithBlobTxSidecar(sidecar)
+}
+
 func setupTxPool() (*TxPool, *ecdsa.PrivateKey) {
 	return setupTxPoolWithConfig(params.TestChainConfig)
 }
@@ -2234,6 +2269,577 @@ func TestTransactionReplacementDynamicFee(t *testing.T) {
 	}
 }
 
+// Tests that blob transactions are only accepted if they carry valid blobs and
+// can pay for them.
+func TestTransactionBlobValidation(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPoolWithConfig(params.TestShardingChainConfig)
+	defer pool.Stop()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	// Blob transactions must carry their blobs, and no more than fit in a block
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 1, key).WithoutBlobTxSidecar()); err != ErrMissingBlobSidecar {
+		t.Errorf("missing sidecar error mismatch: have %v, want %v", err, ErrMissingBlobSidecar)
+	}
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), params.MaxBlobsPerBlock+1, key)); err != ErrTooManyBlobs {
+		t.Errorf("too many blobs error mismatch: have %v, want %v", err, ErrTooManyBlobs)
+	}
+	// The blob limit follows the chain config
+	limited := *params.TestShardingChainConfig
+	limited.Sharding = &params.ShardingConfig{MaxBlobsPerTx: 2}
+	limitedPool, _ := setupTxPoolWithConfig(&limited)
+	defer limitedPool.Stop()
+
+	if err := limitedPool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 3, key)); err != ErrTooManyBlobs {
+		t.Errorf("configured too many blobs error mismatch: have %v, want %v", err, ErrTooManyBlobs)
+	}
+	// The blobs must match the versioned hashes of the transaction
+	tx := blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 1, key)
+	sidecar := *tx.BlobTxSidecar()
+	sidecar.Commitments = []kzg.KZGCommitment{{0x01}}
+	if err := pool.AddRemote(tx.WithBlobTxSidecar(&sidecar)); err == nil {
+		t.Error("expected mismatching sidecar to be rejected")
+	}
+	// The commitments must be points of the G1 subgroup, even if referenced by
+	// the transaction (x = 4 is on the curve, but outside the subgroup)
+	offSubgroup := kzg.KZGCommitment{0x80, 47: 0x04}
+	tx, _ = types.SignNewTx(key, types.LatestSignerForChainID(params.TestChainConfig.ChainID), &types.BlobTx{
+		ChainID:             params.TestChainConfig.ChainID,
+		GasTipCap:           big.NewInt(1),
+		GasFeeCap:           big.NewInt(1),
+		Gas:                 100000,
+		To:                  &common.Address{},
+		MaxFeePerDataGas:    big.NewInt(1),
+		BlobVersionedHashes: []common.Hash{offSubgroup.ComputeVersionedHash()},
+	})
+	sidecar = types.BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}},
+		Commitments: []kzg.KZGCommitment{offSubgroup},
+		Proofs:      []kzg.KZGProof{{0xc0}},
+	}
+	if err := pool.AddRemote(tx.WithBlobTxSidecar(&sidecar)); err == nil {
+		t.Error("expected off-subgroup commitment to be rejected")
+	}
+	// Remote blob transactions must pay the current data gas price
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(0), 1, key)); err != ErrDataFeeCapTooLow {
+		t.Errorf("data fee cap error mismatch: have %v, want %v", err, ErrDataFeeCapTooLow)
+	}
+	// The sender must be able to pay for the data gas on top of the execution
+	testAddBalance(pool, from, big.NewInt(100000+100+params.DataGasPerBlob-1))
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 1, key)); err != ErrInsufficientFunds {
+		t.Errorf("funds error mismatch: have %v, want %v", err, ErrInsufficientFunds)
+	}
+	testAddBalance(pool, from, big.NewInt(1))
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 1, key)); err != nil {
+		t.Fatalf("failed to add valid blob transaction: %v", err)
+	}
+	if blobs := pool.all.Blobs(); blobs != 1 {
+		t.Errorf("tracked blob count mismatch: have %d, want %d", blobs, 1)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the number of blobs is limited both per account and globally, and
+// that cheaper blob transactions are evicted to make room for better ones.
+func TestTransactionBlobLimiting(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{10000000, statedb, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.AccountBlobs = 4
+	config.GlobalBlobs = 6
+
+	pool := NewTxPool(config, params.TestShardingChainConfig, blockchain)
+	defer pool.Stop()
+	<-pool.initDoneCh
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000))
+	}
+	// Fill up the blob allowance of the first account
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(3), 2, keys[0])); err != nil {
+		t.Fatalf("failed to add blob transaction: %v", err)
+	}
+	if err := pool.AddRemote(blobTx(1, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 2, keys[0])); err != nil {
+		t.Fatalf("failed to add blob transaction: %v", err)
+	}
+	if err := pool.AddRemote(blobTx(2, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 1, keys[0])); err != ErrAccountBlobLimit {
+		t.Fatalf("account blob limit error mismatch: have %v, want %v", err, ErrAccountBlobLimit)
+	}
+	// Replacements release the blobs of the replaced transaction
+	if err := pool.AddRemote(blobTx(1, 100000, big.NewInt(2), big.NewInt(2), big.NewInt(2), 2, keys[0])); err != nil {
+		t.Fatalf("failed to replace blob transaction: %v", err)
+	}
+	// Fill up the global blob allowance with a second account
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(3), 2, keys[1])); err != nil {
+		t.Fatalf("failed to add blob transaction: %v", err)
+	}
+	// Blob transactions not paying more than the cheapest ones are rejected
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(2), 1, keys[2])); err != ErrUnderpriced {
+		t.Fatalf("underpriced blob error mismatch: have %v, want %v", err, ErrUnderpriced)
+	}
+	// Better paying ones evict the cheapest blob transactions
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(3), 1, keys[2])); err != nil {
+		t.Fatalf("failed to add better paying blob transaction: %v", err)
+	}
+	if blobs := pool.all.Blobs(); blobs != 5 {
+		t.Errorf("tracked blob count mismatch: have %d, want %d", blobs, 5)
+	}
+	if pool.Get(blobTx(1, 100000, big.NewInt(2), big.NewInt(2), big.NewInt(2), 2, keys[0]).Hash()) != nil {
+		t.Errorf("cheapest blob transaction not evicted")
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that replacing a blob transaction requires bumping its data fee cap too,
+// and that replacing it with a non-blob transaction requires the larger blob
+// price bump.
+func TestTransactionBlobReplacement(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPoolWithConfig(params.TestShardingChainConfig)
+	defer pool.Stop()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(10), big.NewInt(10), big.NewInt(10), 1, key)); err != nil {
+		t.Fatalf("failed to add original blob transaction: %v", err)
+	}
+	// Blob replacements must bump the execution and the data fee caps alike
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(20), big.NewInt(20), big.NewInt(10), 1, key)); err != ErrReplaceUnderpriced {
+		t.Fatalf("unbumped data fee replacement error mismatch: have %v, want %v", err, ErrReplaceUnderpriced)
+	}
+	threshold := int64(10 * (100 + testTxPoolConfig.BlobPriceBump) / 100)
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(20), big.NewInt(20), big.NewInt(threshold-1), 1, key)); err != ErrReplaceUnderpriced {
+		t.Fatalf("insufficient data fee replacement error mismatch: have %v, want %v", err, ErrReplaceUnderpriced)
+	}
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(10), big.NewInt(10), big.NewInt(threshold), 1, key)); err != ErrReplaceUnderpriced {
+		t.Fatalf("unbumped execution fee replacement error mismatch: have %v, want %v", err, ErrReplaceUnderpriced)
+	}
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(11), big.NewInt(11), big.NewInt(threshold), 1, key)); err != nil {
+		t.Fatalf("failed to replace blob transaction: %v", err)
+	}
+	// Non-blob replacements must bump the execution fees by the blob price bump
+	threshold = int64(11 * (100 + testTxPoolConfig.BlobPriceBump) / 100)
+	if err := pool.AddRemote(dynamicFeeTx(0, 100000, big.NewInt(threshold-1), big.NewInt(threshold-1), key)); err != ErrReplaceUnderpriced {
+		t.Fatalf("insufficient non-blob replacement error mismatch: have %v, want %v", err, ErrReplaceUnderpriced)
+	}
+	if err := pool.AddRemote(dynamicFeeTx(0, 100000, big.NewInt(threshold), big.NewInt(threshold), key)); err != nil {
+		t.Fatalf("failed to replace blob transaction with non-blob one: %v", err)
+	}
+	if blobs := pool.all.Blobs(); blobs != 0 {
+		t.Errorf("tracked blob count mismatch: have %d, want %d", blobs, 0)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that a rejected blob replacement doesn't evict the blob transactions of
+// other accounts to make room for itself.
+func TestTransactionBlobRejectedReplacementKeepsOthers(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{10000000, statedb, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.AccountBlobs = 4
+	config.GlobalBlobs = 4
+
+	pool := NewTxPool(config, params.TestShardingChainConfig, blockchain)
+	defer pool.Stop()
+	<-pool.initDoneCh
+
+	keys := make([]*ecdsa.PrivateKey, 2)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000))
+	}
+	// Fill up the global blob allowance with both accounts
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(10), big.NewInt(10), big.NewInt(10), 1, keys[0])); err != nil {
+		t.Fatalf("failed to add blob transaction: %v", err)
+	}
+	other := blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 3, keys[1])
+	if err := pool.AddRemote(other); err != nil {
+		t.Fatalf("failed to add blob transaction: %v", err)
+	}
+	// Replace the first transaction with one needing more blobs, outbidding the
+	// other account on data gas but not bumping the execution fees
+	if err := pool.AddRemote(blobTx(0, 100000, big.NewInt(10), big.NewInt(10), big.NewInt(20), 2, keys[0])); err != ErrReplaceUnderpriced {
+		t.Fatalf("underpriced replacement error mismatch: have %v, want %v", err, ErrReplaceUnderpriced)
+	}
+	if pool.Get(other.Hash()) == nil {
+		t.Errorf("blob transaction of other account evicted by rejected replacement")
+	}
+	if blobs := pool.all.Blobs(); blobs != 4 {
+		t.Errorf("tracked blob count mismatch: have %d, want %d", blobs, 4)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the blobs of pooled transactions are kept on disk instead of in
+// memory, and that blob transactions are restored between restarts.
+func TestTransactionBlobStore(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary blob store: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{10000000, statedb, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.BlobStore = dir
+
+	pool := NewTxPool(config, params.TestShardingChainConfig, blockchain)
+	<-pool.initDoneCh
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	tx := blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 2, key)
+	if err := pool.AddRemote(tx); err != nil {
+		t.Fatalf("failed to add blob transaction: %v", err)
+	}
+	// The pool must only hold the bare transaction, but return the blobs too
+	if pool.all.Get(tx.Hash()).BlobTxSidecar() != nil {
+		t.Errorf("pooled transaction holds its blobs in memory")
+	}
+	if have := pool.Get(tx.Hash()); have == nil || have.BlobTxSidecar() != nil {
+		t.Errorf("plain retrieval loaded the blobs from disk")
+	}
+	if have := pool.GetWithBlobs(tx.Hash()); have == nil || !reflect.DeepEqual(have.BlobTxSidecar(), tx.BlobTxSidecar()) {
+		t.Errorf("retrieved transaction sidecar mismatch")
+	}
+	// Restart the pool and ensure the blob transaction is restored
+	pool.Stop()
+
+	pool = NewTxPool(config, params.TestShardingChainConfig, blockchain)
+	<-pool.initDoneCh
+
+	if have := pool.GetWithBlobs(tx.Hash()); have == nil || !reflect.DeepEqual(have.BlobTxSidecar(), tx.BlobTxSidecar()) {
+		t.Fatalf("blob transaction not restored after restart")
+	}
+	// Removing the transaction must drop its blobs from the store
+	pool.removeTx(tx.Hash(), true)
+	if sidecar := pool.all.store.get(tx.Hash()); sidecar != nil {
+		t.Errorf("blobs of removed transaction still stored")
+	}
+	pool.Stop()
+}
+
+// Tests that local blob transactions are journaled along with their blobs, so
+// they survive restarts even without the blob store.
+func TestTransactionBlobJournaling(t *testing.T) {
+	t.Parallel()
+
+	file, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary journal: %v", err)
+	}
+	journal := file.Name()
+	defer os.Remove(journal)
+
+	file.Close()
+	os.Remove(journal)
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{10000000, statedb, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.Journal = journal
+
+	pool := NewTxPool(config, params.TestShardingChainConfig, blockchain)
+	<-pool.initDoneCh
+
+	local, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(local.PublicKey), big.NewInt(1000000000))
+
+	plain := dynamicFeeTx(0, 100000, big.NewInt(1), big.NewInt(1), local)
+	if err := pool.AddLocal(plain); err != nil {
+		t.Fatalf("failed to add local transaction: %v", err)
+	}
+	blob := blobTx(1, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 2, local)
+	if err := pool.AddLocal(blob); err != nil {
+		t.Fatalf("failed to add local blob transaction: %v", err)
+	}
+	// Restart the pool with an empty blob store, and rotate the journal to also
+	// check the regenerated one
+	for i := 0; i < 2; i++ {
+		pool.Stop()
+		pool = NewTxPool(config, params.TestShardingChainConfig, blockchain)
+		<-pool.initDoneCh
+
+		if pool.Get(plain.Hash()) == nil {
+			t.Fatalf("restart %d: local transaction not restored", i)
+		}
+		if have := pool.GetWithBlobs(blob.Hash()); have == nil || !reflect.DeepEqual(have.BlobTxSidecar(), blob.BlobTxSidecar()) {
+			t.Fatalf("restart %d: local blob transaction not restored with its blobs", i)
+		}
+		if pool.all.GetLocal(blob.Hash()) == nil {
+			t.Fatalf("restart %d: blob transaction not restored as local", i)
+		}
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	pool.Stop()
+}
+
+// reorgTestBlockChain is a testBlockChain serving a set of known blocks, so that
+// the pool can walk the chain during a reorg.
+type reorgTestBlockChain struct {
+	*testBlockChain
+	blocks map[common.Hash]*types.Block
+}
+
+func (bc *reorgTestBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return bc.blocks[hash]
+}
+
+// Tests that blob transactions included in a block which gets reorged out are
+// reinjected into the pool along with their blobs.
+func TestTransactionBlobReorg(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &reorgTestBlockChain{
+		testBlockChain: &testBlockChain{10000000, statedb, new(event.Feed)},
+		blocks:         make(map[common.Hash]*types.Block),
+	}
+	pool := NewTxPool(testTxPoolConfig, params.TestShardingChainConfig, blockchain)
+	defer pool.Stop()
+	<-pool.initDoneCh
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+
+	tx := blobTx(0, 100000, big.NewInt(1), big.NewInt(1), big.NewInt(1), 1, key)
+	if err := pool.AddRemote(tx); err != nil {
+		t.Fatalf("failed to add blob transaction: %v", err)
+	}
+	// Include the transaction in a block, which drops it from the pool
+	var (
+		genesis = types.NewBlock(&types.Header{Number: big.NewInt(0), GasLimit: 10000000, BaseFee: big.NewInt(1)}, nil, nil, nil, trie.NewStackTrie(nil))
+		oldHead = types.NewBlock(&types.Header{Number: big.NewInt(1), GasLimit: 10000000, BaseFee: big.NewInt(1), ParentHash: genesis.Hash()}, []*types.Transaction{tx.WithoutBlobTxSidecar()}, nil, nil, trie.NewStackTrie(nil))
+		newHead = types.NewBlock(&types.Header{Number: big.NewInt(1), GasLimit: 10000000, BaseFee: big.NewInt(1), ParentHash: genesis.Hash(), Extra: []byte("reorg")}, nil, nil, nil, trie.NewStackTrie(nil))
+	)
+	for _, block := range []*types.Block{genesis, oldHead, newHead} {
+		blockchain.blocks[block.Hash()] = block
+	}
+	statedb.SetNonce(from, 1)
+	<-pool.requestReset(genesis.Header(), oldHead.Header())
+
+	if pool.Get(tx.Hash()) != nil {
+		t.Fatalf("included blob transaction still pooled")
+	}
+	// Reorg the block out and ensure the transaction is back along with its blobs
+	statedb.SetNonce(from, 0)
+	<-pool.requestReset(oldHead.Header(), newHead.Header())
+
+	if have := pool.GetWithBlobs(tx.Hash()); have == nil || !reflect.DeepEqual(have.BlobTxSidecar(), tx.BlobTxSidecar()) {
+		t.Fatalf("reorged out blob transaction not reinjected with its blobs")
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	// Blobs of transactions included too deep to be reorged out must be dropped
+	pool.removeTx(tx.Hash(), true)
+	pool.all.store.put(tx)
+	pool.all.store.retain(tx.Hash(), 1)
+	pool.all.store.prune(1 + blobRetentionDepth)
+	if pool.all.store.get(tx.Hash()) == nil {
+		t.Errorf("retained blobs dropped too early")
+	}
+	pool.all.store.prune(2 + blobRetentionDepth)
+	if pool.all.store.get(tx.Hash()) != nil {
+		t.Errorf("retained blobs not dropped")
+	}
+}
+
+var blobSoakFlag = flag.Duration("txpool.blobsoak", 0, "Duration to flood the pool with random blob transactions for")
+
+// Tests that flooding the pool with random valid and invalid blob transactions
+// from many accounts keeps the pooled and stored blobs within their allowances,
+// evicts transactions consistently and doesn't deadlock the pool. By default a
+// CI sized flood is used, a long soak can be run via -txpool.blobsoak.
+func TestTransactionBlobStress(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{10000000, statedb, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.AccountBlobs = 4
+	config.GlobalBlobs = 32
+
+	pool := NewTxPool(config, params.TestShardingChainConfig, blockchain)
+	defer pool.Stop()
+	<-pool.initDoneCh
+
+	keys := make([]*ecdsa.PrivateKey, 64)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000000))
+	}
+	var (
+		rounds  = 4
+		batches = 16
+		seed    = time.Now().UnixNano()
+	)
+	if testing.Short() {
+		rounds = 1
+	}
+	t.Logf("flooding pool with random blob transactions, seed %d", seed)
+
+	deadline := time.Now().Add(*blobSoakFlag)
+	for round := 0; round < rounds || time.Now().Before(deadline); round++ {
+		var (
+			adders  sync.WaitGroup
+			readers sync.WaitGroup
+			quit    = make(chan struct{})
+			done    = make(chan struct{})
+		)
+		// Flood the pool from multiple goroutines, while concurrently reading it
+		for i := 0; i < 4; i++ {
+			adders.Add(1)
+			go func(rng *rand.Rand) {
+				defer adders.Done()
+				for j := 0; j < batches; j++ {
+					txs := make([]*types.Transaction, 8)
+					for k := range txs {
+						txs[k] = randomBlobTx(rng, keys[rng.Intn(len(keys))])
+					}
+					pool.AddRemotesSync(txs)
+				}
+			}(rand.New(rand.NewSource(seed + int64(round*4+i))))
+		}
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-quit:
+					return
+				default:
+				}
+				for _, txs := range pool.Pending(false) {
+					for _, tx := range txs {
+						pool.GetWithBlobs(tx.Hash())
+					}
+				}
+				pool.Content()
+			}
+		}()
+		go func() {
+			adders.Wait()
+			close(quit)
+			readers.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Minute):
+			t.Fatalf("round %d: pool deadlocked", round)
+		}
+		if err := validateBlobPoolInternals(pool); err != nil {
+			t.Fatalf("round %d: %v", round, err)
+		}
+		if err := validateTxPoolInternals(pool); err != nil {
+			t.Fatalf("round %d: pool internal state corrupted: %v", round, err)
+		}
+	}
+}
+
+// randomBlobTx creates a random blob transaction, which is invalid every now
+// and then by carrying too many or mismatching blobs, or none at all.
+func randomBlobTx(rng *rand.Rand, key *ecdsa.PrivateKey) *types.Transaction {
+	var (
+		nonce   = uint64(rng.Intn(4))
+		fee     = big.NewInt(int64(1 + rng.Intn(10)))
+		dataFee = big.NewInt(int64(1 + rng.Intn(10)))
+		blobs   = 1 + rng.Intn(2)
+	)
+	switch rng.Intn(16) {
+	case 0:
+		return blobTx(nonce, 100000, fee, fee, dataFee, blobs, key).WithoutBlobTxSidecar()
+	case 1:
+		return blobTx(nonce, 100000, fee, fee, dataFee, params.MaxBlobsPerBlock+1, key)
+	case 2:
+		tx := blobTx(nonce, 100000, fee, fee, dataFee, blobs, key)
+		sidecar := *tx.BlobTxSidecar()
+		sidecar.Commitments = append([]kzg.KZGCommitment{{0x01}}, sidecar.Commitments[1:]...)
+		return tx.WithBlobTxSidecar(&sidecar)
+	case 3:
+		return blobTx(nonce, 100000, fee, fee, big.NewInt(0), blobs, key)
+	}
+	return blobTx(nonce, 100000, fee, fee, dataFee, blobs, key)
+}
+
+// validateBlobPoolInternals checks that the pooled blobs are within the account
+// and global allowances, and that exactly the pooled blob transactions have
+// their blobs stored.
+func validateBlobPoolInternals(pool *TxPool) error {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	var (
+		owned = make(map[common.Address]int)
+		total int
+		txs   int
+	)
+	for _, lists := range []map[common.Address]*txList{pool.pending, pool.queue} {
+		for addr, list := range lists {
+			for _, tx := range list.txs.items {
+				if blobs := len(tx.DataHashes()); blobs > 0 {
+					owned[addr] += blobs
+					total += blobs
+					txs++
+					if pool.all.store.get(tx.Hash()) == nil {
+						return fmt.Errorf("blobs of pooled transaction %x not stored", tx.Hash())
+					}
+				}
+			}
+		}
+	}
+	for addr, blobs := range owned {
+		if uint64(blobs) > pool.config.AccountBlobs {
+			return fmt.Errorf("account %x holds %d blobs, allowance %d", addr, blobs, pool.config.AccountBlobs)
+		}
+	}
+	if total != pool.all.Blobs() {
+		return fmt.Errorf("tracked blob count mismatch: have %d, want %d", pool.all.Blobs(), total)
+	}
+	if uint64(total) > pool.config.GlobalBlobs {
+		return fmt.Errorf("pool holds %d blobs, allowance %d", total, pool.config.GlobalBlobs)
+	}
+	pool.all.store.lock.Lock()
+	stored := len(pool.all.store.sizes)
+	pool.all.store.lock.Unlock()
+
+	if stored != txs {
+		return fmt.Errorf("stored blob transaction count mismatch: have %d, want %d", stored, txs)
+	}
+	return nil
+}
+
 // Tests that local transactions are journaled to disk, but remote transactions
 // get discarded between restarts.
 func TestTransactionJournaling(t *testing.T)         { testTransactionJournaling(t, false) }
@@ -0,0 +1,311 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// BlobTx is the data of an EIP-4844 shard blob transaction. Only the versioned
+// hashes of the blobs are part of the transaction, the blobs themselves are
+// carried alongside it on the network.
+type BlobTx struct {
+	ChainID             *big.Int
+	Nonce               uint64
+	GasTipCap           *big.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap           *big.Int // a.k.a. maxFeePerGas
+	Gas                 uint64
+	To                  *common.Address `rlp:"nil"` // nil means contract creation
+	Value               *big.Int
+	Data                []byte
+	AccessList          AccessList
+	MaxFeePerDataGas    *big.Int
+	BlobVersionedHashes []common.Hash
+
+	// Signature values
+	V *big.Int `json:"v" gencodec:"required"`
+	R *big.Int `json:"r" gencodec:"required"`
+	S *big.Int `json:"s" gencodec:"required"`
+}
+
+// copy creates a deep copy of the transaction data and initializes all fields.
+func (tx *BlobTx) copy() TxData {
+	cpy := &BlobTx{
+		Nonce: tx.Nonce,
+		To:    copyAddressPtr(tx.To),
+		Data:  common.CopyBytes(tx.Data),
+		Gas:   tx.Gas,
+		// These are copied below.
+		AccessList:          make(AccessList, len(tx.AccessList)),
+		BlobVersionedHashes: make([]common.Hash, len(tx.BlobVersionedHashes)),
+		Value:               new(big.Int),
+		ChainID:             new(big.Int),
+		GasTipCap:           new(big.Int),
+		GasFeeCap:           new(big.Int),
+		MaxFeePerDataGas:    new(big.Int),
+		V:                   new(big.Int),
+		R:                   new(big.Int),
+		S:                   new(big.Int),
+	}
+	copy(cpy.AccessList, tx.AccessList)
+	copy(cpy.BlobVersionedHashes, tx.BlobVersionedHashes)
+	if tx.Value != nil {
+		cpy.Value.Set(tx.Value)
+	}
+	if tx.ChainID != nil {
+		cpy.ChainID.Set(tx.ChainID)
+	}
+	if tx.GasTipCap != nil {
+		cpy.GasTipCap.Set(tx.GasTipCap)
+	}
+	if tx.GasFeeCap != nil {
+		cpy.GasFeeCap.Set(tx.GasFeeCap)
+	}
+	if tx.MaxFeePerDataGas != nil {
+		cpy.MaxFeePerDataGas.Set(tx.MaxFeePerDataGas)
+	}
+	if tx.V != nil {
+		cpy.V.Set(tx.V)
+	}
+	if tx.R != nil {
+		cpy.R.Set(tx.R)
+	}
+	if tx.S != nil {
+		cpy.S.Set(tx.S)
+	}
+	return cpy
+}
+
+// accessors for innerTx.
+func (tx *BlobTx) txType() byte              { return BlobTxType }
+func (tx *BlobTx) chainID() *big.Int         { return tx.ChainID }
+func (tx *BlobTx) accessList() AccessList    { return tx.AccessList }
+func (tx *BlobTx) data() []byte              { return tx.Data }
+func (tx *BlobTx) gas() uint64               { return tx.Gas }
+func (tx *BlobTx) gasFeeCap() *big.Int       { return tx.GasFeeCap }
+func (tx *BlobTx) gasTipCap() *big.Int       { return tx.GasTipCap }
+func (tx *BlobTx) gasPrice() *big.Int        { return tx.GasFeeCap }
+func (tx *BlobTx) value() *big.Int           { return tx.Value }
+func (tx *BlobTx) nonce() uint64             { return tx.Nonce }
+func (tx *BlobTx) to() *common.Address       { return tx.To }
+func (tx *BlobTx) dataHashes() []common.Hash { return tx.BlobVersionedHashes }
+func (tx *BlobTx) dataGasFeeCap() *big.Int   { return tx.MaxFeePerDataGas }
+
+func (tx *BlobTx) rawSignatureValues() (v, r, s *big.Int) {
+	return tx.V, tx.R, tx.S
+}
+
+func (tx *BlobTx) setSignatureValues(chainID, v, r, s *big.Int) {
+	tx.ChainID, tx.V, tx.R, tx.S = chainID, v, r, s
+}
+
+// BlobTxSidecar contains the blobs of a blob transaction along with their KZG
+// commitments and proofs. It travels with the transaction on the network, but
+// is not part of its canonical encoding, and hence not of its hash.
+type BlobTxSidecar struct {
+	Blobs       []kzg.Blob          // Blobs referenced by the versioned hashes
+	Commitments []kzg.KZGCommitment // Commitments to the blobs
+	Proofs      []kzg.KZGProof      // Proofs that the commitments match the blobs
+}
+
+// NewBlobTxSidecar computes the KZG commitments and proofs of the given blobs
+// and bundles them together into a sidecar.
+func NewBlobTxSidecar(blobs []kzg.Blob) (*BlobTxSidecar, error) {
+	sidecar := &BlobTxSidecar{
+		Blobs:       blobs,
+		Commitments: make([]kzg.KZGCommitment, len(blobs)),
+		Proofs:      make([]kzg.KZGProof, len(blobs)),
+	}
+	for i := range blobs {
+		commitment, err := kzg.BlobToKZGCommitment(&blobs[i])
+		if err != nil {
+			return nil, fmt.Errorf("blob %d: %v", i, err)
+		}
+		proof, err := kzg.ComputeBlobKZGProof(&blobs[i], commitment)
+		if err != nil {
+			return nil, fmt.Errorf("blob %d: %v", i, err)
+		}
+		sidecar.Commitments[i], sidecar.Proofs[i] = commitment, proof
+	}
+	return sidecar, nil
+}
+
+// BlobHashes computes the versioned hashes of the blobs in the sidecar, which
+// a blob transaction carrying them has to reference.
+func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
+	hashes := make([]common.Hash, len(sc.Commitments))
+	for i, commitment := range sc.Commitments {
+		hashes[i] = commitment.ComputeVersionedHash()
+	}
+	return hashes
+}
+
+// Verify checks that the sidecar holds exactly the blobs referenced by the given
+// versioned hashes, and that the commitments and proofs match the blobs. The
+// commitments are never recomputed from the blobs: the proofs of all blobs are
+// checked together in a single batch, which also covers what an aggregated
+// proof over the whole transaction would.
+func (sc *BlobTxSidecar) Verify(hashes []common.Hash) error {
+	if err := sc.VerifyHashes(hashes); err != nil {
+		return err
+	}
+	return kzg.VerifyBlobKZGProofBatch(sc.Blobs, sc.Commitments, sc.Proofs)
+}
+
+// VerifyHashes checks that the sidecar holds exactly the blobs referenced by the
+// given versioned hashes, without verifying the KZG proofs. It allows checking
+// the proofs of many sidecars in a single batch.
+func (sc *BlobTxSidecar) VerifyHashes(hashes []common.Hash) error {
+	if len(sc.Blobs) != len(hashes) || len(sc.Commitments) != len(hashes) || len(sc.Proofs) != len(hashes) {
+		return ErrInvalidBlobTxSidecar
+	}
+	for i, hash := range hashes {
+		if have := sc.Commitments[i].ComputeVersionedHash(); have != hash {
+			return &kzg.BlobError{Index: i, Err: fmt.Errorf("%w: have %x, want %x", ErrBlobHashMismatch, have, hash)}
+		}
+		if err := sc.Blobs[i].Validate(); err != nil {
+			return &kzg.BlobError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// blobTxWithSidecar is the network encoding of a blob transaction. It carries a
+// proof per blob, which replaced the single aggregated proof of earlier drafts
+// of the EIP-4844 networking spec.
+type blobTxWithSidecar struct {
+	BlobTx      *BlobTx
+	Blobs       []kzg.Blob
+	Commitments []kzg.KZGCommitment
+	Proofs      []kzg.KZGProof
+}
+
+// blobTxWithSidecarSize returns the size of the RLP encoding of a blob
+// transaction wrapped together with a sidecar of the given number of blobs,
+// commitments and proofs, given the size of the encoded transaction itself.
+func blobTxWithSidecarSize(txSize uint64, nblobs, ncommitments, nproofs int) uint64 {
+	var (
+		blobs       = uint64(nblobs) * rlp.ListSize(uint64(len(kzg.Blob{})))
+		commitments = uint64(ncommitments) * rlp.ListSize(uint64(len(kzg.KZGCommitment{})))
+		proofs      = uint64(nproofs) * rlp.ListSize(uint64(len(kzg.KZGProof{})))
+	)
+	return rlp.ListSize(txSize + rlp.ListSize(blobs) + rlp.ListSize(commitments) + rlp.ListSize(proofs))
+}
+
+// encodeBlobTxWithSidecar writes the RLP encoding of a blob transaction wrapped
+// together with its sidecar into w, equivalent to encoding a blobTxWithSidecar.
+// Everything but the blobs is encoded into a pooled buffer, the blobs are then
+// streamed into w as they are to avoid copying them around.
+func encodeBlobTxWithSidecar(w io.Writer, tx *BlobTx, sc *BlobTxSidecar) error {
+	buf := encodeBufferPool.Get().(*bytes.Buffer)
+	defer encodeBufferPool.Put(buf)
+	buf.Reset()
+
+	// Encode the transaction, commitments and proofs, remembering where the
+	// blobs need to be spliced in
+	if err := rlp.Encode(buf, tx); err != nil {
+		return err
+	}
+	split := buf.Len()
+	if err := rlp.Encode(buf, sc.Commitments); err != nil {
+		return err
+	}
+	if err := rlp.Encode(buf, sc.Proofs); err != nil {
+		return err
+	}
+	var (
+		blobSize  = uint64(len(kzg.Blob{}))
+		blobsSize = uint64(len(sc.Blobs)) * rlp.ListSize(blobSize)
+		header    = make([]byte, 0, 32)
+	)
+	header = appendRLPHeader(header, 0xC0, 0xF7, uint64(buf.Len())+rlp.ListSize(blobsSize))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(buf.Bytes()[:split]); err != nil {
+		return err
+	}
+	header = appendRLPHeader(header[:0], 0xC0, 0xF7, blobsSize)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	header = appendRLPHeader(header[:0], 0x80, 0xB7, blobSize)
+	for i := range sc.Blobs {
+		if _, err := w.Write(header); err != nil {
+			return err
+		}
+		if _, err := w.Write(sc.Blobs[i][:]); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(buf.Bytes()[split:])
+	return err
+}
+
+// appendRLPHeader appends an RLP string or list header for content of the given
+// size to b, using the given tags for short and long content respectively.
+func appendRLPHeader(b []byte, smalltag, largetag byte, size uint64) []byte {
+	if size < 56 {
+		return append(b, smalltag+byte(size))
+	}
+	var enc [8]byte
+	binary.BigEndian.PutUint64(enc[:], size)
+
+	n := (bits.Len64(size) + 7) / 8
+	b = append(b, largetag+byte(n))
+	return append(b, enc[8-n:]...)
+}
+
+// checkBlobTxWithSidecarSize checks that none of the blob, commitment and proof
+// lists of an encoded blobTxWithSidecar hold more items than fit into a block of
+// any chain. It runs before decoding, so oversized sidecars are rejected without
+// the blobs being allocated. The blob limit of the local chain is enforced by the
+// transaction pool.
+func checkBlobTxWithSidecarSize(enc []byte) error {
+	content, _, err := rlp.SplitList(enc)
+	if err != nil {
+		return err
+	}
+	// Skip over the transaction, checking the sidecar lists following it
+	if _, _, content, err = rlp.Split(content); err != nil {
+		return err
+	}
+	for i := 0; i < 3 && len(content) > 0; i++ {
+		var list []byte
+		if list, content, err = rlp.SplitList(content); err != nil {
+			return err
+		}
+		n, err := rlp.CountValues(list)
+		if err != nil {
+			return err
+		}
+		if n > params.MaxBlobsPerBlockLimit {
+			return fmt.Errorf("%w: have %d, max %d", errTooManyBlobs, n, params.MaxBlobsPerBlockLimit)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// To regenerate blob transaction test vectors, run
+//
+//	go test -run TestBlobTxVectors -write-test-vectors
+var writeTestVectorsFlag = flag.Bool("write-test-vectors", false, "Overwrite blob transaction test vectors in testdata/")
+
+// newVectorBlobTx creates the blob transaction the test vectors are made of. It
+// carries a single empty blob, whose commitment and proof are both the point at
+// infinity regardless of the trusted setup, so the vectors can be reproduced by
+// any client.
+func newVectorBlobTx(t testing.TB) *Transaction {
+	key, _ := defaultTestKey()
+	signer := NewShardingSigner(common.Big1)
+
+	sidecar := &BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}},
+		Commitments: []kzg.KZGCommitment{{0xc0}},
+		Proofs:      []kzg.KZGProof{{0xc0}},
+	}
+	tx, err := SignNewTx(key, signer, &BlobTx{
+		ChainID:             big.NewInt(1),
+		Nonce:               7,
+		To:                  &testAddr,
+		Gas:                 123457,
+		GasTipCap:           big.NewInt(1),
+		GasFeeCap:           big.NewInt(10),
+		Value:               big.NewInt(42),
+		Data:                []byte{0xde, 0xad, 0xbe, 0xef},
+		AccessList:          AccessList{{Address: testAddr, StorageKeys: []common.Hash{{0x01}}}},
+		MaxFeePerDataGas:    big.NewInt(100),
+		BlobVersionedHashes: []common.Hash{sidecar.Commitments[0].ComputeVersionedHash()},
+	})
+	if err != nil {
+		t.Fatalf("could not sign transaction: %v", err)
+	}
+	return tx.WithBlobTxSidecar(sidecar)
+}
+
+// TestBlobTxVectors checks the canonical and network encodings of a blob
+// transaction, as well as the encoding of its commitments, against the test
+// vectors in testdata/ byte-for-byte, and that the vectors decode back into
+// the same transaction.
+func TestBlobTxVectors(t *testing.T) {
+	tx := newVectorBlobTx(t)
+
+	canonical, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode canonical transaction: %v", err)
+	}
+	network, err := tx.MarshalNetwork()
+	if err != nil {
+		t.Fatalf("failed to encode network transaction: %v", err)
+	}
+	commitments, err := rlp.EncodeToBytes(tx.BlobTxSidecar().Commitments)
+	if err != nil {
+		t.Fatalf("failed to encode commitments: %v", err)
+	}
+	tests := []struct {
+		name   string
+		data   []byte
+		decode func([]byte) error
+	}{
+		{
+			name: "blobtx-signed",
+			data: canonical,
+			decode: func(enc []byte) error {
+				dec := new(Transaction)
+				if err := dec.UnmarshalBinary(enc); err != nil {
+					return err
+				}
+				if dec.Hash() != tx.Hash() {
+					return fmt.Errorf("hash mismatch: have %x, want %x", dec.Hash(), tx.Hash())
+				}
+				return nil
+			},
+		},
+		{
+			name: "blobtx-wrapper",
+			data: network,
+			decode: func(enc []byte) error {
+				dec := new(Transaction)
+				if err := dec.UnmarshalNetwork(enc); err != nil {
+					return err
+				}
+				if dec.Hash() != tx.Hash() {
+					return fmt.Errorf("hash mismatch: have %x, want %x", dec.Hash(), tx.Hash())
+				}
+				if dec.BlobTxSidecar() == nil {
+					return fmt.Errorf("missing sidecar")
+				}
+				return nil
+			},
+		},
+		{
+			name: "blobtx-kzgs",
+			data: commitments,
+			decode: func(enc []byte) error {
+				var dec []kzg.KZGCommitment
+				if err := rlp.DecodeBytes(enc, &dec); err != nil {
+					return err
+				}
+				for i, commitment := range dec {
+					if have, want := commitment.ComputeVersionedHash(), tx.DataHashes()[i]; have != want {
+						return fmt.Errorf("versioned hash %d mismatch: have %x, want %x", i, have, want)
+					}
+				}
+				return nil
+			},
+		},
+	}
+	for _, test := range tests {
+		file := filepath.Join("testdata", test.name+".txt")
+		if *writeTestVectorsFlag {
+			writeTestVector(file, blobTxVectorComment(tx), test.data)
+		}
+		enc := hexFile(file)
+		if !bytes.Equal(enc, test.data) {
+			t.Errorf("%s: encoding mismatch:\nhave %x\nwant %x", test.name, test.data, enc)
+			continue
+		}
+		if err := test.decode(enc); err != nil {
+			t.Errorf("%s: failed to decode vector: %v", test.name, err)
+		}
+	}
+}
+
+// Tests that streaming the network encoding of blob transactions produces the
+// same output as RLP encoding the wrapper, for any number of blobs.
+func TestBlobTxStreamingEncoding(t *testing.T) {
+	tx := newVectorBlobTx(t)
+	for blobs := 0; blobs <= params.MaxBlobsPerBlock; blobs++ {
+		sidecar := &BlobTxSidecar{
+			Blobs:       make([]kzg.Blob, blobs),
+			Commitments: make([]kzg.KZGCommitment, blobs),
+			Proofs:      make([]kzg.KZGProof, blobs),
+		}
+		for i := range sidecar.Blobs {
+			sidecar.Blobs[i][0], sidecar.Commitments[i][0], sidecar.Proofs[i][0] = byte(i), byte(i), byte(i)
+		}
+		wrapped := tx.WithBlobTxSidecar(sidecar)
+
+		want, err := rlp.EncodeToBytes(&blobTxWithSidecar{
+			BlobTx:      wrapped.inner.(*BlobTx),
+			Blobs:       sidecar.Blobs,
+			Commitments: sidecar.Commitments,
+			Proofs:      sidecar.Proofs,
+		})
+		if err != nil {
+			t.Fatalf("blobs %d: failed to encode wrapper: %v", blobs, err)
+		}
+		want = append([]byte{BlobTxType}, want...)
+
+		var have bytes.Buffer
+		if err := wrapped.EncodeNetwork(&have); err != nil {
+			t.Fatalf("blobs %d: failed to stream wrapper: %v", blobs, err)
+		}
+		if !bytes.Equal(have.Bytes(), want) {
+			t.Errorf("blobs %d: encoding mismatch:\nhave %x\nwant %x", blobs, have.Bytes(), want)
+		}
+		if size := int(wrapped.NetworkSize()); size != len(want) {
+			t.Errorf("blobs %d: size mismatch: have %d, want %d", blobs, size, len(want))
+		}
+	}
+}
+
+func BenchmarkBlobTxEncodeNetwork(b *testing.B) {
+	tx := newVectorBlobTx(b)
+	sidecar := &BlobTxSidecar{
+		Blobs:       make([]kzg.Blob, params.MaxBlobsPerBlock),
+		Commitments: make([]kzg.KZGCommitment, params.MaxBlobsPerBlock),
+		Proofs:      make([]kzg.KZGProof, params.MaxBlobsPerBlock),
+	}
+	tx = tx.WithBlobTxSidecar(sidecar)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := tx.EncodeNetwork(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// blobTxVectorComment creates the commentary for blob transaction test vector
+// files.
+func blobTxVectorComment(tx *Transaction) string {
+	o := new(strings.Builder)
+	v, r, s := tx.RawSignatureValues()
+
+	fmt.Fprintf(o, "chain-id = %d\n", tx.ChainId())
+	fmt.Fprintf(o, "nonce = %d\n", tx.Nonce())
+	fmt.Fprintf(o, "max-priority-fee-per-gas = %d\n", tx.GasTipCap())
+	fmt.Fprintf(o, "max-fee-per-gas = %d\n", tx.GasFeeCap())
+	fmt.Fprintf(o, "gas = %d\n", tx.Gas())
+	fmt.Fprintf(o, "to = %#x\n", tx.To().Bytes())
+	fmt.Fprintf(o, "value = %d\n", tx.Value())
+	fmt.Fprintf(o, "data = %#x\n", tx.Data())
+	for _, tuple := range tx.AccessList() {
+		fmt.Fprintf(o, "access-list.address = %#x\n", tuple.Address.Bytes())
+		for _, key := range tuple.StorageKeys {
+			fmt.Fprintf(o, "access-list.storage-key = %#x\n", key.Bytes())
+		}
+	}
+	fmt.Fprintf(o, "max-fee-per-data-gas = %d\n", tx.MaxFeePerDataGas())
+	for _, hash := range tx.DataHashes() {
+		fmt.Fprintf(o, "blob-versioned-hash = %#x\n", hash.Bytes())
+	}
+	fmt.Fprintf(o, "v = %d\n", v)
+	fmt.Fprintf(o, "r = %#x\n", r)
+	fmt.Fprintf(o, "s = %#x\n", s)
+	fmt.Fprintf(o, "tx-hash = %#x\n", tx.Hash().Bytes())
+
+	sidecar := tx.BlobTxSidecar()
+	for i := range sidecar.Blobs {
+		if sidecar.Blobs[i] == (kzg.Blob{}) {
+			fmt.Fprintf(o, "blob = empty (%d zero bytes)\n", len(kzg.Blob{}))
+		} else {
+			fmt.Fprintf(o, "blob = %#x\n", sidecar.Blobs[i][:])
+		}
+	}
+	for _, commitment := range sidecar.Commitments {
+		fmt.Fprintf(o, "kzg = %#x\n", commitment[:])
+	}
+	for _, proof := range sidecar.Proofs {
+		fmt.Fprintf(o, "proof = %#x\n", proof[:])
+	}
+	return o.String()
+}
+
+// hexFile reads the given file and decodes the hex data contained in it.
+// Whitespace and any lines beginning with the # character are ignored.
+func hexFile(file string) []byte {
+	fileContent, err := ioutil.ReadFile(file)
+	if err != nil {
+		panic(err)
+	}
+	// Gather hex data, ignore comments.
+	var text []byte
+	for _, line := range bytes.Split(fileContent, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && line[0] == '#' {
+			continue
+		}
+		text = append(text, line...)
+	}
+	// Parse the hex.
+	if bytes.HasPrefix(text, []byte("0x")) {
+		text = text[2:]
+	}
+	data := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(data, text); err != nil {
+		panic("invalid hex in " + file)
+	}
+	return data
+}
+
+// writeTestVector writes a test vector file with the given commentary and binary
+// data.
+func writeTestVector(file, comment string, data []byte) {
+	fd, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer fd.Close()
+
+	if len(comment) > 0 {
+		for _, line := range strings.Split(strings.TrimSpace(comment), "\n") {
+			fmt.Fprintf(fd, "# %s\n", line)
+		}
+		fmt.Fprintln(fd)
+	}
+	for len(data) > 0 {
+		var chunk []byte
+		if len(data) < 32 {
+			chunk = data
+		} else {
+			chunk = data[:32]
+		}
+		data = data[len(chunk):]
+		fmt.Fprintf(fd, "%x\n", chunk)
+	}
+}
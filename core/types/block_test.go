@@ -18,6 +18,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/json"
 	"hash"
 	"math/big"
 	"reflect"
@@ -194,6 +195,58 @@ func TestEIP2718BlockEncoding(t *testing.T) {
 	}
 }
 
+func TestEIP4844HeaderEncoding(t *testing.T) {
+	header := &Header{
+		ParentHash:    common.HexToHash("0x112233445566778899001122334455667788990011223344556677889900aabb"),
+		Difficulty:    big.NewInt(131072),
+		Number:        big.NewInt(1),
+		GasLimit:      3141592,
+		Extra:         []byte("coolest block on chain"),
+		BaseFee:       big.NewInt(1000000000),
+		ExcessDataGas: big.NewInt(131072),
+	}
+	// Check the RLP round trip
+	enc, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		t.Fatal("encode error: ", err)
+	}
+	var dec Header
+	if err := rlp.DecodeBytes(enc, &dec); err != nil {
+		t.Fatal("decode error: ", err)
+	}
+	if dec.ExcessDataGas == nil || dec.ExcessDataGas.Cmp(header.ExcessDataGas) != 0 {
+		t.Errorf("ExcessDataGas mismatch: got %v, want %v", dec.ExcessDataGas, header.ExcessDataGas)
+	}
+	if dec.Hash() != header.Hash() {
+		t.Errorf("hash mismatch: got %x, want %x", dec.Hash(), header.Hash())
+	}
+	// Legacy headers must not change their encoding
+	legacy := CopyHeader(header)
+	legacy.ExcessDataGas = nil
+	legacyEnc, err := rlp.EncodeToBytes(legacy)
+	if err != nil {
+		t.Fatal("encode error: ", err)
+	}
+	if err := rlp.DecodeBytes(legacyEnc, &dec); err != nil {
+		t.Fatal("decode error: ", err)
+	}
+	if dec.ExcessDataGas != nil {
+		t.Errorf("ExcessDataGas mismatch: got %v, want nil", dec.ExcessDataGas)
+	}
+	// Check the JSON round trip
+	blob, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal("json encode error: ", err)
+	}
+	var jsonDec Header
+	if err := json.Unmarshal(blob, &jsonDec); err != nil {
+		t.Fatal("json decode error: ", err)
+	}
+	if jsonDec.Hash() != header.Hash() {
+		t.Errorf("json hash mismatch: got %x, want %x", jsonDec.Hash(), header.Hash())
+	}
+}
+
 func TestUncleHash(t *testing.T) {
 	uncles := make([]*Header, 0)
 	h := CalcUncleHash(uncles)
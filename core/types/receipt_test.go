@@ -18,6 +18,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/json"
 	"math"
 	"math/big"
 	"reflect"
@@ -239,6 +240,16 @@ func TestDeriveFields(t *testing.T) {
 			Gas:      3,
 			GasPrice: big.NewInt(3),
 		}),
+		NewTx(&BlobTx{
+			To:                  &to3,
+			Nonce:               4,
+			Value:               big.NewInt(4),
+			Gas:                 4,
+			GasTipCap:           big.NewInt(4),
+			GasFeeCap:           big.NewInt(4),
+			MaxFeePerDataGas:    big.NewInt(4),
+			BlobVersionedHashes: []common.Hash{{0x01}, {0x02}},
+		}),
 	}
 	// Create the corresponding receipts
 	receipts := Receipts{
@@ -276,17 +287,29 @@ func TestDeriveFields(t *testing.T) {
 			ContractAddress: common.BytesToAddress([]byte{0x03, 0x33, 0x33}),
 			GasUsed:         3,
 		},
+		&Receipt{
+			Type:              BlobTxType,
+			PostState:         common.Hash{4}.Bytes(),
+			CumulativeGasUsed: 10,
+			Logs: []*Log{
+				{Address: common.BytesToAddress([]byte{0x44})},
+			},
+			TxHash:          txs[3].Hash(),
+			ContractAddress: common.BytesToAddress([]byte{0x04, 0x44, 0x44}),
+			GasUsed:         4,
+			DataGasUsed:     2 * params.DataGasPerBlob,
+		},
 	}
 	// Clear all the computed fields and re-derive them
 	number := big.NewInt(1)
 	hash := common.BytesToHash([]byte{0x03, 0x14})
 
 	clearComputedFieldsOnReceipts(t, receipts)
-	if err := receipts.DeriveFields(params.TestChainConfig, hash, number.Uint64(), txs); err != nil {
+	if err := receipts.DeriveFields(params.TestShardingChainConfig, hash, number.Uint64(), 0, txs); err != nil {
 		t.Fatalf("DeriveFields(...) = %v, want <nil>", err)
 	}
 	// Iterate over all the computed fields and check that they're correct
-	signer := MakeSigner(params.TestChainConfig, number)
+	signer := MakeSigner(params.TestShardingChainConfig, number, 0)
 
 	logIndex := uint(0)
 	for i := range receipts {
@@ -308,6 +331,9 @@ func TestDeriveFields(t *testing.T) {
 		if receipts[i].GasUsed != txs[i].Gas() {
 			t.Errorf("receipts[%d].GasUsed = %d, want %d", i, receipts[i].GasUsed, txs[i].Gas())
 		}
+		if receipts[i].DataGasUsed != txs[i].DataGas() {
+			t.Errorf("receipts[%d].DataGasUsed = %d, want %d", i, receipts[i].DataGasUsed, txs[i].DataGas())
+		}
 		if txs[i].To() != nil && receipts[i].ContractAddress != (common.Address{}) {
 			t.Errorf("receipts[%d].ContractAddress = %s, want %s", i, receipts[i].ContractAddress.String(), (common.Address{}).String())
 		}
@@ -337,6 +363,69 @@ func TestDeriveFields(t *testing.T) {
 	}
 }
 
+// Tests that the data gas price of blob transaction receipts survives the storage
+// and JSON encodings, while receipts without it keep their previous encoding.
+func TestReceiptDataGasEncoding(t *testing.T) {
+	receipt := &Receipt{
+		Type:              BlobTxType,
+		Status:            ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		Logs:              []*Log{},
+		TxHash:            common.Hash{0x01},
+		GasUsed:           21000,
+		DataGasUsed:       params.DataGasPerBlob,
+		DataGasPrice:      big.NewInt(7),
+	}
+	enc, err := rlp.EncodeToBytes((*ReceiptForStorage)(receipt))
+	if err != nil {
+		t.Fatalf("failed to encode receipt: %v", err)
+	}
+	var dec ReceiptForStorage
+	if err := rlp.DecodeBytes(enc, &dec); err != nil {
+		t.Fatalf("failed to decode receipt: %v", err)
+	}
+	if dec.DataGasPrice == nil || dec.DataGasPrice.Cmp(receipt.DataGasPrice) != 0 {
+		t.Errorf("data gas price mismatch: have %v, want %v", dec.DataGasPrice, receipt.DataGasPrice)
+	}
+	// Receipts without a data gas price must encode as before
+	legacy := *receipt
+	legacy.DataGasPrice = nil
+	have, err := rlp.EncodeToBytes((*ReceiptForStorage)(&legacy))
+	if err != nil {
+		t.Fatalf("failed to encode receipt: %v", err)
+	}
+	want, err := encodeAsStoredReceiptRLP(&legacy)
+	if err != nil {
+		t.Fatalf("failed to encode receipt: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("storage encoding changed: have %x, want %x", have, want)
+	}
+	// The fields are exposed in JSON
+	blob, err := json.Marshal(receipt)
+	if err != nil {
+		t.Fatalf("failed to marshal receipt: %v", err)
+	}
+	var decJSON Receipt
+	if err := json.Unmarshal(blob, &decJSON); err != nil {
+		t.Fatalf("failed to unmarshal receipt: %v", err)
+	}
+	if decJSON.DataGasUsed != receipt.DataGasUsed {
+		t.Errorf("data gas used mismatch: have %d, want %d", decJSON.DataGasUsed, receipt.DataGasUsed)
+	}
+	if decJSON.DataGasPrice == nil || decJSON.DataGasPrice.Cmp(receipt.DataGasPrice) != 0 {
+		t.Errorf("data gas price mismatch: have %v, want %v", decJSON.DataGasPrice, receipt.DataGasPrice)
+	}
+	// Receipts of other transactions omit them
+	blob, err = json.Marshal(&Receipt{Logs: []*Log{}})
+	if err != nil {
+		t.Fatalf("failed to marshal receipt: %v", err)
+	}
+	if bytes.Contains(blob, []byte("dataGas")) {
+		t.Errorf("data gas fields present in non-blob receipt: %s", blob)
+	}
+}
+
 // TestTypedReceiptEncodingDecoding reproduces a flaw that existed in the receipt
 // rlp decoder, which failed due to a shadowing error.
 func TestTypedReceiptEncodingDecoding(t *testing.T) {
@@ -481,6 +570,7 @@ func clearComputedFieldsOnReceipt(t *testing.T, receipt *Receipt) {
 	receipt.TransactionIndex = math.MaxUint32
 	receipt.ContractAddress = common.Address{}
 	receipt.GasUsed = 0
+	receipt.DataGasUsed = 0
 
 	clearComputedFieldsOnLogs(t, receipt.Logs)
 }
@@ -28,6 +28,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -38,6 +40,9 @@ var (
 	ErrTxTypeNotSupported   = errors.New("transaction type not supported")
 	ErrGasFeeCapTooLow      = errors.New("fee cap less than base fee")
 	errEmptyTypedTx         = errors.New("empty typed transaction bytes")
+	ErrInvalidBlobTxSidecar = errors.New("blob tx sidecar does not match versioned hashes")
+	ErrBlobHashMismatch     = errors.New("versioned hash mismatch")
+	errTooManyBlobs         = errors.New("too many blobs in transaction")
 )
 
 // Transaction types.
@@ -45,12 +50,14 @@ const (
 	LegacyTxType = iota
 	AccessListTxType
 	DynamicFeeTxType
+	BlobTxType = 0x05
 )
 
 // Transaction is an Ethereum transaction.
 type Transaction struct {
-	inner TxData    // Consensus contents of a transaction
-	time  time.Time // Time first seen locally (spam avoidance)
+	inner   TxData         // Consensus contents of a transaction
+	sidecar *BlobTxSidecar // Blobs carried alongside a blob transaction on the network
+	time    time.Time      // Time first seen locally (spam avoidance)
 
 	// caches
 	hash atomic.Value
@@ -67,7 +74,7 @@ func NewTx(inner TxData) *Transaction {
 
 // TxData is the underlying data of a transaction.
 //
-// This is implemented by DynamicFeeTx, LegacyTx and AccessListTx.
+// This is implemented by DynamicFeeTx, LegacyTx, AccessListTx and BlobTx.
 type TxData interface {
 	txType() byte // returns the type ID
 	copy() TxData // creates a deep copy and initializes all fields
@@ -82,6 +89,8 @@ type TxData interface {
 	value() *big.Int
 	nonce() uint64
 	to() *common.Address
+	dataHashes() []common.Hash
+	dataGasFeeCap() *big.Int
 
 	rawSignatureValues() (v, r, s *big.Int)
 	setSignatureValues(chainID, v, r, s *big.Int)
@@ -120,6 +129,114 @@ func (tx *Transaction) MarshalBinary() ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// MarshalMinimal returns the canonical encoding of the transaction, which is
+// the form it is hashed and included in blocks with. Any blob sidecar is
+// omitted.
+func (tx *Transaction) MarshalMinimal() ([]byte, error) {
+	return tx.MarshalBinary()
+}
+
+// MarshalNetwork returns the encoding of the transaction used when relaying it
+// to other nodes. For blob transactions carrying a sidecar, it returns the
+// type and the payload wrapped together with the blobs, commitments and proofs.
+// All other transactions are encoded in their canonical form.
+func (tx *Transaction) MarshalNetwork() ([]byte, error) {
+	if tx.Type() != BlobTxType || tx.sidecar == nil {
+		return tx.MarshalBinary()
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, int(tx.NetworkSize())))
+	err := tx.EncodeNetwork(buf)
+	return buf.Bytes(), err
+}
+
+// EncodeNetwork writes the encoding of the transaction used when relaying it to
+// other nodes into w, as returned by MarshalNetwork. The blobs of blob
+// transactions are streamed into w directly, so encoding a wrapped transaction
+// doesn't allocate memory for its blobs.
+func (tx *Transaction) EncodeNetwork(w io.Writer) error {
+	if tx.Type() == LegacyTxType {
+		return rlp.Encode(w, tx.inner)
+	}
+	if tx.Type() == BlobTxType && tx.sidecar != nil {
+		if _, err := w.Write([]byte{tx.Type()}); err != nil {
+			return err
+		}
+		return encodeBlobTxWithSidecar(w, tx.inner.(*BlobTx), tx.sidecar)
+	}
+	buf := encodeBufferPool.Get().(*bytes.Buffer)
+	defer encodeBufferPool.Put(buf)
+	buf.Reset()
+	if err := tx.encodeTyped(buf); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// NetworkSize returns the size of the network encoding of the transaction, as
+// returned by MarshalNetwork.
+func (tx *Transaction) NetworkSize() common.StorageSize {
+	if tx.Type() != BlobTxType || tx.sidecar == nil {
+		return tx.Size()
+	}
+	sc := tx.sidecar
+	return common.StorageSize(1 + blobTxWithSidecarSize(uint64(tx.Size()), len(sc.Blobs), len(sc.Commitments), len(sc.Proofs))) // type byte
+}
+
+// PooledNetworkSize returns the size of the network encoding of the transaction
+// once wrapped with a sidecar carrying a blob, commitment and proof for each of
+// its versioned hashes, as blob transactions are served from the pool. Unlike
+// NetworkSize it doesn't need the sidecar to be loaded.
+func (tx *Transaction) PooledNetworkSize() common.StorageSize {
+	if tx.Type() != BlobTxType {
+		return tx.Size()
+	}
+	n := len(tx.DataHashes())
+	return common.StorageSize(1 + blobTxWithSidecarSize(uint64(tx.Size()), n, n, n)) // type byte
+}
+
+// UnmarshalNetwork decodes the network encoding of transactions. Next to the
+// canonical encodings it supports blob transactions wrapped together with their
+// sidecar.
+func (tx *Transaction) UnmarshalNetwork(b []byte) error {
+	if len(b) == 0 || b[0] != BlobTxType {
+		return tx.UnmarshalBinary(b)
+	}
+	// Blob transactions are wrapped if their payload starts with a list
+	content, _, err := rlp.SplitList(b[1:])
+	if err != nil {
+		return err
+	}
+	if kind, _, _, err := rlp.Split(content); err != nil || kind != rlp.List {
+		return tx.UnmarshalBinary(b)
+	}
+	if err := checkBlobTxWithSidecarSize(b[1:]); err != nil {
+		return err
+	}
+	var wrapped blobTxWithSidecar
+	if err := rlp.DecodeBytes(b[1:], &wrapped); err != nil {
+		return err
+	}
+	sidecar := &BlobTxSidecar{
+		Blobs:       wrapped.Blobs,
+		Commitments: wrapped.Commitments,
+		Proofs:      wrapped.Proofs,
+	}
+	hashes := wrapped.BlobTx.BlobVersionedHashes
+	if len(sidecar.Blobs) != len(hashes) || len(sidecar.Commitments) != len(hashes) || len(sidecar.Proofs) != len(hashes) {
+		return ErrInvalidBlobTxSidecar
+	}
+	// Reject non-canonical blobs right away, they could never be verified
+	for i := range sidecar.Blobs {
+		if err := sidecar.Blobs[i].Validate(); err != nil {
+			return &kzg.BlobError{Index: i, Err: err}
+		}
+	}
+	tx.setDecoded(wrapped.BlobTx, 0)
+	tx.sidecar = sidecar
+	return nil
+}
+
 // DecodeRLP implements rlp.Decoder
 func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
 	kind, size, err := s.Kind()
@@ -186,6 +303,10 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 		var inner DynamicFeeTx
 		err := rlp.DecodeBytes(b[1:], &inner)
 		return &inner, err
+	case BlobTxType:
+		var inner BlobTx
+		err := rlp.DecodeBytes(b[1:], &inner)
+		return &inner, err
 	default:
 		return nil, ErrTxTypeNotSupported
 	}
@@ -194,6 +315,7 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 // setDecoded sets the inner transaction and size after decoding.
 func (tx *Transaction) setDecoded(inner TxData, size int) {
 	tx.inner = inner
+	tx.sidecar = nil
 	tx.time = time.Now()
 	if size > 0 {
 		tx.size.Store(common.StorageSize(size))
@@ -287,10 +409,33 @@ func (tx *Transaction) To() *common.Address {
 	return copyAddressPtr(tx.inner.to())
 }
 
-// Cost returns gas * gasPrice + value.
+// DataHashes returns the versioned hashes of the blobs referenced by the
+// transaction. It is nil for all but blob transactions.
+func (tx *Transaction) DataHashes() []common.Hash { return tx.inner.dataHashes() }
+
+// MaxFeePerDataGas returns the fee cap per data gas of the transaction. It is
+// nil for all but blob transactions.
+func (tx *Transaction) MaxFeePerDataGas() *big.Int {
+	if feeCap := tx.inner.dataGasFeeCap(); feeCap != nil {
+		return new(big.Int).Set(feeCap)
+	}
+	return nil
+}
+
+// DataGas returns the amount of data gas consumed by the blobs of the
+// transaction. It is zero for all but blob transactions.
+func (tx *Transaction) DataGas() uint64 {
+	return uint64(len(tx.DataHashes())) * params.DataGasPerBlob
+}
+
+// Cost returns gas * gasPrice + value, plus dataGas * maxFeePerDataGas for
+// blob transactions.
 func (tx *Transaction) Cost() *big.Int {
 	total := new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(tx.Gas()))
 	total.Add(total, tx.Value())
+	if feeCap := tx.inner.dataGasFeeCap(); feeCap != nil {
+		total.Add(total, new(big.Int).Mul(feeCap, new(big.Int).SetUint64(tx.DataGas())))
+	}
 	return total
 }
 
@@ -395,7 +540,41 @@ func (tx *Transaction) WithSignature(signer Signer, sig []byte) (*Transaction, e
 	}
 	cpy := tx.inner.copy()
 	cpy.setSignatureValues(signer.ChainID(), v, r, s)
-	return &Transaction{inner: cpy, time: tx.time}, nil
+	return &Transaction{inner: cpy, sidecar: tx.sidecar, time: tx.time}, nil
+}
+
+// BlobTxSidecar returns the blobs, commitments and proofs carried alongside a
+// blob transaction on the network, or nil if there are none.
+func (tx *Transaction) BlobTxSidecar() *BlobTxSidecar {
+	return tx.sidecar
+}
+
+// WithBlobTxSidecar returns a copy of the transaction carrying the given
+// sidecar. The canonical encoding and hash of the transaction are unaffected.
+func (tx *Transaction) WithBlobTxSidecar(sidecar *BlobTxSidecar) *Transaction {
+	return tx.withSidecar(sidecar)
+}
+
+// WithoutBlobTxSidecar returns a copy of the transaction without its sidecar,
+// as it is included in blocks.
+func (tx *Transaction) WithoutBlobTxSidecar() *Transaction {
+	return tx.withSidecar(nil)
+}
+
+// withSidecar returns a copy of the transaction with the given sidecar. As the
+// consensus contents are unchanged, the cached hash, size and sender are kept.
+func (tx *Transaction) withSidecar(sidecar *BlobTxSidecar) *Transaction {
+	cpy := &Transaction{inner: tx.inner.copy(), sidecar: sidecar, time: tx.time}
+	if hash := tx.hash.Load(); hash != nil {
+		cpy.hash.Store(hash)
+	}
+	if size := tx.size.Load(); size != nil {
+		cpy.size.Store(size)
+	}
+	if from := tx.from.Load(); from != nil {
+		cpy.from.Store(from)
+	}
+	return cpy
 }
 
 // Transactions implements DerivableList for transactions.
@@ -573,7 +752,10 @@ type Message struct {
 	gasTipCap  *big.Int
 	data       []byte
 	accessList AccessList
+	dataHashes []common.Hash
 	isFake     bool
+
+	maxFeePerDataGas *big.Int
 }
 
 func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, gasPrice, gasFeeCap, gasTipCap *big.Int, data []byte, accessList AccessList, isFake bool) Message {
@@ -604,7 +786,10 @@ func (tx *Transaction) AsMessage(s Signer, baseFee *big.Int) (Message, error) {
 		amount:     tx.Value(),
 		data:       tx.Data(),
 		accessList: tx.AccessList(),
+		dataHashes: tx.DataHashes(),
 		isFake:     false,
+
+		maxFeePerDataGas: tx.MaxFeePerDataGas(),
 	}
 	// If baseFee provided, set gasPrice to effectiveGasPrice.
 	if baseFee != nil {
@@ -615,17 +800,29 @@ func (tx *Transaction) AsMessage(s Signer, baseFee *big.Int) (Message, error) {
 	return msg, err
 }
 
-func (m Message) From() common.Address   { return m.from }
-func (m Message) To() *common.Address    { return m.to }
-func (m Message) GasPrice() *big.Int     { return m.gasPrice }
-func (m Message) GasFeeCap() *big.Int    { return m.gasFeeCap }
-func (m Message) GasTipCap() *big.Int    { return m.gasTipCap }
-func (m Message) Value() *big.Int        { return m.amount }
-func (m Message) Gas() uint64            { return m.gasLimit }
-func (m Message) Nonce() uint64          { return m.nonce }
-func (m Message) Data() []byte           { return m.data }
-func (m Message) AccessList() AccessList { return m.accessList }
-func (m Message) IsFake() bool           { return m.isFake }
+func (m Message) From() common.Address      { return m.from }
+func (m Message) To() *common.Address       { return m.to }
+func (m Message) GasPrice() *big.Int        { return m.gasPrice }
+func (m Message) GasFeeCap() *big.Int       { return m.gasFeeCap }
+func (m Message) GasTipCap() *big.Int       { return m.gasTipCap }
+func (m Message) Value() *big.Int           { return m.amount }
+func (m Message) Gas() uint64               { return m.gasLimit }
+func (m Message) Nonce() uint64             { return m.nonce }
+func (m Message) Data() []byte              { return m.data }
+func (m Message) AccessList() AccessList    { return m.accessList }
+func (m Message) DataHashes() []common.Hash { return m.dataHashes }
+func (m Message) IsFake() bool              { return m.isFake }
+
+// MaxFeePerDataGas returns the data gas fee cap of blob transaction messages.
+func (m Message) MaxFeePerDataGas() *big.Int { return m.maxFeePerDataGas }
+
+// WithDataHashes returns a copy of the message referencing the given blobs, and
+// willing to pay at most maxFeePerDataGas for their data gas.
+func (m Message) WithDataHashes(hashes []common.Hash, maxFeePerDataGas *big.Int) Message {
+	m.dataHashes = hashes
+	m.maxFeePerDataGas = maxFeePerDataGas
+	return m
+}
 
 // copyAddressPtr copies an address.
 func copyAddressPtr(a *common.Address) *common.Address {
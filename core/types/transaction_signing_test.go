@@ -22,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -136,3 +137,18 @@ func TestChainId(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+// Tests that the sharding signer is only picked once a time scheduled sharding
+// fork is active.
+func TestMakeSignerShardingTime(t *testing.T) {
+	config := *params.TestChainConfig
+	forkTime := uint64(1000)
+	config.ShardingForkTime = &forkTime
+
+	if signer := MakeSigner(&config, big.NewInt(1), forkTime-1); signer.Equal(NewShardingSigner(config.ChainID)) {
+		t.Errorf("sharding signer picked before the fork")
+	}
+	if signer := MakeSigner(&config, big.NewInt(1), forkTime); !signer.Equal(NewShardingSigner(config.ChainID)) {
+		t.Errorf("sharding signer not picked after the fork")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -29,6 +30,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -488,6 +491,223 @@ func TestTransactionCoding(t *testing.T) {
 	}
 }
 
+// TestBlobTxCoding tests serializing/de-serializing blob transactions to/from
+// the canonical encoding and recovering their sender.
+func TestBlobTxCoding(t *testing.T) {
+	key, addr := defaultTestKey()
+	signer := NewShardingSigner(common.Big1)
+
+	tx, err := SignNewTx(key, signer, &BlobTx{
+		ChainID:             big.NewInt(1),
+		Nonce:               7,
+		To:                  &testAddr,
+		Gas:                 123457,
+		GasTipCap:           big.NewInt(1),
+		GasFeeCap:           big.NewInt(10),
+		MaxFeePerDataGas:    big.NewInt(100),
+		AccessList:          AccessList{{Address: testAddr, StorageKeys: []common.Hash{{0}}}},
+		BlobVersionedHashes: []common.Hash{{0x01, 0xaa}, {0x01, 0xbb}},
+		Data:                []byte("abcdef"),
+	})
+	if err != nil {
+		t.Fatalf("could not sign transaction: %v", err)
+	}
+	parsedTx, err := encodeDecodeBinary(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := assertEqual(parsedTx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if parsedTx.Type() != BlobTxType {
+		t.Fatalf("wrong tx type: have %d, want %d", parsedTx.Type(), BlobTxType)
+	}
+	if !reflect.DeepEqual(parsedTx.DataHashes(), tx.DataHashes()) {
+		t.Fatalf("versioned hashes mismatch: have %v, want %v", parsedTx.DataHashes(), tx.DataHashes())
+	}
+	if parsedTx.MaxFeePerDataGas().Cmp(tx.MaxFeePerDataGas()) != 0 {
+		t.Fatalf("data gas fee cap mismatch: have %v, want %v", parsedTx.MaxFeePerDataGas(), tx.MaxFeePerDataGas())
+	}
+	from, err := Sender(signer, parsedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Fatalf("sender mismatch: have %x, want %x", from, addr)
+	}
+	jsonTx, err := encodeDecodeJSON(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := assertEqual(jsonTx, tx); err != nil {
+		t.Fatal(err)
+	}
+	// Signers predating the sharding fork must reject blob transactions
+	if _, err := Sender(NewLondonSigner(common.Big1), parsedTx); err != ErrTxTypeNotSupported {
+		t.Fatalf("london signer: have %v, want %v", err, ErrTxTypeNotSupported)
+	}
+}
+
+func TestBlobTxNetworkCoding(t *testing.T) {
+	key, _ := defaultTestKey()
+	signer := NewShardingSigner(common.Big1)
+
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0 // point at infinity, committing to the empty blob
+	sidecar := &BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}},
+		Commitments: []kzg.KZGCommitment{commitment},
+		Proofs:      []kzg.KZGProof{{0xc0}},
+	}
+	tx, err := SignNewTx(key, signer, &BlobTx{
+		ChainID:             big.NewInt(1),
+		Nonce:               7,
+		To:                  &testAddr,
+		Gas:                 123457,
+		GasTipCap:           big.NewInt(1),
+		GasFeeCap:           big.NewInt(10),
+		MaxFeePerDataGas:    big.NewInt(100),
+		BlobVersionedHashes: []common.Hash{commitment.ComputeVersionedHash()},
+	})
+	if err != nil {
+		t.Fatalf("could not sign transaction: %v", err)
+	}
+	wrapped := tx.WithBlobTxSidecar(sidecar)
+	if wrapped.Hash() != tx.Hash() {
+		t.Fatalf("sidecar changed the hash: have %x, want %x", wrapped.Hash(), tx.Hash())
+	}
+	// The minimal encoding must match the canonical one and exclude the blobs
+	minimal, err := wrapped.MarshalMinimal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(minimal, canonical) {
+		t.Fatalf("minimal encoding mismatch:\nhave %x\nwant %x", minimal, canonical)
+	}
+	network, err := wrapped.MarshalNetwork()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(network) < len(minimal)+len(kzg.Blob{}) {
+		t.Fatalf("network encoding too short to contain the blobs: %d bytes", len(network))
+	}
+	if size := wrapped.NetworkSize(); int(size) != len(network) {
+		t.Fatalf("network size mismatch: have %d, want %d", int(size), len(network))
+	}
+	// Decoding the network form must restore the sidecar, but not alter the hash
+	var parsed Transaction
+	if err := parsed.UnmarshalNetwork(network); err != nil {
+		t.Fatalf("failed to decode network encoding: %v", err)
+	}
+	if parsed.Hash() != tx.Hash() {
+		t.Fatalf("hash mismatch: have %x, want %x", parsed.Hash(), tx.Hash())
+	}
+	if !reflect.DeepEqual(parsed.BlobTxSidecar(), sidecar) {
+		t.Fatal("sidecar mismatch after network round trip")
+	}
+	// Decoding the minimal form over the network must not produce a sidecar
+	var parsedMinimal Transaction
+	if err := parsedMinimal.UnmarshalNetwork(minimal); err != nil {
+		t.Fatalf("failed to decode minimal encoding: %v", err)
+	}
+	if parsedMinimal.Hash() != tx.Hash() {
+		t.Fatalf("hash mismatch: have %x, want %x", parsedMinimal.Hash(), tx.Hash())
+	}
+	if parsedMinimal.BlobTxSidecar() != nil {
+		t.Fatal("sidecar present after decoding minimal encoding")
+	}
+	// Stripping the sidecar must fall back to the canonical encoding
+	stripped, err := wrapped.WithoutBlobTxSidecar().MarshalNetwork()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stripped, canonical) {
+		t.Fatalf("stripped encoding mismatch:\nhave %x\nwant %x", stripped, canonical)
+	}
+	// The sidecar must verify against the versioned hashes of the transaction
+	if err := sidecar.Verify(tx.DataHashes()); err != nil {
+		t.Fatalf("failed to verify sidecar: %v", err)
+	}
+	if err := sidecar.Verify([]common.Hash{{0x01}}); err == nil {
+		t.Fatal("sidecar verified against wrong versioned hash")
+	}
+	tampered := &BlobTxSidecar{
+		Blobs:       []kzg.Blob{{31: 0x01}},
+		Commitments: sidecar.Commitments,
+		Proofs:      sidecar.Proofs,
+	}
+	if err := tampered.Verify(tx.DataHashes()); err == nil {
+		t.Fatal("sidecar verified with blob not matching the commitment")
+	}
+	// Sidecars not matching the versioned hashes must be rejected
+	bad, err := tx.WithBlobTxSidecar(&BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}, {}},
+		Commitments: []kzg.KZGCommitment{commitment, commitment},
+		Proofs:      []kzg.KZGProof{{0xc0}, {0xc0}},
+	}).MarshalNetwork()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := new(Transaction).UnmarshalNetwork(bad); err != ErrInvalidBlobTxSidecar {
+		t.Fatalf("mismatched sidecar: have %v, want %v", err, ErrInvalidBlobTxSidecar)
+	}
+	// Sidecars with non-canonical blobs must be rejected before verification
+	noncanonical := &BlobTxSidecar{
+		Blobs:       []kzg.Blob{{0: 0xff}},
+		Commitments: sidecar.Commitments,
+		Proofs:      sidecar.Proofs,
+	}
+	invalid, err := tx.WithBlobTxSidecar(noncanonical).MarshalNetwork()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := new(Transaction).UnmarshalNetwork(invalid); !errors.Is(err, kzg.ErrInvalidFieldElement) {
+		t.Fatalf("non-canonical blob: have %v, want %v", err, kzg.ErrInvalidFieldElement)
+	}
+	if err := noncanonical.Verify(tx.DataHashes()); err == nil {
+		t.Fatal("sidecar verified with non-canonical blob")
+	}
+	// Sidecars with more blobs than fit into a block of any chain must be rejected
+	oversized := new(BlobTxSidecar)
+	for i := 0; i <= params.MaxBlobsPerBlockLimit; i++ {
+		oversized.Blobs = append(oversized.Blobs, kzg.Blob{})
+		oversized.Commitments = append(oversized.Commitments, commitment)
+		oversized.Proofs = append(oversized.Proofs, kzg.KZGProof{0xc0})
+	}
+	huge, err := tx.WithBlobTxSidecar(oversized).MarshalNetwork()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := new(Transaction).UnmarshalNetwork(huge); !errors.Is(err, errTooManyBlobs) {
+		t.Fatalf("oversized sidecar: have %v, want %v", err, errTooManyBlobs)
+	}
+}
+
+func TestNewBlobTxSidecar(t *testing.T) {
+	blobs := []kzg.Blob{{}, {31: 0x01}}
+	sidecar, err := NewBlobTxSidecar(blobs)
+	if err != nil {
+		t.Fatalf("failed to create sidecar: %v", err)
+	}
+	// The empty blob commits to the point at infinity
+	var infinity kzg.KZGCommitment
+	infinity[0] = 0xc0
+	if sidecar.Commitments[0] != infinity {
+		t.Fatalf("empty blob commitment mismatch: have %x, want %x", sidecar.Commitments[0], infinity)
+	}
+	hashes := sidecar.BlobHashes()
+	if len(hashes) != len(blobs) || hashes[0] != infinity.ComputeVersionedHash() {
+		t.Fatalf("versioned hashes mismatch: have %x", hashes)
+	}
+	if err := sidecar.Verify(hashes); err != nil {
+		t.Fatalf("failed to verify sidecar: %v", err)
+	}
+}
+
 func encodeDecodeJSON(tx *Transaction) (*Transaction, error) {
 	data, err := json.Marshal(tx)
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -28,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/blake2b"
 	"github.com/ethereum/go-ethereum/crypto/bls12381"
 	"github.com/ethereum/go-ethereum/crypto/bn256"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/params"
 
 	//lint:ignore SA1019 Needed for precompile
@@ -106,7 +108,23 @@ var PrecompiledContractsBLS = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{18}): &bls12381MapG2{},
 }
 
+// PrecompiledContractsSharding contains the set of pre-compiled Ethereum
+// contracts used in the sharding (EIP-4844) release.
+var PrecompiledContractsSharding = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}):    &ecrecover{},
+	common.BytesToAddress([]byte{2}):    &sha256hash{},
+	common.BytesToAddress([]byte{3}):    &ripemd160hash{},
+	common.BytesToAddress([]byte{4}):    &dataCopy{},
+	common.BytesToAddress([]byte{5}):    &bigModExp{eip2565: true},
+	common.BytesToAddress([]byte{6}):    &bn256AddIstanbul{},
+	common.BytesToAddress([]byte{7}):    &bn256ScalarMulIstanbul{},
+	common.BytesToAddress([]byte{8}):    &bn256PairingIstanbul{},
+	common.BytesToAddress([]byte{9}):    &blake2F{},
+	common.BytesToAddress([]byte{0x14}): &pointEvaluation{},
+}
+
 var (
+	PrecompiledAddressesSharding  []common.Address
 	PrecompiledAddressesBerlin    []common.Address
 	PrecompiledAddressesIstanbul  []common.Address
 	PrecompiledAddressesByzantium []common.Address
@@ -126,11 +144,16 @@ func init() {
 	for k := range PrecompiledContractsBerlin {
 		PrecompiledAddressesBerlin = append(PrecompiledAddressesBerlin, k)
 	}
+	for k := range PrecompiledContractsSharding {
+		PrecompiledAddressesSharding = append(PrecompiledAddressesSharding, k)
+	}
 }
 
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules params.Rules) []common.Address {
 	switch {
+	case rules.IsSharding:
+		return PrecompiledAddressesSharding
 	case rules.IsBerlin:
 		return PrecompiledAddressesBerlin
 	case rules.IsIstanbul:
@@ -1043,3 +1066,78 @@ func (c *bls12381MapG2) Run(input []byte) ([]byte, error) {
 	// Encode the G2 point to 256 bytes
 	return g.EncodePoint(r), nil
 }
+
+// PointEvaluationAddress is the address of the EIP-4844 point evaluation
+// precompile.
+var PointEvaluationAddress = common.BytesToAddress([]byte{0x14})
+
+// The point evaluation precompile fails with one of the errors below (or one of
+// the kzg commitment, proof and pairing errors) so that tracers can tell why a
+// verification failed. The failure itself stays a bare one towards contracts:
+// like every other precompile it consumes all supplied gas and returns no data.
+var (
+	errPointEvaluationInputLength         = errors.New("invalid input length")
+	errPointEvaluationMismatchVersionHash = errors.New("mismatched versioned hash")
+	errPointEvaluationInvalidPoint        = errors.New("invalid evaluation point")
+	errPointEvaluationInvalidValue        = errors.New("invalid claimed value")
+)
+
+// pointEvaluation implements the EIP-4844 point evaluation precompile.
+type pointEvaluation struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *pointEvaluation) RequiredGas(input []byte) uint64 {
+	return params.PointEvaluationGas
+}
+
+func (c *pointEvaluation) Run(input []byte) ([]byte, error) {
+	in, err := ParsePointEvaluationInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if in.Commitment.ComputeVersionedHash() != in.VersionedHash {
+		return nil, errPointEvaluationMismatchVersionHash
+	}
+	if _, err := kzg.ReadFieldElement(in.Z); err != nil {
+		return nil, fmt.Errorf("%v: %w", errPointEvaluationInvalidPoint, err)
+	}
+	if _, err := kzg.ReadFieldElement(in.Y); err != nil {
+		return nil, fmt.Errorf("%v: %w", errPointEvaluationInvalidValue, err)
+	}
+	if err := kzg.VerifyKZGProof(in.Commitment, in.Z, in.Y, in.Proof); err != nil {
+		return nil, err
+	}
+	return []byte{}, nil
+}
+
+// PointEvaluationInput is the decoded input of the point evaluation precompile.
+type PointEvaluationInput struct {
+	VersionedHash common.Hash       `json:"versionedHash"` // Versioned hash of the commitment
+	Z             common.Hash       `json:"z"`             // Evaluation point, big endian field element
+	Y             common.Hash       `json:"y"`             // Claimed value, big endian field element
+	Commitment    kzg.KZGCommitment `json:"commitment"`    // Compressed G1 commitment
+	Proof         kzg.KZGProof      `json:"proof"`         // Compressed G1 proof
+}
+
+// ParsePointEvaluationInput splits the input of the point evaluation precompile
+// into its fields, without verifying them.
+//
+// Implements EIP-4844 point evaluation precompile input parsing.
+// > The call expects `192` bytes as an input that is interpreted as byte concatenation of:
+// > - `32` bytes versioned hash of the commitment
+// > - `32` bytes evaluation point `z` and `32` bytes claimed value `y`, both big endian field elements
+// > - `48` bytes compressed G1 commitment and `48` bytes compressed G1 proof
+// > Output is empty; the call fails if the proof does not verify.
+func ParsePointEvaluationInput(input []byte) (*PointEvaluationInput, error) {
+	if len(input) != 192 {
+		return nil, errPointEvaluationInputLength
+	}
+	in := &PointEvaluationInput{
+		VersionedHash: common.BytesToHash(input[:32]),
+		Z:             common.BytesToHash(input[32:64]),
+		Y:             common.BytesToHash(input[64:96]),
+	}
+	copy(in.Commitment[:], input[96:144])
+	copy(in.Proof[:], input[144:192])
+	return in, nil
+}
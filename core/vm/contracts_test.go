@@ -19,12 +19,14 @@ package vm
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 )
 
 // precompiledTest defines the input/output pairs for precompiled contract tests.
@@ -65,6 +67,7 @@ var allPrecompiles = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{16}):   &bls12381Pairing{},
 	common.BytesToAddress([]byte{17}):   &bls12381MapG1{},
 	common.BytesToAddress([]byte{18}):   &bls12381MapG2{},
+	common.BytesToAddress([]byte{0x14}): &pointEvaluation{},
 }
 
 // EIP-152 test vectors
@@ -333,6 +336,38 @@ func TestPrecompiledBLS12381PairingFail(t *testing.T)    { testJsonFail("blsPair
 func TestPrecompiledBLS12381MapG1Fail(t *testing.T)      { testJsonFail("blsMapG1", "11", t) }
 func TestPrecompiledBLS12381MapG2Fail(t *testing.T)      { testJsonFail("blsMapG2", "12", t) }
 
+func TestPrecompiledPointEvaluation(t *testing.T)      { testJson("pointEvaluation", "14", t) }
+func TestPrecompiledPointEvaluationFail(t *testing.T)  { testJsonFail("pointEvaluation", "14", t) }
+func BenchmarkPrecompiledPointEvaluation(b *testing.B) { benchJson("pointEvaluation", "14", b) }
+
+// Tests that the failures of the point evaluation precompile can be told apart
+// by their cause, not just by their message.
+func TestPrecompiledPointEvaluationFailureCauses(t *testing.T) {
+	tests, err := loadJsonFail("pointEvaluation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	causes := map[string]error{
+		"pointevaluation_empty_input":        errPointEvaluationInputLength,
+		"pointevaluation_wrong_version":      errPointEvaluationMismatchVersionHash,
+		"pointevaluation_z_out_of_range":     kzg.ErrInvalidFieldElement,
+		"pointevaluation_y_out_of_range":     kzg.ErrInvalidFieldElement,
+		"pointevaluation_wrong_evaluation":   kzg.ErrProofMismatch,
+		"pointevaluation_invalid_commitment": kzg.ErrInvalidCommitment,
+		"pointevaluation_invalid_proof":      kzg.ErrInvalidProof,
+	}
+	for _, test := range tests {
+		cause, ok := causes[test.Name]
+		if !ok {
+			continue
+		}
+		_, err := new(pointEvaluation).Run(common.Hex2Bytes(test.Input))
+		if !errors.Is(err, cause) {
+			t.Errorf("%s: error mismatch: have %v, want %v", test.Name, err, cause)
+		}
+	}
+}
+
 func loadJson(name string) ([]precompiledTest, error) {
 	data, err := ioutil.ReadFile(fmt.Sprintf("testdata/precompiles/%v.json", name))
 	if err != nil {
@@ -25,6 +25,7 @@ import (
 )
 
 var activators = map[int]func(*JumpTable){
+	4844: enable4844,
 	3529: enable3529,
 	3198: enable3198,
 	2929: enable2929,
@@ -174,3 +175,27 @@ func opBaseFee(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]
 	scope.Stack.push(baseFee)
 	return nil, nil
 }
+
+// enable4844 applies EIP-4844 (DATAHASH opcode)
+// - Adds an opcode that returns the versioned blob hash of the tx at the given index.
+func enable4844(jt *JumpTable) {
+	// New opcode
+	jt[DATAHASH] = &operation{
+		execute:     opDataHash,
+		constantGas: GasFastestStep,
+		minStack:    minStack(1, 1),
+		maxStack:    maxStack(1, 1),
+	}
+}
+
+// opDataHash implements DATAHASH opcode
+func opDataHash(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	idx := scope.Stack.peek()
+	if idx.LtUint64(uint64(len(interpreter.evm.TxContext.DataHashes))) {
+		hash := interpreter.evm.TxContext.DataHashes[idx.Uint64()]
+		idx.SetBytes(hash.Bytes())
+	} else {
+		idx.Clear()
+	}
+	return nil, nil
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
@@ -44,6 +45,8 @@ type (
 func (evm *EVM) precompile(addr common.Address) (PrecompiledContract, bool) {
 	var precompiles map[common.Address]PrecompiledContract
 	switch {
+	case evm.chainRules.IsSharding:
+		precompiles = PrecompiledContractsSharding
 	case evm.chainRules.IsBerlin:
 		precompiles = PrecompiledContractsBerlin
 	case evm.chainRules.IsIstanbul:
@@ -76,14 +79,18 @@ type BlockContext struct {
 	Difficulty  *big.Int       // Provides information for DIFFICULTY
 	BaseFee     *big.Int       // Provides information for BASEFEE
 	Random      *common.Hash   // Provides information for RANDOM
+
+	DataGasPrice *big.Int // Price of data gas in the block, nil without data gas accounting
 }
 
 // TxContext provides the EVM with information about a transaction.
 // All fields can change between transactions.
 type TxContext struct {
 	// Message information
-	Origin   common.Address // Provides information for ORIGIN
-	GasPrice *big.Int       // Provides information for GASPRICE
+	Origin     common.Address   // Provides information for ORIGIN
+	GasPrice   *big.Int         // Provides information for GASPRICE
+	DataHashes []common.Hash    // Provides information for DATAHASH
+	AccessList types.AccessList // Accounts and storage slots warmed up ahead of execution
 }
 
 // EVM is the Ethereum Virtual Machine base object and provides
@@ -132,8 +139,12 @@ func NewEVM(blockCtx BlockContext, txCtx TxContext, statedb StateDB, chainConfig
 		StateDB:     statedb,
 		Config:      config,
 		chainConfig: chainConfig,
-		chainRules:  chainConfig.Rules(blockCtx.BlockNumber, blockCtx.Random != nil),
 	}
+	var time uint64
+	if blockCtx.Time != nil {
+		time = blockCtx.Time.Uint64()
+	}
+	evm.chainRules = chainConfig.Rules(blockCtx.BlockNumber, blockCtx.Random != nil, time)
 	evm.interpreter = NewEVMInterpreter(evm, config)
 	return evm
 }
@@ -513,3 +524,6 @@ func (evm *EVM) Create2(caller ContractRef, code []byte, gas uint64, endowment *
 
 // ChainConfig returns the environment's chain configuration
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
+
+// ChainRules returns the rules of the chain configuration active in the environment.
+func (evm *EVM) ChainRules() params.Rules { return evm.chainRules }
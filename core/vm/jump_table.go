@@ -55,6 +55,7 @@ var (
 	berlinInstructionSet           = newBerlinInstructionSet()
 	londonInstructionSet           = newLondonInstructionSet()
 	mergeInstructionSet            = newMergeInstructionSet()
+	shardingInstructionSet         = newShardingInstructionSet()
 )
 
 // JumpTable contains the EVM opcodes supported at a given fork.
@@ -78,6 +79,14 @@ func validate(jt JumpTable) JumpTable {
 	return jt
 }
 
+// newShardingInstructionSet returns the frontier, homestead, byzantium,
+// contantinople, istanbul, petersburg, berlin, london, merge and sharding instructions.
+func newShardingInstructionSet() JumpTable {
+	instructionSet := newMergeInstructionSet()
+	enable4844(&instructionSet) // Data hash opcode https://eips.ethereum.org/EIPS/eip-4844
+	return validate(instructionSet)
+}
+
 func newMergeInstructionSet() JumpTable {
 	instructionSet := newLondonInstructionSet()
 	instructionSet[RANDOM] = &operation{
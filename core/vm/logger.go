@@ -36,3 +36,13 @@ type EVMLogger interface {
 	CaptureFault(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, depth int, err error)
 	CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error)
 }
+
+// BlobTxLogger is an optional extension of EVMLogger for tracers accounting for
+// the blob data of transactions. CaptureBlobTxStart is called before a message
+// referencing blobs is executed, with the versioned hashes of its blobs, their
+// data gas and the price it was bought at, which is nil if no data gas was paid
+// for (e.g. in eth_call). CaptureBlobTxEnd is called once the execution ended.
+type BlobTxLogger interface {
+	CaptureBlobTxStart(hashes []common.Hash, dataGas uint64, dataGasPrice *big.Int)
+	CaptureBlobTxEnd(err error)
+}
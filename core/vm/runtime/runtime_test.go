@@ -239,6 +239,11 @@ func (d *dummyChain) Engine() consensus.Engine {
 	return nil
 }
 
+// Config retrieves the chain's fork configuration.
+func (d *dummyChain) Config() *params.ChainConfig {
+	return nil
+}
+
 // GetHeader returns the hash corresponding to their hash.
 func (d *dummyChain) GetHeader(h common.Hash, n uint64) *types.Header {
 	d.counter++
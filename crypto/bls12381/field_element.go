@@ -176,6 +176,15 @@ func (e *fe) sign() bool {
 	return r[0]&1 == 0
 }
 
+// signBE reports whether the element is not larger than its negation,
+// comparing the big-endian (non-Montgomery) representations.
+func (e *fe) signBE() bool {
+	negZ, z := new(fe), new(fe)
+	fromMont(z, e)
+	neg(negZ, z)
+	return negZ.cmp(z) > -1
+}
+
 func (fe *fe) div2(e uint64) {
 	fe[0] = fe[0]>>1 | fe[1]<<63
 	fe[1] = fe[1]>>1 | fe[2]<<63
@@ -238,6 +247,15 @@ func (e *fe2) equal(e2 *fe2) bool {
 	return e[0].equal(&e2[0]) && e[1].equal(&e2[1])
 }
 
+// signBE reports whether the element is not larger than its negation, comparing
+// the imaginary parts first and falling back to the real parts if those are zero.
+func (e *fe2) signBE() bool {
+	if !e[1].isZero() {
+		return e[1].signBE()
+	}
+	return e[0].signBE()
+}
+
 func (e *fe2) sign() bool {
 	r := new(fe)
 	if !e[0].isZero() {
@@ -158,6 +158,71 @@ func (g *G1) EncodePoint(p *PointG1) []byte {
 	return out
 }
 
+// FromCompressed constructs a new point given 48 bytes of compressed input.
+// Serialization rules are in line with the zcash library: the most significant
+// bit flags compression, the next one flags the point at infinity and the third
+// one is set if y is the lexicographically largest of the two candidates.
+// FromCompressed also checks that the point is in the correct subgroup.
+func (g *G1) FromCompressed(compressed []byte) (*PointG1, error) {
+	if len(compressed) != 48 {
+		return nil, errors.New("input string should be equal 48 bytes")
+	}
+	var in [48]byte
+	copy(in[:], compressed)
+	if in[0]&(1<<7) == 0 {
+		return nil, errors.New("compression flag should be set")
+	}
+	if in[0]&(1<<6) != 0 {
+		// in[0] == (1 << 6) + (1 << 7)
+		for i, v := range in {
+			if (i == 0 && v != 0xc0) || (i != 0 && v != 0x00) {
+				return nil, errors.New("input string should be zero when infinity flag is set")
+			}
+		}
+		return g.Zero(), nil
+	}
+	a := in[0]&(1<<5) != 0
+	in[0] &= 0x1f
+	x, err := fromBytes(in[:])
+	if err != nil {
+		return nil, err
+	}
+	// solve curve equation
+	y := &fe{}
+	square(y, x)
+	mul(y, y, x)
+	add(y, y, b)
+	if ok := sqrt(y, y); !ok {
+		return nil, errors.New("point is not on curve")
+	}
+	if y.signBE() == a {
+		neg(y, y)
+	}
+	z := new(fe).one()
+	p := &PointG1{*x, *y, *z}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errors.New("point is not on correct subgroup")
+	}
+	return p, nil
+}
+
+// ToCompressed serializes a point into 48 bytes of compressed form
+// following the zcash flag conventions.
+func (g *G1) ToCompressed(p *PointG1) []byte {
+	out := make([]byte, 48)
+	g.Affine(p)
+	if g.IsZero(p) {
+		out[0] |= 1 << 6
+	} else {
+		copy(out[:], toBytes(&p[0]))
+		if !p[1].signBE() {
+			out[0] |= 1 << 5
+		}
+	}
+	out[0] |= 1 << 7
+	return out
+}
+
 // New creates a new G1 Point which is equal to zero in other words point at infinity.
 func (g *G1) New() *PointG1 {
 	return g.Zero()
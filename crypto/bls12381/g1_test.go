@@ -51,6 +51,48 @@ func TestG1Serialization(t *testing.T) {
 			t.Fatal("bad serialization encode/decode")
 		}
 	}
+	for i := 0; i < fuz; i++ {
+		a := g1.rand()
+		compressed := g1.ToCompressed(a)
+		b, err := g1.FromCompressed(compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g1.Equal(a, b) {
+			t.Fatal("bad serialization compress/decompress")
+		}
+	}
+}
+
+func TestG1CompressedKnownValues(t *testing.T) {
+	g := NewG1()
+	one := common.FromHex("97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	if !bytes.Equal(g.ToCompressed(g.one()), one) {
+		t.Fatal("bad compression of generator")
+	}
+	p, err := g.FromCompressed(one)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Equal(p, g.one()) {
+		t.Fatal("bad decompression of generator")
+	}
+	infinity := make([]byte, 48)
+	infinity[0] = 0xc0
+	if !bytes.Equal(g.ToCompressed(g.Zero()), infinity) {
+		t.Fatal("bad compression of point at infinity")
+	}
+	p, err = g.FromCompressed(infinity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.IsZero(p) {
+		t.Fatal("bad decompression of point at infinity")
+	}
+	// Missing compression flag
+	if _, err := g.FromCompressed(common.FromHex("17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")); err == nil {
+		t.Fatal("expected error for uncompressed flag")
+	}
 }
 
 func TestG1IsOnCurve(t *testing.T) {
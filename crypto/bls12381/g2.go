@@ -179,6 +179,70 @@ func (g *G2) EncodePoint(p *PointG2) []byte {
 	return out
 }
 
+// FromCompressed constructs a new point given 96 bytes of compressed input.
+// Serialization rules are in line with the zcash library, as for G1 points, with
+// x encoded as the concatenation of its imaginary and real parts.
+// FromCompressed also checks that the point is in the correct subgroup.
+func (g *G2) FromCompressed(compressed []byte) (*PointG2, error) {
+	if len(compressed) != 96 {
+		return nil, errors.New("input string should be equal 96 bytes")
+	}
+	var in [96]byte
+	copy(in[:], compressed)
+	if in[0]&(1<<7) == 0 {
+		return nil, errors.New("compression flag should be set")
+	}
+	if in[0]&(1<<6) != 0 {
+		// in[0] == (1 << 6) + (1 << 7)
+		for i, v := range in {
+			if (i == 0 && v != 0xc0) || (i != 0 && v != 0x00) {
+				return nil, errors.New("input string should be zero when infinity flag is set")
+			}
+		}
+		return g.Zero(), nil
+	}
+	a := in[0]&(1<<5) != 0
+	in[0] &= 0x1f
+	x, err := g.f.fromBytes(in[:])
+	if err != nil {
+		return nil, err
+	}
+	// solve curve equation
+	y := &fe2{}
+	g.f.square(y, x)
+	g.f.mul(y, y, x)
+	g.f.add(y, y, b2)
+	if ok := g.f.sqrt(y, y); !ok {
+		return nil, errors.New("point is not on curve")
+	}
+	if y.signBE() == a {
+		g.f.neg(y, y)
+	}
+	z := new(fe2).one()
+	p := &PointG2{*x, *y, *z}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errors.New("point is not on correct subgroup")
+	}
+	return p, nil
+}
+
+// ToCompressed serializes a point into 96 bytes of compressed form
+// following the zcash flag conventions.
+func (g *G2) ToCompressed(p *PointG2) []byte {
+	out := make([]byte, 96)
+	g.Affine(p)
+	if g.IsZero(p) {
+		out[0] |= 1 << 6
+	} else {
+		copy(out[:], g.f.toBytes(&p[0]))
+		if !p[1].signBE() {
+			out[0] |= 1 << 5
+		}
+	}
+	out[0] |= 1 << 7
+	return out
+}
+
 // New creates a new G2 Point which is equal to zero in other words point at infinity.
 func (g *G2) New() *PointG2 {
 	return new(PointG2).Zero()
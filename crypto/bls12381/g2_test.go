@@ -53,6 +53,48 @@ func TestG2Serialization(t *testing.T) {
 			t.Fatal("bad serialization encode/decode")
 		}
 	}
+	for i := 0; i < fuz; i++ {
+		a := g2.rand()
+		compressed := g2.ToCompressed(a)
+		b, err := g2.FromCompressed(compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g2.Equal(a, b) {
+			t.Fatal("bad serialization compress/decompress")
+		}
+	}
+}
+
+func TestG2CompressedKnownValues(t *testing.T) {
+	g := NewG2()
+	one := common.FromHex("93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8")
+	if !bytes.Equal(g.ToCompressed(g.one()), one) {
+		t.Fatal("bad compression of generator")
+	}
+	p, err := g.FromCompressed(one)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Equal(p, g.one()) {
+		t.Fatal("bad decompression of generator")
+	}
+	infinity := make([]byte, 96)
+	infinity[0] = 0xc0
+	if !bytes.Equal(g.ToCompressed(g.Zero()), infinity) {
+		t.Fatal("bad compression of point at infinity")
+	}
+	p, err = g.FromCompressed(infinity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.IsZero(p) {
+		t.Fatal("bad decompression of point at infinity")
+	}
+	// Missing compression flag
+	if _, err := g.FromCompressed(common.FromHex("13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8")); err == nil {
+		t.Fatal("expected error for uncompressed flag")
+	}
 }
 
 func TestG2IsOnCurve(t *testing.T) {
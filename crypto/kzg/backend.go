@@ -0,0 +1,110 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// Backend implements the BLS12-381 operations dominating the cost of KZG
+// commitments and proofs: multi-scalar multiplications in G1 and multi-pairing
+// checks. Points are exchanged in the representation of the bls12381 package,
+// which stays in charge of decoding them and checking their subgroups, so every
+// backend accepts and rejects exactly the same inputs.
+type Backend interface {
+	// Name returns the name the backend is selected by.
+	Name() string
+
+	// MultiExpG1 returns the sum of scalars[i]·points[i]. The scalars must be
+	// reduced field elements. Neither slice is modified.
+	MultiExpG1(points []*bls12381.PointG1, scalars []*big.Int) *bls12381.PointG1
+
+	// PairingCheck reports whether the product of the pairings e(g1s[i], g2s[i])
+	// is the identity. Neither slice is modified.
+	PairingCheck(g1s []*bls12381.PointG1, g2s []*bls12381.PointG2) bool
+}
+
+var (
+	// backends are the BLS12-381 backends compiled into the binary, by name.
+	backends = map[string]Backend{
+		nativeBackendName: nativeBackend{},
+	}
+
+	// backend is the BLS12-381 backend in use. Backends compiled in through
+	// build tags replace the native one as the default.
+	backend Backend = nativeBackend{}
+)
+
+// Backends returns the names of the BLS12-381 backends compiled into the binary.
+func Backends() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BackendName returns the name of the BLS12-381 backend in use.
+func BackendName() string {
+	return backend.Name()
+}
+
+// SetBackend switches to the BLS12-381 backend with the given name, which must
+// be compiled into the binary. Like LoadTrustedSetup, SetBackend is not safe for
+// concurrent use with commitments and proofs, so it should be called on startup.
+func SetBackend(name string) error {
+	b, ok := backends[name]
+	if !ok {
+		return fmt.Errorf("unknown kzg backend %q, available: %v", name, Backends())
+	}
+	backend = b
+	return nil
+}
+
+// nativeBackendName is the name of the pure Go backend built on the bls12381
+// package, available on every platform.
+const nativeBackendName = "native"
+
+// nativeBackend implements the backend operations with the bls12381 package.
+type nativeBackend struct{}
+
+func (nativeBackend) Name() string { return nativeBackendName }
+
+func (nativeBackend) MultiExpG1(points []*bls12381.PointG1, scalars []*big.Int) *bls12381.PointG1 {
+	g1 := bls12381.NewG1()
+
+	// MultiExp overwrites the scalar slice, hand it a copy
+	cpy := make([]*big.Int, len(scalars))
+	copy(cpy, scalars)
+
+	p, _ := g1.MultiExp(g1.New(), points, cpy)
+	return p
+}
+
+func (nativeBackend) PairingCheck(g1s []*bls12381.PointG1, g2s []*bls12381.PointG2) bool {
+	e := bls12381.NewPairingEngine()
+	for i := range g1s {
+		// The engine converts the points to affine form in place, hand it copies
+		e.AddPair(new(bls12381.PointG1).Set(g1s[i]), new(bls12381.PointG2).Set(g2s[i]))
+	}
+	return e.Check()
+}
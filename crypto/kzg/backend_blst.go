@@ -0,0 +1,182 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build blst && cgo
+// +build blst,cgo
+
+package kzg
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// blstBackendName is the name of the backend built on the supranational blst
+// library, compiled in with the blst build tag.
+const blstBackendName = "blst"
+
+func init() {
+	b := new(blstBackend)
+	backends[blstBackendName] = b
+	backend = b
+}
+
+// blstBackend implements the backend operations with the blst library. Points
+// are converted through their uncompressed encodings, which skips the subgroup
+// checks already done by the bls12381 package when the points were decoded.
+type blstBackend struct {
+	// The trusted setup is the base of most multi-scalar multiplications, so the
+	// conversion of the last blob sized base is kept around
+	lock      sync.Mutex
+	baseFirst **bls12381.PointG1 // Address of the first point of the converted base
+	base      blst.P1Affines     // Converted base, in the blst representation
+}
+
+func (b *blstBackend) Name() string { return blstBackendName }
+
+func (b *blstBackend) MultiExpG1(points []*bls12381.PointG1, scalars []*big.Int) *bls12381.PointG1 {
+	bases := b.convertBase(points)
+
+	// Pack the scalars little endian, the way blst expects them. Its Pippenger
+	// implementation mishandles the identity, so leave those terms out.
+	var (
+		g1      = bls12381.NewG1()
+		affines = make(blst.P1Affines, 0, len(bases))
+		packed  = make([]byte, 0, 32*len(scalars))
+	)
+	for i := range bases {
+		if g1.IsZero(points[i]) {
+			continue
+		}
+		affines = append(affines, bases[i])
+
+		var enc [32]byte
+		scalars[i].FillBytes(enc[:])
+		for j := len(enc) - 1; j >= 0; j-- {
+			packed = append(packed, enc[j])
+		}
+	}
+	if len(affines) == 0 {
+		return bls12381.NewG1().Zero()
+	}
+	return fromBlstP1(blst.P1AffinesMult(affines, packed, 255))
+}
+
+// convertBase converts the given points to the blst representation, reusing the
+// previous conversion if the points are the same blob sized base. Bases are
+// never modified after they are created, so the first point's address
+// identifies them; keeping a reference to it prevents the address from being
+// reused for a different base.
+func (b *blstBackend) convertBase(points []*bls12381.PointG1) blst.P1Affines {
+	if len(points) != FieldElementsPerBlob {
+		return toBlstG1s(points)
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.baseFirst != &points[0] {
+		b.baseFirst, b.base = &points[0], toBlstG1s(points)
+	}
+	return b.base
+}
+
+func (b *blstBackend) PairingCheck(g1s []*bls12381.PointG1, g2s []*bls12381.PointG2) bool {
+	var (
+		g1 = bls12381.NewG1()
+		g2 = bls12381.NewG2()
+		ps []blst.P1Affine
+		qs []blst.P2Affine
+	)
+	for i := range g1s {
+		// Pairings with the identity are trivial, leave them out
+		if g1.IsZero(g1s[i]) || g2.IsZero(g2s[i]) {
+			continue
+		}
+		ps = append(ps, toBlstG1(g1, g1s[i]))
+		qs = append(qs, toBlstG2(g2, g2s[i]))
+	}
+	if len(ps) == 0 {
+		return true
+	}
+	gt := blst.Fp12MillerLoopN(qs, ps)
+	gt.FinalExp()
+
+	one := blst.Fp12One()
+	return gt.Equals(&one)
+}
+
+// toBlstG1s converts G1 points to the blst representation.
+func toBlstG1s(points []*bls12381.PointG1) blst.P1Affines {
+	var (
+		g1  = bls12381.NewG1()
+		out = make(blst.P1Affines, len(points))
+	)
+	for i, p := range points {
+		out[i] = toBlstG1(g1, p)
+	}
+	return out
+}
+
+// toBlstG1 converts a G1 point to the blst representation.
+func toBlstG1(g1 *bls12381.G1, p *bls12381.PointG1) blst.P1Affine {
+	var enc []byte
+	if g1.IsZero(p) {
+		enc = make([]byte, 96)
+		enc[0] = 0x40 // infinity flag
+	} else {
+		// ToBytes converts the point to affine form in place, hand it a copy
+		enc = g1.ToBytes(new(bls12381.PointG1).Set(p))
+	}
+	var out blst.P1Affine
+	if out.Deserialize(enc) == nil {
+		panic("kzg: invalid G1 point handed to blst backend")
+	}
+	return out
+}
+
+// toBlstG2 converts a G2 point to the blst representation.
+func toBlstG2(g2 *bls12381.G2, p *bls12381.PointG2) blst.P2Affine {
+	var enc []byte
+	if g2.IsZero(p) {
+		enc = make([]byte, 192)
+		enc[0] = 0x40 // infinity flag
+	} else {
+		enc = g2.ToBytes(new(bls12381.PointG2).Set(p))
+	}
+	var out blst.P2Affine
+	if out.Deserialize(enc) == nil {
+		panic("kzg: invalid G2 point handed to blst backend")
+	}
+	return out
+}
+
+// fromBlstP1 converts a G1 point from the blst representation.
+func fromBlstP1(p *blst.P1) *bls12381.PointG1 {
+	g1 := bls12381.NewG1()
+
+	enc := p.Serialize()
+	if enc[0]&0x40 != 0 {
+		return g1.Zero()
+	}
+	out, err := g1.FromBytes(enc)
+	if err != nil {
+		panic("kzg: invalid G1 point returned by blst backend")
+	}
+	return out
+}
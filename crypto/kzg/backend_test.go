@@ -0,0 +1,327 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// withBackend runs fn with every compiled in backend selected in turn.
+func withBackend(t testing.TB, fn func(name string)) {
+	defer SetBackend(BackendName())
+
+	for _, name := range Backends() {
+		if err := SetBackend(name); err != nil {
+			t.Fatal(err)
+		}
+		fn(name)
+	}
+}
+
+func TestSetBackend(t *testing.T) {
+	defer SetBackend(BackendName())
+
+	if err := SetBackend("nonexistent"); err == nil {
+		t.Fatalf("unknown backend selected")
+	}
+	if err := SetBackend(nativeBackendName); err != nil {
+		t.Fatalf("failed to select native backend: %v", err)
+	}
+	if name := BackendName(); name != nativeBackendName {
+		t.Fatalf("backend mismatch: have %s, want %s", name, nativeBackendName)
+	}
+}
+
+// Tests the backend operations against results computed point by point.
+func TestBackendOperations(t *testing.T) {
+	var (
+		g1 = bls12381.NewG1()
+		g2 = bls12381.NewG2()
+
+		points  = []*bls12381.PointG1{g1.One(), g1.Zero(), g1.MulScalar(g1.New(), g1.One(), big.NewInt(5))}
+		scalars = []*big.Int{big.NewInt(3), big.NewInt(7), new(big.Int).Sub(BLSModulus, big.NewInt(1))}
+		want    = g1.Neg(g1.New(), g1.MulScalar(g1.New(), g1.One(), big.NewInt(2))) // 3·G + 7·O - 5·G
+	)
+	withBackend(t, func(name string) {
+		if have := backend.MultiExpG1(points, scalars); !g1.Equal(have, want) {
+			t.Errorf("%s: multi-exponentiation mismatch", name)
+		}
+		if scalars[2].Cmp(new(big.Int).Sub(BLSModulus, big.NewInt(1))) != 0 {
+			t.Errorf("%s: scalars modified", name)
+		}
+		if have := backend.MultiExpG1(nil, nil); !g1.IsZero(have) {
+			t.Errorf("%s: empty multi-exponentiation not the identity", name)
+		}
+		// e(6·G1, G2)·e(-2·G1, 3·G2) = 1, unless the exponents don't cancel out
+		for _, tt := range []struct {
+			a    int64
+			want bool
+		}{{6, true}, {7, false}} {
+			g1s := []*bls12381.PointG1{
+				g1.MulScalar(g1.New(), g1.One(), big.NewInt(tt.a)),
+				g1.Neg(g1.New(), g1.MulScalar(g1.New(), g1.One(), big.NewInt(2))),
+				g1.Zero(),
+			}
+			g2s := []*bls12381.PointG2{
+				g2.One(),
+				g2.MulScalar(g2.New(), g2.One(), big.NewInt(3)),
+				g2.One(),
+			}
+			if have := backend.PairingCheck(g1s, g2s); have != tt.want {
+				t.Errorf("%s: pairing check of %d·G1 mismatch: have %v, want %v", name, tt.a, have, tt.want)
+			}
+		}
+	})
+}
+
+func BenchmarkBackendCommit(b *testing.B) {
+	poly, _ := blobToPolynomial(makeTestBlob([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}))
+	lagrangeSetupG1()
+
+	withBackend(b, func(name string) {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				commitToPolynomial(poly)
+			}
+		})
+	})
+}
+
+func BenchmarkBackendVerify(b *testing.B) {
+	blob := makeTestBlob([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	commitment, _ := BlobToKZGCommitment(blob)
+	proof, _ := ComputeBlobKZGProof(blob, commitment)
+
+	withBackend(b, func(name string) {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := verifyBlobKZGProof(blob, commitment, proof); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	})
+}
+
+// randomFieldElement returns a random scalar field element, biased towards the
+// edge cases of zero, one and the largest element.
+func randomFieldElement(rng *mrand.Rand) *big.Int {
+	switch rng.Intn(8) {
+	case 0:
+		return big.NewInt(0)
+	case 1:
+		return big.NewInt(1)
+	case 2:
+		return new(big.Int).Sub(BLSModulus, big.NewInt(1))
+	default:
+		return new(big.Int).Rand(rng, BLSModulus)
+	}
+}
+
+// randomTestBlob returns a blob of random field elements, all zero, sparse or
+// dense. The zero blob commits to the identity.
+func randomTestBlob(rng *mrand.Rand) *Blob {
+	blob := new(Blob)
+	switch rng.Intn(3) {
+	case 0:
+	case 1:
+		for i := 0; i < 4; i++ {
+			j := rng.Intn(FieldElementsPerBlob)
+			randomFieldElement(rng).FillBytes(blob[j*32 : (j+1)*32])
+		}
+	default:
+		for j := 0; j < FieldElementsPerBlob; j++ {
+			randomFieldElement(rng).FillBytes(blob[j*32 : (j+1)*32])
+		}
+	}
+	return blob
+}
+
+// kzgTranscript runs commitments, proofs and verifications, both valid and
+// corrupted, on the given blobs with the backend in use, and records their
+// outcomes. Every backend must produce the same transcript.
+func kzgTranscript(blobs []*Blob, point KZGCommitment) []string {
+	var (
+		g1          = bls12381.NewG1()
+		transcript  []string
+		commitments = make([]KZGCommitment, len(blobs))
+		proofs      = make([]KZGProof, len(blobs))
+		zs          = make([][32]byte, len(blobs))
+		ys          = make([][32]byte, len(blobs))
+	)
+	record := func(format string, args ...interface{}) {
+		transcript = append(transcript, fmt.Sprintf(format, args...))
+	}
+	// Commit to and open the blobs, bypassing the caches
+	for i, blob := range blobs {
+		poly, err := blobToPolynomial(blob)
+		if err != nil {
+			panic(err)
+		}
+		copy(commitments[i][:], g1.ToCompressed(commitToPolynomial(poly)))
+
+		z := computeChallenge(blob, commitments[i])
+		proof, y := computeKZGProof(poly, z)
+		proofs[i] = proof
+		z.FillBytes(zs[i][:])
+		y.FillBytes(ys[i][:])
+
+		record("blob %d: commitment %x, proof %x", i, commitments[i], proofs[i])
+	}
+	for i, blob := range blobs {
+		var (
+			other        = (i + 1) % len(blobs)
+			nonCanonical = *blob
+			tampered     = *blob
+		)
+		BLSModulus.FillBytes(nonCanonical[:32])
+		tampered[FieldElementsPerBlob*32-1] ^= 0x01
+
+		for _, tt := range []struct {
+			name       string
+			blob       *Blob
+			commitment KZGCommitment
+			proof      KZGProof
+		}{
+			{"valid", blob, commitments[i], proofs[i]},
+			{"other proof", blob, commitments[i], proofs[other]},
+			{"other commitment", blob, commitments[other], proofs[i]},
+			{"random commitment", blob, point, proofs[i]},
+			{"random proof", blob, commitments[i], KZGProof(point)},
+			{"identity commitment", blob, KZGCommitment{0xc0}, proofs[i]},
+			{"identity proof", blob, commitments[i], KZGProof{0xc0}},
+			{"off-subgroup proof", blob, commitments[i], KZGProof(offSubgroupPoint)},
+			{"non-canonical blob", &nonCanonical, commitments[i], proofs[i]},
+			{"tampered blob", &tampered, commitments[i], proofs[i]},
+		} {
+			record("blob %d, %s: %v", i, tt.name, verifyBlobKZGProof(tt.blob, tt.commitment, tt.proof))
+		}
+		wrongY := ys[i]
+		wrongY[31] ^= 0x01
+		record("blob %d, point evaluation: %v", i, VerifyKZGProof(commitments[i], zs[i], ys[i], proofs[i]))
+		record("blob %d, wrong point evaluation: %v", i, VerifyKZGProof(commitments[i], zs[i], wrongY, proofs[i]))
+	}
+	// Verify the blobs as a batch, then with a single swapped proof
+	var (
+		all     = make([]Blob, len(blobs))
+		indices = make([]int, len(blobs))
+	)
+	for i, blob := range blobs {
+		all[i], indices[i] = *blob, i
+	}
+	record("batch: %v", verifyBlobKZGProofBatch(all, commitments, proofs, indices))
+
+	swapped := append([]KZGProof{}, proofs...)
+	swapped[0], swapped[len(swapped)-1] = swapped[len(swapped)-1], swapped[0]
+	record("swapped batch: %v", verifyBlobKZGProofBatch(all, commitments, swapped, indices))
+
+	return transcript
+}
+
+// Tests that all compiled in backends agree on randomized inputs, so switching
+// backends can't change which blobs a node accepts. The inputs include the
+// identity, the edge cases of the scalar field and corrupted proofs.
+func TestBackendDifferential(t *testing.T) {
+	if len(Backends()) < 2 {
+		t.Skip("only one kzg backend compiled in, build with the blst tag")
+	}
+	seed := time.Now().UnixNano()
+	t.Logf("random seed %d", seed)
+
+	var (
+		rng = mrand.New(mrand.NewSource(seed))
+		g1  = bls12381.NewG1()
+		g2  = bls12381.NewG2()
+	)
+	// Compare the backend operations on random inputs
+	for i := 0; i < 32; i++ {
+		var (
+			points  = make([]*bls12381.PointG1, rng.Intn(48))
+			scalars = make([]*big.Int, len(points))
+		)
+		for j := range points {
+			points[j] = g1.MulScalar(g1.New(), g1.One(), randomFieldElement(rng))
+			scalars[j] = randomFieldElement(rng)
+		}
+		var want *bls12381.PointG1
+		withBackend(t, func(name string) {
+			have := backend.MultiExpG1(points, scalars)
+			if want == nil {
+				want = have
+			} else if !g1.Equal(have, want) {
+				t.Errorf("multi-exponentiation %d: %s result mismatch", i, name)
+			}
+		})
+	}
+	for i := 0; i < 32; i++ {
+		// e(a·G1, b·G2)·e(-c·G1, G2), which is the identity if c = a·b
+		var (
+			a = randomFieldElement(rng)
+			b = randomFieldElement(rng)
+			c = new(big.Int).Mul(a, b)
+		)
+		if rng.Intn(2) == 0 {
+			c.Add(c, big.NewInt(1))
+		}
+		c.Mod(c, BLSModulus)
+
+		g1s := []*bls12381.PointG1{
+			g1.MulScalar(g1.New(), g1.One(), a),
+			g1.Neg(g1.New(), g1.MulScalar(g1.New(), g1.One(), c)),
+		}
+		g2s := []*bls12381.PointG2{
+			g2.MulScalar(g2.New(), g2.One(), b),
+			g2.One(),
+		}
+		want := new(big.Int).Mod(new(big.Int).Mul(a, b), BLSModulus).Cmp(c) == 0
+		withBackend(t, func(name string) {
+			if have := backend.PairingCheck(g1s, g2s); have != want {
+				t.Errorf("pairing check %d: %s result mismatch: have %v, want %v", i, name, have, want)
+			}
+		})
+	}
+	// Compare commitments, proofs and their verification on random blobs
+	blobs := []*Blob{new(Blob)} // The identity commitment, always included
+	for i := 0; i < 3; i++ {
+		blobs = append(blobs, randomTestBlob(rng))
+	}
+	var point KZGCommitment
+	copy(point[:], g1.ToCompressed(g1.MulScalar(g1.New(), g1.One(), randomFieldElement(rng))))
+
+	var (
+		want     []string
+		wantName string
+	)
+	withBackend(t, func(name string) {
+		have := kzgTranscript(blobs, point)
+		if want == nil {
+			want, wantName = have, name
+			return
+		}
+		for i := range want {
+			if have[i] != want[i] {
+				t.Errorf("transcript mismatch:\n%s: %s\n%s: %s", name, have[i], wantName, want[i])
+			}
+		}
+	})
+}
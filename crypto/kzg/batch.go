@@ -0,0 +1,190 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// batchDomain separates the randomness combining batched proofs from other uses
+// of the hash function.
+var batchDomain = []byte("RCKZGBATCH___V1_")
+
+// batchCheck is a single opening proof accumulated into a batch.
+type batchCheck struct {
+	commitment KZGCommitment
+	proof      KZGProof
+	c, pi      *bls12381.PointG1
+	z, y       *big.Int
+}
+
+// Batch accumulates KZG opening proofs, both point evaluations and blob proofs,
+// to verify them with a single multi-pairing instead of a pairing check each,
+// amortizing the final exponentiation. The checks are combined with random
+// weights derived from all of them, so the outcome is deterministic; a failing
+// batch doesn't tell which of its proofs is invalid.
+type Batch struct {
+	checks []*batchCheck
+}
+
+// NewBatch creates an empty batch of proofs.
+func NewBatch() *Batch {
+	return new(Batch)
+}
+
+// Len returns the number of proofs accumulated in the batch.
+func (b *Batch) Len() int {
+	return len(b.checks)
+}
+
+// AddKZGProof adds the check that proof attests to p(z) = y for the polynomial
+// p committed to by commitment, like VerifyKZGProof does. Malformed inputs are
+// rejected immediately.
+func (b *Batch) AddKZGProof(commitment KZGCommitment, z, y [32]byte, proof KZGProof) error {
+	zFr, err := ReadFieldElement(z)
+	if err != nil {
+		return err
+	}
+	yFr, err := ReadFieldElement(y)
+	if err != nil {
+		return err
+	}
+	c, err := commitment.Point()
+	if err != nil {
+		return err
+	}
+	pi, err := proof.Point()
+	if err != nil {
+		return err
+	}
+	b.add(commitment, c, zFr, yFr, proof, pi)
+	return nil
+}
+
+// AddBlobKZGProof adds the check that commitment commits to the blob, like
+// VerifyBlobKZGProof does, evaluating the blob at its challenge right away.
+// Malformed inputs are rejected immediately.
+func (b *Batch) AddBlobKZGProof(blob *Blob, commitment KZGCommitment, proof KZGProof) error {
+	c, err := commitment.Point()
+	if err != nil {
+		return err
+	}
+	pi, err := proof.Point()
+	if err != nil {
+		return err
+	}
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return err
+	}
+	z := computeChallenge(blob, commitment)
+	b.add(commitment, c, z, evaluatePolynomial(poly, z), proof, pi)
+	return nil
+}
+
+// add accumulates an opening proof whose inputs are already decoded.
+func (b *Batch) add(commitment KZGCommitment, c *bls12381.PointG1, z, y *big.Int, proof KZGProof, pi *bls12381.PointG1) {
+	b.checks = append(b.checks, &batchCheck{
+		commitment: commitment,
+		proof:      proof,
+		c:          c,
+		pi:         pi,
+		z:          z,
+		y:          y,
+	})
+}
+
+// Verify checks all the proofs of the batch at once. Each pairing check
+// e(C - [y], [1]) = e(π, [s - z]) is rearranged into
+// e(C - [y] + z·π, [1]) = e(π, [s]), so a linear combination with random
+// weights r^i collapses them into a single multi-pairing:
+//
+//	e(sum(r^i·(C_i - [y_i] + z_i·π_i)), [1]) = e(sum(r^i·π_i), [s])
+//
+// An empty batch is valid.
+func (b *Batch) Verify() error {
+	if len(b.checks) == 0 {
+		return nil
+	}
+	defer batchVerifyTimer.UpdateSince(time.Now())
+
+	// Derive the random weights from everything being verified
+	h := sha256.New()
+	h.Write(batchDomain)
+	var count [8]byte
+	binary.BigEndian.PutUint64(count[:], uint64(len(b.checks)))
+	h.Write(count[:])
+	for _, check := range b.checks {
+		var z, y [32]byte
+		check.z.FillBytes(z[:])
+		check.y.FillBytes(y[:])
+
+		h.Write(check.commitment[:])
+		h.Write(z[:])
+		h.Write(y[:])
+		h.Write(check.proof[:])
+	}
+	r := new(big.Int).SetBytes(h.Sum(nil))
+	r.Mod(r, BLSModulus)
+
+	// Combine the proofs and the left hand sides of the checks
+	var (
+		g1     = bls12381.NewG1()
+		points = make([]*bls12381.PointG1, 0, 2*len(b.checks)+1)
+		lhs    = make([]*big.Int, 0, 2*len(b.checks)+1)
+		pis    = make([]*bls12381.PointG1, len(b.checks))
+		rhs    = make([]*big.Int, len(b.checks))
+		ysum   = new(big.Int)
+		weight = big.NewInt(1)
+	)
+	for i, check := range b.checks {
+		pis[i], rhs[i] = check.pi, new(big.Int).Set(weight)
+
+		rz := new(big.Int).Mul(weight, check.z)
+		rz.Mod(rz, BLSModulus)
+		points = append(points, check.c, check.pi)
+		lhs = append(lhs, new(big.Int).Set(weight), rz)
+
+		ry := new(big.Int).Mul(weight, check.y)
+		ysum.Add(ysum, ry)
+		ysum.Mod(ysum, BLSModulus)
+
+		weight.Mul(weight, r)
+		weight.Mod(weight, BLSModulus)
+	}
+	points = append(points, g1.One())
+	lhs = append(lhs, ysum.Sub(BLSModulus, ysum).Mod(ysum, BLSModulus))
+
+	var (
+		combined = backend.MultiExpG1(points, lhs)
+		proof    = backend.MultiExpG1(pis, rhs)
+	)
+	g1.Neg(proof, proof)
+
+	if !backend.PairingCheck(
+		[]*bls12381.PointG1{combined, proof},
+		[]*bls12381.PointG2{bls12381.NewG2().One(), kzgSetupG2[1]},
+	) {
+		return ErrProofMismatch
+	}
+	return nil
+}
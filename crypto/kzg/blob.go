@@ -0,0 +1,450 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// primitiveRootOfUnity generates the multiplicative group of the scalar field.
+const primitiveRootOfUnity = 7
+
+// challengeDomain separates the Fiat-Shamir challenges of blob proofs from
+// other uses of the hash function.
+var challengeDomain = []byte("FSBLOBVERIFY_V1_")
+
+// rootsOfUnity holds the evaluation domain of blobs: the FieldElementsPerBlob
+// roots of unity, in bit-reversed order.
+var rootsOfUnity []*big.Int
+
+func init() {
+	rootsOfUnity = bitReversedRootsOfUnity(FieldElementsPerBlob)
+}
+
+// computeRootsOfUnity returns the n-th roots of unity in their natural order
+// ω^0, ω^1, ..., ω^(n-1). The domain size n must be a power of two.
+func computeRootsOfUnity(n int) []*big.Int {
+	exp := new(big.Int).Sub(BLSModulus, big.NewInt(1))
+	exp.Div(exp, big.NewInt(int64(n)))
+	omega := new(big.Int).Exp(big.NewInt(primitiveRootOfUnity), exp, BLSModulus)
+
+	roots := make([]*big.Int, n)
+	roots[0] = big.NewInt(1)
+	for i := 1; i < n; i++ {
+		roots[i] = new(big.Int).Mul(roots[i-1], omega)
+		roots[i].Mod(roots[i], BLSModulus)
+	}
+	return roots
+}
+
+// reverseBits reverses the bits of an index into a power of two sized list of
+// n items, mapping between natural and bit-reversed order.
+func reverseBits(i, n int) int {
+	if n <= 1 {
+		return i
+	}
+	return int(bits.Reverse(uint(i)) >> (bits.UintSize - bits.Len(uint(n-1))))
+}
+
+// blobToPolynomial interprets the blob as the evaluations of a polynomial over
+// the roots of unity, rejecting non-canonical field elements.
+func blobToPolynomial(blob *Blob) ([]*big.Int, error) {
+	poly := make([]*big.Int, FieldElementsPerBlob)
+	for i := range poly {
+		var elem [32]byte
+		copy(elem[:], blob[i*32:(i+1)*32])
+
+		x, err := ReadFieldElement(elem)
+		if err != nil {
+			return nil, err
+		}
+		poly[i] = x
+	}
+	return poly, nil
+}
+
+// evaluatePolynomial evaluates a polynomial given in evaluation form at an
+// arbitrary point z, using the barycentric formula:
+//
+//	p(z) = (z^N - 1) / N * sum(p_i * w_i / (z - w_i))
+func evaluatePolynomial(poly []*big.Int, z *big.Int) *big.Int {
+	denoms := make([]*big.Int, len(poly))
+	for i, root := range rootsOfUnity {
+		// The formula does not apply within the domain, but the evaluation is known
+		if root.Cmp(z) == 0 {
+			return new(big.Int).Set(poly[i])
+		}
+		denoms[i] = new(big.Int).Sub(z, root)
+		denoms[i].Mod(denoms[i], BLSModulus)
+	}
+	invs := batchInverse(denoms)
+
+	sum, term := new(big.Int), new(big.Int)
+	for i := range poly {
+		term.Mul(poly[i], rootsOfUnity[i])
+		term.Mod(term, BLSModulus)
+		term.Mul(term, invs[i])
+		sum.Add(sum, term)
+		sum.Mod(sum, BLSModulus)
+	}
+	n := big.NewInt(int64(len(poly)))
+	factor := new(big.Int).Exp(z, n, BLSModulus)
+	factor.Sub(factor, big.NewInt(1))
+	factor.Mul(factor, new(big.Int).ModInverse(n, BLSModulus))
+
+	sum.Mul(sum, factor)
+	return sum.Mod(sum, BLSModulus)
+}
+
+// batchInverse inverts the given non-zero field elements using a single
+// modular inversion.
+func batchInverse(xs []*big.Int) []*big.Int {
+	prods := make([]*big.Int, len(xs))
+	acc := big.NewInt(1)
+	for i, x := range xs {
+		prods[i] = new(big.Int).Set(acc)
+		acc.Mul(acc, x)
+		acc.Mod(acc, BLSModulus)
+	}
+	acc.ModInverse(acc, BLSModulus)
+
+	invs := make([]*big.Int, len(xs))
+	for i := len(xs) - 1; i >= 0; i-- {
+		invs[i] = new(big.Int).Mul(acc, prods[i])
+		invs[i].Mod(invs[i], BLSModulus)
+		acc.Mul(acc, xs[i])
+		acc.Mod(acc, BLSModulus)
+	}
+	return invs
+}
+
+// computeChallenge derives the evaluation point of a blob proof from the blob
+// and its commitment.
+func computeChallenge(blob *Blob, commitment KZGCommitment) *big.Int {
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], FieldElementsPerBlob)
+
+	h := sha256.New()
+	h.Write(challengeDomain)
+	h.Write(degree[:])
+	h.Write(blob[:])
+	h.Write(commitment[:])
+
+	z := new(big.Int).SetBytes(h.Sum(nil))
+	return z.Mod(z, BLSModulus)
+}
+
+// commitToPolynomial computes the KZG commitment to a polynomial given in
+// evaluation form.
+func commitToPolynomial(poly []*big.Int) *bls12381.PointG1 {
+	return backend.MultiExpG1(lagrangeSetupG1(), poly)
+}
+
+// BlobToKZGCommitment computes the KZG commitment to the given blob. Commitments
+// are cached by blob contents, so committing to a recently seen blob is cheap.
+func BlobToKZGCommitment(blob *Blob) (KZGCommitment, error) {
+	if verifyOnly {
+		return KZGCommitment{}, ErrVerifyOnly
+	}
+	hash := blobHash(blob)
+	if cached, ok := commitmentCache.Get(hash); ok {
+		commitmentCacheHitMeter.Mark(1)
+		return cached.(KZGCommitment), nil
+	}
+	commitmentCacheMissMeter.Mark(1)
+
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	var commitment KZGCommitment
+	copy(commitment[:], bls12381.NewG1().ToCompressed(commitToPolynomial(poly)))
+	commitmentCache.Add(hash, commitment)
+	return commitment, nil
+}
+
+// ComputeBlobKZGProof computes the proof that the given commitment commits to
+// the blob, opening it at the challenge derived from both.
+func ComputeBlobKZGProof(blob *Blob, commitment KZGCommitment) (KZGProof, error) {
+	if verifyOnly {
+		return KZGProof{}, ErrVerifyOnly
+	}
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	proof, _ := computeKZGProof(poly, computeChallenge(blob, commitment))
+	return proof, nil
+}
+
+// ComputeKZGProof computes the proof opening the polynomial of the given blob
+// at an arbitrary point z, along with the evaluation y = p(z). Both z and y are
+// big-endian field elements, as verified by VerifyKZGProof.
+func ComputeKZGProof(blob *Blob, z [32]byte) (KZGProof, [32]byte, error) {
+	if verifyOnly {
+		return KZGProof{}, [32]byte{}, ErrVerifyOnly
+	}
+	zFr, err := ReadFieldElement(z)
+	if err != nil {
+		return KZGProof{}, [32]byte{}, err
+	}
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, [32]byte{}, err
+	}
+	proof, y := computeKZGProof(poly, zFr)
+
+	var yBytes [32]byte
+	y.FillBytes(yBytes[:])
+	return proof, yBytes, nil
+}
+
+// computeKZGProof computes the proof opening a polynomial given in evaluation
+// form at z, returning it along with the evaluation at z.
+func computeKZGProof(poly []*big.Int, z *big.Int) (KZGProof, *big.Int) {
+	y := evaluatePolynomial(poly, z)
+
+	// The quotient q(x) = (p(x) - y) / (x - z) in evaluation form. The formula
+	// is undefined at a root of unity equal to z, which is filled in below.
+	var (
+		denoms = make([]*big.Int, len(poly))
+		within = -1
+	)
+	for i, root := range rootsOfUnity {
+		if root.Cmp(z) == 0 {
+			within, denoms[i] = i, big.NewInt(1)
+			continue
+		}
+		denoms[i] = new(big.Int).Sub(root, z)
+		denoms[i].Mod(denoms[i], BLSModulus)
+	}
+	quotient := batchInverse(denoms)
+	for i := range quotient {
+		num := new(big.Int).Sub(poly[i], y)
+		quotient[i].Mul(quotient[i], num)
+		quotient[i].Mod(quotient[i], BLSModulus)
+	}
+	// Within the domain, the quotient at z is the derivative of p at z:
+	//
+	//	q(z) = sum((p_i - y) * w_i / (z * (z - w_i))) for all w_i != z
+	if within >= 0 {
+		for i, root := range rootsOfUnity {
+			if i == within {
+				denoms[i] = big.NewInt(1)
+				continue
+			}
+			denoms[i] = new(big.Int).Sub(z, root)
+			denoms[i].Mul(denoms[i], z)
+			denoms[i].Mod(denoms[i], BLSModulus)
+		}
+		invs := batchInverse(denoms)
+
+		sum, term := new(big.Int), new(big.Int)
+		for i, root := range rootsOfUnity {
+			if i == within {
+				continue
+			}
+			term.Sub(poly[i], y)
+			term.Mul(term, root)
+			term.Mod(term, BLSModulus)
+			term.Mul(term, invs[i])
+			sum.Add(sum, term)
+			sum.Mod(sum, BLSModulus)
+		}
+		quotient[within] = sum
+	}
+	var proof KZGProof
+	copy(proof[:], bls12381.NewG1().ToCompressed(commitToPolynomial(quotient)))
+	return proof, y
+}
+
+// VerifyBlobKZGProof checks that the given commitment commits to the blob, by
+// verifying the opening proof at the challenge derived from both. The outcomes
+// of recent verifications are cached, so checking the same sidecar twice (e.g.
+// in the transaction pool and on block import) only costs a hash.
+func VerifyBlobKZGProof(blob *Blob, commitment KZGCommitment, proof KZGProof) error {
+	hash := blobHash(blob)
+	key := verificationKey(hash, commitment, proof)
+	if cached, ok := verificationCache.Get(key); ok {
+		verificationCacheHitMeter.Mark(1)
+		err, _ := cached.(error)
+		return err
+	}
+	verificationCacheMissMeter.Mark(1)
+
+	err := verifyBlobKZGProof(blob, commitment, proof)
+	cacheVerification(hash, key, commitment, err)
+	return err
+}
+
+// VerifyBlobKZGProofBatch checks that the given commitments commit to the blobs
+// at the same indices, like VerifyBlobKZGProof does one by one. The proofs not
+// verified recently are checked at once, by a random linear combination of their
+// pairing checks. The randomness is derived from the inputs, so the outcome is
+// deterministic. If the combined check fails, the proofs are verified one by one
+// to report the offending blob.
+func VerifyBlobKZGProofBatch(blobs []Blob, commitments []KZGCommitment, proofs []KZGProof) error {
+	if len(commitments) != len(blobs) || len(proofs) != len(blobs) {
+		return ErrBatchLengthMismatch
+	}
+	batchSizeHistogram.Update(int64(len(blobs)))
+
+	var (
+		hashes  = make([][32]byte, len(blobs))
+		keys    = make([][32]byte, len(blobs))
+		pending []int
+	)
+	for i := range blobs {
+		hashes[i] = blobHash(&blobs[i])
+		keys[i] = verificationKey(hashes[i], commitments[i], proofs[i])
+		if cached, ok := verificationCache.Get(keys[i]); ok {
+			verificationCacheHitMeter.Mark(1)
+			if err, _ := cached.(error); err != nil {
+				return &BlobError{Index: i, Err: err}
+			}
+			continue
+		}
+		verificationCacheMissMeter.Mark(1)
+		pending = append(pending, i)
+	}
+	if len(pending) > 1 {
+		if err := verifyBlobKZGProofBatch(blobs, commitments, proofs, pending); err == nil {
+			for _, i := range pending {
+				cacheVerification(hashes[i], keys[i], commitments[i], nil)
+			}
+			return nil
+		}
+		batchFailMeter.Mark(1)
+	}
+	for _, i := range pending {
+		err := verifyBlobKZGProof(&blobs[i], commitments[i], proofs[i])
+		cacheVerification(hashes[i], keys[i], commitments[i], err)
+		if err != nil {
+			return &BlobError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// verifyBlobKZGProof checks that the given commitment commits to the blob,
+// without consulting the verification cache.
+func verifyBlobKZGProof(blob *Blob, commitment KZGCommitment, proof KZGProof) (err error) {
+	defer blobVerifyTimer.UpdateSince(time.Now())
+	defer func() { blobVerifyFailures.mark(err) }()
+
+	// Decode the points first, rejecting anything outside the G1 subgroup before
+	// doing any work on the blob
+	c, err := commitment.Point()
+	if err != nil {
+		return err
+	}
+	pi, err := proof.Point()
+	if err != nil {
+		return err
+	}
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return err
+	}
+	var (
+		z = computeChallenge(blob, commitment)
+		y = evaluatePolynomial(poly, z)
+	)
+	if !verifyKZGProof(c, z, y, pi) {
+		return ErrProofMismatch
+	}
+	return nil
+}
+
+// verifyBlobKZGProofBatch checks the proofs of the blobs at the given indices
+// at once, without consulting the verification cache. The blobs are evaluated
+// concurrently, then all proofs are checked by a single multi-pairing.
+func verifyBlobKZGProofBatch(blobs []Blob, commitments []KZGCommitment, proofs []KZGProof, indices []int) error {
+	var (
+		cs   = make([]*bls12381.PointG1, len(indices))
+		pis  = make([]*bls12381.PointG1, len(indices))
+		zs   = make([]*big.Int, len(indices))
+		ys   = make([]*big.Int, len(indices))
+		errs = make([]error, len(indices))
+	)
+	// Decoding and evaluating the blobs dominates the cost of verification, do
+	// it for all blobs concurrently
+	parallelize(len(indices), func(j int) {
+		i := indices[j]
+		if cs[j], errs[j] = commitments[i].Point(); errs[j] != nil {
+			return
+		}
+		if pis[j], errs[j] = proofs[i].Point(); errs[j] != nil {
+			return
+		}
+		poly, err := blobToPolynomial(&blobs[i])
+		if err != nil {
+			errs[j] = err
+			return
+		}
+		zs[j] = computeChallenge(&blobs[i], commitments[i])
+		ys[j] = evaluatePolynomial(poly, zs[j])
+	})
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	batch := NewBatch()
+	for j, i := range indices {
+		batch.add(commitments[i], cs[j], zs[j], ys[j], proofs[i], pis[j])
+	}
+	return batch.Verify()
+}
+
+// parallelize calls fn with every index in [0, n), spreading the calls over as
+// many goroutines as there are usable CPU cores.
+func parallelize(n int, fn func(i int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	var (
+		next = int64(-1)
+		wg   sync.WaitGroup
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := int(atomic.AddInt64(&next, 1)); i < n; i = int(atomic.AddInt64(&next, 1)) {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,356 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// makeTestBlob creates a blob holding the evaluations of the polynomial with
+// the given coefficients over the roots of unity.
+func makeTestBlob(coeffs []*big.Int) *Blob {
+	blob := new(Blob)
+	for i, root := range rootsOfUnity {
+		evalPoly(coeffs, root).FillBytes(blob[i*32 : (i+1)*32])
+	}
+	return blob
+}
+
+func TestRootsOfUnity(t *testing.T) {
+	seen := make(map[string]bool)
+	for i, root := range rootsOfUnity {
+		if seen[root.String()] {
+			t.Fatalf("root %d: duplicate root of unity %v", i, root)
+		}
+		seen[root.String()] = true
+
+		if x := new(big.Int).Exp(root, big.NewInt(FieldElementsPerBlob), BLSModulus); x.Cmp(big.NewInt(1)) != 0 {
+			t.Fatalf("root %d: not a root of unity: %v", i, root)
+		}
+	}
+}
+
+func TestEvaluatePolynomial(t *testing.T) {
+	coeffs := []*big.Int{big.NewInt(12), big.NewInt(34), big.NewInt(56), big.NewInt(78)}
+	poly, err := blobToPolynomial(makeTestBlob(coeffs))
+	if err != nil {
+		t.Fatalf("failed to parse blob: %v", err)
+	}
+	for _, z := range []*big.Int{big.NewInt(0), big.NewInt(1337), rootsOfUnity[5], new(big.Int).Sub(BLSModulus, big.NewInt(1))} {
+		if have, want := evaluatePolynomial(poly, z), evalPoly(coeffs, z); have.Cmp(want) != 0 {
+			t.Errorf("evaluation at %v mismatch: have %v, want %v", z, have, want)
+		}
+	}
+}
+
+func TestBlobKZGProof(t *testing.T) {
+	coeffs := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+	blob := makeTestBlob(coeffs)
+
+	commitment, err := BlobToKZGCommitment(blob)
+	if err != nil {
+		t.Fatalf("failed to commit to blob: %v", err)
+	}
+	// The commitment must match the one derived directly from the secret
+	g1 := bls12381.NewG1()
+	want := g1.ToCompressed(g1.MulScalar(g1.New(), g1.One(), evalPoly(coeffs, insecureSecret)))
+	if string(commitment[:]) != string(want) {
+		t.Fatalf("commitment mismatch: have %x, want %x", commitment, want)
+	}
+	proof, err := ComputeBlobKZGProof(blob, commitment)
+	if err != nil {
+		t.Fatalf("failed to compute proof: %v", err)
+	}
+	if err := VerifyBlobKZGProof(blob, commitment, proof); err != nil {
+		t.Fatalf("failed to verify valid proof: %v", err)
+	}
+	// Tampering with the blob must invalidate the proof
+	tampered := *blob
+	tampered[31] ^= 0x01
+	if err := VerifyBlobKZGProof(&tampered, commitment, proof); err != ErrProofMismatch {
+		t.Fatalf("tampered blob: have %v, want %v", err, ErrProofMismatch)
+	}
+	// Non-canonical field elements must be rejected
+	BLSModulus.FillBytes(tampered[:32])
+	if err := VerifyBlobKZGProof(&tampered, commitment, proof); err != ErrInvalidFieldElement {
+		t.Fatalf("non-canonical blob: have %v, want %v", err, ErrInvalidFieldElement)
+	}
+}
+
+// Tests that proofs opening a blob at arbitrary points, including ones within
+// the evaluation domain, verify against the blob's commitment.
+func TestComputeKZGProof(t *testing.T) {
+	coeffs := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+	blob := makeTestBlob(coeffs)
+
+	commitment, err := BlobToKZGCommitment(blob)
+	if err != nil {
+		t.Fatalf("failed to commit to blob: %v", err)
+	}
+	for _, z := range []*big.Int{big.NewInt(0), big.NewInt(1337), rootsOfUnity[0], rootsOfUnity[5], new(big.Int).Sub(BLSModulus, big.NewInt(1))} {
+		var zBytes [32]byte
+		z.FillBytes(zBytes[:])
+
+		proof, y, err := ComputeKZGProof(blob, zBytes)
+		if err != nil {
+			t.Fatalf("z=%v: failed to compute proof: %v", z, err)
+		}
+		if have, want := new(big.Int).SetBytes(y[:]), evalPoly(coeffs, z); have.Cmp(want) != 0 {
+			t.Errorf("z=%v: evaluation mismatch: have %v, want %v", z, have, want)
+		}
+		if err := VerifyKZGProof(commitment, zBytes, y, proof); err != nil {
+			t.Errorf("z=%v: failed to verify proof: %v", z, err)
+		}
+	}
+	// Evaluation points outside of the field must be rejected
+	var outOfRange [32]byte
+	BLSModulus.FillBytes(outOfRange[:])
+	if _, _, err := ComputeKZGProof(blob, outOfRange); err != ErrInvalidFieldElement {
+		t.Fatalf("out of range point: have %v, want %v", err, ErrInvalidFieldElement)
+	}
+}
+
+// Tests that batches of blob proofs verify at once, and that a single invalid
+// proof fails the batch and gets reported.
+func TestBlobKZGProofBatch(t *testing.T) {
+	purgeCaches()
+	defer purgeCaches()
+
+	var (
+		blobs       = make([]Blob, 4)
+		commitments = make([]KZGCommitment, 4)
+		proofs      = make([]KZGProof, 4)
+	)
+	for i := range blobs {
+		blobs[i] = *makeTestBlob([]*big.Int{big.NewInt(int64(i)), big.NewInt(int64(i + 1)), big.NewInt(int64(i + 2))})
+
+		var err error
+		if commitments[i], err = BlobToKZGCommitment(&blobs[i]); err != nil {
+			t.Fatalf("blob %d: failed to commit: %v", i, err)
+		}
+		if proofs[i], err = ComputeBlobKZGProof(&blobs[i], commitments[i]); err != nil {
+			t.Fatalf("blob %d: failed to compute proof: %v", i, err)
+		}
+	}
+	all := []int{0, 1, 2, 3}
+	if err := verifyBlobKZGProofBatch(blobs, commitments, proofs, all); err != nil {
+		t.Fatalf("failed to verify valid batch: %v", err)
+	}
+	// Swapping two proofs must fail the combined check
+	swapped := append([]KZGProof{}, proofs...)
+	swapped[1], swapped[2] = swapped[2], swapped[1]
+	if err := verifyBlobKZGProofBatch(blobs, commitments, swapped, all); err != ErrProofMismatch {
+		t.Fatalf("swapped proofs: have %v, want %v", err, ErrProofMismatch)
+	}
+	// The public API reports the offending blob, and caches valid outcomes
+	if err := VerifyBlobKZGProofBatch(blobs, commitments, swapped); !errors.Is(err, ErrProofMismatch) || !strings.HasPrefix(err.Error(), "blob 1:") {
+		t.Fatalf("swapped proofs: have %v, want blob 1 mismatch", err)
+	}
+	if err := VerifyBlobKZGProofBatch(blobs, commitments, proofs); err != nil {
+		t.Fatalf("failed to verify valid batch: %v", err)
+	}
+	if _, ok := verificationCache.Get(verificationKey(blobHash(&blobs[3]), commitments[3], proofs[3])); !ok {
+		t.Errorf("batch verification outcome not cached")
+	}
+	if err := VerifyBlobKZGProofBatch(blobs, commitments[:3], proofs); err != ErrBatchLengthMismatch {
+		t.Fatalf("length mismatch: have %v, want %v", err, ErrBatchLengthMismatch)
+	}
+}
+
+func TestParallelize(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 64} {
+		calls := make([]int32, n)
+		parallelize(n, func(i int) { atomic.AddInt32(&calls[i], 1) })
+		for i, c := range calls {
+			if c != 1 {
+				t.Errorf("n=%d: index %d called %d times", n, i, c)
+			}
+		}
+	}
+}
+
+func TestBlobKZGCache(t *testing.T) {
+	purgeCaches()
+	defer purgeCaches()
+
+	blob := makeTestBlob([]*big.Int{big.NewInt(6), big.NewInt(7), big.NewInt(8)})
+	hash := blobHash(blob)
+
+	// Verifying a valid proof must cache both the result and the commitment
+	commitment, err := BlobToKZGCommitment(blob)
+	if err != nil {
+		t.Fatalf("failed to commit to blob: %v", err)
+	}
+	proof, err := ComputeBlobKZGProof(blob, commitment)
+	if err != nil {
+		t.Fatalf("failed to compute proof: %v", err)
+	}
+	purgeCaches()
+	if err := VerifyBlobKZGProof(blob, commitment, proof); err != nil {
+		t.Fatalf("failed to verify valid proof: %v", err)
+	}
+	if !verificationCache.Contains(verificationKey(hash, commitment, proof)) {
+		t.Fatalf("valid proof verification not cached")
+	}
+	if cached, ok := commitmentCache.Get(hash); !ok || cached.(KZGCommitment) != commitment {
+		t.Fatalf("commitment of verified blob not cached")
+	}
+	if have, err := BlobToKZGCommitment(blob); err != nil || have != commitment {
+		t.Fatalf("cached commitment mismatch: have %x, %v, want %x", have, err, commitment)
+	}
+	// Failed verifications must be cached too, without caching the commitment
+	tampered := *blob
+	tampered[31] ^= 0x01
+	for i := 0; i < 2; i++ {
+		if err := VerifyBlobKZGProof(&tampered, commitment, proof); err != ErrProofMismatch {
+			t.Fatalf("attempt %d: tampered blob: have %v, want %v", i, err, ErrProofMismatch)
+		}
+	}
+	if !verificationCache.Contains(verificationKey(blobHash(&tampered), commitment, proof)) {
+		t.Fatalf("invalid proof verification not cached")
+	}
+	if commitmentCache.Contains(blobHash(&tampered)) {
+		t.Fatalf("commitment of unverified blob cached")
+	}
+}
+
+func TestBlobValidate(t *testing.T) {
+	modulus := BLSModulus.Bytes()
+	below := new(big.Int).Sub(BLSModulus, common.Big1).Bytes()
+	above := new(big.Int).Add(BLSModulus, common.Big1).Bytes()
+
+	tests := []struct {
+		elem  []byte
+		valid bool
+	}{
+		{nil, true},
+		{below, true},
+		{modulus, false},
+		{above, false},
+		{bytes.Repeat([]byte{0xff}, 32), false},
+		// Differing only in the least significant limb from the modulus
+		{append(common.CopyBytes(modulus[:24]), make([]byte, 8)...), true},
+		{append(common.CopyBytes(modulus[:24]), bytes.Repeat([]byte{0xff}, 8)...), false},
+	}
+	for i, tt := range tests {
+		// Place the element last in the blob, so the whole blob is scanned
+		var blob Blob
+		copy(blob[len(blob)-len(tt.elem):], tt.elem)
+
+		err := blob.Validate()
+		if tt.valid && err != nil {
+			t.Errorf("test %d: valid blob rejected: %v", i, err)
+		}
+		if !tt.valid && err != ErrInvalidFieldElement {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, ErrInvalidFieldElement)
+		}
+		// The limb comparison must agree with the big integer one
+		var elem [32]byte
+		copy(elem[32-len(tt.elem):], tt.elem)
+		if have, want := isCanonicalFieldElement(elem[:]), new(big.Int).SetBytes(elem[:]).Cmp(BLSModulus) < 0; have != want {
+			t.Errorf("test %d: canonicality mismatch: have %v, want %v", i, have, want)
+		}
+	}
+}
+
+func BenchmarkBlobValidate(b *testing.B) {
+	var blob Blob
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		BLSModulus.FillBytes(blob[i*32 : (i+1)*32])
+		blob[i*32+31]-- // largest canonical element, forcing all limbs to be compared
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := blob.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifyBlobKZGProofBatch(b *testing.B) {
+	var (
+		blobs       = make([]Blob, 4)
+		commitments = make([]KZGCommitment, 4)
+		proofs      = make([]KZGProof, 4)
+		indices     = []int{0, 1, 2, 3}
+	)
+	for i := range blobs {
+		blobs[i] = *makeTestBlob([]*big.Int{big.NewInt(int64(i)), big.NewInt(1)})
+		commitments[i], _ = BlobToKZGCommitment(&blobs[i])
+		proofs[i], _ = ComputeBlobKZGProof(&blobs[i], commitments[i])
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := verifyBlobKZGProofBatch(blobs, commitments, proofs, indices); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBlobDataEncoding(t *testing.T) {
+	for _, size := range []int{0, 1, 27, 28, 31, 32, 1000, MaxBlobDataSize} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i) | 0x80 // set the top bit to catch modulus overflows
+		}
+		var blob Blob
+		if err := blob.EncodeData(data); err != nil {
+			t.Fatalf("size %d: failed to encode data: %v", size, err)
+		}
+		if _, err := blobToPolynomial(&blob); err != nil {
+			t.Fatalf("size %d: encoded blob invalid: %v", size, err)
+		}
+		have, err := blob.DecodeData()
+		if err != nil {
+			t.Fatalf("size %d: failed to decode data: %v", size, err)
+		}
+		if !bytes.Equal(have, data) {
+			t.Fatalf("size %d: data mismatch after round trip", size)
+		}
+	}
+	// The empty blob holds an empty payload
+	if data, err := new(Blob).DecodeData(); err != nil || len(data) != 0 {
+		t.Fatalf("empty blob decoding mismatch: have %x, %v", data, err)
+	}
+	// Oversized payloads and malformed blobs must be rejected
+	if err := new(Blob).EncodeData(make([]byte, MaxBlobDataSize+1)); err != ErrBlobDataTooLarge {
+		t.Fatalf("oversized data error mismatch: have %v, want %v", err, ErrBlobDataTooLarge)
+	}
+	var blob Blob
+	if err := blob.EncodeData([]byte{0x01, 0x02}); err != nil {
+		t.Fatalf("failed to encode data: %v", err)
+	}
+	for name, tamper := range map[string]func(b *Blob){
+		"top byte": func(b *Blob) { b[32] = 0x01 },
+		"padding":  func(b *Blob) { b[len(b)-1] = 0x01 },
+		"length":   func(b *Blob) { b[1] = 0xff },
+	} {
+		tampered := blob
+		tamper(&tampered)
+		if _, err := tampered.DecodeData(); err != ErrInvalidBlobData {
+			t.Errorf("%s: error mismatch: have %v, want %v", name, err, ErrInvalidBlobData)
+		}
+	}
+}
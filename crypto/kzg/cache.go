@@ -0,0 +1,73 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"crypto/sha256"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	commitmentCacheLimit   = 1024 // Number of blob commitments to keep around
+	verificationCacheLimit = 1024 // Number of blob proof verification results to keep around
+)
+
+var (
+	// commitmentCache maps the hash of a blob's contents to the commitment to it,
+	// so retried transactions and duplicate gossip don't commit to blobs anew.
+	commitmentCache, _ = lru.New(commitmentCacheLimit)
+
+	// verificationCache maps the hash of a blob, commitment and proof triplet to
+	// the outcome of verifying the proof, so sidecars seen by the transaction pool
+	// don't need to be verified again when importing the block including them.
+	verificationCache, _ = lru.New(verificationCacheLimit)
+)
+
+// blobHash returns the hash of the blob's contents used as cache key.
+func blobHash(blob *Blob) [32]byte {
+	return sha256.Sum256(blob[:])
+}
+
+// verificationKey returns the cache key of the verification of a proof that
+// the given commitment commits to the blob with the given contents hash.
+func verificationKey(hash [32]byte, commitment KZGCommitment, proof KZGProof) [32]byte {
+	h := sha256.New()
+	h.Write(hash[:])
+	h.Write(commitment[:])
+	h.Write(proof[:])
+
+	var key [32]byte
+	h.Sum(key[:0])
+	return key
+}
+
+// cacheVerification records the outcome of verifying a blob proof, caching the
+// commitment too if it turned out to commit to the blob.
+func cacheVerification(hash [32]byte, key [32]byte, commitment KZGCommitment, err error) {
+	verificationCache.Add(key, err)
+	if err == nil {
+		commitmentCache.Add(hash, commitment)
+	}
+}
+
+// purgeCaches drops all cached commitments and verification results, which
+// are tied to the trusted setup they were computed with.
+func purgeCaches() {
+	commitmentCache.Purge()
+	verificationCache.Purge()
+}
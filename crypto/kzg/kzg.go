@@ -0,0 +1,289 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package kzg implements the KZG polynomial commitment operations needed by
+// EIP-4844 shard blob transactions, on top of the BLS12-381 curve.
+package kzg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// BlobCommitmentVersionKZG is the version byte of versioned hashes derived
+// from KZG commitments.
+const BlobCommitmentVersionKZG uint8 = 0x01
+
+// FieldElementsPerBlob is the number of field elements a blob is made of.
+const FieldElementsPerBlob = 4096
+
+const (
+	// dataBytesPerFieldElement is the number of payload bytes packed into each
+	// field element. The top byte is left zero to stay below the modulus.
+	dataBytesPerFieldElement = 31
+
+	// dataLengthSize is the size of the payload length prefix packed in front
+	// of the payload.
+	dataLengthSize = 4
+)
+
+// MaxBlobDataSize is the maximum size of a payload that fits into a blob via
+// Blob.EncodeData.
+const MaxBlobDataSize = FieldElementsPerBlob*dataBytesPerFieldElement - dataLengthSize
+
+// BLSModulus is the order of the BLS12-381 scalar field. Every field element
+// committed to or evaluated at must be smaller than it.
+var BLSModulus = bls12381.NewG1().Q()
+
+var (
+	ErrInvalidFieldElement = errors.New("invalid field element")
+	ErrInvalidCommitment   = errors.New("invalid kzg commitment")
+	ErrInvalidProof        = errors.New("invalid kzg proof")
+	ErrProofMismatch       = errors.New("kzg proof does not match the commitment")
+	ErrBatchLengthMismatch = errors.New("mismatching number of blobs, commitments and proofs")
+	ErrBlobDataTooLarge    = errors.New("blob data too large")
+	ErrInvalidBlobData     = errors.New("invalid blob data encoding")
+)
+
+// BlobError is an error concerning a single blob of a batch, identifying the
+// offending blob by its index within the batch.
+type BlobError struct {
+	Index int   // Index of the rejected blob
+	Err   error // Reason the blob was rejected
+}
+
+func (e *BlobError) Error() string { return fmt.Sprintf("blob %d: %v", e.Index, e.Err) }
+func (e *BlobError) Unwrap() error { return e.Err }
+
+// Blob is the data of a shard blob: the evaluations of a polynomial over the
+// roots of unity, each encoded as a 32 byte big-endian field element.
+type Blob [FieldElementsPerBlob * 32]byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Blob) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(b[:]).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Blob) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("Blob", input, b[:])
+}
+
+// Validate checks that every field element of the blob is canonical, that is
+// smaller than the BLS modulus. It's much cheaper than committing to the blob
+// or verifying a proof for it, so blobs received from the network should be
+// validated before any KZG work is done on them.
+func (b *Blob) Validate() error {
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if !isCanonicalFieldElement(b[i*32 : (i+1)*32]) {
+			return ErrInvalidFieldElement
+		}
+	}
+	return nil
+}
+
+// EncodeData packs an arbitrary payload into the blob, replacing its previous
+// contents. The payload is prefixed with its length as a 4 byte big-endian
+// integer, and the result is split into 31 byte chunks, each one stored in the
+// low bytes of a field element. This keeps every field element below the
+// modulus, and allows DecodeData to recover the exact payload.
+func (b *Blob) EncodeData(data []byte) error {
+	if len(data) > MaxBlobDataSize {
+		return ErrBlobDataTooLarge
+	}
+	buf := make([]byte, dataLengthSize+len(data))
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	copy(buf[dataLengthSize:], data)
+
+	*b = Blob{}
+	for i := 0; len(buf) > 0; i++ {
+		n := copy(b[i*32+1:(i+1)*32], buf)
+		buf = buf[n:]
+	}
+	return nil
+}
+
+// DecodeData unpacks the payload stored in the blob by EncodeData. It rejects
+// blobs not encoded that way, including ones with non-zero padding.
+func (b *Blob) DecodeData() ([]byte, error) {
+	buf := make([]byte, FieldElementsPerBlob*dataBytesPerFieldElement)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if b[i*32] != 0 {
+			return nil, ErrInvalidBlobData
+		}
+		copy(buf[i*dataBytesPerFieldElement:], b[i*32+1:(i+1)*32])
+	}
+	size := binary.BigEndian.Uint32(buf)
+	if size > MaxBlobDataSize {
+		return nil, ErrInvalidBlobData
+	}
+	data, padding := buf[dataLengthSize:dataLengthSize+size], buf[dataLengthSize+size:]
+	for _, x := range padding {
+		if x != 0 {
+			return nil, ErrInvalidBlobData
+		}
+	}
+	return data, nil
+}
+
+// KZGCommitment is a compressed BLS12-381 G1 point committing to a polynomial.
+type KZGCommitment [48]byte
+
+// Point decodes the commitment into a G1 point, checking that it lies in the
+// correct subgroup. The pairing checks verifying proofs are only sound for
+// points of the subgroup, so commitments received from the network must always
+// be decoded through it.
+func (c KZGCommitment) Point() (*bls12381.PointG1, error) {
+	p, err := bls12381.NewG1().FromCompressed(c[:])
+	if err != nil {
+		return nil, ErrInvalidCommitment
+	}
+	return p, nil
+}
+
+// ComputeVersionedHash returns the versioned hash the commitment is referenced
+// by from within the EVM.
+func (c KZGCommitment) ComputeVersionedHash() common.Hash {
+	h := common.Hash(sha256.Sum256(c[:]))
+	h[0] = BlobCommitmentVersionKZG
+	return h
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c KZGCommitment) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(c[:]).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *KZGCommitment) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("KZGCommitment", input, c[:])
+}
+
+// KZGProof is a compressed BLS12-381 G1 point proving the evaluation of a
+// committed polynomial at some point.
+type KZGProof [48]byte
+
+// Point decodes the proof into a G1 point, checking that it lies in the
+// correct subgroup.
+func (p KZGProof) Point() (*bls12381.PointG1, error) {
+	pt, err := bls12381.NewG1().FromCompressed(p[:])
+	if err != nil {
+		return nil, ErrInvalidProof
+	}
+	return pt, nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p KZGProof) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(p[:]).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *KZGProof) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("KZGProof", input, p[:])
+}
+
+// ReadFieldElement interprets the given 32 bytes as a big-endian scalar field
+// element, rejecting values that are not smaller than the BLS modulus.
+func ReadFieldElement(in [32]byte) (*big.Int, error) {
+	if !isCanonicalFieldElement(in[:]) {
+		return nil, ErrInvalidFieldElement
+	}
+	return new(big.Int).SetBytes(in[:]), nil
+}
+
+// blsModulusLimbs is the BLS modulus split into big-endian 64 bit limbs, most
+// significant limb first.
+var blsModulusLimbs = func() (limbs [4]uint64) {
+	var enc [32]byte
+	BLSModulus.FillBytes(enc[:])
+	for i := range limbs {
+		limbs[i] = binary.BigEndian.Uint64(enc[i*8:])
+	}
+	return limbs
+}()
+
+// isCanonicalFieldElement reports whether the given 32 big-endian bytes encode
+// a value smaller than the BLS modulus. It compares the value limb by limb, so
+// it doesn't allocate and usually returns after the most significant limb.
+func isCanonicalFieldElement(elem []byte) bool {
+	for i, mod := range blsModulusLimbs {
+		if limb := binary.BigEndian.Uint64(elem[i*8:]); limb != mod {
+			return limb < mod
+		}
+	}
+	return false // equal to the modulus
+}
+
+// VerifyKZGProof checks that proof attests to p(z) = y for the polynomial p
+// committed to by commitment. Both z and y are big-endian field elements.
+func VerifyKZGProof(commitment KZGCommitment, z, y [32]byte, proof KZGProof) (err error) {
+	defer pointVerifyTimer.UpdateSince(time.Now())
+	defer func() { pointVerifyFailures.mark(err) }()
+
+	zFr, err := ReadFieldElement(z)
+	if err != nil {
+		return err
+	}
+	yFr, err := ReadFieldElement(y)
+	if err != nil {
+		return err
+	}
+	c, err := commitment.Point()
+	if err != nil {
+		return err
+	}
+	pi, err := proof.Point()
+	if err != nil {
+		return err
+	}
+	if !verifyKZGProof(c, zFr, yFr, pi) {
+		return ErrProofMismatch
+	}
+	return nil
+}
+
+// verifyKZGProof runs the pairing check e(C - [y], [1]) = e(π, [s - z]) against
+// the trusted setup.
+func verifyKZGProof(commitment *bls12381.PointG1, z, y *big.Int, proof *bls12381.PointG1) bool {
+	g1, g2 := bls12381.NewG1(), bls12381.NewG2()
+
+	// [s - z]₂
+	sMinusZ := g2.New()
+	g2.MulScalar(sMinusZ, g2.One(), z)
+	g2.Sub(sMinusZ, kzgSetupG2[1], sMinusZ)
+
+	// C - [y]₁
+	cMinusY := g1.New()
+	g1.MulScalar(cMinusY, g1.One(), y)
+	g1.Sub(cMinusY, commitment, cMinusY)
+
+	// -π
+	negPi := g1.Neg(g1.New(), proof)
+
+	return backend.PairingCheck(
+		[]*bls12381.PointG1{cMinusY, negPi},
+		[]*bls12381.PointG2{g2.One(), sMinusZ},
+	)
+}
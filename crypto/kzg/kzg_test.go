@@ -0,0 +1,195 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// evalPoly evaluates the polynomial with the given coefficients at x.
+func evalPoly(coeffs []*big.Int, x *big.Int) *big.Int {
+	res := new(big.Int)
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res.Mul(res, x)
+		res.Add(res, coeffs[i])
+		res.Mod(res, BLSModulus)
+	}
+	return res
+}
+
+// makeTestProof commits to the given polynomial and opens it at z, relying on
+// knowledge of the insecure setup secret.
+func makeTestProof(coeffs []*big.Int, z *big.Int) (KZGCommitment, [32]byte, KZGProof) {
+	g1 := bls12381.NewG1()
+
+	ps := evalPoly(coeffs, insecureSecret)
+	y := evalPoly(coeffs, z)
+
+	// q(s) = (p(s) - y) / (s - z)
+	num := new(big.Int).Sub(ps, y)
+	den := new(big.Int).Sub(insecureSecret, z)
+	den.Mod(den, BLSModulus)
+	qs := new(big.Int).Mul(num, new(big.Int).ModInverse(den, BLSModulus))
+	qs.Mod(qs, BLSModulus)
+
+	var (
+		commitment KZGCommitment
+		proof      KZGProof
+		yBytes     [32]byte
+	)
+	copy(commitment[:], g1.ToCompressed(g1.MulScalar(g1.New(), g1.One(), ps)))
+	copy(proof[:], g1.ToCompressed(g1.MulScalar(g1.New(), g1.One(), qs)))
+	y.FillBytes(yBytes[:])
+	return commitment, yBytes, proof
+}
+
+func TestVerifyKZGProof(t *testing.T) {
+	coeffs := []*big.Int{big.NewInt(12), big.NewInt(34), big.NewInt(56), big.NewInt(78)}
+	z := big.NewInt(1337)
+	var zBytes [32]byte
+	z.FillBytes(zBytes[:])
+
+	commitment, y, proof := makeTestProof(coeffs, z)
+	if err := VerifyKZGProof(commitment, zBytes, y, proof); err != nil {
+		t.Fatalf("failed to verify valid proof: %v", err)
+	}
+	// Tamper with the claimed evaluation
+	wrongY := y
+	wrongY[31] ^= 0x01
+	if err := VerifyKZGProof(commitment, zBytes, wrongY, proof); err != ErrProofMismatch {
+		t.Fatalf("wrong evaluation: have %v, want %v", err, ErrProofMismatch)
+	}
+	// Evaluation point out of range
+	var outOfRange [32]byte
+	BLSModulus.FillBytes(outOfRange[:])
+	if err := VerifyKZGProof(commitment, outOfRange, y, proof); err != ErrInvalidFieldElement {
+		t.Fatalf("out of range point: have %v, want %v", err, ErrInvalidFieldElement)
+	}
+	// Garbage commitment
+	var garbage KZGCommitment
+	if err := VerifyKZGProof(garbage, zBytes, y, proof); err != ErrInvalidCommitment {
+		t.Fatalf("garbage commitment: have %v, want %v", err, ErrInvalidCommitment)
+	}
+}
+
+// Tests that point evaluation and blob proofs accumulated into a batch are
+// verified at once, and that a single invalid proof fails the whole batch.
+func TestBatch(t *testing.T) {
+	if err := NewBatch().Verify(); err != nil {
+		t.Fatalf("empty batch failed: %v", err)
+	}
+	var (
+		batch = NewBatch()
+		zs    = make([][32]byte, 3)
+		ys    = make([][32]byte, 3)
+		cs    = make([]KZGCommitment, 3)
+		pis   = make([]KZGProof, 3)
+	)
+	for i := range zs {
+		z := big.NewInt(int64(1000 + i))
+		z.FillBytes(zs[i][:])
+		cs[i], ys[i], pis[i] = makeTestProof([]*big.Int{big.NewInt(int64(i)), big.NewInt(2), big.NewInt(3)}, z)
+		if err := batch.AddKZGProof(cs[i], zs[i], ys[i], pis[i]); err != nil {
+			t.Fatalf("proof %d: failed to add: %v", i, err)
+		}
+	}
+	blob := makeTestBlob([]*big.Int{big.NewInt(5), big.NewInt(6)})
+	commitment, err := BlobToKZGCommitment(blob)
+	if err != nil {
+		t.Fatalf("failed to commit to blob: %v", err)
+	}
+	proof, err := ComputeBlobKZGProof(blob, commitment)
+	if err != nil {
+		t.Fatalf("failed to compute blob proof: %v", err)
+	}
+	if err := batch.AddBlobKZGProof(blob, commitment, proof); err != nil {
+		t.Fatalf("failed to add blob proof: %v", err)
+	}
+	if batch.Len() != 4 {
+		t.Fatalf("batch length mismatch: have %d, want %d", batch.Len(), 4)
+	}
+	if err := batch.Verify(); err != nil {
+		t.Fatalf("failed to verify valid batch: %v", err)
+	}
+	// A single wrong evaluation must fail the batch
+	wrongY := ys[1]
+	wrongY[31] ^= 0x01
+	if err := batch.AddKZGProof(cs[1], zs[1], wrongY, pis[1]); err != nil {
+		t.Fatalf("failed to add proof: %v", err)
+	}
+	if err := batch.Verify(); err != ErrProofMismatch {
+		t.Fatalf("invalid batch: have %v, want %v", err, ErrProofMismatch)
+	}
+	// Malformed inputs are rejected when added
+	var outOfRange [32]byte
+	BLSModulus.FillBytes(outOfRange[:])
+	if err := NewBatch().AddKZGProof(cs[0], outOfRange, ys[0], pis[0]); err != ErrInvalidFieldElement {
+		t.Fatalf("out of range point: have %v, want %v", err, ErrInvalidFieldElement)
+	}
+	if err := NewBatch().AddBlobKZGProof(blob, KZGCommitment(offSubgroupPoint), proof); err != ErrInvalidCommitment {
+		t.Fatalf("off-subgroup commitment: have %v, want %v", err, ErrInvalidCommitment)
+	}
+}
+
+// offSubgroupPoint is the compressed encoding of the G1 point with x = 4, which
+// lies on the curve but not in the prime order subgroup.
+var offSubgroupPoint = [48]byte{0x80, 47: 0x04}
+
+// Tests that commitments and proofs outside of the G1 subgroup are rejected on
+// decoding, before any verification is attempted with them.
+func TestPointSubgroupCheck(t *testing.T) {
+	_, err := bls12381.NewG1().FromCompressed(offSubgroupPoint[:])
+	if err == nil || !strings.Contains(err.Error(), "subgroup") {
+		t.Fatalf("off-subgroup point: have %v, want subgroup error", err)
+	}
+	if _, err := KZGCommitment(offSubgroupPoint).Point(); err != ErrInvalidCommitment {
+		t.Errorf("off-subgroup commitment: have %v, want %v", err, ErrInvalidCommitment)
+	}
+	if _, err := KZGProof(offSubgroupPoint).Point(); err != ErrInvalidProof {
+		t.Errorf("off-subgroup proof: have %v, want %v", err, ErrInvalidProof)
+	}
+	// Both must also be rejected when verifying blob proofs, with the empty blob
+	// being committed to and opened by the point at infinity
+	var (
+		blob     Blob
+		infinity = [48]byte{0xc0}
+	)
+	if err := VerifyBlobKZGProof(&blob, KZGCommitment(infinity), KZGProof(infinity)); err != nil {
+		t.Fatalf("failed to verify empty blob: %v", err)
+	}
+	if err := VerifyBlobKZGProof(&blob, KZGCommitment(offSubgroupPoint), KZGProof(infinity)); err != ErrInvalidCommitment {
+		t.Errorf("off-subgroup blob commitment: have %v, want %v", err, ErrInvalidCommitment)
+	}
+	if err := VerifyBlobKZGProof(&blob, KZGCommitment(infinity), KZGProof(offSubgroupPoint)); err != ErrInvalidProof {
+		t.Errorf("off-subgroup blob proof: have %v, want %v", err, ErrInvalidProof)
+	}
+}
+
+func TestComputeVersionedHash(t *testing.T) {
+	var commitment KZGCommitment
+	commitment[0] = 0xc0 // point at infinity
+
+	want := common.HexToHash("0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014")
+	if have := commitment.ComputeVersionedHash(); have != want {
+		t.Fatalf("versioned hash mismatch: have %x, want %x", have, want)
+	}
+}
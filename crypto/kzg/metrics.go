@@ -0,0 +1,71 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Contains the metrics collected by the KZG proof verification.
+
+package kzg
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	blobVerifyTimer  = metrics.NewRegisteredTimer("kzg/verify/blob", nil)  // Blob proof verifications, also tracking their rate
+	pointVerifyTimer = metrics.NewRegisteredTimer("kzg/verify/point", nil) // Point evaluation verifications
+	batchVerifyTimer = metrics.NewRegisteredTimer("kzg/verify/batch", nil) // Combined checks of batched proofs
+
+	batchSizeHistogram = metrics.NewRegisteredHistogram("kzg/verify/batch/size", nil, metrics.NewExpDecaySample(1028, 0.015)) // Blobs per batch verification
+	batchFailMeter     = metrics.NewRegisteredMeter("kzg/verify/batch/fail", nil)                                             // Combined checks failing, falling back to single verifications
+
+	blobVerifyFailures  = newFailureMeters("kzg/verify/blob/fail")  // Blob proof verification failures, by reason
+	pointVerifyFailures = newFailureMeters("kzg/verify/point/fail") // Point evaluation verification failures, by reason
+
+	commitmentCacheHitMeter    = metrics.NewRegisteredMeter("kzg/cache/commitment/hit", nil)    // Blob commitments found in the cache
+	commitmentCacheMissMeter   = metrics.NewRegisteredMeter("kzg/cache/commitment/miss", nil)   // Blob commitments computed anew
+	verificationCacheHitMeter  = metrics.NewRegisteredMeter("kzg/cache/verification/hit", nil)  // Blob proof verifications found in the cache
+	verificationCacheMissMeter = metrics.NewRegisteredMeter("kzg/cache/verification/miss", nil) // Blob proof verifications done anew
+)
+
+// failureMeters counts verification failures by their reason.
+type failureMeters struct {
+	field      metrics.Meter // Non-canonical field elements
+	commitment metrics.Meter // Commitments not decoding to a subgroup point
+	proof      metrics.Meter // Proofs not decoding to a subgroup point
+	mismatch   metrics.Meter // Proofs failing the pairing check
+}
+
+func newFailureMeters(prefix string) *failureMeters {
+	return &failureMeters{
+		field:      metrics.NewRegisteredMeter(prefix+"/field", nil),
+		commitment: metrics.NewRegisteredMeter(prefix+"/commitment", nil),
+		proof:      metrics.NewRegisteredMeter(prefix+"/proof", nil),
+		mismatch:   metrics.NewRegisteredMeter(prefix+"/mismatch", nil),
+	}
+}
+
+// mark counts the given verification outcome, if it's a failure.
+func (m *failureMeters) mark(err error) {
+	switch err {
+	case ErrInvalidFieldElement:
+		m.field.Mark(1)
+	case ErrInvalidCommitment:
+		m.commitment.Mark(1)
+	case ErrInvalidProof:
+		m.proof.Mark(1)
+	case ErrProofMismatch:
+		m.mismatch.Mark(1)
+	}
+}
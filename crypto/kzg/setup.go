@@ -0,0 +1,91 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// insecureSecret is the toxic waste of the development trusted setup. It is
+// public, so commitments made against this setup are not binding: it must
+// only be used for testing and devnets until a ceremony output is available.
+var insecureSecret, _ = new(big.Int).SetString("1927409816240961209460912649124", 10)
+
+// kzgSetupG2 holds [s^i]₂ for i in [0, 1], which is all that verifying
+// single-point evaluation proofs requires.
+var kzgSetupG2 []*bls12381.PointG2
+
+func init() {
+	g2 := bls12381.NewG2()
+	kzgSetupG2 = []*bls12381.PointG2{
+		g2.One(),
+		g2.MulScalar(g2.New(), g2.One(), insecureSecret),
+	}
+}
+
+var (
+	kzgSetupLagrange     []*bls12381.PointG1
+	kzgSetupLagrangeOnce sync.Once
+
+	// verifyOnly is set if only the verification part of the setup is loaded,
+	// in which case the Lagrange basis is unavailable.
+	verifyOnly bool
+)
+
+// lagrangeSetupG1 returns [L_i(s)]₁ for the Lagrange basis polynomials L_i of
+// the blob evaluation domain, which committing to blobs requires. Deriving them
+// takes a while, so it is deferred until first needed.
+func lagrangeSetupG1() []*bls12381.PointG1 {
+	kzgSetupLagrangeOnce.Do(func() {
+		kzgSetupLagrange = secretLagrangeSetupG1(insecureSecret, rootsOfUnity)
+	})
+	return kzgSetupLagrange
+}
+
+// secretLagrangeSetupG1 derives [L_i(s)]₁ from a known secret s, for the Lagrange
+// basis polynomials L_i of the evaluation domain made up of the given roots of
+// unity, in the order given.
+func secretLagrangeSetupG1(secret *big.Int, roots []*big.Int) []*bls12381.PointG1 {
+	var (
+		n     = big.NewInt(int64(len(roots)))
+		denom = make([]*big.Int, len(roots))
+	)
+	// L_i(s) = (s^N - 1) / N * w_i / (s - w_i)
+	for i, root := range roots {
+		denom[i] = new(big.Int).Sub(secret, root)
+		denom[i].Mod(denom[i], BLSModulus)
+	}
+	invs := batchInverse(denom)
+
+	factor := new(big.Int).Exp(secret, n, BLSModulus)
+	factor.Sub(factor, big.NewInt(1))
+	factor.Mul(factor, new(big.Int).ModInverse(n, BLSModulus))
+	factor.Mod(factor, BLSModulus)
+
+	g1 := bls12381.NewG1()
+	points := make([]*bls12381.PointG1, len(roots))
+	for i, root := range roots {
+		l := new(big.Int).Mul(factor, root)
+		l.Mul(l, invs[i])
+		l.Mod(l, BLSModulus)
+		points[i] = g1.Affine(g1.MulScalar(g1.New(), g1.One(), l))
+	}
+	return points
+}
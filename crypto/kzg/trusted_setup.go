@@ -0,0 +1,395 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	mrand "math/rand"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+var (
+	ErrInvalidSetupSize   = errors.New("invalid trusted setup size")
+	ErrInvalidSetupSecret = errors.New("invalid trusted setup secret")
+	ErrInconsistentSetup  = errors.New("inconsistent trusted setup")
+	ErrVerifyOnly         = errors.New("not supported in verify-only mode")
+)
+
+// TrustedSetup is the serialized form of a KZG trusted setup. It holds the
+// compressed powers [s^i]₁ and [s^i]₂ of the setup secret s, along with the
+// G1 Lagrange basis [L_i(s)]₁ over the evaluation domain made up of as many
+// roots of unity as there are G1 powers, in bit-reversed order.
+type TrustedSetup struct {
+	G1Monomial []hexutil.Bytes `json:"g1Monomial"`
+	G1Lagrange []hexutil.Bytes `json:"g1Lagrange"`
+	G2Monomial []hexutil.Bytes `json:"g2Monomial"`
+}
+
+// NewInsecureTrustedSetup generates a trusted setup with g1Count G1 powers and
+// g2Count G2 powers from a known secret. Anyone knowing the secret can forge
+// proofs, so the setup must only be used for testing and devnets.
+func NewInsecureTrustedSetup(secret *big.Int, g1Count, g2Count int) (*TrustedSetup, error) {
+	if !validSetupSize(g1Count, g2Count) {
+		return nil, ErrInvalidSetupSize
+	}
+	// The secret must not be a root of unity of the domain, nor zero
+	s := new(big.Int).Mod(secret, BLSModulus)
+	if s.Sign() == 0 || new(big.Int).Exp(s, big.NewInt(int64(g1Count)), BLSModulus).Cmp(big.NewInt(1)) == 0 {
+		return nil, ErrInvalidSetupSecret
+	}
+	var (
+		g1    = bls12381.NewG1()
+		g2    = bls12381.NewG2()
+		power = big.NewInt(1)
+		setup = &TrustedSetup{
+			G1Monomial: make([]hexutil.Bytes, g1Count),
+			G1Lagrange: make([]hexutil.Bytes, g1Count),
+			G2Monomial: make([]hexutil.Bytes, g2Count),
+		}
+	)
+	for i := 0; i < g1Count || i < g2Count; i++ {
+		if i < g1Count {
+			setup.G1Monomial[i] = g1.ToCompressed(g1.MulScalar(g1.New(), g1.One(), power))
+		}
+		if i < g2Count {
+			setup.G2Monomial[i] = g2.ToCompressed(g2.MulScalar(g2.New(), g2.One(), power))
+		}
+		power.Mul(power, s)
+		power.Mod(power, BLSModulus)
+	}
+	for i, point := range secretLagrangeSetupG1(s, bitReversedRootsOfUnity(g1Count)) {
+		setup.G1Lagrange[i] = g1.ToCompressed(point)
+	}
+	return setup, nil
+}
+
+// NewTrustedSetup assembles a trusted setup from the compressed powers of the
+// secret, as output by a ceremony, deriving the G1 Lagrange basis from them.
+// The powers are only decoded, not checked for consistency; use Verify for that.
+func NewTrustedSetup(g1Monomial, g2Monomial []hexutil.Bytes) (*TrustedSetup, error) {
+	if !validSetupSize(len(g1Monomial), len(g2Monomial)) {
+		return nil, ErrInvalidSetupSize
+	}
+	points, err := decodeG1Points(g1Monomial)
+	if err != nil {
+		return nil, fmt.Errorf("g1 power %v", err)
+	}
+	if _, err := decodeG2Points(g2Monomial); err != nil {
+		return nil, fmt.Errorf("g2 power %v", err)
+	}
+	setup := &TrustedSetup{
+		G1Monomial: g1Monomial,
+		G1Lagrange: make([]hexutil.Bytes, len(g1Monomial)),
+		G2Monomial: g2Monomial,
+	}
+	g1, lagrange := bls12381.NewG1(), inverseFFTG1(points)
+	for i := range setup.G1Lagrange {
+		setup.G1Lagrange[i] = g1.ToCompressed(lagrange[reverseBits(i, len(lagrange))])
+	}
+	return setup, nil
+}
+
+// Verify checks that the setup is well formed: all points are in the correct
+// subgroups, the powers of the secret are consistent with each other and the G1
+// Lagrange basis matches the G1 powers. Setups distributed without the G1 powers
+// have their Lagrange basis checked against the G2 powers instead. Pairing and
+// multi-exponentiation checks are run on a random sample of indices; if samples
+// is not positive, all of them are checked.
+func (setup *TrustedSetup) Verify(samples int) error {
+	if len(setup.G1Monomial) == 0 {
+		return setup.verifyLagrange(samples)
+	}
+	if !validSetupSize(len(setup.G1Monomial), len(setup.G2Monomial)) || len(setup.G1Lagrange) != len(setup.G1Monomial) {
+		return ErrInvalidSetupSize
+	}
+	g1Monomial, err := decodeG1Points(setup.G1Monomial)
+	if err != nil {
+		return fmt.Errorf("g1 power %v", err)
+	}
+	g1Lagrange, err := decodeG1Points(setup.G1Lagrange)
+	if err != nil {
+		return fmt.Errorf("g1 lagrange point %v", err)
+	}
+	g2Monomial, err := decodeG2Points(setup.G2Monomial)
+	if err != nil {
+		return fmt.Errorf("g2 power %v", err)
+	}
+	e := bls12381.NewPairingEngine()
+	g1, g2 := e.G1, e.G2
+	if !g1.Equal(g1Monomial[0], g1.One()) || !g2.Equal(g2Monomial[0], g2.One()) {
+		return fmt.Errorf("%w: powers do not start at the generators", ErrInconsistentSetup)
+	}
+	// e([s^(i+1)]₁, [1]₂) = e([s^i]₁, [s]₂) for the G1 powers
+	for _, i := range sampleIndices(len(g1Monomial)-1, samples) {
+		e.Reset()
+		e.AddPair(g1Monomial[i+1], g2Monomial[0])
+		e.AddPairInv(g1.New().Set(g1Monomial[i]), g2Monomial[1])
+		if !e.Check() {
+			return fmt.Errorf("%w: g1 power %d is not s times power %d", ErrInconsistentSetup, i+1, i)
+		}
+	}
+	// e([1]₁, [s^(i+1)]₂) = e([s]₁, [s^i]₂) for the G2 powers
+	for _, i := range sampleIndices(len(g2Monomial)-1, samples) {
+		e.Reset()
+		e.AddPair(g1Monomial[0], g2Monomial[i+1])
+		e.AddPairInv(g1.New().Set(g1Monomial[1]), g2Monomial[i])
+		if !e.Check() {
+			return fmt.Errorf("%w: g2 power %d is not s times power %d", ErrInconsistentSetup, i+1, i)
+		}
+	}
+	// Σ w_k^j [L_k(s)]₁ = [s^j]₁, as the Lagrange basis interpolates x^j
+	roots := bitReversedRootsOfUnity(len(g1Lagrange))
+	for _, j := range sampleIndices(len(g1Monomial), samples) {
+		scalars := make([]*big.Int, len(roots))
+		for k, root := range roots {
+			scalars[k] = new(big.Int).Exp(root, big.NewInt(int64(j)), BLSModulus)
+		}
+		sum, err := g1.MultiExp(g1.New(), g1Lagrange, scalars)
+		if err != nil {
+			return err
+		}
+		if !g1.Equal(sum, g1Monomial[j]) {
+			return fmt.Errorf("%w: lagrange basis does not interpolate power %d", ErrInconsistentSetup, j)
+		}
+	}
+	return nil
+}
+
+// verifyLagrange checks a setup without G1 powers: all points have to be in the
+// correct subgroups, the G1 Lagrange basis has to sum up to the generator, like
+// the Lagrange polynomials sum up to one, and each [L_i(s)]₁ has to be related
+// to [L_0(s)]₁ through the G2 powers by
+//
+//	L_i(s)·(s - w_i) = w_i·(s^n - 1)/n = w_i·L_0(s)·(s - 1)
+//
+// for the roots of unity w_i, which also pins down the order of the basis.
+func (setup *TrustedSetup) verifyLagrange(samples int) error {
+	if !validSetupSize(len(setup.G1Lagrange), len(setup.G2Monomial)) {
+		return ErrInvalidSetupSize
+	}
+	g1Lagrange, err := decodeG1Points(setup.G1Lagrange)
+	if err != nil {
+		return fmt.Errorf("g1 lagrange point %v", err)
+	}
+	g2Monomial, err := decodeG2Points(setup.G2Monomial)
+	if err != nil {
+		return fmt.Errorf("g2 power %v", err)
+	}
+	e := bls12381.NewPairingEngine()
+	g1, g2 := e.G1, e.G2
+	if !g2.Equal(g2Monomial[0], g2.One()) {
+		return fmt.Errorf("%w: powers do not start at the generators", ErrInconsistentSetup)
+	}
+	sum := g1.New()
+	for _, point := range g1Lagrange {
+		g1.Add(sum, sum, point)
+	}
+	if !g1.Equal(sum, g1.One()) {
+		return fmt.Errorf("%w: lagrange basis does not sum up to the generator", ErrInconsistentSetup)
+	}
+	// e([L_i(s)]₁, [s - w_i]₂) = e([w_i·L_0(s)]₁, [s - 1]₂)
+	var (
+		roots   = bitReversedRootsOfUnity(len(g1Lagrange))
+		sMinusW = func(w *big.Int) *bls12381.PointG2 {
+			return g2.Sub(g2.New(), g2Monomial[1], g2.MulScalar(g2.New(), g2.One(), w))
+		}
+		sMinusOne = sMinusW(roots[0])
+	)
+	for _, i := range sampleIndices(len(g1Lagrange), samples) {
+		e.Reset()
+		e.AddPair(g1.New().Set(g1Lagrange[i]), sMinusW(roots[i]))
+		e.AddPairInv(g1.MulScalar(g1.New(), g1Lagrange[0], roots[i]), sMinusOne)
+		if !e.Check() {
+			return fmt.Errorf("%w: lagrange point %d does not match the g2 powers", ErrInconsistentSetup, i)
+		}
+	}
+	return nil
+}
+
+// LoadTrustedSetup replaces the insecure development setup with the given one,
+// which must span the blob evaluation domain. Only the G1 Lagrange basis and the
+// first two G2 powers are used. The setup is not checked for consistency, which
+// is expensive; run Verify on it beforehand. LoadTrustedSetup is not safe for
+// concurrent use with commitments and proofs, so it should be called on startup.
+func LoadTrustedSetup(setup *TrustedSetup) error {
+	if len(setup.G1Lagrange) != FieldElementsPerBlob || len(setup.G2Monomial) < 2 {
+		return ErrInvalidSetupSize
+	}
+	g1Lagrange, err := decodeG1Points(setup.G1Lagrange)
+	if err != nil {
+		return fmt.Errorf("g1 lagrange point %v", err)
+	}
+	g2Monomial, err := decodeG2Points(setup.G2Monomial[:2])
+	if err != nil {
+		return fmt.Errorf("g2 power %v", err)
+	}
+	if g2 := bls12381.NewG2(); !g2.Equal(g2Monomial[0], g2.One()) {
+		return fmt.Errorf("%w: powers do not start at the generators", ErrInconsistentSetup)
+	}
+	kzgSetupLagrangeOnce.Do(func() {})
+	kzgSetupLagrange, kzgSetupG2 = g1Lagrange, g2Monomial
+	verifyOnly = false
+	purgeCaches()
+	return nil
+}
+
+// LoadVerificationSetup replaces the insecure development setup with the first
+// two G2 powers of the given one, which is all that verifying proofs requires,
+// and switches to verify-only mode. The G1 points of the setup are ignored and
+// may be omitted. Like LoadTrustedSetup, it should be called on startup.
+func LoadVerificationSetup(setup *TrustedSetup) error {
+	if len(setup.G2Monomial) < 2 {
+		return ErrInvalidSetupSize
+	}
+	g2Monomial, err := decodeG2Points(setup.G2Monomial[:2])
+	if err != nil {
+		return fmt.Errorf("g2 power %v", err)
+	}
+	if g2 := bls12381.NewG2(); !g2.Equal(g2Monomial[0], g2.One()) {
+		return fmt.Errorf("%w: powers do not start at the generators", ErrInconsistentSetup)
+	}
+	kzgSetupG2 = g2Monomial
+	EnableVerifyOnly()
+	purgeCaches()
+	return nil
+}
+
+// EnableVerifyOnly switches to verify-only mode for nodes which verify blobs but
+// never commit to them: the G1 Lagrange basis of the current setup is dropped,
+// or never derived, and computing commitments and proofs fails with
+// ErrVerifyOnly. Loading a full setup with LoadTrustedSetup leaves the mode.
+func EnableVerifyOnly() {
+	kzgSetupLagrangeOnce.Do(func() {})
+	kzgSetupLagrange = nil
+	verifyOnly = true
+}
+
+// IsVerifyOnly reports whether computing commitments and proofs is disabled.
+func IsVerifyOnly() bool {
+	return verifyOnly
+}
+
+// validSetupSize reports whether a setup with the given number of powers can be
+// used for committing and verifying: the G1 powers have to span an evaluation
+// domain with a power of two size, and proofs need [s]₂.
+func validSetupSize(g1Count, g2Count int) bool {
+	return g1Count >= 2 && g1Count&(g1Count-1) == 0 && g2Count >= 2
+}
+
+// bitReversedRootsOfUnity returns the n-th roots of unity in bit-reversed order,
+// the order evaluation domains are laid out in.
+func bitReversedRootsOfUnity(n int) []*big.Int {
+	roots := computeRootsOfUnity(n)
+
+	reversed := make([]*big.Int, n)
+	for i := range reversed {
+		reversed[i] = roots[reverseBits(i, n)]
+	}
+	return reversed
+}
+
+// inverseFFTG1 computes [L_k(s)]₁ = 1/n Σ w^(-jk) [s^j]₁ for the n-th roots of
+// unity w^k in their natural order, given the powers [s^j]₁ for j < n.
+func inverseFFTG1(powers []*bls12381.PointG1) []*bls12381.PointG1 {
+	var (
+		g1    = bls12381.NewG1()
+		n     = len(powers)
+		roots = computeRootsOfUnity(n)
+		out   = make([]*bls12381.PointG1, n)
+	)
+	for i := range out {
+		out[i] = g1.New().Set(powers[reverseBits(i, n)])
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				// Twiddle factor w^(-j*step), with w^(-k) = w^(n-k)
+				t := g1.New().Set(out[start+j+half])
+				if k := j * step; k != 0 {
+					g1.MulScalar(t, t, roots[n-k])
+				}
+				g1.Sub(out[start+j+half], out[start+j], t)
+				g1.Add(out[start+j], out[start+j], t)
+			}
+		}
+	}
+	nInv := new(big.Int).ModInverse(big.NewInt(int64(n)), BLSModulus)
+	for i := range out {
+		g1.Affine(g1.MulScalar(out[i], out[i], nInv))
+	}
+	return out
+}
+
+// decodeG1Points decompresses a list of G1 points, checking subgroup membership.
+func decodeG1Points(encs []hexutil.Bytes) ([]*bls12381.PointG1, error) {
+	g1 := bls12381.NewG1()
+	points := make([]*bls12381.PointG1, len(encs))
+	for i, enc := range encs {
+		p, err := g1.FromCompressed(enc)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %v", i, err)
+		}
+		points[i] = p
+	}
+	return points, nil
+}
+
+// decodeG2Points decompresses a list of G2 points, checking subgroup membership.
+func decodeG2Points(encs []hexutil.Bytes) ([]*bls12381.PointG2, error) {
+	g2 := bls12381.NewG2()
+	points := make([]*bls12381.PointG2, len(encs))
+	for i, enc := range encs {
+		p, err := g2.FromCompressed(enc)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %v", i, err)
+		}
+		points[i] = p
+	}
+	return points, nil
+}
+
+// sampleIndices returns a random selection of samples indices in [0, n), always
+// including the first and the last one. All indices are returned if samples is
+// not positive or not smaller than n.
+func sampleIndices(n, samples int) []int {
+	if samples <= 0 || samples >= n {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+	var seed [8]byte
+	crand.Read(seed[:])
+	rng := mrand.New(mrand.NewSource(int64(binary.BigEndian.Uint64(seed[:]))))
+
+	indices := []int{0, n - 1}
+	for _, i := range rng.Perm(n - 2) {
+		if len(indices) >= samples {
+			break
+		}
+		indices = append(indices, i+1)
+	}
+	return indices
+}
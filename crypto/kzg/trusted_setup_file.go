@@ -0,0 +1,150 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var errUnknownSetupFormat = errors.New("unknown trusted setup format")
+
+// specTrustedSetup is the JSON format of the trusted setups shipped with the
+// consensus specs. Its Lagrange basis is in natural order.
+type specTrustedSetup struct {
+	G1Monomial []hexutil.Bytes `json:"g1_monomial"`
+	G1Lagrange []hexutil.Bytes `json:"g1_lagrange"`
+	G2Monomial []hexutil.Bytes `json:"g2_monomial"`
+}
+
+// ReadTrustedSetupFile reads a trusted setup from the given file, in any of the
+// formats supported by ParseTrustedSetup.
+func ReadTrustedSetupFile(path string) (*TrustedSetup, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTrustedSetup(data)
+}
+
+// ParseTrustedSetup parses a trusted setup in one of the following formats:
+//
+//   - the JSON format of TrustedSetup, as written by the kzgsetup tool
+//   - the JSON format of the consensus specs, with the g1_lagrange, g2_monomial
+//     and optionally g1_monomial fields
+//   - the text format of c-kzg: the number of G1 and of G2 points, followed by
+//     the G1 Lagrange basis and the G2 powers, as hex without prefix, one per line
+//
+// The standard formats hold their Lagrange basis in natural order, which gets
+// bit-reversed. The points are not decoded, use Verify to check them.
+func ParseTrustedSetup(data []byte) (*TrustedSetup, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errUnknownSetupFormat
+	}
+	if data[0] != '{' {
+		return parseTextTrustedSetup(data)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["g1Lagrange"]; ok {
+		setup := new(TrustedSetup)
+		if err := json.Unmarshal(data, setup); err != nil {
+			return nil, err
+		}
+		return setup, nil
+	}
+	if _, ok := fields["g1_lagrange"]; ok {
+		var spec specTrustedSetup
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return nil, err
+		}
+		if len(spec.G1Monomial) != 0 && len(spec.G1Monomial) != len(spec.G1Lagrange) {
+			return nil, ErrInvalidSetupSize
+		}
+		return &TrustedSetup{
+			G1Monomial: spec.G1Monomial,
+			G1Lagrange: bitReversePoints(spec.G1Lagrange),
+			G2Monomial: spec.G2Monomial,
+		}, nil
+	}
+	return nil, errUnknownSetupFormat
+}
+
+// parseTextTrustedSetup parses a trusted setup in the text format of c-kzg.
+func parseTextTrustedSetup(data []byte) (*TrustedSetup, error) {
+	var (
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+		counts  [2]int
+		points  []hexutil.Bytes
+	)
+	for line, values := 1, 0; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if values++; values <= len(counts) {
+			n, err := strconv.Atoi(text)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("line %d: invalid point count %q", line, text)
+			}
+			counts[values-1] = n
+			continue
+		}
+		point, err := hex.DecodeString(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid point: %v", line, err)
+		}
+		points = append(points, point)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(points) != counts[0]+counts[1] {
+		return nil, fmt.Errorf("%w: have %d points, want %d G1 and %d G2 ones", ErrInvalidSetupSize, len(points), counts[0], counts[1])
+	}
+	return &TrustedSetup{
+		G1Lagrange: bitReversePoints(points[:counts[0]]),
+		G2Monomial: points[counts[0]:],
+	}, nil
+}
+
+// bitReversePoints returns the points of a Lagrange basis given in natural order
+// in bit-reversed order, the order evaluation domains are laid out in. Bases not
+// spanning a power of two domain are left as is, to be rejected on use.
+func bitReversePoints(points []hexutil.Bytes) []hexutil.Bytes {
+	n := len(points)
+	if n == 0 || n&(n-1) != 0 {
+		return points
+	}
+	reversed := make([]hexutil.Bytes, n)
+	for i := range reversed {
+		reversed[i] = points[reverseBits(i, n)]
+	}
+	return reversed
+}
@@ -0,0 +1,257 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// Tests that generated setups verify, and that deriving the Lagrange basis from
+// the powers of the secret matches deriving it from the secret itself.
+func TestInsecureTrustedSetup(t *testing.T) {
+	setup, err := NewInsecureTrustedSetup(big.NewInt(1234), 16, 4)
+	if err != nil {
+		t.Fatalf("failed to generate setup: %v", err)
+	}
+	if err := setup.Verify(0); err != nil {
+		t.Fatalf("generated setup failed to verify: %v", err)
+	}
+	if err := setup.Verify(3); err != nil {
+		t.Fatalf("generated setup failed sampled verification: %v", err)
+	}
+	converted, err := NewTrustedSetup(setup.G1Monomial, setup.G2Monomial)
+	if err != nil {
+		t.Fatalf("failed to convert setup: %v", err)
+	}
+	for i := range setup.G1Lagrange {
+		if !bytes.Equal(converted.G1Lagrange[i], setup.G1Lagrange[i]) {
+			t.Errorf("lagrange point %d mismatch: have %x, want %x", i, converted.G1Lagrange[i], setup.G1Lagrange[i])
+		}
+	}
+	// Invalid parameters must be rejected
+	if _, err := NewInsecureTrustedSetup(big.NewInt(1234), 12, 4); err != ErrInvalidSetupSize {
+		t.Errorf("non power of two domain: have %v, want %v", err, ErrInvalidSetupSize)
+	}
+	if _, err := NewInsecureTrustedSetup(big.NewInt(1234), 16, 1); err != ErrInvalidSetupSize {
+		t.Errorf("missing [s]₂: have %v, want %v", err, ErrInvalidSetupSize)
+	}
+	if _, err := NewInsecureTrustedSetup(big.NewInt(1), 16, 4); err != ErrInvalidSetupSecret {
+		t.Errorf("root of unity secret: have %v, want %v", err, ErrInvalidSetupSecret)
+	}
+}
+
+// Tests that inconsistencies in any part of a setup are detected.
+func TestTrustedSetupVerifyInconsistent(t *testing.T) {
+	setup, err := NewInsecureTrustedSetup(big.NewInt(1234), 8, 4)
+	if err != nil {
+		t.Fatalf("failed to generate setup: %v", err)
+	}
+	var (
+		g1    = bls12381.NewG1()
+		g2    = bls12381.NewG2()
+		point = g1.ToCompressed(g1.MulScalar(g1.New(), g1.One(), big.NewInt(42)))
+	)
+	tamper := func(name string, list func(*TrustedSetup) []hexutil.Bytes, index int, enc []byte) {
+		broken := &TrustedSetup{
+			G1Monomial: append([]hexutil.Bytes{}, setup.G1Monomial...),
+			G1Lagrange: append([]hexutil.Bytes{}, setup.G1Lagrange...),
+			G2Monomial: append([]hexutil.Bytes{}, setup.G2Monomial...),
+		}
+		list(broken)[index] = enc
+		if err := broken.Verify(0); !errors.Is(err, ErrInconsistentSetup) {
+			t.Errorf("%s: have %v, want %v", name, err, ErrInconsistentSetup)
+		}
+	}
+	tamper("g1 power", func(s *TrustedSetup) []hexutil.Bytes { return s.G1Monomial }, 5, point)
+	tamper("g1 generator", func(s *TrustedSetup) []hexutil.Bytes { return s.G1Monomial }, 0, point)
+	tamper("g1 lagrange", func(s *TrustedSetup) []hexutil.Bytes { return s.G1Lagrange }, 3, point)
+	tamper("g2 power", func(s *TrustedSetup) []hexutil.Bytes { return s.G2Monomial }, 3, g2.ToCompressed(g2.MulScalar(g2.New(), g2.One(), big.NewInt(42))))
+
+	// Points outside of the subgroup fail decoding
+	broken := *setup
+	broken.G1Lagrange = append([]hexutil.Bytes{}, setup.G1Lagrange...)
+	broken.G1Lagrange[1] = setup.G2Monomial[1][:48]
+	if err := broken.Verify(0); err == nil {
+		t.Errorf("invalid point accepted")
+	}
+}
+
+// Tests that loading a setup only accepts ones spanning the blob domain, and
+// installs the Lagrange basis used for committing.
+func TestLoadTrustedSetup(t *testing.T) {
+	small, err := NewInsecureTrustedSetup(big.NewInt(1234), 16, 2)
+	if err != nil {
+		t.Fatalf("failed to generate setup: %v", err)
+	}
+	if err := LoadTrustedSetup(small); err != ErrInvalidSetupSize {
+		t.Fatalf("undersized setup: have %v, want %v", err, ErrInvalidSetupSize)
+	}
+	// Reload the development setup, which must leave commitments unchanged
+	var (
+		g1    = bls12381.NewG1()
+		g2    = bls12381.NewG2()
+		setup = &TrustedSetup{
+			G1Lagrange: make([]hexutil.Bytes, FieldElementsPerBlob),
+			G2Monomial: []hexutil.Bytes{g2.ToCompressed(g2.New().Set(kzgSetupG2[0])), g2.ToCompressed(g2.New().Set(kzgSetupG2[1]))},
+		}
+	)
+	for i, point := range lagrangeSetupG1() {
+		setup.G1Lagrange[i] = g1.ToCompressed(g1.New().Set(point))
+	}
+	if err := LoadTrustedSetup(setup); err != nil {
+		t.Fatalf("failed to load setup: %v", err)
+	}
+	for i, point := range lagrangeSetupG1() {
+		if !bytes.Equal(g1.ToCompressed(g1.New().Set(point)), setup.G1Lagrange[i]) {
+			t.Fatalf("lagrange point %d mismatch after load", i)
+		}
+	}
+}
+
+// Tests that in verify-only mode proofs are still verified, but commitments and
+// proofs can't be computed, until a full setup is loaded again.
+func TestVerifyOnly(t *testing.T) {
+	var (
+		g1   = bls12381.NewG1()
+		g2   = bls12381.NewG2()
+		full = &TrustedSetup{
+			G1Lagrange: make([]hexutil.Bytes, FieldElementsPerBlob),
+			G2Monomial: []hexutil.Bytes{g2.ToCompressed(g2.New().Set(kzgSetupG2[0])), g2.ToCompressed(g2.New().Set(kzgSetupG2[1]))},
+		}
+	)
+	for i, point := range lagrangeSetupG1() {
+		full.G1Lagrange[i] = g1.ToCompressed(g1.New().Set(point))
+	}
+	defer LoadTrustedSetup(full)
+
+	var blob Blob
+	if err := blob.EncodeData([]byte("verify only")); err != nil {
+		t.Fatalf("failed to encode blob: %v", err)
+	}
+	commitment, err := BlobToKZGCommitment(&blob)
+	if err != nil {
+		t.Fatalf("failed to commit to blob: %v", err)
+	}
+	proof, err := ComputeBlobKZGProof(&blob, commitment)
+	if err != nil {
+		t.Fatalf("failed to compute proof: %v", err)
+	}
+	var z [32]byte
+	z[31] = 7
+	pointProof, y, err := ComputeKZGProof(&blob, z)
+	if err != nil {
+		t.Fatalf("failed to compute point proof: %v", err)
+	}
+	// Load only the verification part of the setup
+	if err := LoadVerificationSetup(&TrustedSetup{G2Monomial: full.G2Monomial[:1]}); err != ErrInvalidSetupSize {
+		t.Fatalf("undersized setup: have %v, want %v", err, ErrInvalidSetupSize)
+	}
+	if err := LoadVerificationSetup(&TrustedSetup{G2Monomial: full.G2Monomial}); err != nil {
+		t.Fatalf("failed to load verification setup: %v", err)
+	}
+	if !IsVerifyOnly() || kzgSetupLagrange != nil {
+		t.Fatalf("verify-only mode not entered")
+	}
+	if err := VerifyBlobKZGProof(&blob, commitment, proof); err != nil {
+		t.Errorf("failed to verify blob proof: %v", err)
+	}
+	if err := VerifyKZGProof(commitment, z, y, pointProof); err != nil {
+		t.Errorf("failed to verify point proof: %v", err)
+	}
+	if _, err := BlobToKZGCommitment(&blob); err != ErrVerifyOnly {
+		t.Errorf("commitment error mismatch: have %v, want %v", err, ErrVerifyOnly)
+	}
+	if _, err := ComputeBlobKZGProof(&blob, commitment); err != ErrVerifyOnly {
+		t.Errorf("blob proof error mismatch: have %v, want %v", err, ErrVerifyOnly)
+	}
+	if _, _, err := ComputeKZGProof(&blob, z); err != ErrVerifyOnly {
+		t.Errorf("point proof error mismatch: have %v, want %v", err, ErrVerifyOnly)
+	}
+	// Loading a full setup leaves verify-only mode
+	if err := LoadTrustedSetup(full); err != nil {
+		t.Fatalf("failed to load setup: %v", err)
+	}
+	if have, err := BlobToKZGCommitment(&blob); err != nil || have != commitment {
+		t.Errorf("commitment mismatch after leaving verify-only mode: have %x, %v, want %x", have, err, commitment)
+	}
+}
+
+// Tests that setups are parsed from the node's format and the standard ones,
+// and that the standard Lagrange bases in natural order are bit-reversed.
+func TestParseTrustedSetup(t *testing.T) {
+	setup, err := NewInsecureTrustedSetup(big.NewInt(1234), 16, 4)
+	if err != nil {
+		t.Fatalf("failed to generate setup: %v", err)
+	}
+	natural := bitReversePoints(setup.G1Lagrange)
+
+	nodeJSON, _ := json.Marshal(setup)
+	specJSON, _ := json.Marshal(&specTrustedSetup{G1Lagrange: natural, G2Monomial: setup.G2Monomial})
+
+	text := new(strings.Builder)
+	fmt.Fprintf(text, "%d\n%d\n", len(natural), len(setup.G2Monomial))
+	for _, point := range append(natural, setup.G2Monomial...) {
+		fmt.Fprintf(text, "%x\n", []byte(point))
+	}
+	for name, data := range map[string][]byte{
+		"node json": nodeJSON,
+		"spec json": specJSON,
+		"text":      []byte(text.String()),
+	} {
+		parsed, err := ParseTrustedSetup(data)
+		if err != nil {
+			t.Errorf("%s: failed to parse setup: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(parsed.G1Lagrange, setup.G1Lagrange) {
+			t.Errorf("%s: lagrange basis mismatch", name)
+		}
+		if !reflect.DeepEqual(parsed.G2Monomial, setup.G2Monomial) {
+			t.Errorf("%s: g2 powers mismatch", name)
+		}
+		if err := parsed.Verify(0); err != nil {
+			t.Errorf("%s: parsed setup failed to verify: %v", name, err)
+		}
+	}
+	// Lagrange bases in the wrong order or not matching the G2 powers must fail
+	reordered := &TrustedSetup{G1Lagrange: natural, G2Monomial: setup.G2Monomial}
+	if err := reordered.Verify(0); !errors.Is(err, ErrInconsistentSetup) {
+		t.Errorf("reordered basis: have %v, want %v", err, ErrInconsistentSetup)
+	}
+	other, _ := NewInsecureTrustedSetup(big.NewInt(4321), 16, 4)
+	mixed := &TrustedSetup{G1Lagrange: setup.G1Lagrange, G2Monomial: other.G2Monomial}
+	if err := mixed.Verify(0); !errors.Is(err, ErrInconsistentSetup) {
+		t.Errorf("mismatching g2 powers: have %v, want %v", err, ErrInconsistentSetup)
+	}
+	// Malformed files must be rejected
+	if _, err := ParseTrustedSetup([]byte("16\n4\n" + strings.Repeat("c0\n", 19))); !errors.Is(err, ErrInvalidSetupSize) {
+		t.Errorf("missing point: have %v, want %v", err, ErrInvalidSetupSize)
+	}
+	if _, err := ParseTrustedSetup([]byte(`{"setup": []}`)); err != errUnknownSetupFormat {
+		t.Errorf("unknown json: have %v, want %v", err, errUnknownSetupFormat)
+	}
+}
@@ -181,6 +181,22 @@ func (b *EthAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (type
 	return b.eth.blockchain.GetReceiptsByHash(hash), nil
 }
 
+func (b *EthAPIBackend) GetBlobSidecars(ctx context.Context, hash common.Hash) ([]*types.BlobTxSidecar, error) {
+	if sidecars := b.eth.blockchain.GetBlobSidecarsByHash(hash); sidecars != nil || b.eth.blobHistory == nil {
+		return sidecars, nil
+	}
+	// The sidecars are not retained locally, try retrieving them from the network
+	block := b.eth.blockchain.GetBlockByHash(hash)
+	if block == nil {
+		return nil, nil
+	}
+	return b.eth.blobHistory.Fetch(ctx, block)
+}
+
+func (b *EthAPIBackend) GetBlobBlockHash(ctx context.Context, versionedHash common.Hash) (common.Hash, error) {
+	return b.eth.blockchain.GetBlobBlockHash(versionedHash), nil
+}
+
 func (b *EthAPIBackend) GetLogs(ctx context.Context, hash common.Hash) ([][]*types.Log, error) {
 	db := b.eth.ChainDb()
 	number := rawdb.ReadHeaderNumber(db, hash)
@@ -252,6 +268,10 @@ func (b *EthAPIBackend) GetPoolTransaction(hash common.Hash) *types.Transaction
 	return b.eth.txPool.Get(hash)
 }
 
+func (b *EthAPIBackend) GetPoolTransactionWithBlobs(hash common.Hash) *types.Transaction {
+	return b.eth.txPool.GetWithBlobs(hash)
+}
+
 func (b *EthAPIBackend) GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
 	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(b.eth.ChainDb(), txHash)
 	return tx, blockHash, blockNumber, index, nil
@@ -289,7 +309,19 @@ func (b *EthAPIBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	return b.gpo.SuggestTipCap(ctx)
 }
 
-func (b *EthAPIBackend) FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, err error) {
+func (b *EthAPIBackend) SuggestDataGasFeeCap(ctx context.Context) (*big.Int, error) {
+	return b.gpo.SuggestDataGasFeeCap(ctx)
+}
+
+func (b *EthAPIBackend) SuggestFees(ctx context.Context) (gasFeeCap, gasTipCap, dataGasFeeCap *big.Int, err error) {
+	return b.gpo.SuggestFees(ctx)
+}
+
+func (b *EthAPIBackend) ForecastDataGasPrices(ctx context.Context, blocks int) (firstBlock *big.Int, blobsPerBlock float64, dataGasPrice []*big.Int, err error) {
+	return b.gpo.ForecastDataGasPrices(ctx, blocks)
+}
+
+func (b *EthAPIBackend) FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, dataGasUsed []uint64, excessDataGas []*big.Int, dataGasPrice []*big.Int, err error) {
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
 
@@ -325,6 +357,10 @@ func (b *EthAPIBackend) RPCTxFeeCap() float64 {
 	return b.eth.config.RPCTxFeeCap
 }
 
+func (b *EthAPIBackend) RPCBeaconBlobs() bool {
+	return b.eth.config.RPCBeaconBlobs
+}
+
 func (b *EthAPIBackend) BloomStatus() (uint64, uint64) {
 	sections, _, _ := b.eth.bloomIndexer.Sections()
 	return params.BloomBitsBlocks, sections
@@ -38,6 +38,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/blobforward"
+	"github.com/ethereum/go-ethereum/eth/blobhistory"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
@@ -74,6 +76,8 @@ type Ethereum struct {
 	ethDialCandidates  enode.Iterator
 	snapDialCandidates enode.Iterator
 	merger             *consensus.Merger
+	blobHistory        *blobhistory.Client    // Retriever of pruned blob sidecars, nil if disabled
+	blobForwarder      *blobforward.Forwarder // Relay of blob transactions to trusted nodes, nil if disabled
 
 	// DB interfaces
 	chainDb ethdb.Database // Block chain database
@@ -194,6 +198,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			TrieTimeLimit:       config.TrieTimeout,
 			SnapshotLimit:       config.SnapshotCache,
 			Preimages:           config.Preimages,
+			BlobRetention:       config.BlobRetention,
 		}
 	)
 	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, chainConfig, eth.engine, vmConfig, eth.shouldPreserve, &config.TxLookupLimit)
@@ -211,6 +216,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
+	if config.TxPool.BlobStore != "" {
+		config.TxPool.BlobStore = stack.ResolvePath(config.TxPool.BlobStore)
+	}
 	eth.txPool = core.NewTxPool(config.TxPool, chainConfig, eth.blockchain)
 
 	// Permit the downloader to use the trie cache allowance during fast sync
@@ -219,6 +227,10 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if checkpoint == nil {
 		checkpoint = params.TrustedCheckpoints[genesisHash]
 	}
+	if len(config.BlobForwardURLs) > 0 {
+		eth.blobForwarder = blobforward.New(eth.txPool, config.BlobForwardURLs)
+		log.Info("Forwarding blob transactions to trusted nodes", "urls", len(config.BlobForwardURLs))
+	}
 	if eth.handler, err = newHandler(&handlerConfig{
 		Database:   chainDb,
 		Chain:      eth.blockchain,
@@ -230,10 +242,15 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		EventMux:   eth.eventMux,
 		Checkpoint: checkpoint,
 		Whitelist:  config.Whitelist,
+
+		BlobForwarder: eth.blobForwarder,
 	}); err != nil {
 		return nil, err
 	}
 
+	if config.BlobHistory {
+		eth.blobHistory = blobhistory.NewClient()
+	}
 	eth.miner = miner.New(eth, &config.Miner, chainConfig, eth.EventMux(), eth.engine, eth.isLocalBlock)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 
@@ -540,6 +557,15 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 func (s *Ethereum) Start() error {
 	eth.StartENRUpdater(s.blockchain, s.p2pServer.LocalNode())
 
+	// Serve and retrieve blob sidecars over discovery if requested
+	if s.blobHistory != nil {
+		if disc := s.p2pServer.DiscV5; disc != nil {
+			blobhistory.NewServer(s.blockchain).Register(disc)
+			s.blobHistory.Start(disc)
+		} else {
+			log.Warn("Blob history requires discovery v5, not serving blob sidecars")
+		}
+	}
 	// Start the bloom bits servicing goroutines
 	s.startBloomHandlers(params.BloomBitsBlocks)
 
@@ -566,6 +592,9 @@ func (s *Ethereum) Stop() error {
 	s.ethDialCandidates.Close()
 	s.snapDialCandidates.Close()
 	s.handler.Stop()
+	if s.blobForwarder != nil {
+		s.blobForwarder.Stop()
+	}
 
 	// Then stop everything else.
 	s.bloomIndexer.Close()
@@ -0,0 +1,178 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package blobforward relays blob transactions to trusted upstream nodes over
+// RPC, for sentry nodes shielding a block producer from the public network.
+package blobforward
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// forwardTimeout is the time an upstream node is allowed to accept a blob
+	// transaction in.
+	forwardTimeout = 10 * time.Second
+
+	// queueSize is the number of transaction batches waiting to be forwarded,
+	// beyond which new ones are dropped.
+	queueSize = 64
+)
+
+var (
+	forwardedMeter = metrics.NewRegisteredMeter("blobforward/forwarded", nil)
+	rejectedMeter  = metrics.NewRegisteredMeter("blobforward/rejected", nil)
+	failedMeter    = metrics.NewRegisteredMeter("blobforward/failed", nil)
+	droppedMeter   = metrics.NewRegisteredMeter("blobforward/dropped", nil)
+)
+
+// TxPool is the transaction pool the forwarded transactions are retrieved from,
+// along with their blobs.
+type TxPool interface {
+	GetWithBlobs(hash common.Hash) *types.Transaction
+}
+
+// upstream is a trusted node blob transactions are forwarded to.
+type upstream struct {
+	url    string
+	client *rpc.Client // Connection to the node, nil until dialed and after failures
+}
+
+// Forwarder relays the blob transactions accepted by the local pool, wrapped
+// with their blobs, to a set of trusted upstream nodes via their
+// eth_sendRawBlobTransaction endpoint. The transactions were validated by the
+// pool, so the upstream nodes receive them from a single vetted source.
+type Forwarder struct {
+	pool      TxPool
+	upstreams []*upstream
+
+	queue chan []common.Hash
+	quit  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// New creates a forwarder relaying blob transactions from the pool to the nodes
+// at the given RPC endpoints, and starts its forwarding loop.
+func New(pool TxPool, urls []string) *Forwarder {
+	f := &Forwarder{
+		pool:  pool,
+		queue: make(chan []common.Hash, queueSize),
+		quit:  make(chan struct{}),
+	}
+	for _, url := range urls {
+		f.upstreams = append(f.upstreams, &upstream{url: url})
+	}
+	f.wg.Add(1)
+	go f.loop()
+	return f
+}
+
+// Forward schedules the blob transactions with the given hashes for forwarding.
+// The call doesn't block: if the upstream nodes fall too far behind, the
+// transactions are dropped.
+func (f *Forwarder) Forward(hashes []common.Hash) {
+	select {
+	case f.queue <- hashes:
+	default:
+		droppedMeter.Mark(int64(len(hashes)))
+		log.Warn("Blob transaction forwarding queue full, dropping", "txs", len(hashes))
+	}
+}
+
+// Stop terminates the forwarding loop and closes the upstream connections.
+func (f *Forwarder) Stop() {
+	close(f.quit)
+	f.wg.Wait()
+
+	for _, u := range f.upstreams {
+		if u.client != nil {
+			u.client.Close()
+		}
+	}
+}
+
+// loop forwards the scheduled transactions until the forwarder is stopped.
+func (f *Forwarder) loop() {
+	defer f.wg.Done()
+
+	for {
+		select {
+		case hashes := <-f.queue:
+			for _, hash := range hashes {
+				// The transaction might have been dropped from the pool meanwhile
+				tx := f.pool.GetWithBlobs(hash)
+				if tx == nil || tx.BlobTxSidecar() == nil {
+					continue
+				}
+				data, err := tx.MarshalNetwork()
+				if err != nil {
+					log.Error("Failed to encode blob transaction", "hash", hash, "err", err)
+					continue
+				}
+				for _, u := range f.upstreams {
+					f.send(u, hash, data)
+				}
+			}
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// send forwards an encoded blob transaction to an upstream node, (re)dialing it
+// if needed. Connections are dropped on transport failures, so the next
+// transaction redials the node.
+func (f *Forwarder) send(u *upstream, hash common.Hash, data []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), forwardTimeout)
+	defer cancel()
+
+	if u.client == nil {
+		client, err := rpc.DialContext(ctx, u.url)
+		if err != nil {
+			failedMeter.Mark(1)
+			log.Warn("Failed to dial blob forwarding upstream", "url", u.url, "err", err)
+			return
+		}
+		u.client = client
+	}
+	err := u.client.CallContext(ctx, nil, "eth_sendRawBlobTransaction", hexutil.Bytes(data))
+	if err == nil {
+		forwardedMeter.Mark(1)
+		return
+	}
+	// Rejections by the node (e.g. already known transactions) keep the connection
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		rejectedMeter.Mark(1)
+		log.Debug("Blob transaction rejected by upstream", "url", u.url, "hash", hash, "err", err)
+		return
+	}
+	failedMeter.Mark(1)
+	log.Warn("Failed to forward blob transaction", "url", u.url, "hash", hash, "err", err)
+
+	u.client.Close()
+	u.client = nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobforward
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testPool is a transaction pool holding a fixed set of transactions.
+type testPool map[common.Hash]*types.Transaction
+
+func (p testPool) GetWithBlobs(hash common.Hash) *types.Transaction { return p[hash] }
+
+// testUpstream is the eth namespace of an upstream node, accepting the blob
+// transactions sent to it.
+type testUpstream struct {
+	txs chan *types.Transaction
+}
+
+func (u *testUpstream) SendRawBlobTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalNetwork(input); err != nil {
+		return common.Hash{}, err
+	}
+	u.txs <- tx
+	return tx.Hash(), nil
+}
+
+// newTestUpstream starts an RPC server accepting blob transactions.
+func newTestUpstream(t *testing.T) (*testUpstream, string) {
+	upstream := &testUpstream{txs: make(chan *types.Transaction, 16)}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", upstream); err != nil {
+		t.Fatalf("failed to register upstream: %v", err)
+	}
+	httpsrv := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpsrv.Close()
+		server.Stop()
+	})
+	return upstream, httpsrv.URL
+}
+
+// newTestBlobTx creates a signed blob transaction wrapped with an empty blob.
+func newTestBlobTx(t *testing.T, nonce uint64) *types.Transaction {
+	key, _ := crypto.GenerateKey()
+
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0 // point at infinity, committing to the empty blob
+	tx, err := types.SignNewTx(key, types.NewShardingSigner(common.Big1), &types.BlobTx{
+		ChainID:             big.NewInt(1),
+		Nonce:               nonce,
+		Gas:                 21000,
+		GasTipCap:           big.NewInt(1),
+		GasFeeCap:           big.NewInt(10),
+		MaxFeePerDataGas:    big.NewInt(100),
+		BlobVersionedHashes: []common.Hash{commitment.ComputeVersionedHash()},
+	})
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	return tx.WithBlobTxSidecar(&types.BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}},
+		Commitments: []kzg.KZGCommitment{commitment},
+		Proofs:      []kzg.KZGProof{{0xc0}},
+	})
+}
+
+// Tests that blob transactions are forwarded with their blobs to all upstream
+// nodes, and that an unreachable upstream doesn't hold back the others.
+func TestForward(t *testing.T) {
+	var (
+		tx1  = newTestBlobTx(t, 0)
+		tx2  = newTestBlobTx(t, 1)
+		pool = testPool{tx1.Hash(): tx1, tx2.Hash(): tx2}
+	)
+	upstream1, url1 := newTestUpstream(t)
+	upstream2, url2 := newTestUpstream(t)
+
+	// Put an unreachable upstream first, closing its server right away
+	dead := httptest.NewServer(nil)
+	dead.Close()
+
+	forwarder := New(pool, []string{dead.URL, url1, url2})
+	defer forwarder.Stop()
+
+	// Forward the transactions, along with one which left the pool
+	forwarder.Forward([]common.Hash{tx1.Hash(), {0x01}, tx2.Hash()})
+
+	for i, upstream := range []*testUpstream{upstream1, upstream2} {
+		for _, want := range []*types.Transaction{tx1, tx2} {
+			select {
+			case have := <-upstream.txs:
+				if have.Hash() != want.Hash() {
+					t.Fatalf("upstream %d: transaction mismatch: have %x, want %x", i, have.Hash(), want.Hash())
+				}
+				if have.BlobTxSidecar() == nil || len(have.BlobTxSidecar().Blobs) != 1 {
+					t.Fatalf("upstream %d: transaction %x forwarded without its blobs", i, have.Hash())
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("upstream %d: transaction %x not forwarded", i, want.Hash())
+			}
+		}
+		select {
+		case tx := <-upstream.txs:
+			t.Fatalf("upstream %d: unexpected transaction %x forwarded", i, tx.Hash())
+		default:
+		}
+	}
+}
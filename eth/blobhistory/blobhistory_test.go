@@ -0,0 +1,195 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobhistory
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// testBackend is a sidecar source serving a fixed set of blocks.
+type testBackend map[common.Hash][]*types.BlobTxSidecar
+
+func (b testBackend) GetBlobSidecarsByHash(hash common.Hash) []*types.BlobTxSidecar {
+	return b[hash]
+}
+
+// testTransport is a discovery transport knowing a fixed set of nodes.
+type testTransport struct {
+	*discover.UDPv5
+	nodes []*enode.Node
+}
+
+func (t *testTransport) AllNodes() []*enode.Node {
+	return t.nodes
+}
+
+// startLocalhostV5 starts a discovery v5 instance listening on localhost.
+func startLocalhostV5(t *testing.T) *discover.UDPv5 {
+	key, _ := crypto.GenerateKey()
+	db, _ := enode.OpenDB("")
+	ln := enode.NewLocalNode(db, key)
+
+	socket, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IP{127, 0, 0, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := socket.LocalAddr().(*net.UDPAddr)
+	ln.SetStaticIP(addr.IP)
+	ln.Set(enr.UDP(addr.Port))
+
+	disc, err := discover.ListenV5(socket, ln, discover.Config{PrivateKey: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return disc
+}
+
+// makeSidecar creates a sidecar with a random blob and the blob transaction
+// referencing it.
+func makeSidecar(t *testing.T) (*types.Transaction, *types.BlobTxSidecar) {
+	data := make([]byte, kzg.MaxBlobDataSize)
+	rand.Read(data)
+
+	var blob kzg.Blob
+	if err := blob.EncodeData(data); err != nil {
+		t.Fatalf("failed to encode blob: %v", err)
+	}
+	commitment, err := kzg.BlobToKZGCommitment(&blob)
+	if err != nil {
+		t.Fatalf("failed to commit to blob: %v", err)
+	}
+	proof, err := kzg.ComputeBlobKZGProof(&blob, commitment)
+	if err != nil {
+		t.Fatalf("failed to compute blob proof: %v", err)
+	}
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg.Blob{blob},
+		Commitments: []kzg.KZGCommitment{commitment},
+		Proofs:      []kzg.KZGProof{proof},
+	}
+	return types.NewTx(&types.BlobTx{BlobVersionedHashes: sidecar.BlobHashes()}), sidecar
+}
+
+// Tests that the blobs of a block are retrieved from the serving nodes, skipping
+// nodes which serve invalid blobs, and that retrieval fails if no node has them.
+func TestFetch(t *testing.T) {
+	var (
+		tx1, sidecar1 = makeSidecar(t)
+		tx2, sidecar2 = makeSidecar(t)
+		txs           = []*types.Transaction{types.NewTx(&types.LegacyTx{}), tx1, tx2}
+		block         = types.NewBlock(&types.Header{Number: big.NewInt(1)}, txs, nil, nil, trie.NewStackTrie(nil))
+		sidecars      = []*types.BlobTxSidecar{sidecar1, sidecar2}
+	)
+	// Start a node serving the blobs, and one serving a corrupted copy of them
+	good := startLocalhostV5(t)
+	defer good.Close()
+	NewServer(testBackend{block.Hash(): sidecars}).Register(good)
+
+	corrupted := *sidecar2
+	corrupted.Blobs = []kzg.Blob{sidecar2.Blobs[0]}
+	corrupted.Blobs[0][5*chunkSize+1] ^= 0x01
+
+	bad := startLocalhostV5(t)
+	defer bad.Close()
+	NewServer(testBackend{block.Hash(): {sidecar1, &corrupted}}).Register(bad)
+
+	// Start a node not serving blobs at all
+	silent := startLocalhostV5(t)
+	defer silent.Close()
+
+	disc := startLocalhostV5(t)
+	defer disc.Close()
+
+	client := NewClient()
+	if _, err := client.Fetch(context.Background(), block); err != errNotStarted {
+		t.Fatalf("unstarted client error mismatch: have %v, want %v", err, errNotStarted)
+	}
+	client.Start(&testTransport{disc, []*enode.Node{good.Self(), bad.Self(), silent.Self()}})
+
+	have, err := client.Fetch(context.Background(), block)
+	if err != nil {
+		t.Fatalf("failed to fetch blobs: %v", err)
+	}
+	if !reflect.DeepEqual(have, sidecars) {
+		t.Fatalf("fetched sidecars mismatch")
+	}
+	// Blocks without blob transactions need no retrieval
+	empty := types.NewBlock(&types.Header{Number: big.NewInt(2)}, txs[:1], nil, nil, trie.NewStackTrie(nil))
+	if have, err := client.Fetch(context.Background(), empty); err != nil || have != nil {
+		t.Fatalf("blob-less block fetch mismatch: have %v, %v, want nil", have, err)
+	}
+	// Blobs served only in corrupted form must be rejected
+	client.Start(&testTransport{disc, []*enode.Node{bad.Self()}})
+	if _, err := client.Fetch(context.Background(), block); err == nil {
+		t.Fatalf("corrupted blob accepted")
+	}
+	// Blobs of unknown blocks are not available
+	unknown := types.NewBlock(&types.Header{Number: big.NewInt(3)}, txs, nil, nil, trie.NewStackTrie(nil))
+	client.Start(&testTransport{disc, []*enode.Node{good.Self()}})
+	if _, err := client.Fetch(context.Background(), unknown); err == nil {
+		t.Fatalf("blobs of unknown block retrieved")
+	}
+}
+
+// Tests that the server rejects requests for chunks it doesn't have.
+func TestServeBounds(t *testing.T) {
+	_, sidecar := makeSidecar(t)
+	server := NewServer(testBackend{{0x01}: {sidecar}})
+
+	tests := []struct {
+		req  request
+		ok   bool
+		data []byte
+	}{
+		{request{Block: common.Hash{0x01}}, true, sidecar.Blobs[0][:chunkSize]},
+		{request{Block: common.Hash{0x01}, Chunk: chunksPerBlob - 1}, true, sidecar.Blobs[0][len(sidecar.Blobs[0])-chunkSize:]},
+		{request{Block: common.Hash{0x01}, Chunk: chunksPerBlob}, false, nil},
+		{request{Block: common.Hash{0x01}, Blob: 1}, false, nil},
+		{request{Block: common.Hash{0x01}, Tx: 1}, false, nil},
+		{request{Block: common.Hash{0x02}}, false, nil},
+	}
+	for i, tt := range tests {
+		res := server.serve(&tt.req)
+		if (res != nil) != tt.ok {
+			t.Errorf("test %d: availability mismatch: have %v, want %v", i, res != nil, tt.ok)
+			continue
+		}
+		if res == nil {
+			continue
+		}
+		if !reflect.DeepEqual(res.Data, tt.data) {
+			t.Errorf("test %d: chunk data mismatch", i)
+		}
+		if (res.Proof != nil) != (tt.req.Chunk == 0) {
+			t.Errorf("test %d: proof presence mismatch: have %v, want %v", i, res.Proof != nil, tt.req.Chunk == 0)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobhistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// maxParallelChunks is the number of chunk requests a blob is retrieved with
+// concurrently from a single node.
+const maxParallelChunks = 16
+
+var (
+	errNotStarted   = errors.New("blob history retrieval not started")
+	errUnavailable  = errors.New("blob chunk not available")
+	errInvalidChunk = errors.New("invalid blob chunk")
+
+	fetchedBlobMeter = metrics.NewRegisteredMeter("blobhistory/fetched", nil)
+	invalidBlobMeter = metrics.NewRegisteredMeter("blobhistory/invalid", nil)
+)
+
+// Transport is the discovery functionality needed to retrieve blobs from other
+// nodes, implemented by *discover.UDPv5.
+type Transport interface {
+	// AllNodes returns the nodes known to the discovery.
+	AllNodes() []*enode.Node
+
+	// TalkRequest sends a talk request to a node and waits for the response.
+	TalkRequest(n *enode.Node, protocol string, request []byte) ([]byte, error)
+}
+
+// Client retrieves the blob sidecars of blocks from the nodes advertising the
+// blob history protocol.
+type Client struct {
+	transport Transport
+	lock      sync.RWMutex
+}
+
+// NewClient creates a blob history client. Retrieval fails until the client is
+// started with a transport.
+func NewClient() *Client {
+	return new(Client)
+}
+
+// Start sets the transport to retrieve blobs over.
+func (c *Client) Start(transport Transport) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.transport = transport
+}
+
+// Fetch retrieves the sidecars of the blob transactions in a block, in
+// transaction order. Each blob is verified against the versioned hash of its
+// transaction and its KZG proof.
+func (c *Client) Fetch(ctx context.Context, block *types.Block) ([]*types.BlobTxSidecar, error) {
+	c.lock.RLock()
+	transport := c.transport
+	c.lock.RUnlock()
+
+	var sidecars []*types.BlobTxSidecar
+	for _, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		if transport == nil {
+			return nil, errNotStarted
+		}
+		var (
+			hashes  = tx.DataHashes()
+			sidecar = &types.BlobTxSidecar{
+				Blobs:       make([]kzg.Blob, len(hashes)),
+				Commitments: make([]kzg.KZGCommitment, len(hashes)),
+				Proofs:      make([]kzg.KZGProof, len(hashes)),
+			}
+		)
+		for i, hash := range hashes {
+			req := &request{Block: block.Hash(), Tx: uint64(len(sidecars)), Blob: uint64(i)}
+			if err := c.fetchBlob(ctx, transport, req, hash, sidecar, i); err != nil {
+				return nil, fmt.Errorf("blob %d of transaction %#x: %w", i, tx.Hash(), err)
+			}
+		}
+		sidecars = append(sidecars, sidecar)
+	}
+	return sidecars, nil
+}
+
+// fetchBlob retrieves a blob from the first advertising node able to serve a
+// valid one, and stores it in the sidecar at the given index.
+func (c *Client) fetchBlob(ctx context.Context, transport Transport, req *request, hash common.Hash, sidecar *types.BlobTxSidecar, index int) error {
+	var nodes []*enode.Node
+	for _, node := range transport.AllNodes() {
+		if node.Load(&enrEntry{}) == nil {
+			nodes = append(nodes, node)
+		}
+	}
+	// Spread the load over the serving nodes
+	rand.Shuffle(len(nodes), func(i, j int) { nodes[i], nodes[j] = nodes[j], nodes[i] })
+
+	for _, node := range nodes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		blob, proof, err := fetchBlobFrom(transport, node, req, hash)
+		if err == nil {
+			err = kzg.VerifyBlobKZGProof(blob, proof.Commitment, proof.Proof)
+		}
+		if err != nil {
+			if err != errUnavailable {
+				invalidBlobMeter.Mark(1)
+			}
+			log.Debug("Failed to retrieve historical blob", "block", req.Block, "tx", req.Tx, "blob", req.Blob, "node", node.ID(), "err", err)
+			continue
+		}
+		fetchedBlobMeter.Mark(1)
+		sidecar.Blobs[index] = *blob
+		sidecar.Commitments[index] = proof.Commitment
+		sidecar.Proofs[index] = proof.Proof
+		return nil
+	}
+	return fmt.Errorf("not available from any of %d nodes", len(nodes))
+}
+
+// fetchBlobFrom retrieves all the chunks of a blob from a node, along with its
+// commitment and proof. The commitment is checked against the versioned hash of
+// the blob before retrieving the rest of it.
+func fetchBlobFrom(transport Transport, node *enode.Node, req *request, hash common.Hash) (*kzg.Blob, *blobProof, error) {
+	var blob kzg.Blob
+
+	first, err := fetchChunk(transport, node, req, 0, &blob)
+	if err != nil {
+		return nil, nil, err
+	}
+	if first.Proof == nil {
+		return nil, nil, errInvalidChunk
+	}
+	if have := first.Proof.Commitment.ComputeVersionedHash(); have != hash {
+		return nil, nil, fmt.Errorf("commitment hash mismatch: have %x, want %x", have, hash)
+	}
+	var (
+		chunks = make(chan uint64, chunksPerBlob)
+		errc   = make(chan error, maxParallelChunks)
+	)
+	for chunk := uint64(1); chunk < chunksPerBlob; chunk++ {
+		chunks <- chunk
+	}
+	close(chunks)
+
+	for i := 0; i < maxParallelChunks; i++ {
+		go func() {
+			for chunk := range chunks {
+				if _, err := fetchChunk(transport, node, req, chunk, &blob); err != nil {
+					errc <- err
+					return
+				}
+			}
+			errc <- nil
+		}()
+	}
+	for i := 0; i < maxParallelChunks; i++ {
+		if e := <-errc; e != nil && err == nil {
+			err = e
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &blob, first.Proof, nil
+}
+
+// fetchChunk retrieves a single chunk of a blob from a node, copying it into the
+// blob at its position. Chunks are written to disjoint parts of the blob, so
+// they may be fetched concurrently.
+func fetchChunk(transport Transport, node *enode.Node, req *request, chunk uint64, blob *kzg.Blob) (*response, error) {
+	msg, err := rlp.EncodeToBytes(&request{Block: req.Block, Tx: req.Tx, Blob: req.Blob, Chunk: chunk})
+	if err != nil {
+		return nil, err
+	}
+	reply, err := transport.TalkRequest(node, protocolName, msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) == 0 {
+		return nil, errUnavailable
+	}
+	var res response
+	if err := rlp.DecodeBytes(reply, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Data) != chunkSize {
+		return nil, errInvalidChunk
+	}
+	copy(blob[chunk*chunkSize:], res.Data)
+	return &res, nil
+}
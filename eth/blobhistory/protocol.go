@@ -0,0 +1,77 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package blobhistory implements a best-effort protocol for retrieving the blob
+// sidecars of blocks which are beyond the retention window of the local node.
+//
+// Nodes volunteering to serve the sidecars they retain advertise the protocol in
+// their ENR and answer discovery v5 talk requests for single chunks of blobs,
+// small enough to fit into one packet. Retrieved blobs are verified against the
+// versioned hashes of their transactions and their KZG proofs.
+package blobhistory
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// protocolName is the name of the talk protocol and of the ENR entry
+	// advertising it.
+	protocolName = "blobs"
+
+	// chunkSize is the number of blob bytes served in a single response, leaving
+	// room for the commitment, proof and packet overhead within the 1280 byte
+	// discovery packet limit.
+	chunkSize = 1024
+
+	// chunksPerBlob is the number of chunks a blob is served in.
+	chunksPerBlob = kzg.FieldElementsPerBlob * 32 / chunkSize
+)
+
+// enrEntry is the ENR entry which advertises the blob history protocol on the
+// discovery.
+type enrEntry struct {
+	// Ignore additional fields (for forward compatibility).
+	Rest []rlp.RawValue `rlp:"tail"`
+}
+
+// ENRKey implements enr.Entry.
+func (e enrEntry) ENRKey() string {
+	return protocolName
+}
+
+// request is a talk request for a chunk of a blob.
+type request struct {
+	Block common.Hash // Hash of the block including the blob transaction
+	Tx    uint64      // Index of the transaction among the blob transactions of the block
+	Blob  uint64      // Index of the blob within the transaction
+	Chunk uint64      // Index of the requested chunk of the blob
+}
+
+// blobProof is the commitment to a blob and the proof of the blob matching it.
+type blobProof struct {
+	Commitment kzg.KZGCommitment
+	Proof      kzg.KZGProof
+}
+
+// response is the answer to a request, an empty message is sent instead if the
+// requested chunk is not available.
+type response struct {
+	Proof *blobProof `rlp:"nil"` // Only sent along with the first chunk
+	Data  []byte
+}
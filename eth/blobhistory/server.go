@@ -0,0 +1,120 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobhistory
+
+import (
+	"net"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// sidecarCacheSize is the number of blocks whose sidecars are cached by the
+// server, as a blob is served in many chunks.
+const sidecarCacheSize = 16
+
+var (
+	servedChunkMeter   = metrics.NewRegisteredMeter("blobhistory/served", nil)
+	unservedChunkMeter = metrics.NewRegisteredMeter("blobhistory/unserved", nil)
+)
+
+// Backend is the source of the blob sidecars served to other nodes.
+type Backend interface {
+	// GetBlobSidecarsByHash retrieves the sidecars of the blob transactions of
+	// a block in transaction order, or nil if they are not retained.
+	GetBlobSidecarsByHash(hash common.Hash) []*types.BlobTxSidecar
+}
+
+// Server serves the blob sidecars retained by the local node to other nodes.
+type Server struct {
+	backend Backend
+	cache   *lru.Cache // Sidecars of the recently requested blocks
+}
+
+// NewServer creates a server for the sidecars retained by backend.
+func NewServer(backend Backend) *Server {
+	cache, _ := lru.New(sidecarCacheSize)
+	return &Server{
+		backend: backend,
+		cache:   cache,
+	}
+}
+
+// Register starts serving blob chunks over the given discovery and advertises
+// the protocol in the local ENR.
+func (s *Server) Register(disc *discover.UDPv5) {
+	disc.RegisterTalkHandler(protocolName, s.handle)
+	disc.LocalNode().Set(enrEntry{})
+}
+
+// handle answers a talk request for a blob chunk.
+func (s *Server) handle(id enode.ID, addr *net.UDPAddr, msg []byte) []byte {
+	var req request
+	if err := rlp.DecodeBytes(msg, &req); err != nil {
+		return nil
+	}
+	res := s.serve(&req)
+	if res == nil {
+		unservedChunkMeter.Mark(1)
+		return nil
+	}
+	enc, err := rlp.EncodeToBytes(res)
+	if err != nil {
+		return nil
+	}
+	servedChunkMeter.Mark(1)
+	return enc
+}
+
+// serve retrieves the requested blob chunk, or nil if it's not available.
+func (s *Server) serve(req *request) *response {
+	if req.Chunk >= chunksPerBlob {
+		return nil
+	}
+	var sidecars []*types.BlobTxSidecar
+	if cached, ok := s.cache.Get(req.Block); ok {
+		sidecars = cached.([]*types.BlobTxSidecar)
+	} else {
+		sidecars = s.backend.GetBlobSidecarsByHash(req.Block)
+		if sidecars == nil {
+			return nil
+		}
+		s.cache.Add(req.Block, sidecars)
+	}
+	if req.Tx >= uint64(len(sidecars)) {
+		return nil
+	}
+	sidecar := sidecars[req.Tx]
+	if req.Blob >= uint64(len(sidecar.Blobs)) || req.Blob >= uint64(len(sidecar.Commitments)) || req.Blob >= uint64(len(sidecar.Proofs)) {
+		return nil
+	}
+	res := &response{
+		Data: sidecar.Blobs[req.Blob][req.Chunk*chunkSize : (req.Chunk+1)*chunkSize],
+	}
+	if req.Chunk == 0 {
+		res.Proof = &blobProof{
+			Commitment: sidecar.Commitments[req.Blob],
+			Proof:      sidecar.Proofs[req.Blob],
+		}
+	}
+	return res
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/core/beacon"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth"
@@ -36,10 +37,11 @@ func Register(stack *node.Node, backend *eth.Ethereum) error {
 	log.Warn("Catalyst mode enabled", "protocol", "eth")
 	stack.RegisterAPIs([]rpc.API{
 		{
-			Namespace: "engine",
-			Version:   "1.0",
-			Service:   NewConsensusAPI(backend),
-			Public:    true,
+			Namespace:     "engine",
+			Version:       "1.0",
+			Service:       NewConsensusAPI(backend),
+			Public:        true,
+			Authenticated: true,
 		},
 	})
 	return nil
@@ -48,6 +50,7 @@ func Register(stack *node.Node, backend *eth.Ethereum) error {
 type ConsensusAPI struct {
 	eth            *eth.Ethereum
 	preparedBlocks *payloadQueue // preparedBlocks caches payloads (*ExecutableDataV1) by payload ID (PayloadID)
+	blobsBundles   *bundleCache  // blobsBundles caches the blobs bundles (*BlobsBundleV1) of prepared payloads
 }
 
 // NewConsensusAPI creates a new consensus api for the given backend.
@@ -59,6 +62,7 @@ func NewConsensusAPI(eth *eth.Ethereum) *ConsensusAPI {
 	return &ConsensusAPI{
 		eth:            eth,
 		preparedBlocks: newPayloadQueue(),
+		blobsBundles:   newBundleCache(mclock.System{}),
 	}
 }
 
@@ -97,12 +101,13 @@ func (api *ConsensusAPI) ForkchoiceUpdatedV1(heads beacon.ForkchoiceStateV1, pay
 	}
 	// Assemble block (if needed). It only works for full node.
 	if payloadAttributes != nil {
-		data, err := api.assembleBlock(heads.HeadBlockHash, payloadAttributes)
+		data, bundle, err := api.assembleBlock(heads.HeadBlockHash, payloadAttributes)
 		if err != nil {
 			return beacon.INVALID, err
 		}
 		id := computePayloadId(heads.HeadBlockHash, payloadAttributes)
 		api.preparedBlocks.put(id, data)
+		api.blobsBundles.put(id, bundle)
 		log.Info("Created payload", "payloadID", id)
 		return beacon.ForkChoiceResponse{Status: beacon.SUCCESS.Status, PayloadID: &id}, nil
 	}
@@ -119,6 +124,37 @@ func (api *ConsensusAPI) GetPayloadV1(payloadID beacon.PayloadID) (*beacon.Execu
 	return data, nil
 }
 
+// GetPayloadV3 returns a cached payload by id, along with the blobs of the blob
+// transactions it includes.
+func (api *ConsensusAPI) GetPayloadV3(payloadID beacon.PayloadID) (*beacon.ExecutionPayloadBlobsBundleV1, error) {
+	log.Trace("Engine API request received", "method", "GetPayloadV3", "id", payloadID)
+	data := api.preparedBlocks.get(payloadID)
+	if data == nil {
+		return nil, &beacon.UnknownPayload
+	}
+	bundle := api.blobsBundles.get(payloadID)
+	if bundle == nil {
+		return nil, &beacon.EvictedBundle
+	}
+	return &beacon.ExecutionPayloadBlobsBundleV1{
+		ExecutionPayload: data,
+		BlobsBundle:      bundle,
+	}, nil
+}
+
+// GetBlobsBundleV1 returns the blobs bundle of a cached payload by id. Bundles
+// may be evicted before their payloads, in which case an error is returned.
+func (api *ConsensusAPI) GetBlobsBundleV1(payloadID beacon.PayloadID) (*beacon.BlobsBundleV1, error) {
+	log.Trace("Engine API request received", "method", "GetBlobsBundle", "id", payloadID)
+	if bundle := api.blobsBundles.get(payloadID); bundle != nil {
+		return bundle, nil
+	}
+	if api.preparedBlocks.get(payloadID) != nil {
+		return nil, &beacon.EvictedBundle
+	}
+	return nil, &beacon.UnknownPayload
+}
+
 // ExecutePayloadV1 creates an Eth1 block, inserts it in the chain, and returns the status of the chain.
 func (api *ConsensusAPI) ExecutePayloadV1(params beacon.ExecutableDataV1) (beacon.ExecutePayloadResponse, error) {
 	log.Trace("Engine API request received", "method", "ExecutePayload", params.BlockHash, "number", params.Number)
@@ -126,6 +162,36 @@ func (api *ConsensusAPI) ExecutePayloadV1(params beacon.ExecutableDataV1) (beaco
 	if err != nil {
 		return api.invalid(), err
 	}
+	return api.executeBlock(block)
+}
+
+// ExecutePayloadV3 creates an Eth1 block, verifies that the blob transactions in
+// it reference exactly the given versioned hashes, inserts it in the chain, and
+// returns the status of the chain.
+func (api *ConsensusAPI) ExecutePayloadV3(params beacon.ExecutableDataV1, versionedHashes []common.Hash) (beacon.ExecutePayloadResponse, error) {
+	log.Trace("Engine API request received", "method", "ExecutePayloadV3", params.BlockHash, "number", params.Number)
+	block, err := beacon.ExecutableDataToBlock(params)
+	if err != nil {
+		return api.invalid(), err
+	}
+	var hashes []common.Hash
+	for _, tx := range block.Transactions() {
+		hashes = append(hashes, tx.DataHashes()...)
+	}
+	if len(hashes) != len(versionedHashes) {
+		return api.invalid(), fmt.Errorf("invalid number of versioned hashes: have %d, want %d", len(versionedHashes), len(hashes))
+	}
+	for i := range hashes {
+		if hashes[i] != versionedHashes[i] {
+			return api.invalid(), fmt.Errorf("invalid versioned hash %d: have %x, want %x", i, versionedHashes[i], hashes[i])
+		}
+	}
+	return api.executeBlock(block)
+}
+
+// executeBlock inserts a block decoded from an execution payload into the
+// chain, and returns the status of the chain.
+func (api *ConsensusAPI) executeBlock(block *types.Block) (beacon.ExecutePayloadResponse, error) {
 	if !api.eth.BlockChain().HasBlock(block.ParentHash(), block.NumberU64()-1) {
 		/*
 			TODO (MariusVanDerWijden) reenable once sync is merged
@@ -136,14 +202,14 @@ func (api *ConsensusAPI) ExecutePayloadV1(params beacon.ExecutableDataV1) (beaco
 		// TODO (MariusVanDerWijden) we should return nil here not empty hash
 		return beacon.ExecutePayloadResponse{Status: beacon.SYNCING.Status, LatestValidHash: common.Hash{}}, nil
 	}
-	parent := api.eth.BlockChain().GetBlockByHash(params.ParentHash)
+	parent := api.eth.BlockChain().GetBlockByHash(block.ParentHash())
 	td := api.eth.BlockChain().GetTd(parent.Hash(), block.NumberU64()-1)
 	ttd := api.eth.BlockChain().Config().TerminalTotalDifficulty
 	if td.Cmp(ttd) < 0 {
 		return api.invalid(), fmt.Errorf("can not execute payload on top of block with low td got: %v threshold %v", td, ttd)
 	}
 	log.Trace("Inserting block without head", "hash", block.Hash(), "number", block.Number)
-	if err := api.eth.BlockChain().InsertBlockWithoutSetHead(block); err != nil {
+	if err := api.eth.BlockChain().InsertBlockWithoutSetHead(api.withPoolBlobs(block)); err != nil {
 		return api.invalid(), err
 	}
 
@@ -153,6 +219,32 @@ func (api *ConsensusAPI) ExecutePayloadV1(params beacon.ExecutableDataV1) (beaco
 	return beacon.ExecutePayloadResponse{Status: beacon.VALID.Status, LatestValidHash: block.Hash()}, nil
 }
 
+// withPoolBlobs attaches the sidecars of the blob transactions in a block from
+// the transaction pool, so they are persisted along with the block. Execution
+// payloads don't carry blobs, so the block is returned as is if any of them
+// is unknown to the pool.
+func (api *ConsensusAPI) withPoolBlobs(block *types.Block) *types.Block {
+	var (
+		txs   = make(types.Transactions, len(block.Transactions()))
+		blobs bool
+	)
+	for i, tx := range block.Transactions() {
+		txs[i] = tx
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		pooled := api.eth.TxPool().GetWithBlobs(tx.Hash())
+		if pooled == nil || pooled.BlobTxSidecar() == nil {
+			return block
+		}
+		txs[i], blobs = tx.WithBlobTxSidecar(pooled.BlobTxSidecar()), true
+	}
+	if !blobs {
+		return block
+	}
+	return block.WithBody(txs, block.Uncles())
+}
+
 // computePayloadId computes a pseudo-random payloadid, based on the parameters.
 func computePayloadId(headBlockHash common.Hash, params *beacon.PayloadAttributesV1) beacon.PayloadID {
 	// Hash
@@ -172,14 +264,19 @@ func (api *ConsensusAPI) invalid() beacon.ExecutePayloadResponse {
 }
 
 // assembleBlock creates a new block and returns the "execution
-// data" required for beacon clients to process the new block.
-func (api *ConsensusAPI) assembleBlock(parentHash common.Hash, params *beacon.PayloadAttributesV1) (*beacon.ExecutableDataV1, error) {
+// data" required for beacon clients to process the new block, along
+// with the blobs bundle needed to construct its blob sidecars.
+func (api *ConsensusAPI) assembleBlock(parentHash common.Hash, params *beacon.PayloadAttributesV1) (*beacon.ExecutableDataV1, *beacon.BlobsBundleV1, error) {
 	log.Info("Producing block", "parentHash", parentHash)
 	block, err := api.eth.Miner().GetSealingBlock(parentHash, params.Timestamp, params.SuggestedFeeRecipient, params.Random)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	bundle, err := beacon.BlockToBlobsBundle(block)
+	if err != nil {
+		return nil, nil, err
 	}
-	return beacon.BlockToExecutableData(block), nil
+	return beacon.BlockToExecutableData(block), bundle, nil
 }
 
 // Used in tests to add a the list of transactions from a block to the tx pool.
@@ -23,12 +23,14 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/beacon"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/node"
@@ -46,8 +48,19 @@ var (
 )
 
 func generatePreMergeChain(n int) (*core.Genesis, []*types.Block) {
+	return generatePreMergeChainWithConfig(params.AllEthashProtocolChanges, n)
+}
+
+// generatePreMergeShardingChain is like generatePreMergeChain, but with the
+// sharding fork active from genesis, so blob transactions can be included.
+func generatePreMergeShardingChain(n int) (*core.Genesis, []*types.Block) {
+	config := *params.AllEthashProtocolChanges
+	config.ShardingForkBlock = common.Big0
+	return generatePreMergeChainWithConfig(&config, n)
+}
+
+func generatePreMergeChainWithConfig(config *params.ChainConfig, n int) (*core.Genesis, []*types.Block) {
 	db := rawdb.NewMemoryDatabase()
-	config := params.AllEthashProtocolChanges
 	genesis := &core.Genesis{
 		Config:    config,
 		Alloc:     core.GenesisAlloc{testAddr: {Balance: testBalance}},
@@ -74,6 +87,35 @@ func generatePreMergeChain(n int) (*core.Genesis, []*types.Block) {
 	return genesis, blocks
 }
 
+// newBlobTx creates a blob transaction from the test account, wrapped with a
+// sidecar of empty blobs.
+func newBlobTx(config *params.ChainConfig, nonce uint64, blobs int) *types.Transaction {
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0 // point at infinity, committing to the empty blob
+
+	var (
+		hashes  []common.Hash
+		sidecar = new(types.BlobTxSidecar)
+	)
+	for i := 0; i < blobs; i++ {
+		hashes = append(hashes, commitment.ComputeVersionedHash())
+		sidecar.Blobs = append(sidecar.Blobs, kzg.Blob{})
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, kzg.KZGProof{0xc0})
+	}
+	tx := types.MustSignNewTx(testKey, types.LatestSigner(config), &types.BlobTx{
+		ChainID:             config.ChainID,
+		Nonce:               nonce,
+		GasTipCap:           big.NewInt(params.InitialBaseFee),
+		GasFeeCap:           big.NewInt(2 * params.InitialBaseFee),
+		Gas:                 params.TxGas,
+		To:                  &testAddr,
+		MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+		BlobVersionedHashes: hashes,
+	})
+	return tx.WithBlobTxSidecar(sidecar)
+}
+
 func TestEth2AssembleBlock(t *testing.T) {
 	genesis, blocks := generatePreMergeChain(10)
 	n, ethservice := startEthService(t, genesis, blocks)
@@ -89,7 +131,7 @@ func TestEth2AssembleBlock(t *testing.T) {
 	blockParams := beacon.PayloadAttributesV1{
 		Timestamp: blocks[9].Time() + 5,
 	}
-	execData, err := api.assembleBlock(blocks[9].Hash(), &blockParams)
+	execData, _, err := api.assembleBlock(blocks[9].Hash(), &blockParams)
 	if err != nil {
 		t.Fatalf("error producing block, err=%v", err)
 	}
@@ -110,7 +152,7 @@ func TestEth2AssembleBlockWithAnotherBlocksTxs(t *testing.T) {
 	blockParams := beacon.PayloadAttributesV1{
 		Timestamp: blocks[8].Time() + 5,
 	}
-	execData, err := api.assembleBlock(blocks[8].Hash(), &blockParams)
+	execData, _, err := api.assembleBlock(blocks[8].Hash(), &blockParams)
 	if err != nil {
 		t.Fatalf("error producing block, err=%v", err)
 	}
@@ -176,6 +218,73 @@ func TestEth2PrepareAndGetPayload(t *testing.T) {
 	}
 }
 
+func TestEth2GetPayloadBlobsBundle(t *testing.T) {
+	genesis, blocks := generatePreMergeShardingChain(10)
+	n, ethservice := startEthService(t, genesis, blocks)
+	defer n.Close()
+
+	api := NewConsensusAPI(ethservice)
+
+	// Add a blob transaction to the pool, committing to two empty blobs
+	tx := newBlobTx(ethservice.BlockChain().Config(), 10, 2)
+	if err := ethservice.TxPool().AddLocal(tx); err != nil {
+		t.Fatalf("failed to add blob transaction: %v", err)
+	}
+	blockParams := beacon.PayloadAttributesV1{
+		Timestamp: blocks[9].Time() + 5,
+	}
+	fcState := beacon.ForkchoiceStateV1{
+		HeadBlockHash:      blocks[9].Hash(),
+		SafeBlockHash:      common.Hash{},
+		FinalizedBlockHash: common.Hash{},
+	}
+	if _, err := api.ForkchoiceUpdatedV1(fcState, &blockParams); err != nil {
+		t.Fatalf("error preparing payload, err=%v", err)
+	}
+	payloadID := computePayloadId(fcState.HeadBlockHash, &blockParams)
+	payload, err := api.GetPayloadV3(payloadID)
+	if err != nil {
+		t.Fatalf("error getting payload, err=%v", err)
+	}
+	if len(payload.ExecutionPayload.Transactions) != 1 {
+		t.Fatalf("invalid number of transactions %d != 1", len(payload.ExecutionPayload.Transactions))
+	}
+	bundle := payload.BlobsBundle
+	if bundle.BlockHash != payload.ExecutionPayload.BlockHash {
+		t.Errorf("bundle block hash mismatch: have %x, want %x", bundle.BlockHash, payload.ExecutionPayload.BlockHash)
+	}
+	if len(bundle.KZGs) != 2 || len(bundle.Blobs) != 2 {
+		t.Fatalf("invalid bundle size: have %d kzgs, %d blobs, want 2", len(bundle.KZGs), len(bundle.Blobs))
+	}
+	for i, have := range bundle.KZGs {
+		if want := tx.BlobTxSidecar().Commitments[i]; have != want {
+			t.Errorf("kzg %d mismatch: have %x, want %x", i, have, want)
+		}
+	}
+	// Test invalid payloadID
+	var invPayload beacon.PayloadID
+	copy(invPayload[:], payloadID[:])
+	invPayload[0] = ^invPayload[0]
+	if _, err := api.GetPayloadV3(invPayload); err == nil {
+		t.Fatal("expected error retrieving invalid payload")
+	}
+	if _, err := api.GetBlobsBundleV1(invPayload); err != &beacon.UnknownPayload {
+		t.Fatalf("error mismatch retrieving invalid bundle: have %v, want %v", err, &beacon.UnknownPayload)
+	}
+	// Bundles are retrievable on their own until evicted, after which an error
+	// is returned even though the payload is still available
+	if have, err := api.GetBlobsBundleV1(payloadID); err != nil || have != bundle {
+		t.Fatalf("bundle mismatch: have %v, want %v, err=%v", have, bundle, err)
+	}
+	api.blobsBundles = newBundleCache(mclock.System{}) // drop all tracked bundles
+	if _, err := api.GetBlobsBundleV1(payloadID); err != &beacon.EvictedBundle {
+		t.Fatalf("error mismatch retrieving evicted bundle: have %v, want %v", err, &beacon.EvictedBundle)
+	}
+	if _, err := api.GetPayloadV3(payloadID); err != &beacon.EvictedBundle {
+		t.Fatalf("error mismatch retrieving payload of evicted bundle: have %v, want %v", err, &beacon.EvictedBundle)
+	}
+}
+
 func checkLogEvents(t *testing.T, logsCh <-chan []*types.Log, rmLogsCh <-chan core.RemovedLogsEvent, wantNew, wantRemoved int) {
 	t.Helper()
 
@@ -261,7 +370,7 @@ func TestEth2NewBlock(t *testing.T) {
 		tx, _ := types.SignTx(types.NewContractCreation(nonce, new(big.Int), 1000000, big.NewInt(2*params.InitialBaseFee), logCode), types.LatestSigner(ethservice.BlockChain().Config()), testKey)
 		ethservice.TxPool().AddLocal(tx)
 
-		execData, err := api.assembleBlock(parent.Hash(), &beacon.PayloadAttributesV1{
+		execData, _, err := api.assembleBlock(parent.Hash(), &beacon.PayloadAttributesV1{
 			Timestamp: parent.Time() + 5,
 		})
 		if err != nil {
@@ -301,7 +410,7 @@ func TestEth2NewBlock(t *testing.T) {
 	)
 	parent = preMergeBlocks[len(preMergeBlocks)-1]
 	for i := 0; i < 10; i++ {
-		execData, err := api.assembleBlock(parent.Hash(), &beacon.PayloadAttributesV1{
+		execData, _, err := api.assembleBlock(parent.Hash(), &beacon.PayloadAttributesV1{
 			Timestamp: parent.Time() + 6,
 		})
 		if err != nil {
@@ -467,3 +576,65 @@ func TestFullAPI(t *testing.T) {
 		parent = ethservice.BlockChain().CurrentBlock()
 	}
 }
+
+func TestExecutePayloadV3VersionedHashes(t *testing.T) {
+	genesis, preMergeBlocks := generatePreMergeShardingChain(10)
+	n, ethservice := startEthService(t, genesis, preMergeBlocks)
+	ethservice.Merger().ReachTTD()
+	defer n.Close()
+
+	var (
+		api    = NewConsensusAPI(ethservice)
+		parent = ethservice.BlockChain().CurrentBlock()
+	)
+	tx := newBlobTx(ethservice.BlockChain().Config(), 10, 2)
+	if err := ethservice.TxPool().AddLocal(tx); err != nil {
+		t.Fatalf("failed to add blob transaction: %v", err)
+	}
+	params := beacon.PayloadAttributesV1{
+		Timestamp:             parent.Time() + 1,
+		SuggestedFeeRecipient: parent.Coinbase(),
+	}
+	fcState := beacon.ForkchoiceStateV1{
+		HeadBlockHash:      parent.Hash(),
+		SafeBlockHash:      common.Hash{},
+		FinalizedBlockHash: common.Hash{},
+	}
+	if _, err := api.ForkchoiceUpdatedV1(fcState, &params); err != nil {
+		t.Fatalf("error preparing payload, err=%v", err)
+	}
+	payload, err := api.GetPayloadV3(computePayloadId(parent.Hash(), &params))
+	if err != nil {
+		t.Fatalf("can't get payload: %v", err)
+	}
+	if payload.ExecutionPayload.ExcessDataGas == nil {
+		t.Fatalf("payload is missing the excess data gas")
+	}
+	hashes := tx.DataHashes()
+	for i, versionedHashes := range [][]common.Hash{
+		nil,                            // missing hashes
+		hashes[:1],                     // too few hashes
+		append(hashes, hashes[0]),      // too many hashes
+		{hashes[0], common.Hash{0x01}}, // mismatching hash
+	} {
+		resp, err := api.ExecutePayloadV3(*payload.ExecutionPayload, versionedHashes)
+		if err == nil {
+			t.Errorf("test %d: expected versioned hash mismatch error", i)
+		}
+		if resp.Status != beacon.INVALID.Status {
+			t.Errorf("test %d: invalid status: have %v, want %v", i, resp.Status, beacon.INVALID.Status)
+		}
+	}
+	resp, err := api.ExecutePayloadV3(*payload.ExecutionPayload, hashes)
+	if err != nil {
+		t.Fatalf("can't execute payload: %v", err)
+	}
+	if resp.Status != beacon.VALID.Status {
+		t.Fatalf("invalid status: %v", resp.Status)
+	}
+	// The blobs known to the pool are persisted along with the imported block
+	sidecars := ethservice.BlockChain().GetBlobSidecarsByHash(payload.ExecutionPayload.BlockHash)
+	if len(sidecars) != 1 || len(sidecars[0].Blobs) != 2 {
+		t.Fatalf("blob sidecars not persisted on import: have %v", sidecars)
+	}
+}
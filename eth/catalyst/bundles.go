@@ -0,0 +1,147 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/core/beacon"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// maxTrackedBundles is the maximum number of blobs bundles the execution
+	// engine tracks before evicting old ones. It matches the number of tracked
+	// payloads, as there's no use for a bundle without its payload.
+	maxTrackedBundles = maxTrackedPayloads
+
+	// maxBundleCacheSize is the maximum number of bytes the tracked blobs bundles
+	// may occupy in total. Whenever it's exceeded, the oldest bundles get evicted.
+	maxBundleCacheSize = 16 * 1024 * 1024
+
+	// maxBundleAge is the time after which a blobs bundle is evicted, even if
+	// there's space left for it. The beacon chain is expected to retrieve it
+	// within the slot it was built for.
+	maxBundleAge = 2 * time.Minute
+)
+
+var (
+	bundleHitMeter   = metrics.NewRegisteredMeter("eth/catalyst/bundles/hit", nil)
+	bundleMissMeter  = metrics.NewRegisteredMeter("eth/catalyst/bundles/miss", nil)
+	bundleEvictMeter = metrics.NewRegisteredMeter("eth/catalyst/bundles/evict", nil)
+	bundleSizeGauge  = metrics.NewRegisteredGauge("eth/catalyst/bundles/size", nil)
+)
+
+// bundleCacheItem represents an id->bundle tuple to store until it's evicted.
+type bundleCacheItem struct {
+	id     beacon.PayloadID
+	bundle *beacon.BlobsBundleV1
+	size   uint64
+	time   mclock.AbsTime
+}
+
+// bundleCache tracks the blobs bundles of the latest handful of constructed
+// payloads, bounded both by their total size and by their age.
+type bundleCache struct {
+	items []*bundleCacheItem // Tracked bundles, ordered from oldest to newest
+	size  uint64             // Total size of the tracked bundles
+	clock mclock.Clock       // Time source to expire bundles with
+	lock  sync.Mutex
+}
+
+// newBundleCache creates an empty blobs bundle cache.
+func newBundleCache(clock mclock.Clock) *bundleCache {
+	return &bundleCache{
+		clock: clock,
+	}
+}
+
+// put inserts the blobs bundle of a new payload into the cache at the given id,
+// evicting expired bundles and, if the cache is full, the oldest ones.
+func (c *bundleCache) put(id beacon.PayloadID, bundle *beacon.BlobsBundleV1) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Drop any previous bundle at the same id, it's superseded by the new one
+	for i, item := range c.items {
+		if item.id == id {
+			c.size -= item.size
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			break
+		}
+	}
+	c.items = append(c.items, &bundleCacheItem{
+		id:     id,
+		bundle: bundle,
+		size:   bundleSize(bundle),
+		time:   c.clock.Now(),
+	})
+	c.size += c.items[len(c.items)-1].size
+
+	// Evict the oldest bundles until the cache is within its limits, always
+	// keeping the newly inserted one
+	c.expire()
+	for len(c.items) > 1 && (len(c.items) > maxTrackedBundles || c.size > maxBundleCacheSize) {
+		c.evict()
+	}
+	bundleSizeGauge.Update(int64(c.size))
+}
+
+// get retrieves a previously stored blobs bundle or nil if it does not exist,
+// either because it was never added or because it was already evicted.
+func (c *bundleCache) get(id beacon.PayloadID) *beacon.BlobsBundleV1 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.expire()
+	bundleSizeGauge.Update(int64(c.size))
+
+	for _, item := range c.items {
+		if item.id == id {
+			bundleHitMeter.Mark(1)
+			return item.bundle
+		}
+	}
+	bundleMissMeter.Mark(1)
+	return nil
+}
+
+// expire evicts all the bundles that have been tracked for longer than allowed.
+// The caller must hold the cache lock.
+func (c *bundleCache) expire() {
+	now := c.clock.Now()
+	for len(c.items) > 0 && time.Duration(now-c.items[0].time) > maxBundleAge {
+		c.evict()
+	}
+}
+
+// evict drops the oldest tracked bundle. The caller must hold the cache lock.
+func (c *bundleCache) evict() {
+	c.size -= c.items[0].size
+	c.items[0] = nil
+	c.items = c.items[1:]
+
+	bundleEvictMeter.Mark(1)
+}
+
+// bundleSize returns the approximate number of bytes a blobs bundle occupies.
+func bundleSize(bundle *beacon.BlobsBundleV1) uint64 {
+	return uint64(len(bundle.Blobs)*len(kzg.Blob{}) + len(bundle.KZGs)*len(kzg.KZGCommitment{}))
+}
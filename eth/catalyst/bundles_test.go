@@ -0,0 +1,97 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/core/beacon"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+)
+
+// newTestBundle creates a blobs bundle with the given number of empty blobs.
+func newTestBundle(blobs int) *beacon.BlobsBundleV1 {
+	return &beacon.BlobsBundleV1{
+		KZGs:  make([]kzg.KZGCommitment, blobs),
+		Blobs: make([]kzg.Blob, blobs),
+	}
+}
+
+// Tests that the bundle cache evicts the oldest bundles when too many of them
+// are tracked.
+func TestBundleCacheCountEviction(t *testing.T) {
+	cache := newBundleCache(new(mclock.Simulated))
+	for i := 0; i < maxTrackedBundles+2; i++ {
+		cache.put(beacon.PayloadID{byte(i)}, newTestBundle(0))
+	}
+	for i := 0; i < maxTrackedBundles+2; i++ {
+		bundle := cache.get(beacon.PayloadID{byte(i)})
+		if evicted := i < 2; evicted != (bundle == nil) {
+			t.Errorf("bundle %d: evicted mismatch: have %v, want %v", i, bundle == nil, evicted)
+		}
+	}
+}
+
+// Tests that the bundle cache evicts the oldest bundles when the tracked blobs
+// exceed the allowed size, but always retains the latest one.
+func TestBundleCacheSizeEviction(t *testing.T) {
+	var (
+		cache = newBundleCache(new(mclock.Simulated))
+		blobs = maxBundleCacheSize / len(kzg.Blob{}) / 3
+	)
+	for i := 0; i < 4; i++ {
+		cache.put(beacon.PayloadID{byte(i)}, newTestBundle(blobs))
+	}
+	for i := 0; i < 4; i++ {
+		bundle := cache.get(beacon.PayloadID{byte(i)})
+		if evicted := i < 1; evicted != (bundle == nil) {
+			t.Errorf("bundle %d: evicted mismatch: have %v, want %v", i, bundle == nil, evicted)
+		}
+	}
+	// Insert a bundle larger than the entire cache, it should still be retained
+	cache.put(beacon.PayloadID{0xff}, newTestBundle(2*maxBundleCacheSize/len(kzg.Blob{})))
+	if cache.get(beacon.PayloadID{0xff}) == nil {
+		t.Errorf("oversized bundle evicted")
+	}
+	if len(cache.items) != 1 {
+		t.Errorf("tracked bundle count mismatch: have %d, want %d", len(cache.items), 1)
+	}
+}
+
+// Tests that the bundle cache evicts bundles once they become too old.
+func TestBundleCacheAgeEviction(t *testing.T) {
+	var (
+		clock = new(mclock.Simulated)
+		cache = newBundleCache(clock)
+	)
+	cache.put(beacon.PayloadID{0}, newTestBundle(1))
+	clock.Run(maxBundleAge / 2)
+	cache.put(beacon.PayloadID{1}, newTestBundle(1))
+	clock.Run(maxBundleAge/2 + time.Second)
+
+	if cache.get(beacon.PayloadID{0}) != nil {
+		t.Errorf("expired bundle retained")
+	}
+	if cache.get(beacon.PayloadID{1}) == nil {
+		t.Errorf("live bundle evicted")
+	}
+	if cache.size != bundleSize(newTestBundle(1)) {
+		t.Errorf("cache size mismatch: have %d, want %d", cache.size, bundleSize(newTestBundle(1)))
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/event"
@@ -56,6 +57,12 @@ type downloadTester struct {
 
 // newTester creates a new downloader test mocker.
 func newTester() *downloadTester {
+	return newTesterWithConfig(params.TestChainConfig)
+}
+
+// newTesterWithConfig creates a new downloader test mocker whose local chain runs
+// with the given chain config.
+func newTesterWithConfig(config *params.ChainConfig) *downloadTester {
 	freezer, err := ioutil.TempDir("", "")
 	if err != nil {
 		panic(err)
@@ -66,7 +73,7 @@ func newTester() *downloadTester {
 	}
 	core.GenesisBlockForTesting(db, testAddress, big.NewInt(1000000000000000))
 
-	chain, err := core.NewBlockChain(db, nil, params.TestChainConfig, ethash.NewFaker(), vm.Config{}, nil, nil)
+	chain, err := core.NewBlockChain(db, nil, config, ethash.NewFaker(), vm.Config{}, nil, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -449,6 +456,75 @@ func testCanonSync(t *testing.T, protocol uint, mode SyncMode) {
 	assertOwnChain(t, tester, len(chain.blocks))
 }
 
+// Tests that chains containing blob transactions can be synchronised, the bodies
+// carrying the transactions in their canonical form, without any blobs.
+func TestBlobSynchronisation66Full(t *testing.T)  { testBlobSync(t, eth.ETH66, FullSync) }
+func TestBlobSynchronisation66Snap(t *testing.T)  { testBlobSync(t, eth.ETH66, SnapSync) }
+func TestBlobSynchronisation66Light(t *testing.T) { testBlobSync(t, eth.ETH66, LightSync) }
+
+func testBlobSync(t *testing.T, protocol uint, mode SyncMode) {
+	tester := newTesterWithConfig(params.TestShardingChainConfig)
+	defer tester.terminate()
+
+	chain := testChainBlobs
+	tester.newPeer("peer", protocol, chain.blocks[1:])
+
+	// Synchronise with the peer and make sure all relevant data was retrieved
+	if err := tester.sync("peer", nil, mode); err != nil {
+		t.Fatalf("failed to synchronise blocks: %v", err)
+	}
+	assertOwnChain(t, tester, len(chain.blocks))
+
+	// Ensure the header fields of the sharding fork made it through too
+	for _, block := range chain.blocks[1:] {
+		header := tester.chain.GetHeaderByHash(block.Hash())
+		if header == nil {
+			t.Fatalf("block %d: header missing", block.NumberU64())
+		}
+		if header.ExcessDataGas == nil || header.ExcessDataGas.Cmp(block.ExcessDataGas()) != 0 {
+			t.Fatalf("block %d: excess data gas mismatch: have %v, want %v", block.NumberU64(), header.ExcessDataGas, block.ExcessDataGas())
+		}
+	}
+	if mode == LightSync {
+		return
+	}
+	// Ensure the blob transactions were retrieved without any blobs, and that
+	// their blobs play no part in the body validation
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0
+
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}},
+		Commitments: []kzg.KZGCommitment{commitment},
+		Proofs:      []kzg.KZGProof{{0xc0}},
+	}
+	blobs := 0
+	for _, block := range chain.blocks[1:] {
+		synced := tester.chain.GetBlockByHash(block.Hash())
+		if synced == nil {
+			t.Fatalf("block %d: body missing", block.NumberU64())
+		}
+		var wrapped types.Transactions
+		for _, tx := range synced.Transactions() {
+			if tx.Type() != types.BlobTxType {
+				wrapped = append(wrapped, tx)
+				continue
+			}
+			if tx.BlobTxSidecar() != nil {
+				t.Fatalf("block %d: synced blob transaction carries blobs", block.NumberU64())
+			}
+			wrapped = append(wrapped, tx.WithBlobTxSidecar(sidecar))
+			blobs++
+		}
+		if hash := types.DeriveSha(wrapped, trie.NewStackTrie(nil)); hash != block.TxHash() {
+			t.Fatalf("block %d: transaction root depends on blobs: have %x, want %x", block.NumberU64(), hash, block.TxHash())
+		}
+	}
+	if want := len(chain.blocks) / 10; blobs < want {
+		t.Fatalf("synchronised blob transaction count mismatch: have %d, want at least %d", blobs, want)
+	}
+}
+
 // Tests that if a large batch of blocks are being downloaded, it is throttled
 // until the cached blocks are retrieved.
 func TestThrottling66Full(t *testing.T) { testThrottling(t, eth.ETH66, FullSync) }
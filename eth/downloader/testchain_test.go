@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -46,6 +47,9 @@ var testChainBase *testChain
 // Different forks on top of the base chain:
 var testChainForkLightA, testChainForkLightB, testChainForkHeavy *testChain
 
+// A chain on top of the genesis containing blob transactions:
+var testChainBlobs *testChain
+
 var pregenerated bool
 
 func init() {
@@ -57,6 +61,7 @@ func init() {
 	fsHeaderContCheck = 500 * time.Millisecond
 
 	testChainBase = newTestChain(blockCacheMaxItems+200, testGenesis)
+	testChainBlobs = newTestBlobChain(3*fsMinFullBlocks, testGenesis)
 
 	var forkLen = int(fullMaxForkAncestry + 50)
 	var wg sync.WaitGroup
@@ -97,13 +102,17 @@ func init() {
 		testChainForkLightB.shorten(len(testChainBase.blocks) + MaxHeaderFetch),
 		testChainForkHeavy.shorten(len(testChainBase.blocks) + 79),
 	}
-	wg.Add(len(chains))
+	wg.Add(len(chains) + 1)
 	for _, chain := range chains {
 		go func(blocks []*types.Block) {
 			newTestBlockchain(blocks)
 			wg.Done()
 		}(chain.blocks[1:])
 	}
+	go func() {
+		newTestBlockchainWithConfig(params.TestShardingChainConfig, testChainBlobs.blocks[1:])
+		wg.Done()
+	}()
 	wg.Wait()
 
 	// Mark the chains pregenerated. Generating a new one will lead to a panic.
@@ -123,6 +132,37 @@ func newTestChain(length int, genesis *types.Block) *testChain {
 	return tc
 }
 
+// newTestBlobChain creates a blockchain of the given length, where every 10th
+// block contains a blob transaction. The blocks only carry the transactions in
+// their canonical form, without the blobs themselves.
+func newTestBlobChain(length int, genesis *types.Block) *testChain {
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0 // point at infinity, committing to the empty blob
+
+	blocks, _ := core.GenerateChain(params.TestShardingChainConfig, genesis, ethash.NewFaker(), testDB, length-1, func(i int, block *core.BlockGen) {
+		if i%10 != 0 {
+			return
+		}
+		signer := types.MakeSigner(params.TestShardingChainConfig, block.Number(), block.Time())
+		tx, err := types.SignNewTx(testKey, signer, &types.BlobTx{
+			ChainID:             params.TestShardingChainConfig.ChainID,
+			Nonce:               block.TxNonce(testAddress),
+			GasTipCap:           common.Big0,
+			GasFeeCap:           block.BaseFee(),
+			Gas:                 params.TxGas,
+			To:                  &common.Address{0xb1},
+			Value:               big.NewInt(1000),
+			MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+			BlobVersionedHashes: []common.Hash{commitment.ComputeVersionedHash()},
+		})
+		if err != nil {
+			panic(err)
+		}
+		block.AddTx(tx)
+	})
+	return &testChain{blocks: append([]*types.Block{genesis}, blocks...)}
+}
+
 // makeFork creates a fork on top of the test chain.
 func (tc *testChain) makeFork(length int, heavy bool, seed byte) *testChain {
 	fork := tc.copy(len(tc.blocks) + length)
@@ -162,7 +202,7 @@ func (tc *testChain) generate(n int, seed byte, parent *types.Block, heavy bool)
 		}
 		// Include transactions to the miner to make blocks more interesting.
 		if parent == tc.blocks[0] && i%22 == 0 {
-			signer := types.MakeSigner(params.TestChainConfig, block.Number())
+			signer := types.MakeSigner(params.TestChainConfig, block.Number(), block.Time())
 			tx, err := types.SignTx(types.NewTransaction(block.TxNonce(testAddress), common.Address{seed}, big.NewInt(1000), params.TxGas, block.BaseFee(), nil), signer, testKey)
 			if err != nil {
 				panic(err)
@@ -194,6 +234,13 @@ type testBlockchain struct {
 // either actually running them, or reusing a previously created one. The returned
 // chains are *shared*, so *do not* mutate them.
 func newTestBlockchain(blocks []*types.Block) *core.BlockChain {
+	return newTestBlockchainWithConfig(params.TestChainConfig, blocks)
+}
+
+// newTestBlockchainWithConfig is like newTestBlockchain, but runs the blocks with
+// the given chain config. Previously created chains are reused regardless of the
+// config they were created with.
+func newTestBlockchainWithConfig(config *params.ChainConfig, blocks []*types.Block) *core.BlockChain {
 	// Retrieve an existing database, or create a new one
 	head := testGenesis.Hash()
 	if len(blocks) > 0 {
@@ -214,7 +261,7 @@ func newTestBlockchain(blocks []*types.Block) *core.BlockChain {
 		db := rawdb.NewMemoryDatabase()
 		core.GenesisBlockForTesting(db, testAddress, big.NewInt(1000000000000000))
 
-		chain, err := core.NewBlockChain(db, nil, params.TestChainConfig, ethash.NewFaker(), vm.Config{}, nil, nil)
+		chain, err := core.NewBlockChain(db, nil, config, ethash.NewFaker(), vm.Config{}, nil, nil)
 		if err != nil {
 			panic(err)
 		}
@@ -74,6 +74,7 @@ var Defaults = Config{
 	},
 	NetworkId:               1,
 	TxLookupLimit:           2350000,
+	BlobRetention:           129600,
 	LightPeers:              100,
 	UltraLightFraction:      75,
 	DatabaseCache:           512,
@@ -137,6 +138,12 @@ type Config struct {
 	NoPrefetch bool // Whether to disable prefetching and only load state on demand
 
 	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
+	BlobRetention uint64 `toml:",omitempty"` // The maximum number of blocks from head whose blob sidecars are retained.
+	BlobHistory   bool   `toml:",omitempty"` // Whether to serve retained blob sidecars over discovery and retrieve pruned ones from other nodes.
+
+	// RPC endpoints of trusted nodes to forward blob transactions to, instead of
+	// announcing them to peers
+	BlobForwardURLs []string `toml:",omitempty"`
 
 	// Whitelist of required block number -> hash values to accept
 	Whitelist map[uint64]common.Hash `toml:"-"`
@@ -197,6 +204,9 @@ type Config struct {
 	// send-transction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// RPCBeaconBlobs returns blob sidecars over RPC in the beacon API format.
+	RPCBeaconBlobs bool
+
 	// Checkpoint is a hardcoded checkpoint which can be nil.
 	Checkpoint *params.TrustedCheckpoint `toml:",omitempty"`
 
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	mrand "math/rand"
 	"sort"
+	"sync"
 	"time"
 
 	mapset "github.com/deckarep/golang-set"
@@ -29,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 )
@@ -53,6 +55,10 @@ const (
 	// re-request them.
 	maxTxUnderpricedSetSize = 32768
 
+	// blobFailureLogInterval is the minimum time between two warnings about blob
+	// transactions rejected for their blobs, to avoid peers flooding the logs.
+	blobFailureLogInterval = 10 * time.Second
+
 	// txArriveTimeout is the time allowance before an announced transaction is
 	// explicitly requested.
 	txArriveTimeout = 500 * time.Millisecond
@@ -176,6 +182,10 @@ type TxFetcher struct {
 	step  chan struct{} // Notification channel when the fetcher loop iterates
 	clock mclock.Clock  // Time wrapper to simulate in tests
 	rand  *mrand.Rand   // Randomizer to use in tests instead of map range loops (soft-random)
+
+	blobFailureLock       sync.Mutex     // Lock protecting the blob failure log limiter
+	blobFailureNext       mclock.AbsTime // Time from which on the next blob failure may be logged
+	blobFailureSuppressed int            // Number of blob failures not logged since the last one
 }
 
 // NewTxFetcher creates a transaction fetcher to retrieve transaction
@@ -211,6 +221,53 @@ func NewTxFetcherForTests(
 	}
 }
 
+// classifyBlobFailure returns the class of failure a blob transaction was rejected
+// for along with the index of the offending blob, or -1 if the failure doesn't
+// concern a single blob. Failures unrelated to blobs have an empty class.
+func classifyBlobFailure(err error) (string, int) {
+	index := -1
+	if berr := new(kzg.BlobError); errors.As(err, &berr) {
+		index = berr.Index
+	}
+	switch {
+	case err == nil:
+		return "", -1
+	case errors.Is(err, core.ErrMissingBlobSidecar):
+		return "missing", index
+	case errors.Is(err, core.ErrTooManyBlobs):
+		return "toomany", index
+	case errors.Is(err, types.ErrInvalidBlobTxSidecar), errors.Is(err, kzg.ErrBatchLengthMismatch):
+		return "shape", index
+	case errors.Is(err, types.ErrBlobHashMismatch):
+		return "hash", index
+	case errors.Is(err, kzg.ErrInvalidFieldElement):
+		return "field", index
+	case errors.Is(err, kzg.ErrInvalidCommitment):
+		return "commitment", index
+	case errors.Is(err, kzg.ErrInvalidProof):
+		return "proof", index
+	case errors.Is(err, kzg.ErrProofMismatch):
+		return "mismatch", index
+	default:
+		return "", -1
+	}
+}
+
+// logBlobFailure warns about a blob transaction rejected for its blobs, at most
+// once every blobFailureLogInterval, counting the failures not logged in between.
+func (f *TxFetcher) logBlobFailure(peer string, hash common.Hash, class string, index int, err error) {
+	f.blobFailureLock.Lock()
+	defer f.blobFailureLock.Unlock()
+
+	now := f.clock.Now()
+	if now < f.blobFailureNext {
+		f.blobFailureSuppressed++
+		return
+	}
+	log.Warn("Rejected blob transaction", "peer", peer, "hash", hash, "class", class, "blob", index, "suppressed", f.blobFailureSuppressed, "err", err)
+	f.blobFailureNext, f.blobFailureSuppressed = now.Add(blobFailureLogInterval), 0
+}
+
 // Notify announces the fetcher of the potential availability of a new batch of
 // transactions in the network.
 func (f *TxFetcher) Notify(peer string, hashes []common.Hash) error {
@@ -287,6 +344,10 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 			}
 			f.underpriced.Add(txs[i].Hash())
 		}
+		// Report transactions rejected for their blobs
+		if class, index := classifyBlobFailure(err); class != "" {
+			f.logBlobFailure(peer, txs[i].Hash(), class, index, err)
+		}
 		// Track a few interesting failure types
 		switch {
 		case err == nil: // Noop, but need to handle to not count these
@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 )
 
 var (
@@ -1517,6 +1518,70 @@ func testTransactionFetcher(t *testing.T, tt txFetcherTest) {
 	}
 }
 
+// Tests that the reasons blob transactions are rejected for are classified along
+// with the offending blob.
+func TestClassifyBlobFailure(t *testing.T) {
+	commitment := kzg.KZGCommitment{0xc0} // point at infinity, committing to the empty blob
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}, {}},
+		Commitments: []kzg.KZGCommitment{commitment, commitment},
+		Proofs:      []kzg.KZGProof{{0xc0}, {0xc0}},
+	}
+	hash := commitment.ComputeVersionedHash()
+
+	var nonCanonical kzg.Blob
+	for i := 0; i < 32; i++ {
+		nonCanonical[i] = 0xff // exceeds the BLS modulus
+	}
+
+	tests := []struct {
+		err   error
+		class string
+		index int
+	}{
+		{nil, "", -1},
+		{core.ErrUnderpriced, "", -1},
+		{core.ErrMissingBlobSidecar, "missing", -1},
+		{core.ErrTooManyBlobs, "toomany", -1},
+		{sidecar.Verify([]common.Hash{hash}), "shape", -1},
+		{sidecar.Verify([]common.Hash{hash, {0x01}}), "hash", 1},
+		{(&types.BlobTxSidecar{
+			Blobs:       []kzg.Blob{{}, nonCanonical},
+			Commitments: sidecar.Commitments,
+			Proofs:      sidecar.Proofs,
+		}).Verify([]common.Hash{hash, hash}), "field", 1},
+		{(&types.BlobTxSidecar{
+			Blobs:       sidecar.Blobs,
+			Commitments: sidecar.Commitments,
+			Proofs:      []kzg.KZGProof{{0xc0}, {0x01}},
+		}).Verify([]common.Hash{hash, hash}), "proof", 1},
+	}
+	for i, tt := range tests {
+		if class, index := classifyBlobFailure(tt.err); class != tt.class || index != tt.index {
+			t.Errorf("test %d: classification mismatch for %v: have %q/%d, want %q/%d", i, tt.err, class, index, tt.class, tt.index)
+		}
+	}
+}
+
+// Tests that blob failures are logged at most once per interval, counting the
+// ones suppressed in between.
+func TestBlobFailureLogLimit(t *testing.T) {
+	clock := new(mclock.Simulated)
+	fetcher := NewTxFetcherForTests(nil, nil, nil, clock, nil)
+
+	for i := 0; i < 3; i++ {
+		fetcher.logBlobFailure("peer", common.Hash{}, "field", 0, kzg.ErrInvalidFieldElement)
+	}
+	if fetcher.blobFailureSuppressed != 2 {
+		t.Fatalf("suppressed failures mismatch: have %d, want 2", fetcher.blobFailureSuppressed)
+	}
+	clock.Run(blobFailureLogInterval)
+	fetcher.logBlobFailure("peer", common.Hash{}, "field", 0, kzg.ErrInvalidFieldElement)
+	if fetcher.blobFailureSuppressed != 0 || fetcher.blobFailureNext != clock.Now().Add(blobFailureLogInterval) {
+		t.Fatalf("failure not logged after the interval")
+	}
+}
+
 // containsHash returns whether a hash is contained within a hash slice.
 func containsHash(slice []common.Hash, hash common.Hash) bool {
 	for _, have := range slice {
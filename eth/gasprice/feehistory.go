@@ -61,6 +61,9 @@ type processedFees struct {
 	reward               []*big.Int
 	baseFee, nextBaseFee *big.Int
 	gasUsedRatio         float64
+	dataGasUsed          uint64
+	excessDataGas        *big.Int
+	nextDataGasPrice     *big.Int
 }
 
 // txGasAndReward is sorted in ascending order based on reward
@@ -94,6 +97,21 @@ func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 		bf.results.nextBaseFee = new(big.Int)
 	}
 	bf.results.gasUsedRatio = float64(bf.header.GasUsed) / float64(bf.header.GasLimit)
+
+	// Fill in the data gas accounting of EIP-4844 blocks. The price of data gas in
+	// the next block is derived from the excess data gas of this one.
+	bf.results.excessDataGas, bf.results.nextDataGasPrice = new(big.Int), new(big.Int)
+	if bf.header.ExcessDataGas != nil {
+		bf.results.excessDataGas.Set(bf.header.ExcessDataGas)
+		bf.results.nextDataGasPrice = misc.GetDataGasPrice(chainconfig, bf.header.ExcessDataGas)
+		if bf.block == nil {
+			log.Error("Block is missing while data gas used is requested")
+			return
+		}
+		for _, tx := range bf.block.Transactions() {
+			bf.results.dataGasUsed += tx.DataGas()
+		}
+	}
 	if len(percentiles) == 0 {
 		// rewards were not requested, return null
 		return
@@ -183,16 +201,19 @@ func (oracle *Oracle) resolveBlockRange(ctx context.Context, lastBlock rpc.Block
 // or blocks older than a certain age (specified in maxHistory). The first block of the
 // actually processed range is returned to avoid ambiguity when parts of the requested range
 // are not available or when the head has changed during processing this request.
-// Three arrays are returned based on the processed blocks:
+// Six arrays are returned based on the processed blocks:
 // - reward: the requested percentiles of effective priority fees per gas of transactions in each
 //   block, sorted in ascending order and weighted by gas used.
 // - baseFee: base fee per gas in the given block
 // - gasUsedRatio: gasUsed/gasLimit in the given block
-// Note: baseFee includes the next block after the newest of the returned range, because this
-// value can be derived from the newest block.
-func (oracle *Oracle) FeeHistory(ctx context.Context, blocks int, unresolvedLastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
+// - dataGasUsed: data gas consumed by the blob transactions in the given block
+// - excessDataGas: excess data gas of the given block
+// - dataGasPrice: price per data gas in the given block
+// Note: baseFee and dataGasPrice include the next block after the newest of the returned range,
+// because these values can be derived from the newest block.
+func (oracle *Oracle) FeeHistory(ctx context.Context, blocks int, unresolvedLastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, []uint64, []*big.Int, []*big.Int, error) {
 	if blocks < 1 {
-		return common.Big0, nil, nil, nil, nil // returning with no data and no error means there are no retrievable blocks
+		return common.Big0, nil, nil, nil, nil, nil, nil, nil // returning with no data and no error means there are no retrievable blocks
 	}
 	maxFeeHistory := oracle.maxHeaderHistory
 	if len(rewardPercentiles) != 0 {
@@ -204,10 +225,10 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks int, unresolvedLast
 	}
 	for i, p := range rewardPercentiles {
 		if p < 0 || p > 100 {
-			return common.Big0, nil, nil, nil, fmt.Errorf("%w: %f", errInvalidPercentile, p)
+			return common.Big0, nil, nil, nil, nil, nil, nil, fmt.Errorf("%w: %f", errInvalidPercentile, p)
 		}
 		if i > 0 && p < rewardPercentiles[i-1] {
-			return common.Big0, nil, nil, nil, fmt.Errorf("%w: #%d:%f > #%d:%f", errInvalidPercentile, i-1, rewardPercentiles[i-1], i, p)
+			return common.Big0, nil, nil, nil, nil, nil, nil, fmt.Errorf("%w: #%d:%f > #%d:%f", errInvalidPercentile, i-1, rewardPercentiles[i-1], i, p)
 		}
 	}
 	var (
@@ -217,7 +238,7 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks int, unresolvedLast
 	)
 	pendingBlock, pendingReceipts, lastBlock, blocks, err := oracle.resolveBlockRange(ctx, unresolvedLastBlock, blocks)
 	if err != nil || blocks == 0 {
-		return common.Big0, nil, nil, nil, err
+		return common.Big0, nil, nil, nil, nil, nil, nil, err
 	}
 	oldestBlock := lastBlock + 1 - uint64(blocks)
 
@@ -262,6 +283,11 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks int, unresolvedLast
 							}
 						} else {
 							fees.header, fees.err = oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(blockNumber))
+
+							// Data gas used can only be gathered from the transactions
+							if fees.header != nil && fees.header.ExcessDataGas != nil && fees.err == nil {
+								fees.block, fees.err = oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(blockNumber))
+							}
 						}
 						if fees.header != nil && fees.err == nil {
 							oracle.processBlock(fees, rewardPercentiles)
@@ -277,19 +303,23 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks int, unresolvedLast
 		}()
 	}
 	var (
-		reward       = make([][]*big.Int, blocks)
-		baseFee      = make([]*big.Int, blocks+1)
-		gasUsedRatio = make([]float64, blocks)
-		firstMissing = blocks
+		reward        = make([][]*big.Int, blocks)
+		baseFee       = make([]*big.Int, blocks+1)
+		gasUsedRatio  = make([]float64, blocks)
+		dataGasUsed   = make([]uint64, blocks)
+		excessDataGas = make([]*big.Int, blocks)
+		dataGasPrice  = make([]*big.Int, blocks+1)
+		firstMissing  = blocks
 	)
 	for ; blocks > 0; blocks-- {
 		fees := <-results
 		if fees.err != nil {
-			return common.Big0, nil, nil, nil, fees.err
+			return common.Big0, nil, nil, nil, nil, nil, nil, fees.err
 		}
 		i := int(fees.blockNumber - oldestBlock)
 		if fees.results.baseFee != nil {
 			reward[i], baseFee[i], baseFee[i+1], gasUsedRatio[i] = fees.results.reward, fees.results.baseFee, fees.results.nextBaseFee, fees.results.gasUsedRatio
+			dataGasUsed[i], excessDataGas[i], dataGasPrice[i+1] = fees.results.dataGasUsed, fees.results.excessDataGas, fees.results.nextDataGasPrice
 		} else {
 			// getting no block and no error means we are requesting into the future (might happen because of a reorg)
 			if i < firstMissing {
@@ -298,7 +328,18 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks int, unresolvedLast
 		}
 	}
 	if firstMissing == 0 {
-		return common.Big0, nil, nil, nil, nil
+		return common.Big0, nil, nil, nil, nil, nil, nil, nil
+	}
+	// The data gas price of the oldest block is derived from its parent
+	dataGasPrice[0] = new(big.Int)
+	if oldestBlock > 0 {
+		parent, err := oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(oldestBlock-1))
+		if err != nil {
+			return common.Big0, nil, nil, nil, nil, nil, nil, err
+		}
+		if parent != nil && parent.ExcessDataGas != nil {
+			dataGasPrice[0] = misc.GetDataGasPrice(oracle.backend.ChainConfig(), parent.ExcessDataGas)
+		}
 	}
 	if len(rewardPercentiles) != 0 {
 		reward = reward[:firstMissing]
@@ -306,5 +347,6 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks int, unresolvedLast
 		reward = nil
 	}
 	baseFee, gasUsedRatio = baseFee[:firstMissing+1], gasUsedRatio[:firstMissing]
-	return new(big.Int).SetUint64(oldestBlock), reward, baseFee, gasUsedRatio, nil
+	dataGasUsed, excessDataGas, dataGasPrice = dataGasUsed[:firstMissing], excessDataGas[:firstMissing], dataGasPrice[:firstMissing+1]
+	return new(big.Int).SetUint64(oldestBlock), reward, baseFee, gasUsedRatio, dataGasUsed, excessDataGas, dataGasPrice, nil
 }
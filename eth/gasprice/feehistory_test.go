@@ -22,6 +22,10 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -59,7 +63,7 @@ func TestFeeHistory(t *testing.T) {
 		backend := newTestBackend(t, big.NewInt(16), c.pending)
 		oracle := NewOracle(backend, config)
 
-		first, reward, baseFee, ratio, err := oracle.FeeHistory(context.Background(), c.count, c.last, c.percent)
+		first, reward, baseFee, ratio, dataGasUsed, excessDataGas, dataGasPrice, err := oracle.FeeHistory(context.Background(), c.count, c.last, c.percent)
 
 		expReward := c.expCount
 		if len(c.percent) == 0 {
@@ -82,8 +86,49 @@ func TestFeeHistory(t *testing.T) {
 		if len(ratio) != c.expCount {
 			t.Fatalf("Test case %d: gasUsedRatio array length mismatch, want %d, got %d", i, c.expCount, len(ratio))
 		}
+		if len(dataGasUsed) != c.expCount {
+			t.Fatalf("Test case %d: dataGasUsed array length mismatch, want %d, got %d", i, c.expCount, len(dataGasUsed))
+		}
+		if len(excessDataGas) != c.expCount {
+			t.Fatalf("Test case %d: excessDataGas array length mismatch, want %d, got %d", i, c.expCount, len(excessDataGas))
+		}
+		if len(dataGasPrice) != expBaseFee {
+			t.Fatalf("Test case %d: dataGasPrice array length mismatch, want %d, got %d", i, expBaseFee, len(dataGasPrice))
+		}
 		if err != c.expErr && !errors.Is(err, c.expErr) {
 			t.Fatalf("Test case %d: error mismatch, want %v, got %v", i, c.expErr, err)
 		}
 	}
 }
+
+func TestFeeHistoryDataGas(t *testing.T) {
+	backend := newTestBackend(t, big.NewInt(16), false)
+	oracle := NewOracle(backend, Config{MaxHeaderHistory: 1000, MaxBlockHistory: 1000})
+
+	header := &types.Header{
+		Number:        big.NewInt(1),
+		GasLimit:      params.GenesisGasLimit,
+		ExcessDataGas: big.NewInt(10 * params.DataGasPerBlob),
+	}
+	txs := []*types.Transaction{
+		types.NewTx(&types.BlobTx{BlobVersionedHashes: []common.Hash{{0x01}, {0x02}}}),
+		types.NewTx(&types.DynamicFeeTx{}),
+		types.NewTx(&types.BlobTx{BlobVersionedHashes: []common.Hash{{0x03}}}),
+	}
+	fees := &blockFees{
+		blockNumber: 1,
+		header:      header,
+		block:       types.NewBlockWithHeader(header).WithBody(txs, nil),
+	}
+	oracle.processBlock(fees, nil)
+
+	if have, want := fees.results.dataGasUsed, uint64(3*params.DataGasPerBlob); have != want {
+		t.Errorf("data gas used mismatch: have %d, want %d", have, want)
+	}
+	if have, want := fees.results.excessDataGas, header.ExcessDataGas; have.Cmp(want) != 0 {
+		t.Errorf("excess data gas mismatch: have %v, want %v", have, want)
+	}
+	if have, want := fees.results.nextDataGasPrice, misc.GetDataGasPrice(backend.ChainConfig(), header.ExcessDataGas); have.Cmp(want) != 0 {
+		t.Errorf("next data gas price mismatch: have %v, want %v", have, want)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
@@ -73,6 +74,9 @@ type Oracle struct {
 	checkBlocks, percentile           int
 	maxHeaderHistory, maxBlockHistory int
 	historyCache                      *lru.Cache
+
+	lastFeesHead                                    common.Hash // Head the fees suggested last were based on
+	lastGasFeeCap, lastGasTipCap, lastDataGasFeeCap *big.Int
 }
 
 // NewOracle returns a new gasprice oracle which can recommend suitable
@@ -175,7 +179,7 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 		results   []*big.Int
 	)
 	for sent < oracle.checkBlocks && number > 0 {
-		go oracle.getBlockValues(ctx, types.MakeSigner(oracle.backend.ChainConfig(), big.NewInt(int64(number))), number, sampleNumber, oracle.ignorePrice, result, quit)
+		go oracle.getBlockValues(ctx, number, sampleNumber, oracle.ignorePrice, result, quit)
 		sent++
 		exp++
 		number--
@@ -198,7 +202,7 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 		// meaningful returned, try to query more blocks. But the maximum
 		// is 2*checkBlocks.
 		if len(res.values) == 1 && len(results)+1+exp < oracle.checkBlocks*2 && number > 0 {
-			go oracle.getBlockValues(ctx, types.MakeSigner(oracle.backend.ChainConfig(), big.NewInt(int64(number))), number, sampleNumber, oracle.ignorePrice, result, quit)
+			go oracle.getBlockValues(ctx, number, sampleNumber, oracle.ignorePrice, result, quit)
 			sent++
 			exp++
 			number--
@@ -221,6 +225,193 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 	return new(big.Int).Set(price), nil
 }
 
+// SuggestDataGasFeeCap returns a data gas fee cap recommendation for blob
+// transactions, so that they have a very high chance to be included in the
+// following blocks.
+//
+// Unlike the tip, the price of data gas is fully determined by the excess data
+// gas of the head block, which grows as long as blocks include more blobs than
+// the target. The growth over the recently checked blocks is extrapolated over
+// as many future blocks to cover the price rising before inclusion.
+func (oracle *Oracle) SuggestDataGasFeeCap(ctx context.Context) (*big.Int, error) {
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return oracle.suggestDataGasFeeCap(ctx, head)
+}
+
+// suggestDataGasFeeCap returns the data gas fee cap recommendation on top of
+// the given head, see SuggestDataGasFeeCap.
+func (oracle *Oracle) suggestDataGasFeeCap(ctx context.Context, head *types.Header) (*big.Int, error) {
+	var (
+		excess = new(big.Int)
+		oldest = new(big.Int)
+		number = head.Number.Uint64()
+		blocks = uint64(oracle.checkBlocks)
+	)
+	if head.ExcessDataGas != nil {
+		excess.Set(head.ExcessDataGas)
+	}
+	if blocks > number {
+		blocks = number
+	}
+	if blocks > 0 {
+		header, err := oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(number-blocks))
+		if err != nil {
+			return nil, err
+		}
+		if header != nil && header.ExcessDataGas != nil {
+			oldest.Set(header.ExcessDataGas)
+		}
+	}
+	if growth := new(big.Int).Sub(excess, oldest); growth.Sign() > 0 {
+		excess.Add(excess, growth)
+	}
+	return misc.GetDataGasPrice(oracle.backend.ChainConfig(), excess), nil
+}
+
+// ForecastDataGasPrices projects the price of data gas over the given number of
+// blocks following the head, so that blob submissions can be scheduled into the
+// cheaper blocks instead of reacting after the price changed.
+//
+// Blob demand is assumed to stay at the average of the recently checked blocks:
+// the excess data gas keeps growing by the demand above the target and drains
+// by the demand below it. The number of the first forecast block is returned
+// along with the average blobs per block and the projected prices. The number
+// of forecast blocks is limited by the maximum header history.
+func (oracle *Oracle) ForecastDataGasPrices(ctx context.Context, blocks int) (*big.Int, float64, []*big.Int, error) {
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	first := new(big.Int).Add(head.Number, common.Big1)
+	if blocks < 1 {
+		return first, 0, nil, nil
+	}
+	if blocks > oracle.maxHeaderHistory {
+		blocks = oracle.maxHeaderHistory
+	}
+	// Measure the average blob demand of the recently checked blocks
+	var (
+		number  = head.Number.Uint64()
+		checked uint64
+		blobs   uint64
+	)
+	for n := uint64(0); n < uint64(oracle.checkBlocks) && n <= number; n++ {
+		block, err := oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(number-n))
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		if block == nil {
+			continue
+		}
+		for _, tx := range block.Transactions() {
+			blobs += uint64(len(tx.DataHashes()))
+		}
+		checked++
+	}
+	var (
+		demand  = new(big.Int)
+		average float64
+	)
+	if checked > 0 {
+		demand.SetUint64(blobs * params.DataGasPerBlob / checked)
+		average = float64(blobs) / float64(checked)
+	}
+	// Project the excess data gas, starting from the one pricing the next block
+	var (
+		config = oracle.backend.ChainConfig()
+		target = new(big.Int).SetUint64(config.ShardingParamsAt(head.Time).TargetDataGasPerBlock())
+		excess = new(big.Int)
+		prices = make([]*big.Int, blocks)
+	)
+	if head.ExcessDataGas != nil {
+		excess.Set(head.ExcessDataGas)
+	}
+	for i := range prices {
+		prices[i] = misc.GetDataGasPrice(config, excess)
+
+		excess.Add(excess, demand)
+		if excess.Cmp(target) < 0 {
+			excess.SetUint64(0)
+		} else {
+			excess.Sub(excess, target)
+		}
+	}
+	return first, average, prices, nil
+}
+
+// SuggestFees returns a fee recommendation for blob transactions, covering both
+// of their fee dimensions: the gas fee cap and tip cap paid for the execution,
+// and the data gas fee cap paid for the blobs.
+//
+// The gas fee cap starts out from the base fee of the next block, which rises
+// while blocks use more than their gas target, so it's raised by as much as the
+// recently checked blocks would raise the base fee, if the demand kept up until
+// inclusion. The data gas fee cap is the one suggested by SuggestDataGasFeeCap.
+func (oracle *Oracle) SuggestFees(ctx context.Context) (gasFeeCap, gasTipCap, dataGasFeeCap *big.Int, err error) {
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	headHash := head.Hash()
+
+	// If the latest fees are still available, return them.
+	oracle.cacheLock.RLock()
+	if headHash == oracle.lastFeesHead {
+		gasFeeCap, gasTipCap, dataGasFeeCap = oracle.lastGasFeeCap, oracle.lastGasTipCap, oracle.lastDataGasFeeCap
+	}
+	oracle.cacheLock.RUnlock()
+	if gasFeeCap != nil {
+		return new(big.Int).Set(gasFeeCap), new(big.Int).Set(gasTipCap), new(big.Int).Set(dataGasFeeCap), nil
+	}
+	if gasTipCap, err = oracle.SuggestTipCap(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+	if dataGasFeeCap, err = oracle.suggestDataGasFeeCap(ctx, head); err != nil {
+		return nil, nil, nil, err
+	}
+	var (
+		config = oracle.backend.ChainConfig()
+		number = head.Number.Uint64()
+		first  uint64
+
+		congested int // Number of checked blocks using more gas than the target
+	)
+	if number >= uint64(oracle.checkBlocks) {
+		first = number - uint64(oracle.checkBlocks) + 1
+	}
+	for n := first; n <= number; n++ {
+		block, err := oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(n))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if block == nil {
+			continue
+		}
+		if block.GasUsed() > block.GasLimit()/params.ElasticityMultiplier {
+			congested++
+		}
+	}
+	// Raise the next base fee by the maximum step for every congested block
+	baseFee := new(big.Int)
+	if config.IsLondon(new(big.Int).Add(head.Number, common.Big1)) {
+		baseFee = misc.CalcBaseFee(config, head)
+		for i := 0; i < congested; i++ {
+			baseFee.Add(baseFee, new(big.Int).Div(baseFee, big.NewInt(params.BaseFeeChangeDenominator)))
+		}
+	}
+	gasFeeCap = new(big.Int).Add(baseFee, gasTipCap)
+
+	oracle.cacheLock.Lock()
+	oracle.lastFeesHead = headHash
+	oracle.lastGasFeeCap, oracle.lastGasTipCap, oracle.lastDataGasFeeCap = gasFeeCap, gasTipCap, dataGasFeeCap
+	oracle.cacheLock.Unlock()
+
+	return new(big.Int).Set(gasFeeCap), new(big.Int).Set(gasTipCap), new(big.Int).Set(dataGasFeeCap), nil
+}
+
 type results struct {
 	values []*big.Int
 	err    error
@@ -254,7 +445,7 @@ func (s *txSorter) Less(i, j int) bool {
 // and sends it to the result channel. If the block is empty or all transactions
 // are sent by the miner itself(it doesn't make any sense to include this kind of
 // transaction prices for sampling), nil gasprice is returned.
-func (oracle *Oracle) getBlockValues(ctx context.Context, signer types.Signer, blockNum uint64, limit int, ignoreUnder *big.Int, result chan results, quit chan struct{}) {
+func (oracle *Oracle) getBlockValues(ctx context.Context, blockNum uint64, limit int, ignoreUnder *big.Int, result chan results, quit chan struct{}) {
 	block, err := oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(blockNum))
 	if block == nil {
 		select {
@@ -263,6 +454,8 @@ func (oracle *Oracle) getBlockValues(ctx context.Context, signer types.Signer, b
 		}
 		return
 	}
+	signer := types.MakeSigner(oracle.backend.ChainConfig(), block.Number(), block.Time())
+
 	// Sort the transaction by effective tip in ascending sort.
 	txs := make([]*types.Transaction, len(block.Transactions()))
 	copy(txs, block.Transactions())
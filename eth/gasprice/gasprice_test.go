@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -190,3 +191,194 @@ func TestSuggestTipCap(t *testing.T) {
 		}
 	}
 }
+
+// dataGasBackend serves headers with a predefined excess data gas.
+type dataGasBackend struct {
+	*testBackend
+	excess []*big.Int
+}
+
+func (b *dataGasBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if number == rpc.LatestBlockNumber {
+		number = rpc.BlockNumber(len(b.excess) - 1)
+	}
+	return &types.Header{Number: big.NewInt(int64(number)), ExcessDataGas: b.excess[number]}, nil
+}
+
+func TestSuggestDataGasFeeCap(t *testing.T) {
+	// Data gas price steps that are large enough to tell apart
+	excess := func(steps int64) *big.Int {
+		return big.NewInt(steps * params.DataGasPriceUpdateFraction)
+	}
+	var cases = []struct {
+		excess []*big.Int // Excess data gas of the chain, nil before EIP-4844
+		expect *big.Int   // Excess data gas to expect the suggestion to be based on
+	}{
+		{[]*big.Int{nil}, excess(0)},                                        // Genesis only
+		{[]*big.Int{nil, nil, nil}, excess(0)},                              // Before EIP-4844
+		{[]*big.Int{nil, excess(0), excess(0)}, excess(0)},                  // No blobs above target
+		{[]*big.Int{excess(0), excess(1), excess(2)}, excess(4)},            // Growing excess
+		{[]*big.Int{excess(3), excess(2), excess(1)}, excess(1)},            // Shrinking excess
+		{[]*big.Int{excess(5), excess(0), excess(1), excess(2)}, excess(4)}, // Beyond checked blocks
+	}
+	for i, c := range cases {
+		backend := &dataGasBackend{testBackend: newTestBackend(t, big.NewInt(0), false), excess: c.excess}
+		oracle := NewOracle(backend, Config{Blocks: 2, Percentile: 60})
+
+		got, err := oracle.SuggestDataGasFeeCap(context.Background())
+		if err != nil {
+			t.Fatalf("Test case %d: failed to retrieve recommended data gas fee cap, %v", i, err)
+		}
+		if want := misc.GetDataGasPrice(backend.ChainConfig(), c.expect); got.Cmp(want) != 0 {
+			t.Fatalf("Test case %d: data gas fee cap mismatch, want %d, got %d", i, want, got)
+		}
+	}
+}
+
+// feesBackend serves a chain of blocks with predefined gas and blob usage.
+type feesBackend struct {
+	*testBackend
+	blocks []*types.Block
+}
+
+func newFeesBackend(t *testing.T, gasUsed []uint64, blobs []int, excess *big.Int) *feesBackend {
+	backend := &feesBackend{testBackend: newTestBackend(t, big.NewInt(0), false)}
+	for i := range gasUsed {
+		header := &types.Header{
+			Number:        big.NewInt(int64(i)),
+			GasLimit:      params.GenesisGasLimit,
+			GasUsed:       gasUsed[i],
+			BaseFee:       big.NewInt(64 * params.GWei),
+			ExcessDataGas: excess,
+		}
+		var txs []*types.Transaction
+		if blobs[i] > 0 {
+			txs = append(txs, types.NewTx(&types.BlobTx{
+				GasTipCap:           new(big.Int),
+				GasFeeCap:           new(big.Int),
+				BlobVersionedHashes: make([]common.Hash, blobs[i]),
+			}))
+		}
+		backend.blocks = append(backend.blocks, types.NewBlockWithHeader(header).WithBody(txs, nil))
+	}
+	return backend
+}
+
+func (b *feesBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	block, err := b.BlockByNumber(ctx, number)
+	if block == nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+func (b *feesBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	if number == rpc.LatestBlockNumber {
+		number = rpc.BlockNumber(len(b.blocks) - 1)
+	}
+	if int(number) >= len(b.blocks) {
+		return nil, nil
+	}
+	return b.blocks[number], nil
+}
+
+func TestSuggestFees(t *testing.T) {
+	const (
+		target = params.GenesisGasLimit / params.ElasticityMultiplier
+		full   = params.GenesisGasLimit
+	)
+	excess := big.NewInt(params.DataGasPriceUpdateFraction)
+
+	var cases = []struct {
+		gasUsed []uint64 // Gas used by the blocks of the chain
+		blobs   []int    // Blobs included in the blocks of the chain
+		baseFee int64    // Base fee in gwei to expect the gas fee cap to be based on
+	}{
+		// Blocks at their targets keep the prices of the next block
+		{[]uint64{target, target, target, target}, []int{0, 0, 2, 0}, 64},
+		// Every congested block raises the base fee by the maximum step
+		{[]uint64{target, full, full, target}, []int{0, 0, 0, 0}, 81},
+		// Blob usage doesn't affect the execution fees
+		{[]uint64{target, target, target, target}, []int{0, 4, 1, 3}, 64},
+		// Blocks beyond the checked ones are ignored
+		{[]uint64{full, target, target, target}, []int{4, 0, 0, 0}, 64},
+	}
+	for i, c := range cases {
+		backend := newFeesBackend(t, c.gasUsed, c.blobs, excess)
+		oracle := NewOracle(backend, Config{Blocks: 3, Percentile: 60, Default: big.NewInt(params.GWei)})
+
+		gasFeeCap, gasTipCap, dataGasFeeCap, err := oracle.SuggestFees(context.Background())
+		if err != nil {
+			t.Fatalf("Test case %d: failed to retrieve recommended fees, %v", i, err)
+		}
+		if want := big.NewInt(params.GWei); gasTipCap.Cmp(want) != 0 {
+			t.Errorf("Test case %d: gas tip cap mismatch, want %d, got %d", i, want, gasTipCap)
+		}
+		if want := big.NewInt((c.baseFee + 1) * params.GWei); gasFeeCap.Cmp(want) != 0 {
+			t.Errorf("Test case %d: gas fee cap mismatch, want %d, got %d", i, want, gasFeeCap)
+		}
+		want, err := oracle.SuggestDataGasFeeCap(context.Background())
+		if err != nil {
+			t.Fatalf("Test case %d: failed to retrieve recommended data gas fee cap, %v", i, err)
+		}
+		if dataGasFeeCap.Cmp(want) != 0 {
+			t.Errorf("Test case %d: data gas fee cap mismatch, want %d, got %d", i, want, dataGasFeeCap)
+		}
+		// Fees suggested on top of the same head are served from the cache
+		backend.blocks[len(backend.blocks)-2] = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(len(backend.blocks) - 2))})
+		if cached, _, _, _ := oracle.SuggestFees(context.Background()); cached.Cmp(gasFeeCap) != 0 {
+			t.Errorf("Test case %d: cached gas fee cap mismatch, want %d, got %d", i, gasFeeCap, cached)
+		}
+	}
+}
+
+func TestForecastDataGasPrices(t *testing.T) {
+	excess := big.NewInt(params.DataGasPriceUpdateFraction)
+
+	var cases = []struct {
+		blobs  []int   // Blobs included in the blocks of the chain
+		growth int64   // Excess data gas growth per block to expect
+		avg    float64 // Average blobs per block to expect
+	}{
+		// Demand above the target keeps raising the price
+		{[]int{0, 4, 4, 4}, 2 * params.DataGasPerBlob, 4},
+		// Demand at the target keeps the price
+		{[]int{0, 2, 1, 3}, 0, 2},
+		// Demand below the target drains the excess down to the minimum price
+		{[]int{0, 0, 0, 0}, -2 * params.DataGasPerBlob, 0},
+		// Blocks beyond the checked ones are ignored, partial blobs are averaged
+		{[]int{4, 2, 0, 0}, 2*params.DataGasPerBlob/3 - 2*params.DataGasPerBlob, 2.0 / 3},
+	}
+	for i, c := range cases {
+		backend := newFeesBackend(t, make([]uint64, len(c.blobs)), c.blobs, excess)
+		oracle := NewOracle(backend, Config{Blocks: 3, Percentile: 60, MaxHeaderHistory: 64})
+
+		first, blobs, prices, err := oracle.ForecastDataGasPrices(context.Background(), 40)
+		if err != nil {
+			t.Fatalf("Test case %d: failed to forecast data gas prices, %v", i, err)
+		}
+		if first.Uint64() != uint64(len(c.blobs)) {
+			t.Errorf("Test case %d: first block mismatch, want %d, got %d", i, len(c.blobs), first)
+		}
+		if blobs != c.avg {
+			t.Errorf("Test case %d: blobs per block mismatch, want %v, got %v", i, c.avg, blobs)
+		}
+		if len(prices) != 40 {
+			t.Fatalf("Test case %d: forecast length mismatch, want 40, got %d", i, len(prices))
+		}
+		want := new(big.Int).Set(excess)
+		for j, price := range prices {
+			if expect := misc.GetDataGasPrice(backend.ChainConfig(), want); price.Cmp(expect) != 0 {
+				t.Fatalf("Test case %d: block %d price mismatch, want %d, got %d", i, j, expect, price)
+			}
+			if want.Add(want, big.NewInt(c.growth)); want.Sign() < 0 {
+				want.SetUint64(0)
+			}
+		}
+	}
+	// Forecasts are capped to the maximum header history
+	oracle := NewOracle(newFeesBackend(t, []uint64{0}, []int{0}, nil), Config{Blocks: 3, Percentile: 60, MaxHeaderHistory: 8})
+	if _, _, prices, _ := oracle.ForecastDataGasPrices(context.Background(), 100); len(prices) != 8 {
+		t.Errorf("capped forecast length mismatch, want 8, got %d", len(prices))
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/blobforward"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
@@ -62,6 +63,10 @@ type txPool interface {
 	// tx hash.
 	Get(hash common.Hash) *types.Transaction
 
+	// GetWithBlobs retrieves the transaction from local txpool with given
+	// tx hash, along with its blobs if any.
+	GetWithBlobs(hash common.Hash) *types.Transaction
+
 	// AddRemotes should add the given transactions to the pool.
 	AddRemotes([]*types.Transaction) []error
 
@@ -87,6 +92,8 @@ type handlerConfig struct {
 	EventMux   *event.TypeMux            // Legacy event mux, deprecate for `feed`
 	Checkpoint *params.TrustedCheckpoint // Hard coded checkpoint for sync challenges
 	Whitelist  map[uint64]common.Hash    // Hard coded whitelist for sync challenged
+
+	BlobForwarder *blobforward.Forwarder // Trusted nodes to relay blob transactions to instead of peers
 }
 
 type handler struct {
@@ -117,6 +124,8 @@ type handler struct {
 
 	whitelist map[uint64]common.Hash
 
+	blobForwarder *blobforward.Forwarder
+
 	// channels for fetcher, syncer, txsyncLoop
 	quitSync chan struct{}
 
@@ -142,6 +151,8 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		merger:     config.Merger,
 		whitelist:  config.Whitelist,
 		quitSync:   make(chan struct{}),
+
+		blobForwarder: config.BlobForwarder,
 	}
 	if config.Sync == downloader.FullSync {
 		// The database seems empty as the current block is the genesis. Yet the snap
@@ -300,7 +311,7 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 		number  = head.Number.Uint64()
 		td      = h.chain.GetTd(hash, number)
 	)
-	forkID := forkid.NewID(h.chain.Config(), h.chain.Genesis().Hash(), h.chain.CurrentHeader().Number.Uint64())
+	forkID := forkid.NewID(h.chain.Config(), genesis.Hash(), number, head.Time)
 	if err := peer.Handshake(h.networkID, td, hash, genesis.Hash(), forkID, h.forkFilter); err != nil {
 		peer.Log().Debug("Ethereum handshake failed", "err", err)
 		return err
@@ -594,12 +605,22 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		txset = make(map[*ethPeer][]common.Hash) // Set peer->hash to transfer directly
 		annos = make(map[*ethPeer][]common.Hash) // Set peer->hash to announce
 
+		forwarded []common.Hash // Blob transactions relayed to trusted nodes
 	)
 	// Broadcast transactions to a batch of peers not knowing about it
 	for _, tx := range txs {
+		// Sentry nodes relay blob transactions to their trusted nodes only
+		if h.blobForwarder != nil && tx.Type() == types.BlobTxType {
+			forwarded = append(forwarded, tx.Hash())
+			continue
+		}
 		peers := h.peers.peersWithoutTransaction(tx.Hash())
-		// Send the tx unconditionally to a subset of our peers
+		// Send the tx unconditionally to a subset of our peers, unless it's a
+		// blob transaction: those are too large to push and are only announced
 		numDirect := int(math.Sqrt(float64(len(peers))))
+		if tx.Type() == types.BlobTxType {
+			numDirect = 0
+		}
 		for _, peer := range peers[:numDirect] {
 			txset[peer] = append(txset[peer], tx.Hash())
 		}
@@ -618,9 +639,12 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		annoCount += len(hashes)
 		peer.AsyncSendPooledTransactionHashes(hashes)
 	}
+	if len(forwarded) > 0 {
+		h.blobForwarder.Forward(forwarded)
+	}
 	log.Debug("Transaction broadcast", "txs", len(txs),
 		"announce packs", annoPeers, "announced hashes", annoCount,
-		"tx packs", directPeers, "broadcast txs", directCount)
+		"tx packs", directPeers, "broadcast txs", directCount, "forwarded", len(forwarded))
 }
 
 // minedBroadcastLoop sends mined blocks to connected peers.
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"reflect"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -32,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/event"
@@ -65,6 +67,10 @@ func (h *testEthHandler) Handle(peer *eth.Peer, packet eth.Packet) error {
 		h.txAnnounces.Send(([]common.Hash)(*packet))
 		return nil
 
+	case *eth.NewPooledTransactionHashesPacket68:
+		h.txAnnounces.Send(packet.Hashes)
+		return nil
+
 	case *eth.TransactionsPacket:
 		h.txBroadcasts.Send(([]*types.Transaction)(*packet))
 		return nil
@@ -238,6 +244,7 @@ func testForkIDSplit(t *testing.T, protocol uint) {
 
 // Tests that received transactions are added to the local pool.
 func TestRecvTransactions66(t *testing.T) { testRecvTransactions(t, eth.ETH66) }
+func TestRecvTransactions68(t *testing.T) { testRecvTransactions(t, eth.ETH68) }
 
 func testRecvTransactions(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -295,6 +302,7 @@ func testRecvTransactions(t *testing.T, protocol uint) {
 
 // This test checks that pending transactions are sent.
 func TestSendTransactions66(t *testing.T) { testSendTransactions(t, eth.ETH66) }
+func TestSendTransactions68(t *testing.T) { testSendTransactions(t, eth.ETH68) }
 
 func testSendTransactions(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -353,7 +361,7 @@ func testSendTransactions(t *testing.T, protocol uint) {
 	seen := make(map[common.Hash]struct{})
 	for len(seen) < len(insert) {
 		switch protocol {
-		case 66:
+		case 66, 68:
 			select {
 			case hashes := <-anns:
 				for _, hash := range hashes {
@@ -380,6 +388,7 @@ func testSendTransactions(t *testing.T, protocol uint) {
 // Tests that transactions get propagated to all attached peers, either via direct
 // broadcasts or via announcements/retrievals.
 func TestTransactionPropagation66(t *testing.T) { testTransactionPropagation(t, eth.ETH66) }
+func TestTransactionPropagation68(t *testing.T) { testTransactionPropagation(t, eth.ETH68) }
 
 func testTransactionPropagation(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -450,6 +459,108 @@ func testTransactionPropagation(t *testing.T, protocol uint) {
 	}
 }
 
+// Tests that blob transactions are never broadcast directly, only announced,
+// and that the announced transactions are served along with their blobs.
+func TestBlobTransactionAnnouncement68(t *testing.T) {
+	t.Parallel()
+
+	// Create a source handler to send messages through and a sink peer to receive them
+	handler := newTestHandler()
+	defer handler.close()
+
+	p2pSrc, p2pSink := p2p.MsgPipe()
+	defer p2pSrc.Close()
+	defer p2pSink.Close()
+
+	src := eth.NewPeer(eth.ETH68, p2p.NewPeerPipe(enode.ID{1}, "", nil, p2pSrc), p2pSrc, handler.txpool)
+	sink := eth.NewPeer(eth.ETH68, p2p.NewPeerPipe(enode.ID{2}, "", nil, p2pSink), p2pSink, handler.txpool)
+	defer src.Close()
+	defer sink.Close()
+
+	go handler.handler.runEthPeer(src, func(peer *eth.Peer) error {
+		return eth.Handle((*ethHandler)(handler.handler), peer)
+	})
+	// Run the handshake locally to avoid spinning up a source handler
+	var (
+		genesis = handler.chain.Genesis()
+		head    = handler.chain.CurrentBlock()
+		td      = handler.chain.GetTd(head.Hash(), head.NumberU64())
+	)
+	if err := sink.Handshake(1, td, head.Hash(), genesis.Hash(), forkid.NewIDWithChain(handler.chain), forkid.NewFilter(handler.chain)); err != nil {
+		t.Fatalf("failed to run protocol handshake")
+	}
+	backend := new(testEthHandler)
+
+	anns := make(chan []common.Hash)
+	annSub := backend.txAnnounces.Subscribe(anns)
+	defer annSub.Unsubscribe()
+
+	bcasts := make(chan []*types.Transaction)
+	bcastSub := backend.txBroadcasts.Subscribe(bcasts)
+	defer bcastSub.Unsubscribe()
+
+	go eth.Handle(backend, sink)
+
+	// Wait for the peer to be registered, a lone peer would otherwise be sent
+	// all transactions directly
+	for handler.handler.peers.len() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Add a blob transaction to the source pool, it should only be announced
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0 // point at infinity, committing to the empty blob
+
+	tx := types.MustSignNewTx(testKey, types.LatestSignerForChainID(params.TestChainConfig.ChainID), &types.BlobTx{
+		ChainID:             params.TestChainConfig.ChainID,
+		GasTipCap:           big.NewInt(1),
+		GasFeeCap:           big.NewInt(1),
+		Gas:                 params.TxGas,
+		To:                  &common.Address{},
+		MaxFeePerDataGas:    big.NewInt(1),
+		BlobVersionedHashes: []common.Hash{commitment.ComputeVersionedHash()},
+	})
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}},
+		Commitments: []kzg.KZGCommitment{commitment},
+		Proofs:      []kzg.KZGProof{{0xc0}},
+	}
+	go handler.txpool.AddRemotes([]*types.Transaction{tx.WithBlobTxSidecar(sidecar)}) // Need goroutine to not block on feed
+
+	select {
+	case hashes := <-anns:
+		if len(hashes) != 1 || hashes[0] != tx.Hash() {
+			t.Fatalf("announced hashes mismatch: have %x, want [%x]", hashes, tx.Hash())
+		}
+	case <-bcasts:
+		t.Fatalf("blob transaction broadcast directly")
+	case <-time.After(time.Second):
+		t.Fatalf("blob transaction announcement timed out")
+	}
+	// Retrieve the announced transaction, it should arrive with its blobs
+	if err := sink.RequestTxs([]common.Hash{tx.Hash()}); err != nil {
+		t.Fatalf("failed to request transaction: %v", err)
+	}
+	select {
+	case txs := <-bcasts:
+		if len(txs) != 1 || txs[0].Hash() != tx.Hash() {
+			t.Fatalf("retrieved transactions mismatch: have %v, want [%x]", txs, tx.Hash())
+		}
+		if !reflect.DeepEqual(txs[0].BlobTxSidecar(), sidecar) {
+			t.Errorf("retrieved transaction sidecar mismatch")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("blob transaction retrieval timed out")
+	}
+	// Both ends should have accounted for the blob bandwidth
+	size := uint64(tx.WithBlobTxSidecar(sidecar).NetworkSize())
+	if sent, _ := src.BlobTraffic(); sent != size {
+		t.Errorf("sent blob bytes mismatch: have %d, want %d", sent, size)
+	}
+	if _, received := sink.BlobTraffic(); received != size {
+		t.Errorf("received blob bytes mismatch: have %d, want %d", received, size)
+	}
+}
+
 // Tests that post eth protocol handshake, clients perform a mutual checkpoint
 // challenge to validate each other's chains. Hash mismatches, or missing ones
 // during a fast sync should lead to the peer getting dropped.
@@ -78,6 +78,12 @@ func (p *testTxPool) Get(hash common.Hash) *types.Transaction {
 	return p.pool[hash]
 }
 
+// GetWithBlobs retrieves the transaction from local txpool with given
+// tx hash, along with its blobs if any.
+func (p *testTxPool) GetWithBlobs(hash common.Hash) *types.Transaction {
+	return p.Get(hash)
+}
+
 // AddRemotes appends a batch of transactions to the pool, and notifies any
 // listeners if the addition channel is non nil
 func (p *testTxPool) AddRemotes(txs []*types.Transaction) []error {
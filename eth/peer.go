@@ -26,9 +26,11 @@ import (
 // ethPeerInfo represents a short summary of the `eth` sub-protocol metadata known
 // about a connected peer.
 type ethPeerInfo struct {
-	Version    uint     `json:"version"`    // Ethereum protocol version negotiated
-	Difficulty *big.Int `json:"difficulty"` // Total difficulty of the peer's blockchain
-	Head       string   `json:"head"`       // Hex hash of the peer's best owned block
+	Version           uint     `json:"version"`           // Ethereum protocol version negotiated
+	Difficulty        *big.Int `json:"difficulty"`        // Total difficulty of the peer's blockchain
+	Head              string   `json:"head"`              // Hex hash of the peer's best owned block
+	BlobBytesSent     uint64   `json:"blobBytesSent"`     // Bytes of blob transactions served to the peer
+	BlobBytesReceived uint64   `json:"blobBytesReceived"` // Bytes of blob transactions received from the peer
 }
 
 // ethPeer is a wrapper around eth.Peer to maintain a few extra metadata.
@@ -41,11 +43,14 @@ type ethPeer struct {
 // info gathers and returns some `eth` protocol metadata known about a peer.
 func (p *ethPeer) info() *ethPeerInfo {
 	hash, td := p.Head()
+	sent, received := p.BlobTraffic()
 
 	return &ethPeerInfo{
-		Version:    p.Version(),
-		Difficulty: td,
-		Head:       hash.Hex(),
+		Version:           p.Version(),
+		Difficulty:        td,
+		Head:              hash.Hex(),
+		BlobBytesSent:     sent,
+		BlobBytesReceived: received,
 	}
 }
 
@@ -19,6 +19,8 @@ package eth
 import (
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -444,45 +446,110 @@ func handleNewPooledTransactionHashes(backend Backend, msg Decoder, peer *Peer)
 	for _, hash := range *ann {
 		peer.markTransaction(hash)
 	}
+	// Announcements without types may hide blob transactions, ignore them while
+	// the peer is over its blob allowance
+	if peer.blobFetch.blocked(time.Now()) {
+		blobAnnounceDropMeter.Mark(int64(len(*ann)))
+		return nil
+	}
 	return backend.Handle(peer, ann)
 }
 
+func handleNewPooledTransactionHashes68(backend Backend, msg Decoder, peer *Peer) error {
+	// New transaction announcement arrived, make sure we have
+	// a valid and fresh chain to handle them
+	if !backend.AcceptTxs() {
+		return nil
+	}
+	ann := new(NewPooledTransactionHashesPacket68)
+	if err := msg.Decode(ann); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	if len(ann.Hashes) != len(ann.Types) || len(ann.Hashes) != len(ann.Sizes) {
+		return fmt.Errorf("%w: message %v: invalid len of fields: %v %v %v", errDecode, msg, len(ann.Hashes), len(ann.Types), len(ann.Sizes))
+	}
+	// Schedule all the unknown hashes for retrieval, dropping the blob transactions
+	// beyond the blob allowance of the peer
+	var (
+		now      = time.Now()
+		accepted = new(NewPooledTransactionHashesPacket68)
+	)
+	for i, hash := range ann.Hashes {
+		peer.markTransaction(hash)
+		if ann.Types[i] == types.BlobTxType && !peer.blobFetch.allow(now, max(int(ann.Sizes[i]), minBlobTxSize)) {
+			blobAnnounceDropMeter.Mark(1)
+			continue
+		}
+		accepted.Types = append(accepted.Types, ann.Types[i])
+		accepted.Sizes = append(accepted.Sizes, ann.Sizes[i])
+		accepted.Hashes = append(accepted.Hashes, hash)
+	}
+	if len(accepted.Hashes) == 0 {
+		return nil
+	}
+	return backend.Handle(peer, accepted)
+}
+
 func handleGetPooledTransactions66(backend Backend, msg Decoder, peer *Peer) error {
 	// Decode the pooled transactions retrieval message
 	var query GetPooledTransactionsPacket66
 	if err := msg.Decode(&query); err != nil {
 		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 	}
-	hashes, txs := answerGetPooledTransactions(backend, query.GetPooledTransactionsPacket, peer)
+	hashes, txs, delay := answerGetPooledTransactions(backend, query.GetPooledTransactionsPacket, peer)
+
+	// If the peer retrieved too many blobs lately, hold back the reply until
+	// it's within its allowance again, without blocking its other messages
+	if delay > 0 {
+		peer.AsyncReplyPooledTransactionsRLP(query.RequestId, hashes, txs, delay)
+		return nil
+	}
 	return peer.ReplyPooledTransactionsRLP(query.RequestId, hashes, txs)
 }
 
-func answerGetPooledTransactions(backend Backend, query GetPooledTransactionsPacket, peer *Peer) ([]common.Hash, []rlp.RawValue) {
+// answerGetPooledTransactions gathers the requested transactions in their network
+// encoding. Blob transactions are only served within the blob bandwidth of the
+// peer, the returned delay is the time to wait before sending the reply for it
+// to stay within.
+func answerGetPooledTransactions(backend Backend, query GetPooledTransactionsPacket, peer *Peer) ([]common.Hash, []rlp.RawValue, time.Duration) {
 	// Gather transactions until the fetch or network limits is reached
 	var (
 		bytes  int
 		hashes []common.Hash
 		txs    []rlp.RawValue
+		delay  time.Duration
 	)
 	for _, hash := range query {
 		if bytes >= softResponseLimit {
 			break
 		}
 		// Retrieve the requested transaction, skipping if unknown to us
-		tx := backend.TxPool().Get(hash)
+		tx := backend.TxPool().GetWithBlobs(hash)
 		if tx == nil {
 			continue
 		}
-		// If known, encode and queue for response packet
-		if encoded, err := rlp.EncodeToBytes(tx); err != nil {
+		// If known, encode (along with any blobs) and queue for response packet
+		encoded, err := encodePooledTransaction(tx)
+		if err != nil {
 			log.Error("Failed to encode transaction", "err", err)
-		} else {
-			hashes = append(hashes, hash)
-			txs = append(txs, encoded)
-			bytes += len(encoded)
+			continue
 		}
+		// Skip blob transactions exceeding the bandwidth allowance of the peer
+		if tx.BlobTxSidecar() != nil {
+			wait, ok := peer.blobServe.reserve(time.Now(), len(encoded))
+			if !ok {
+				continue
+			}
+			if wait > delay {
+				delay = wait
+			}
+			atomic.AddUint64(&peer.blobBytesSent, uint64(tx.NetworkSize()))
+		}
+		hashes = append(hashes, hash)
+		txs = append(txs, encoded)
+		bytes += len(encoded)
 	}
-	return hashes, txs
+	return hashes, txs, delay
 }
 
 func handleTransactions(backend Backend, msg Decoder, peer *Peer) error {
@@ -500,6 +567,10 @@ func handleTransactions(backend Backend, msg Decoder, peer *Peer) error {
 		if tx == nil {
 			return fmt.Errorf("%w: transaction %d is nil", errDecode, i)
 		}
+		// Blob transactions may only be announced, never broadcast
+		if tx.Type() == types.BlobTxType {
+			return fmt.Errorf("%w: transaction %d (%x)", errBlobTxBroadcast, i, tx.Hash())
+		}
 		peer.markTransaction(tx.Hash())
 	}
 	return backend.Handle(peer, &txs)
@@ -521,6 +592,18 @@ func handlePooledTransactions66(backend Backend, msg Decoder, peer *Peer) error
 			return fmt.Errorf("%w: transaction %d is nil", errDecode, i)
 		}
 		peer.markTransaction(tx.Hash())
+
+		// Account for the blob bandwidth consumed by the peer. Blob transactions
+		// announced without their type weren't charged to it yet.
+		if tx.BlobTxSidecar() != nil {
+			size := uint64(tx.NetworkSize())
+			atomic.AddUint64(&peer.blobBytesReceived, size)
+			blobReceiveMeter.Mark(int64(size))
+
+			if peer.version < ETH68 {
+				peer.blobFetch.charge(time.Now(), int(size))
+			}
+		}
 	}
 	requestTracker.Fulfil(peer.id, peer.version, PooledTransactionsMsg, txs.RequestId)
 
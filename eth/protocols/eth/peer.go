@@ -20,6 +20,8 @@ import (
 	"math/big"
 	"math/rand"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/ethereum/go-ethereum/common"
@@ -66,6 +68,9 @@ func max(a, b int) int {
 
 // Peer is a collection of relevant information we have about a `eth` peer.
 type Peer struct {
+	blobBytesSent     uint64 // Bytes of blob transactions served to the peer (atomic, 64-bit aligned)
+	blobBytesReceived uint64 // Bytes of blob transactions received from the peer (atomic, 64-bit aligned)
+
 	id string // Unique ID for the peer, cached
 
 	*p2p.Peer                   // The embedded P2P package peer
@@ -83,6 +88,10 @@ type Peer struct {
 	knownTxs    *knownCache        // Set of transaction hashes known to be known by this peer
 	txBroadcast chan []common.Hash // Channel used to queue transaction propagation requests
 	txAnnounce  chan []common.Hash // Channel used to queue transaction announcement requests
+	blobServe   *blobThrottle      // Throttle limiting the blob bandwidth served to the peer
+	blobFetch   *blobFetchThrottle // Throttle limiting the blob transactions retrieved from the peer
+
+	delayedReplies chan *delayedReply // Queue of pooled transaction replies held back by the blob throttle
 
 	reqDispatch chan *request  // Dispatch channel to send requests and track then until fulfilment
 	reqCancel   chan *cancel   // Dispatch channel to cancel pending requests and untrack them
@@ -106,6 +115,9 @@ func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter, txpool TxPool) *Pe
 		queuedBlockAnns: make(chan *types.Block, maxQueuedBlockAnns),
 		txBroadcast:     make(chan []common.Hash),
 		txAnnounce:      make(chan []common.Hash),
+		blobServe:       newBlobThrottle(),
+		blobFetch:       newBlobFetchThrottle(),
+		delayedReplies:  make(chan *delayedReply, maxQueuedBlobReplies),
 		reqDispatch:     make(chan *request),
 		reqCancel:       make(chan *cancel),
 		resDispatch:     make(chan *response),
@@ -116,6 +128,7 @@ func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter, txpool TxPool) *Pe
 	go peer.broadcastBlocks()
 	go peer.broadcastTransactions()
 	go peer.announceTransactions()
+	go peer.serveDelayedReplies()
 	go peer.dispatcher()
 
 	return peer
@@ -133,6 +146,12 @@ func (p *Peer) ID() string {
 	return p.id
 }
 
+// BlobTraffic retrieves the number of bytes of blob transactions, wrapped along
+// with their blobs, served to and received from the peer.
+func (p *Peer) BlobTraffic() (sent uint64, received uint64) {
+	return atomic.LoadUint64(&p.blobBytesSent), atomic.LoadUint64(&p.blobBytesReceived)
+}
+
 // Version retrieves the peer's negoatiated `eth` protocol version.
 func (p *Peer) Version() uint {
 	return p.version
@@ -216,9 +235,12 @@ func (p *Peer) AsyncSendTransactions(hashes []common.Hash) {
 // This method is a helper used by the async transaction announcer. Don't call it
 // directly as the queueing (memory) and transmission (bandwidth) costs should
 // not be managed directly.
-func (p *Peer) sendPooledTransactionHashes(hashes []common.Hash) error {
+func (p *Peer) sendPooledTransactionHashes(hashes []common.Hash, txTypes []byte, sizes []uint32) error {
 	// Mark all the transactions as known, but ensure we don't overflow our limits
 	p.knownTxs.Add(hashes...)
+	if p.version >= ETH68 {
+		return p2p.Send(p.rw, NewPooledTransactionHashesMsg, &NewPooledTransactionHashesPacket68{Types: txTypes, Sizes: sizes, Hashes: hashes})
+	}
 	return p2p.Send(p.rw, NewPooledTransactionHashesMsg, NewPooledTransactionHashesPacket(hashes))
 }
 
@@ -247,6 +269,17 @@ func (p *Peer) ReplyPooledTransactionsRLP(id uint64, hashes []common.Hash, txs [
 	})
 }
 
+// AsyncReplyPooledTransactionsRLP queues a reply to a pooled transactions query,
+// to be sent after the given delay. If the peer's reply queue is full, the reply
+// is dropped.
+func (p *Peer) AsyncReplyPooledTransactionsRLP(id uint64, hashes []common.Hash, txs []rlp.RawValue, delay time.Duration) {
+	select {
+	case p.delayedReplies <- &delayedReply{id: id, hashes: hashes, txs: txs, deadline: time.Now().Add(delay)}:
+	default:
+		p.Log().Debug("Dropping delayed pooled transactions reply", "count", len(txs))
+	}
+}
+
 // SendNewBlockHashes announces the availability of a number of blocks through
 // a hash notification.
 func (p *Peer) SendNewBlockHashes(hashes []common.Hash, numbers []uint64) error {
@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 )
@@ -86,3 +87,39 @@ func TestPeerSet(t *testing.T) {
 		t.Fatalf("bad size")
 	}
 }
+
+// Tests that eth/68 announcements carry the size of the transactions as they are
+// served, i.e. including the sidecar of blob transactions.
+func TestAnnouncedTransactionSizes(t *testing.T) {
+	backend := &testPoolBackend{testBackend: newTestBackend(0), pool: make(testTxPool)}
+	defer backend.close()
+
+	txs, blobTxs := newTestBlobTxs(1)
+	backend.pool[txs[0].Hash()], backend.pool[blobTxs[0].Hash()] = txs[0], blobTxs[0]
+
+	peer, _ := newTestPeer("peer", ETH68, backend)
+	defer peer.close()
+
+	peer.AsyncSendPooledTransactionHashes([]common.Hash{txs[0].Hash(), blobTxs[0].Hash()})
+
+	msg, err := peer.app.ReadMsg()
+	if err != nil {
+		t.Fatalf("failed to read announcement: %v", err)
+	}
+	var ann NewPooledTransactionHashesPacket68
+	if err := msg.Decode(&ann); err != nil {
+		t.Fatalf("failed to decode announcement: %v", err)
+	}
+	if len(ann.Sizes) != 2 {
+		t.Fatalf("announced size count mismatch: have %d, want 2", len(ann.Sizes))
+	}
+	for i, tx := range []*types.Transaction{txs[0], blobTxs[0]} {
+		enc, err := tx.MarshalNetwork()
+		if err != nil {
+			t.Fatalf("tx %d: failed to encode: %v", i, err)
+		}
+		if ann.Hashes[i] != tx.Hash() || ann.Sizes[i] != uint32(len(enc)) {
+			t.Errorf("tx %d: announcement mismatch: have %x with size %d, want %x with size %d", i, ann.Hashes[i], ann.Sizes[i], tx.Hash(), len(enc))
+		}
+	}
+}
@@ -31,6 +31,7 @@ import (
 // Constants to match up protocol versions and messages
 const (
 	ETH66 = 66
+	ETH68 = 68
 )
 
 // ProtocolName is the official short name of the `eth` protocol used during
@@ -39,11 +40,11 @@ const ProtocolName = "eth"
 
 // ProtocolVersions are the supported versions of the `eth` protocol (first
 // is primary).
-var ProtocolVersions = []uint{ETH66}
+var ProtocolVersions = []uint{ETH68, ETH66}
 
 // protocolLengths are the number of implemented message corresponding to
 // different protocol versions.
-var protocolLengths = map[uint]uint64{ETH66: 17}
+var protocolLengths = map[uint]uint64{ETH68: 17, ETH66: 17}
 
 // maxMessageSize is the maximum cap on the size of a protocol message.
 const maxMessageSize = 10 * 1024 * 1024
@@ -75,6 +76,7 @@ var (
 	errNetworkIDMismatch       = errors.New("network ID mismatch")
 	errGenesisMismatch         = errors.New("genesis mismatch")
 	errForkIDRejected          = errors.New("fork ID rejected")
+	errBlobTxBroadcast         = errors.New("blob transaction broadcast")
 )
 
 // Packet represents a p2p message in the `eth` protocol.
@@ -300,6 +302,14 @@ type ReceiptsRLPPacket66 struct {
 // NewPooledTransactionHashesPacket represents a transaction announcement packet.
 type NewPooledTransactionHashesPacket []common.Hash
 
+// NewPooledTransactionHashesPacket68 represents a transaction announcement packet
+// on eth/68 and newer, carrying the types and network sizes of the transactions.
+type NewPooledTransactionHashesPacket68 struct {
+	Types  []byte
+	Sizes  []uint32
+	Hashes []common.Hash
+}
+
 // GetPooledTransactionsPacket represents a transaction query.
 type GetPooledTransactionsPacket []common.Hash
 
@@ -309,14 +319,90 @@ type GetPooledTransactionsPacket66 struct {
 }
 
 // PooledTransactionsPacket is the network packet for transaction distribution.
+// Unlike in blocks, blob transactions are relayed wrapped with their blobs.
 type PooledTransactionsPacket []*types.Transaction
 
+// encodePooledTransaction encodes a single transaction in its network form, as
+// an element of a PooledTransactionsPacket.
+func encodePooledTransaction(tx *types.Transaction) (rlp.RawValue, error) {
+	if tx.Type() == types.LegacyTxType {
+		return rlp.EncodeToBytes(tx)
+	}
+	enc, err := tx.MarshalNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(enc)
+}
+
 // PooledTransactionsPacket is the network packet for transaction distribution over eth/66.
 type PooledTransactionsPacket66 struct {
 	RequestId uint64
 	PooledTransactionsPacket
 }
 
+// EncodeRLP implements rlp.Encoder, encoding the transactions in their network
+// form.
+func (p PooledTransactionsPacket66) EncodeRLP(w io.Writer) error {
+	txs := make(PooledTransactionsRLPPacket, len(p.PooledTransactionsPacket))
+	for i, tx := range p.PooledTransactionsPacket {
+		enc, err := encodePooledTransaction(tx)
+		if err != nil {
+			return err
+		}
+		txs[i] = enc
+	}
+	return rlp.Encode(w, &PooledTransactionsRLPPacket66{
+		RequestId:                   p.RequestId,
+		PooledTransactionsRLPPacket: txs,
+	})
+}
+
+// DecodeRLP implements rlp.Decoder, decoding the transactions from their network
+// form.
+func (p *PooledTransactionsPacket66) DecodeRLP(s *rlp.Stream) error {
+	if _, err := s.List(); err != nil {
+		return err
+	}
+	id, err := s.Uint64()
+	if err != nil {
+		return err
+	}
+	if _, err := s.List(); err != nil {
+		return err
+	}
+	var txs PooledTransactionsPacket
+	for {
+		kind, _, err := s.Kind()
+		if err == rlp.EOL {
+			break
+		} else if err != nil {
+			return err
+		}
+		tx := new(types.Transaction)
+		if kind == rlp.List {
+			// Legacy transactions are never wrapped
+			if err := s.Decode(tx); err != nil {
+				return err
+			}
+		} else {
+			enc, err := s.Bytes()
+			if err != nil {
+				return err
+			}
+			if err := tx.UnmarshalNetwork(enc); err != nil {
+				return err
+			}
+		}
+		txs = append(txs, tx)
+	}
+	if err := s.ListEnd(); err != nil {
+		return err
+	}
+	p.RequestId, p.PooledTransactionsPacket = id, txs
+	return s.ListEnd()
+}
+
 // PooledTransactionsPacket is the network packet for transaction distribution, used
 // in the cases we already have them in rlp-encoded form
 type PooledTransactionsRLPPacket []rlp.RawValue
@@ -366,6 +452,9 @@ func (*ReceiptsPacket) Kind() byte   { return ReceiptsMsg }
 func (*NewPooledTransactionHashesPacket) Name() string { return "NewPooledTransactionHashes" }
 func (*NewPooledTransactionHashesPacket) Kind() byte   { return NewPooledTransactionHashesMsg }
 
+func (*NewPooledTransactionHashesPacket68) Name() string { return "NewPooledTransactionHashes" }
+func (*NewPooledTransactionHashesPacket68) Kind() byte   { return NewPooledTransactionHashesMsg }
+
 func (*GetPooledTransactionsPacket) Name() string { return "GetPooledTransactions" }
 func (*GetPooledTransactionsPacket) Kind() byte   { return GetPooledTransactionsMsg }
 
@@ -19,10 +19,12 @@ package eth
 import (
 	"bytes"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -266,3 +268,49 @@ func TestEth66Messages(t *testing.T) {
 		}
 	}
 }
+
+// Tests that pooled blob transactions are relayed along with their blobs, while
+// the other transactions keep their canonical encoding.
+func TestPooledBlobTransactionsEncodeDecode(t *testing.T) {
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0 // point at infinity, committing to the empty blob
+
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}},
+		Commitments: []kzg.KZGCommitment{commitment},
+		Proofs:      []kzg.KZGProof{{0xc0}},
+	}
+	txs := []*types.Transaction{
+		types.NewTransaction(8, common.HexToAddress("0x3535353535353535353535353535353535353535"), big.NewInt(512), 189000, big.NewInt(20000000008), nil),
+		types.NewTx(&types.BlobTx{
+			ChainID:             big.NewInt(1),
+			Nonce:               9,
+			GasTipCap:           big.NewInt(1),
+			GasFeeCap:           big.NewInt(1),
+			MaxFeePerDataGas:    big.NewInt(1),
+			BlobVersionedHashes: []common.Hash{commitment.ComputeVersionedHash()},
+		}).WithBlobTxSidecar(sidecar),
+	}
+	enc, err := rlp.EncodeToBytes(PooledTransactionsPacket66{1111, PooledTransactionsPacket(txs)})
+	if err != nil {
+		t.Fatalf("failed to encode packet: %v", err)
+	}
+	var packet PooledTransactionsPacket66
+	if err := rlp.DecodeBytes(enc, &packet); err != nil {
+		t.Fatalf("failed to decode packet: %v", err)
+	}
+	if packet.RequestId != 1111 {
+		t.Errorf("request id mismatch: have %d, want %d", packet.RequestId, 1111)
+	}
+	if len(packet.PooledTransactionsPacket) != len(txs) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(packet.PooledTransactionsPacket), len(txs))
+	}
+	for i, tx := range packet.PooledTransactionsPacket {
+		if tx.Hash() != txs[i].Hash() {
+			t.Errorf("tx %d: hash mismatch: have %x, want %x", i, tx.Hash(), txs[i].Hash())
+		}
+		if !reflect.DeepEqual(tx.BlobTxSidecar(), txs[i].BlobTxSidecar()) {
+			t.Errorf("tx %d: sidecar mismatch", i)
+		}
+	}
+}
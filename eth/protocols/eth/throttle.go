@@ -0,0 +1,239 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// blobServeRate is the maximum number of bytes of blob transactions (along
+	// with their blobs) served to a single peer per second.
+	blobServeRate = 1024 * 1024
+
+	// blobServeBurst is the number of bytes of blob transactions that can be
+	// served to an idle peer at once, enough for one full reply.
+	blobServeBurst = softResponseLimit
+
+	// maxQueuedBlobBytes is the maximum number of blob transaction bytes that
+	// can be queued up for a peer beyond its allowance, ahead of a transaction
+	// to be served. Replies are delayed until their bytes are within the serving
+	// rate, transactions queued behind more than this are not served at all.
+	maxQueuedBlobBytes = 2 * 1024 * 1024
+
+	// maxBlobServeDelay is the maximum time the bytes queued ahead of a blob
+	// transaction may hold back its reply to keep a peer within its rate.
+	maxBlobServeDelay = time.Duration(maxQueuedBlobBytes) * time.Second / blobServeRate
+
+	// maxQueuedBlobReplies is the maximum number of delayed blob replies to queue
+	// up for a peer before dropping them.
+	maxQueuedBlobReplies = 8
+
+	// blobFetchRate is the maximum number of bytes of blob transactions (along
+	// with their blobs) a single peer may make the node retrieve per second.
+	blobFetchRate = 1024 * 1024
+
+	// blobFetchBurst is the number of bytes of blob transactions an idle peer
+	// may make the node retrieve at once.
+	blobFetchBurst = 4 * 1024 * 1024
+
+	// blobFetchResume is the allowance a throttled peer needs to regain before
+	// its blob transactions are accepted again. Resuming only well above zero
+	// keeps a peer announcing at its limit from flapping in and out of it.
+	blobFetchResume = blobFetchBurst / 2
+
+	// minBlobTxSize is the minimum size charged for an announced blob
+	// transaction, as it carries at least one blob.
+	minBlobTxSize = kzg.FieldElementsPerBlob * 32
+)
+
+var (
+	blobServeMeter     = metrics.NewRegisteredMeter("eth/protocols/eth/blobs/serve", nil)
+	blobThrottledMeter = metrics.NewRegisteredMeter("eth/protocols/eth/blobs/throttled", nil)
+	blobReceiveMeter   = metrics.NewRegisteredMeter("eth/protocols/eth/blobs/receive", nil)
+
+	blobAnnounceDropMeter  = metrics.NewRegisteredMeter("eth/protocols/eth/blobs/announce/dropped", nil)
+	blobFetchThrottleMeter = metrics.NewRegisteredMeter("eth/protocols/eth/blobs/fetch/throttled", nil)
+)
+
+// blobThrottle limits the bandwidth a single peer can consume by retrieving
+// blob transactions, so it can't monopolize the upload bandwidth of the node by
+// repeatedly requesting large sidecars.
+type blobThrottle struct {
+	limiter *rate.Limiter
+}
+
+// newBlobThrottle creates a throttle allowing the default blob serving rate.
+func newBlobThrottle() *blobThrottle {
+	return &blobThrottle{
+		limiter: rate.NewLimiter(blobServeRate, blobServeBurst),
+	}
+}
+
+// reserve attempts to reserve the bandwidth for serving a blob transaction of
+// the given size at the given time. It returns the delay after which the reply
+// may be sent, or false if the transaction should not be served as the queue
+// of the peer is full.
+//
+// Transactions larger than the burst are reserved in burst sized chunks, so any
+// blob transaction can be served once the allowance of the peer covers it.
+func (t *blobThrottle) reserve(now time.Time, size int) (time.Duration, bool) {
+	var (
+		reservations []*rate.Reservation
+		delay        time.Duration
+	)
+	for left := size; left > 0; left -= blobServeBurst {
+		chunk := left
+		if chunk > blobServeBurst {
+			chunk = blobServeBurst
+		}
+		r := t.limiter.ReserveN(now, chunk)
+		reservations = append(reservations, r)
+		delay = r.DelayFrom(now)
+	}
+	// Reject the transaction if too many bytes are queued up ahead of it
+	if delay > maxBlobServeDelay+time.Duration(size)*time.Second/blobServeRate {
+		for i := len(reservations) - 1; i >= 0; i-- {
+			reservations[i].CancelAt(now)
+		}
+		blobThrottledMeter.Mark(int64(size))
+		return 0, false
+	}
+	blobServeMeter.Mark(int64(size))
+	return delay, true
+}
+
+// blobFetchThrottle limits the blob transactions a single peer can make the node
+// retrieve, so it can't keep the node busy with large fetches and verifying
+// their KZG proofs by announcing blob transactions at an arbitrary rate.
+//
+// Once the allowance of the peer is exhausted, the peer is throttled until it
+// regains blobFetchResume bytes of allowance.
+type blobFetchThrottle struct {
+	allowance float64   // Bytes of blob transactions the peer may currently make the node retrieve
+	updated   time.Time // Time the allowance was last refilled
+	throttled bool      // Whether the peer exhausted its allowance and didn't regain enough yet
+	lock      sync.Mutex
+}
+
+// newBlobFetchThrottle creates a throttle with the full burst allowance.
+func newBlobFetchThrottle() *blobFetchThrottle {
+	return &blobFetchThrottle{
+		allowance: blobFetchBurst,
+		updated:   time.Now(),
+	}
+}
+
+// refill adds the allowance accumulated since the last update and lifts the
+// throttling if enough of it was regained. The caller must hold the lock.
+func (t *blobFetchThrottle) refill(now time.Time) {
+	if elapsed := now.Sub(t.updated); elapsed > 0 {
+		t.allowance += elapsed.Seconds() * blobFetchRate
+		if t.allowance > blobFetchBurst {
+			t.allowance = blobFetchBurst
+		}
+		t.updated = now
+	}
+	if t.throttled && t.allowance >= blobFetchResume {
+		t.throttled = false
+	}
+}
+
+// allow reports whether a blob transaction of the given size may be retrieved
+// from the peer, deducting it from the allowance if so.
+func (t *blobFetchThrottle) allow(now time.Time, size int) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.refill(now)
+	if t.throttled {
+		return false
+	}
+	if t.allowance < float64(size) {
+		t.throttled = true
+		blobFetchThrottleMeter.Mark(1)
+		return false
+	}
+	t.allowance -= float64(size)
+	return true
+}
+
+// charge deducts the size of a blob transaction retrieved from the peer without
+// it being announced with its type, throttling the peer if it's over its limit.
+func (t *blobFetchThrottle) charge(now time.Time, size int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.refill(now)
+	t.allowance -= float64(size)
+	if t.allowance < 0 && !t.throttled {
+		t.throttled = true
+		blobFetchThrottleMeter.Mark(1)
+	}
+}
+
+// blocked reports whether the peer is currently throttled.
+func (t *blobFetchThrottle) blocked(now time.Time) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.refill(now)
+	return t.throttled
+}
+
+// delayedReply is a pooled transaction reply held back until the blob serving
+// rate of the peer allows it to be sent.
+type delayedReply struct {
+	id       uint64
+	hashes   []common.Hash
+	txs      []rlp.RawValue
+	deadline time.Time
+}
+
+// serveDelayedReplies is a write loop that sends the delayed pooled transaction
+// replies once they are due, so the message handler of the peer isn't blocked
+// while waiting for the blob allowance.
+func (p *Peer) serveDelayedReplies() {
+	for {
+		select {
+		case reply := <-p.delayedReplies:
+			if wait := time.Until(reply.deadline); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-timer.C:
+				case <-p.term:
+					timer.Stop()
+					return
+				}
+			}
+			if err := p.ReplyPooledTransactionsRLP(reply.id, reply.hashes, reply.txs); err != nil {
+				return
+			}
+			p.Log().Trace("Sent delayed pooled transactions", "count", len(reply.txs))
+
+		case <-p.term:
+			return
+		}
+	}
+}
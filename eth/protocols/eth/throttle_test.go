@@ -0,0 +1,305 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that the blob throttle serves the burst right away, delays replies
+// while the queue has room and rejects anything beyond it.
+func TestBlobThrottle(t *testing.T) {
+	var (
+		throttle = newBlobThrottle()
+		now      = time.Now()
+	)
+	// The burst is served without any delay
+	if delay, ok := throttle.reserve(now, blobServeBurst); !ok || delay != 0 {
+		t.Fatalf("burst: have delay %v (ok %v), want immediate", delay, ok)
+	}
+	// Queued bytes are delayed proportionally to the serving rate
+	delay, ok := throttle.reserve(now, blobServeRate/2)
+	if !ok || delay != time.Second/2 {
+		t.Fatalf("queued: have delay %v (ok %v), want %v", delay, ok, time.Second/2)
+	}
+	// Transactions are served as long as the queue ahead of them has room
+	delay, ok = throttle.reserve(now, maxQueuedBlobBytes)
+	if want := time.Second/2 + maxBlobServeDelay; !ok || delay != want {
+		t.Fatalf("queue filled: have delay %v (ok %v), want %v", delay, ok, want)
+	}
+	// Bytes beyond the queue are rejected and don't consume any allowance
+	if _, ok := throttle.reserve(now, blobServeRate/2); ok {
+		t.Fatalf("overflow: reserved beyond the queue")
+	}
+	// Waiting refills the allowance
+	delay, ok = throttle.reserve(now.Add(time.Second), blobServeRate/2)
+	if !ok || delay != maxBlobServeDelay {
+		t.Fatalf("refilled: have delay %v (ok %v), want %v", delay, ok, maxBlobServeDelay)
+	}
+}
+
+// Tests that blob transactions larger than the burst are still served to an
+// idle peer, once its allowance covers them.
+func TestBlobThrottleLargeTransaction(t *testing.T) {
+	var (
+		throttle = newBlobThrottle()
+		now      = time.Now()
+		size     = params.MaxBlobsPerBlockLimit * (len(kzg.Blob{}) + len(kzg.KZGCommitment{}) + len(kzg.KZGProof{}))
+	)
+	delay, ok := throttle.reserve(now, size)
+	if want := time.Duration(size-blobServeBurst) * time.Second / blobServeRate; !ok || delay != want {
+		t.Fatalf("max blob tx: have delay %v (ok %v), want %v", delay, ok, want)
+	}
+	// Anything queued behind it beyond the queue limit is rejected
+	if _, ok := throttle.reserve(now, blobServeRate/2); ok {
+		t.Fatalf("reserved behind max blob tx beyond the queue")
+	}
+}
+
+// testTxPool is a transaction pool serving a fixed set of transactions. Like
+// the real pool, it only returns the blobs of blob transactions if asked to.
+type testTxPool map[common.Hash]*types.Transaction
+
+func (p testTxPool) GetWithBlobs(hash common.Hash) *types.Transaction { return p[hash] }
+
+func (p testTxPool) Get(hash common.Hash) *types.Transaction {
+	if tx := p[hash]; tx != nil {
+		return tx.WithoutBlobTxSidecar()
+	}
+	return nil
+}
+
+// testPoolBackend is a mock backend serving transactions from a testTxPool.
+type testPoolBackend struct {
+	*testBackend
+	pool testTxPool
+}
+
+func (b *testPoolBackend) TxPool() TxPool { return b.pool }
+
+// newTestBlobTxs creates n plain and n blob transactions, the latter carrying an
+// empty blob each.
+func newTestBlobTxs(n int) (txs []*types.Transaction, blobTxs []*types.Transaction) {
+	commitment := kzg.KZGCommitment{0xc0} // point at infinity, committing to the empty blob
+	for i := 0; i < n; i++ {
+		txs = append(txs, types.NewTransaction(uint64(i), common.Address{}, big.NewInt(1), 21000, big.NewInt(1), nil))
+		blobTxs = append(blobTxs, types.NewTx(&types.BlobTx{
+			ChainID:             big.NewInt(1),
+			Nonce:               uint64(i),
+			GasTipCap:           big.NewInt(1),
+			GasFeeCap:           big.NewInt(1),
+			MaxFeePerDataGas:    big.NewInt(1),
+			BlobVersionedHashes: []common.Hash{commitment.ComputeVersionedHash()},
+		}).WithBlobTxSidecar(&types.BlobTxSidecar{
+			Blobs:       []kzg.Blob{{}},
+			Commitments: []kzg.KZGCommitment{commitment},
+			Proofs:      []kzg.KZGProof{{0xc0}},
+		}))
+	}
+	return txs, blobTxs
+}
+
+// Tests that blob transactions are only served to a peer within its blob
+// bandwidth allowance, while other transactions are served regardless.
+func TestGetPooledBlobTransactionsThrottling(t *testing.T) {
+	var (
+		backend = &testPoolBackend{testBackend: newTestBackend(0), pool: make(testTxPool)}
+		query   GetPooledTransactionsPacket
+	)
+	defer backend.close()
+
+	txs, blobTxs := newTestBlobTxs(4)
+	for i := range txs {
+		backend.pool[txs[i].Hash()], backend.pool[blobTxs[i].Hash()] = txs[i], blobTxs[i]
+		query = append(query, txs[i].Hash(), blobTxs[i].Hash())
+	}
+	peer := NewPeer(ETH66, p2p.NewPeer(enode.ID{}, "", nil), nil, backend.pool)
+	defer peer.Close()
+
+	// Exhaust the blob allowance of the peer, leaving room for two blob txs in
+	// its queue, and check that only those are served
+	size := int(backend.pool[query[1]].NetworkSize()) + 3 // string header of the blob tx
+	if _, ok := peer.blobServe.reserve(time.Now(), blobServeBurst); !ok {
+		t.Fatalf("failed to exhaust burst")
+	}
+	if _, ok := peer.blobServe.reserve(time.Now(), maxQueuedBlobBytes-size-1024); !ok {
+		t.Fatalf("failed to fill queue")
+	}
+
+	hashes, encoded, delay := answerGetPooledTransactions(backend, query, peer)
+	if len(hashes) != 6 || len(encoded) != 6 {
+		t.Fatalf("served transaction count mismatch: have %d, want %d", len(hashes), 6)
+	}
+	for i, want := range []common.Hash{query[0], query[1], query[2], query[3], query[4], query[6]} {
+		if hashes[i] != want {
+			t.Errorf("served transaction %d mismatch: have %x, want %x", i, hashes[i], want)
+		}
+	}
+	if max := maxBlobServeDelay + time.Duration(size)*time.Second/blobServeRate; delay <= maxBlobServeDelay-time.Second || delay > max {
+		t.Errorf("reply delay mismatch: have %v, want close to %v", delay, maxBlobServeDelay)
+	}
+}
+
+// Tests that replies held back by the blob throttle don't block the peer from
+// being served other requests in the meantime.
+func TestDelayedBlobRepliesNonBlocking(t *testing.T) {
+	backend := &testPoolBackend{testBackend: newTestBackend(0), pool: make(testTxPool)}
+	defer backend.close()
+
+	txs, blobTxs := newTestBlobTxs(1)
+	backend.pool[txs[0].Hash()], backend.pool[blobTxs[0].Hash()] = txs[0], blobTxs[0]
+
+	peer, _ := newTestPeer("peer", ETH66, backend)
+	defer peer.close()
+
+	// Exhaust the blob allowance of the peer so the blob reply gets delayed
+	if _, ok := peer.blobServe.reserve(time.Now(), blobServeBurst); !ok {
+		t.Fatalf("failed to exhaust burst")
+	}
+	p2p.Send(peer.app, GetPooledTransactionsMsg, &GetPooledTransactionsPacket66{
+		RequestId:                   1,
+		GetPooledTransactionsPacket: GetPooledTransactionsPacket{blobTxs[0].Hash()},
+	})
+	p2p.Send(peer.app, GetPooledTransactionsMsg, &GetPooledTransactionsPacket66{
+		RequestId:                   2,
+		GetPooledTransactionsPacket: GetPooledTransactionsPacket{txs[0].Hash()},
+	})
+	// The reply without blobs must overtake the delayed one
+	for _, want := range []uint64{2, 1} {
+		msg, err := peer.app.ReadMsg()
+		if err != nil {
+			t.Fatalf("failed to read reply: %v", err)
+		}
+		var reply PooledTransactionsRLPPacket66
+		if err := msg.Decode(&reply); err != nil {
+			t.Fatalf("failed to decode reply: %v", err)
+		}
+		if reply.RequestId != want || len(reply.PooledTransactionsRLPPacket) != 1 {
+			t.Fatalf("reply mismatch: have request %d with %d txs, want request %d with 1 tx", reply.RequestId, len(reply.PooledTransactionsRLPPacket), want)
+		}
+	}
+}
+
+// Tests that the blob fetch throttle accepts blob transactions up to the burst,
+// and only accepts new ones from a throttled peer once it regained enough of its
+// allowance.
+func TestBlobFetchThrottle(t *testing.T) {
+	var (
+		throttle = newBlobFetchThrottle()
+		now      = throttle.updated
+	)
+	if !throttle.allow(now, blobFetchBurst) {
+		t.Fatalf("burst not allowed")
+	}
+	if throttle.allow(now, minBlobTxSize) || !throttle.blocked(now) {
+		t.Fatalf("blob transaction allowed beyond the burst")
+	}
+	// Regaining allowance for a few transactions is not enough to resume
+	now = now.Add(time.Second)
+	if throttle.allow(now, minBlobTxSize) {
+		t.Fatalf("throttled peer resumed below the resume threshold")
+	}
+	// Regaining the resume threshold lifts the throttling
+	now = now.Add(time.Duration(blobFetchResume-blobFetchRate) * time.Second / blobFetchRate)
+	if !throttle.allow(now, minBlobTxSize) || throttle.blocked(now) {
+		t.Fatalf("peer still throttled at the resume threshold")
+	}
+	// Unannounced blob transactions retrieved from the peer count against it too
+	throttle.charge(now, blobFetchBurst)
+	if !throttle.blocked(now) {
+		t.Fatalf("peer not throttled after exceeding its allowance")
+	}
+}
+
+// announceBackend is a mock backend accepting transactions and recording the
+// packets handed to it.
+type announceBackend struct {
+	*testBackend
+	handled []Packet
+}
+
+func (b *announceBackend) AcceptTxs() bool { return true }
+
+func (b *announceBackend) Handle(peer *Peer, packet Packet) error {
+	b.handled = append(b.handled, packet)
+	return nil
+}
+
+// Tests that the blob transactions announced by a peer beyond its blob fetch
+// allowance are dropped, and that a throttled peer's untyped announcements are
+// ignored altogether.
+func TestBlobAnnouncementThrottling(t *testing.T) {
+	backend := &announceBackend{testBackend: newTestBackend(0)}
+	defer backend.close()
+
+	encode := func(packet interface{}) p2p.Msg {
+		size, r, err := rlp.EncodeToReader(packet)
+		if err != nil {
+			t.Fatalf("failed to encode announcement: %v", err)
+		}
+		return p2p.Msg{Size: uint32(size), Payload: r}
+	}
+	// Announce one blob transaction more than the allowance of the peer covers,
+	// along with a plain transaction
+	peer := NewPeer(ETH68, p2p.NewPeer(enode.ID{}, "", nil), nil, backend.TxPool())
+	defer peer.Close()
+
+	var (
+		allowed = blobFetchBurst / minBlobTxSize
+		ann     = new(NewPooledTransactionHashesPacket68)
+	)
+	for i := 0; i <= allowed; i++ {
+		ann.Types = append(ann.Types, types.BlobTxType)
+		ann.Sizes = append(ann.Sizes, 1) // Lying about the size must not help
+		ann.Hashes = append(ann.Hashes, common.Hash{byte(i), 0x01})
+	}
+	ann.Types = append(ann.Types, types.LegacyTxType)
+	ann.Sizes = append(ann.Sizes, 100)
+	ann.Hashes = append(ann.Hashes, common.Hash{0x02})
+
+	if err := handleNewPooledTransactionHashes68(backend, encode(ann), peer); err != nil {
+		t.Fatalf("failed to handle announcement: %v", err)
+	}
+	if len(backend.handled) != 1 {
+		t.Fatalf("handled packet count mismatch: have %d, want 1", len(backend.handled))
+	}
+	handled := backend.handled[0].(*NewPooledTransactionHashesPacket68)
+	if len(handled.Hashes) != allowed+1 || handled.Hashes[allowed-1] != ann.Hashes[allowed-1] || handled.Hashes[allowed] != ann.Hashes[allowed+1] {
+		t.Fatalf("accepted announcements mismatch: have %d, want %d blob and one plain", len(handled.Hashes), allowed)
+	}
+	// Untyped announcements of a peer over its allowance are ignored
+	legacy := NewPeer(ETH66, p2p.NewPeer(enode.ID{}, "", nil), nil, backend.TxPool())
+	defer legacy.Close()
+
+	legacy.blobFetch.charge(time.Now(), blobFetchBurst+1)
+	if err := handleNewPooledTransactionHashes(backend, encode(&NewPooledTransactionHashesPacket{{0x03}}), legacy); err != nil {
+		t.Fatalf("failed to handle announcement: %v", err)
+	}
+	if len(backend.handled) != 1 {
+		t.Fatalf("announcement of throttled peer handled")
+	}
+}
@@ -20,9 +20,11 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"runtime"
 	"sync"
@@ -36,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -101,6 +104,10 @@ func (context *chainContext) Engine() consensus.Engine {
 	return context.api.backend.Engine()
 }
 
+func (context *chainContext) Config() *params.ChainConfig {
+	return context.api.backend.ChainConfig()
+}
+
 func (context *chainContext) GetHeader(hash common.Hash, number uint64) *types.Header {
 	header, err := context.api.backend.HeaderByNumber(context.ctx, rpc.BlockNumber(number))
 	if err != nil {
@@ -271,7 +278,7 @@ func (api *API) traceChain(ctx context.Context, start, end *types.Block, config
 
 			// Fetch and execute the next block trace tasks
 			for task := range tasks {
-				signer := types.MakeSigner(api.backend.ChainConfig(), task.block.Number())
+				signer := types.MakeSigner(api.backend.ChainConfig(), task.block.Number(), task.block.Time())
 				blockCtx := core.NewEVMBlockContext(task.block.Header(), api.chainContext(localctx), nil)
 				// Trace all the transactions contained within
 				for i, tx := range task.block.Transactions() {
@@ -520,7 +527,7 @@ func (api *API) IntermediateRoots(ctx context.Context, hash common.Hash, config
 	}
 	var (
 		roots              []common.Hash
-		signer             = types.MakeSigner(api.backend.ChainConfig(), block.Number())
+		signer             = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		chainConfig        = api.backend.ChainConfig()
 		vmctx              = core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 		deleteEmptyObjects = chainConfig.IsEIP158(block.Number())
@@ -560,6 +567,119 @@ func (api *API) StandardTraceBadBlockToFile(ctx context.Context, hash common.Has
 	return api.standardTraceBlockToFile(ctx, block, config)
 }
 
+// blockTimings is the time spent in the stages of importing a block.
+type blockTimings struct {
+	Transactions     int    `json:"transactions"`
+	Blobs            int    `json:"blobs"`
+	PointEvaluations int    `json:"pointEvaluations"`
+	SenderRecovery   string `json:"senderRecovery"`
+	KZGVerification  string `json:"kzgVerification"`
+	Execution        string `json:"execution"`
+}
+
+// TraceBlockTimings re-executes a block and reports the time spent recovering
+// the transaction senders, verifying KZG proofs in the point evaluation
+// precompile and executing the rest of the EVM code. It is meant to help
+// diagnosing slow imports of blocks laden with blob transactions.
+func (api *API) TraceBlockTimings(ctx context.Context, hash common.Hash, config *TraceConfig) (*blockTimings, error) {
+	block, err := api.blockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	parent, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(block.NumberU64()-1), block.ParentHash())
+	if err != nil {
+		return nil, err
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, err := api.backend.StateAtBlock(ctx, parent, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		chainConfig = api.backend.ChainConfig()
+		signer      = types.MakeSigner(chainConfig, block.Number(), block.Time())
+		txs         = block.Transactions()
+		timings     = &blockTimings{Transactions: len(txs)}
+	)
+	// Recover the senders bypassing the sender cache of the transactions
+	start := time.Now()
+	for _, tx := range txs {
+		if _, err := signer.Sender(tx); err != nil {
+			return nil, fmt.Errorf("transaction %#x: %v", tx.Hash(), err)
+		}
+	}
+	timings.SenderRecovery = time.Since(start).String()
+
+	// Execute the transactions, timing the point evaluations separately
+	var (
+		timer     = new(pointEvaluationTimer)
+		blockCtx  = core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+		execution time.Duration
+	)
+	for i, tx := range txs {
+		msg, _ := tx.AsMessage(signer, block.BaseFee())
+		statedb.Prepare(tx.Hash(), i)
+		vmenv := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), statedb, chainConfig, vm.Config{Debug: true, Tracer: timer})
+
+		start := time.Now()
+		if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas())); err != nil {
+			return nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+		}
+		execution += time.Since(start)
+		statedb.Finalise(vmenv.ChainConfig().IsEIP158(block.Number()))
+
+		timings.Blobs += len(tx.DataHashes())
+	}
+	timings.PointEvaluations = timer.calls
+	timings.KZGVerification = timer.elapsed.String()
+	timings.Execution = (execution - timer.elapsed).String()
+	return timings, nil
+}
+
+// pointEvaluationTimer is an EVM logger measuring the time spent in the point
+// evaluation precompile.
+type pointEvaluationTimer struct {
+	start   time.Time     // Time the running point evaluation started at
+	calls   int           // Number of point evaluations executed
+	elapsed time.Duration // Total time spent in point evaluations
+}
+
+func (t *pointEvaluationTimer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.CaptureEnter(vm.CALL, from, to, input, gas, value)
+}
+
+func (t *pointEvaluationTimer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {
+	t.CaptureExit(output, gasUsed, err)
+}
+
+func (t *pointEvaluationTimer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if to == vm.PointEvaluationAddress {
+		t.start = time.Now()
+	}
+}
+
+func (t *pointEvaluationTimer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	// Precompiles don't call into other contracts, so the first exit after
+	// entering the point evaluation precompile always belongs to it
+	if !t.start.IsZero() {
+		t.elapsed += time.Since(t.start)
+		t.calls++
+		t.start = time.Time{}
+	}
+}
+
+func (t *pointEvaluationTimer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+
+func (t *pointEvaluationTimer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
 // traceBlock configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requestd tracer.
@@ -581,7 +701,7 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	}
 	// Execute all the transaction contained within the block concurrently
 	var (
-		signer  = types.MakeSigner(api.backend.ChainConfig(), block.Number())
+		signer  = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		txs     = block.Transactions()
 		results = make([]*txTraceResult, len(txs))
 
@@ -646,7 +766,8 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 
 // standardTraceBlockToFile configures a new tracer which uses standard JSON output,
 // and traces either a full block or an individual transaction. The return value will
-// be one filename per transaction traced.
+// be one filename per transaction traced, preceded by the file holding the blob
+// data of the block if any of the traced transactions carries blobs.
 func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block, config *StdTraceConfig) ([]string, error) {
 	// If we're tracing a single transaction, make sure it's present
 	if config != nil && config.TxHash != (common.Hash{}) {
@@ -683,7 +804,7 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 	// Execute transaction, either tracing all or just the requested one
 	var (
 		dumps       []string
-		signer      = types.MakeSigner(api.backend.ChainConfig(), block.Number())
+		signer      = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		chainConfig = api.backend.ChainConfig()
 		vmctx       = core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 		canon       = true
@@ -705,6 +826,12 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 			canon = false
 		}
 	}
+	// Dump the blob data of the block next to the traces of its blob transactions
+	if dump, err := api.dumpBlockBlobs(block, txHash); err != nil {
+		return nil, err
+	} else if dump != "" {
+		dumps = append(dumps, dump)
+	}
 	for i, tx := range block.Transactions() {
 		// Prepare the trasaction for un-traced execution
 		var (
@@ -762,6 +889,77 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 	return dumps, nil
 }
 
+// blockBlobsDump is the blob data of a block written next to its standard traces.
+type blockBlobsDump struct {
+	BlockHash    common.Hash        `json:"blockHash"`
+	BlockNumber  uint64             `json:"blockNumber"`
+	Sidecars     string             `json:"sidecars"` // available, pruned or missing
+	Transactions []*blobTxBlobsDump `json:"transactions"`
+}
+
+// blobTxBlobsDump is the blob data of a single blob transaction.
+type blobTxBlobsDump struct {
+	TxHash          common.Hash         `json:"txHash"`
+	TxIndex         int                 `json:"txIndex"`
+	VersionedHashes []common.Hash       `json:"versionedHashes"`
+	Commitments     []kzg.KZGCommitment `json:"commitments,omitempty"`
+}
+
+// dumpBlockBlobs writes the versioned hashes and, if the sidecars are still
+// retained, the commitments of the blob transactions in a block into a temporary
+// JSON file. If txHash is set, only the matching transaction is dumped. The name
+// of the file is returned, or an empty string if there were no blobs to dump.
+func (api *API) dumpBlockBlobs(block *types.Block, txHash common.Hash) (string, error) {
+	var (
+		blobTxs []*blobTxBlobsDump
+		traced  []*blobTxBlobsDump
+	)
+	for i, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		blobTx := &blobTxBlobsDump{TxHash: tx.Hash(), TxIndex: i, VersionedHashes: tx.DataHashes()}
+		blobTxs = append(blobTxs, blobTx)
+		if txHash == (common.Hash{}) || tx.Hash() == txHash {
+			traced = append(traced, blobTx)
+		}
+	}
+	if len(traced) == 0 {
+		return "", nil
+	}
+	// Attach the commitments if the sidecars of the block are available
+	result := &blockBlobsDump{
+		BlockHash:    block.Hash(),
+		BlockNumber:  block.NumberU64(),
+		Sidecars:     "missing",
+		Transactions: traced,
+	}
+	db := api.backend.ChainDb()
+	if sidecars := rawdb.ReadBlobSidecars(db, block.Hash(), block.NumberU64()); len(sidecars) == len(blobTxs) {
+		result.Sidecars = "available"
+		for i, sidecar := range sidecars {
+			blobTxs[i].Commitments = sidecar.Commitments
+		}
+	} else if tail := rawdb.ReadBlobSidecarsTail(db); tail != nil && block.NumberU64() < *tail {
+		result.Sidecars = "pruned"
+	}
+	blob, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	dump, err := ioutil.TempFile(os.TempDir(), fmt.Sprintf("block_%#x-blobs-", block.Hash().Bytes()[:4]))
+	if err != nil {
+		return "", err
+	}
+	defer dump.Close()
+
+	if _, err := dump.Write(blob); err != nil {
+		return "", err
+	}
+	log.Info("Wrote blob data of traced block", "file", dump.Name())
+	return dump.Name(), nil
+}
+
 // containsTx reports whether the transaction with a certain hash
 // is contained within the specified block.
 func containsTx(block *types.Block, hash common.Hash) bool {
@@ -915,10 +1113,13 @@ func (api *API) traceTx(ctx context.Context, message core.Message, txctx *Contex
 			returnVal = fmt.Sprintf("%x", result.Revert())
 		}
 		return &ethapi.ExecutionResult{
-			Gas:         result.UsedGas,
-			Failed:      result.Failed(),
-			ReturnValue: returnVal,
-			StructLogs:  ethapi.FormatLogs(tracer.StructLogs()),
+			Gas:          result.UsedGas,
+			Failed:       result.Failed(),
+			ReturnValue:  returnVal,
+			StructLogs:   ethapi.FormatLogs(tracer.StructLogs()),
+			DataHashes:   tracer.DataHashes(),
+			DataGasUsed:  result.DataGasUsed,
+			DataGasPrice: (*hexutil.Big)(result.DataGasPrice),
 		}, nil
 
 	case Tracer:
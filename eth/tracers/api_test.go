@@ -23,7 +23,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/big"
+	"os"
 	"reflect"
 	"sort"
 	"testing"
@@ -39,6 +41,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/params"
@@ -59,13 +62,16 @@ type testBackend struct {
 }
 
 func newTestBackend(t *testing.T, n int, gspec *core.Genesis, generator func(i int, b *core.BlockGen)) *testBackend {
+	// Default to the test chain config unless the genesis specifies one
+	if gspec.Config == nil {
+		gspec.Config = params.TestChainConfig
+	}
 	backend := &testBackend{
-		chainConfig: params.TestChainConfig,
+		chainConfig: gspec.Config,
 		engine:      ethash.NewFaker(),
 		chaindb:     rawdb.NewMemoryDatabase(),
 	}
 	// Generate blocks for testing
-	gspec.Config = backend.chainConfig
 	var (
 		gendb   = rawdb.NewMemoryDatabase()
 		genesis = gspec.MustCommit(gendb)
@@ -159,7 +165,7 @@ func (b *testBackend) StateAtTransaction(ctx context.Context, block *types.Block
 		return nil, vm.BlockContext{}, statedb, nil
 	}
 	// Recompute transactions up to the target index.
-	signer := types.MakeSigner(b.chainConfig, block.Number())
+	signer := types.MakeSigner(b.chainConfig, block.Number(), block.Time())
 	for idx, tx := range block.Transactions() {
 		msg, _ := tx.AsMessage(signer, block.BaseFee())
 		txContext := core.NewEVMTxContext(msg)
@@ -306,6 +312,52 @@ func TestTraceCall(t *testing.T) {
 	}
 }
 
+// Tests that calls can reference blobs, which are exposed to the traced code via
+// the DATAHASH opcode.
+func TestTraceCallBlobHashes(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts, along with a contract returning the first blob hash
+	accounts := newAccounts(1)
+	contract := common.HexToAddress("0x00000000000000000000000000000000000b10b5")
+	genesis := &core.Genesis{Config: params.TestShardingChainConfig, Alloc: core.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		contract: {
+			Balance: common.Big0,
+			Code: []byte{
+				byte(vm.PUSH1), 0x00, byte(vm.DATAHASH),
+				byte(vm.PUSH1), 0x00, byte(vm.MSTORE),
+				byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x00, byte(vm.RETURN),
+			},
+		},
+	}}
+	api := NewAPI(newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {}))
+
+	hash := common.Hash{kzg.BlobCommitmentVersionKZG, 0x01, 0x02, 0x03}
+	head := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	result, err := api.TraceCall(context.Background(), ethapi.TransactionArgs{
+		From:                &accounts[0].addr,
+		To:                  &contract,
+		BlobVersionedHashes: []common.Hash{hash},
+	}, head, nil)
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	if have := result.(*ethapi.ExecutionResult); have.Failed || have.ReturnValue != fmt.Sprintf("%x", hash) {
+		t.Errorf("blob hash mismatch: have %s (failed %v), want %x", have.ReturnValue, have.Failed, hash)
+	}
+	// Hashes which cannot be carried by a blob transaction must be rejected
+	_, err = api.TraceCall(context.Background(), ethapi.TransactionArgs{
+		From:                &accounts[0].addr,
+		To:                  &contract,
+		BlobVersionedHashes: []common.Hash{{0xff}},
+	}, head, nil)
+	if err == nil {
+		t.Errorf("invalid blob versioned hash accepted")
+	}
+}
+
 func TestTraceTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -339,6 +391,182 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+// Tests that tracing a blob transaction reports the data gas bought for its blobs
+// and the versioned hashes it references.
+func TestTraceBlobTransaction(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{Config: params.TestShardingChainConfig, Alloc: core.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+	}}
+	var (
+		target common.Hash
+		hashes = []common.Hash{{kzg.BlobCommitmentVersionKZG, 0x01}, {kzg.BlobCommitmentVersionKZG, 0x02}}
+	)
+	api := NewAPI(newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignNewTx(accounts[0].key, types.MakeSigner(genesis.Config, b.Number(), b.Time()), &types.BlobTx{
+			ChainID:             genesis.Config.ChainID,
+			Nonce:               uint64(i),
+			GasTipCap:           common.Big0,
+			GasFeeCap:           b.BaseFee(),
+			Gas:                 params.TxGas,
+			To:                  &accounts[1].addr,
+			Value:               big.NewInt(1000),
+			MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+			BlobVersionedHashes: hashes,
+		})
+		b.AddTx(tx)
+		target = tx.Hash()
+	}))
+	result, err := api.TraceTransaction(context.Background(), target, nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	if !reflect.DeepEqual(result, &ethapi.ExecutionResult{
+		Gas:          params.TxGas,
+		Failed:       false,
+		ReturnValue:  "",
+		StructLogs:   []ethapi.StructLogRes{},
+		DataHashes:   hashes,
+		DataGasUsed:  2 * params.DataGasPerBlob,
+		DataGasPrice: (*hexutil.Big)(big.NewInt(params.MinDataGasPrice)),
+	}) {
+		t.Errorf("transaction tracing result mismatch: %+v", result)
+	}
+}
+
+// Tests that the block timings account for the transactions, blobs and point
+// evaluations of a block.
+func TestTraceBlockTimings(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(1)
+	genesis := &core.Genesis{Config: params.TestShardingChainConfig, Alloc: core.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+	}}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		signer := types.MakeSigner(genesis.Config, b.Number(), b.Time())
+
+		// Evaluate a point through the precompile (failing on the bogus input)
+		// from a transaction carrying two blobs
+		b.AddTx(types.MustSignNewTx(accounts[0].key, signer, &types.BlobTx{
+			ChainID:             genesis.Config.ChainID,
+			Nonce:               0,
+			GasTipCap:           common.Big0,
+			GasFeeCap:           b.BaseFee(),
+			Gas:                 100000,
+			To:                  &vm.PointEvaluationAddress,
+			Value:               common.Big0,
+			Data:                make([]byte, 192),
+			MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+			BlobVersionedHashes: []common.Hash{{kzg.BlobCommitmentVersionKZG, 0x01}, {kzg.BlobCommitmentVersionKZG, 0x02}},
+		}))
+		b.AddTx(types.MustSignNewTx(accounts[0].key, signer, &types.DynamicFeeTx{
+			ChainID:   genesis.Config.ChainID,
+			Nonce:     1,
+			GasTipCap: common.Big0,
+			GasFeeCap: b.BaseFee(),
+			Gas:       params.TxGas,
+			To:        &common.Address{0xaa},
+			Value:     big.NewInt(1000),
+		}))
+	})
+	api := NewAPI(backend)
+
+	timings, err := api.TraceBlockTimings(context.Background(), backend.chain.CurrentBlock().Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to time block: %v", err)
+	}
+	if timings.Transactions != 2 || timings.Blobs != 2 || timings.PointEvaluations != 1 {
+		t.Errorf("timed block mismatch: have %d txs, %d blobs, %d point evaluations, want 2, 2, 1",
+			timings.Transactions, timings.Blobs, timings.PointEvaluations)
+	}
+	for name, timing := range map[string]string{"sender recovery": timings.SenderRecovery, "kzg verification": timings.KZGVerification, "execution": timings.Execution} {
+		if _, err := time.ParseDuration(timing); err != nil {
+			t.Errorf("invalid %s timing %q: %v", name, timing, err)
+		}
+	}
+}
+
+// Tests that standard block traces of blocks with blob transactions come with a
+// dump of the blob data of the block.
+func TestStandardTraceBlockToFileBlobs(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(1)
+	genesis := &core.Genesis{Config: params.TestShardingChainConfig, Alloc: core.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+	}}
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0 // point at infinity, committing to the empty blob
+
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		b.AddTx(types.MustSignNewTx(accounts[0].key, types.MakeSigner(genesis.Config, b.Number(), b.Time()), &types.BlobTx{
+			ChainID:             genesis.Config.ChainID,
+			GasTipCap:           common.Big0,
+			GasFeeCap:           b.BaseFee(),
+			Gas:                 params.TxGas,
+			To:                  &common.Address{0xaa},
+			Value:               common.Big0,
+			MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+			BlobVersionedHashes: []common.Hash{commitment.ComputeVersionedHash()},
+		}))
+	})
+	var (
+		api   = NewAPI(backend)
+		block = backend.chain.CurrentBlock()
+		tx    = block.Transactions()[0]
+	)
+	check := func(sidecars string, commitments []kzg.KZGCommitment) {
+		t.Helper()
+
+		files, err := api.StandardTraceBlockToFile(context.Background(), block.Hash(), nil)
+		if err != nil {
+			t.Fatalf("failed to trace block: %v", err)
+		}
+		for _, file := range files {
+			defer os.Remove(file)
+		}
+		if len(files) != 2 {
+			t.Fatalf("dump count mismatch: have %d, want 2", len(files))
+		}
+		blob, err := ioutil.ReadFile(files[0])
+		if err != nil {
+			t.Fatalf("failed to read blob dump: %v", err)
+		}
+		var dump blockBlobsDump
+		if err := json.Unmarshal(blob, &dump); err != nil {
+			t.Fatalf("failed to decode blob dump: %v", err)
+		}
+		want := blockBlobsDump{
+			BlockHash:   block.Hash(),
+			BlockNumber: block.NumberU64(),
+			Sidecars:    sidecars,
+			Transactions: []*blobTxBlobsDump{{
+				TxHash:          tx.Hash(),
+				VersionedHashes: tx.DataHashes(),
+				Commitments:     commitments,
+			}},
+		}
+		if !reflect.DeepEqual(dump, want) {
+			t.Errorf("blob dump mismatch: have %s", blob)
+		}
+	}
+	check("missing", nil)
+
+	rawdb.WriteBlobSidecars(backend.chaindb, block.Hash(), block.NumberU64(), []*types.BlobTxSidecar{{
+		Blobs:       []kzg.Blob{{}},
+		Commitments: []kzg.KZGCommitment{commitment},
+		Proofs:      []kzg.KZGProof{{0xc0}},
+	}})
+	check("available", []kzg.KZGCommitment{commitment})
+
+	rawdb.DeleteBlobSidecars(backend.chaindb, block.Hash(), block.NumberU64())
+	rawdb.WriteBlobSidecarsTail(backend.chaindb, block.NumberU64()+1)
+	check("pruned", nil)
+}
+
 func TestTraceBlock(t *testing.T) {
 	t.Parallel()
 
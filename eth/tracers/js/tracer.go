@@ -36,6 +36,7 @@ import (
 	tracers2 "github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/js/internal/tracers"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"gopkg.in/olebedev/go-duktape.v3"
 )
 
@@ -313,6 +314,8 @@ type frame struct {
 	input []byte
 	gas   *uint
 	value *big.Int
+
+	pointEvaluation *vm.PointEvaluationInput // Decoded input of point evaluation precompile calls
 }
 
 func newFrame() *frame {
@@ -351,6 +354,33 @@ func (f *frame) pushObject(vm *duktape.Context) {
 		return 1
 	})
 	vm.PutPropString(obj, "getValue")
+
+	vm.PushGoFunction(func(ctx *duktape.Context) int {
+		if f.pointEvaluation != nil {
+			pushPointEvaluation(ctx, f.pointEvaluation)
+		} else {
+			ctx.PushUndefined()
+		}
+		return 1
+	})
+	vm.PutPropString(obj, "getPointEvaluation")
+}
+
+// pushPointEvaluation pushes the decoded input of a point evaluation precompile
+// call as a JS object.
+func pushPointEvaluation(ctx *duktape.Context, in *vm.PointEvaluationInput) {
+	obj := ctx.PushObject()
+
+	pushValue(ctx, in.VersionedHash)
+	ctx.PutPropString(obj, "versionedHash")
+	pushValue(ctx, in.Z)
+	ctx.PutPropString(obj, "z")
+	pushValue(ctx, in.Y)
+	ctx.PutPropString(obj, "y")
+	pushValue(ctx, in.Commitment[:])
+	ctx.PutPropString(obj, "commitment")
+	pushValue(ctx, in.Proof[:])
+	ctx.PutPropString(obj, "proof")
 }
 
 type frameResult struct {
@@ -692,18 +722,25 @@ func (jst *jsTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Ad
 	jst.ctx["gas"] = gas
 	jst.ctx["gasPrice"] = env.TxContext.GasPrice
 	jst.ctx["value"] = value
+	if len(env.TxContext.DataHashes) > 0 {
+		jst.ctx["dataHashes"] = env.TxContext.DataHashes
+		jst.ctx["dataGasUsed"] = uint64(len(env.TxContext.DataHashes)) * params.DataGasPerBlob
+		if env.Context.DataGasPrice != nil {
+			jst.ctx["dataGasPrice"] = env.Context.DataGasPrice
+		}
+	}
 
 	// Initialize the context
 	jst.ctx["block"] = env.Context.BlockNumber.Uint64()
 	jst.dbWrapper.db = env.StateDB
 	// Update list of precompiles based on current block
-	rules := env.ChainConfig().Rules(env.Context.BlockNumber, env.Context.Random != nil)
+	rules := env.ChainRules()
 	jst.activePrecompiles = vm.ActivePrecompiles(rules)
 
 	// Compute intrinsic gas
 	isHomestead := env.ChainConfig().IsHomestead(env.Context.BlockNumber)
 	isIstanbul := env.ChainConfig().IsIstanbul(env.Context.BlockNumber)
-	intrinsicGas, err := core.IntrinsicGas(input, nil, jst.ctx["type"] == "CREATE", isHomestead, isIstanbul)
+	intrinsicGas, err := core.IntrinsicGas(input, env.TxContext.AccessList, jst.ctx["type"] == "CREATE", isHomestead, isIstanbul)
 	if err != nil {
 		return
 	}
@@ -794,6 +831,10 @@ func (jst *jsTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.
 	if value != nil {
 		jst.frame.value = new(big.Int).SetBytes(value.Bytes())
 	}
+	jst.frame.pointEvaluation = nil
+	if to == vm.PointEvaluationAddress {
+		jst.frame.pointEvaluation, _ = vm.ParsePointEvaluationInput(input)
+	}
 
 	if _, err := jst.call(true, "enter", "frame"); err != nil {
 		jst.err = wrapError("enter", err)
@@ -874,6 +915,12 @@ func pushValue(ctx *duktape.Context, val interface{}) {
 	case common.Hash:
 		ptr := ctx.PushFixedBuffer(32)
 		copy(makeSlice(ptr, 32), val[:])
+	case []common.Hash:
+		arr := ctx.PushArray()
+		for i, hash := range val {
+			pushValue(ctx, hash)
+			ctx.PutPropIndex(arr, uint(i))
+		}
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", val))
 	}
@@ -19,7 +19,9 @@ package js
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -255,3 +257,36 @@ func TestEnterExit(t *testing.T) {
 		t.Errorf("Number of invocations of enter() and exit() is wrong. Have %s, want %s\n", have, want)
 	}
 }
+
+func TestPointEvaluation(t *testing.T) {
+	// test that blob versioned hashes are exposed and point evaluations decoded
+	tracer, err := newJsTracer("{evals: [], step: function() {}, fault: function() {}, result: function(ctx) { return {dataHashes: ctx.dataHashes.map(function(hash) { return toHex(hash); }), dataGasUsed: ctx.dataGasUsed, evals: this.evals} }, enter: function(frame) { var eval = frame.getPointEvaluation(); this.evals.push(eval === undefined ? null : toHex(eval.versionedHash)+'.'+toHex(eval.z)+'.'+toHex(eval.y)+'.'+toHex(eval.commitment).length+'.'+toHex(eval.proof).length); }, exit: function() {}}", new(tracers.Context))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := testCtx()
+	ctx.txCtx.DataHashes = []common.Hash{{0x01, 0xaa}, {0x01, 0xbb}}
+	env := vm.NewEVM(ctx.blockCtx, ctx.txCtx, &dummyStatedb{}, params.TestChainConfig, vm.Config{Debug: true, Tracer: tracer})
+
+	input := make([]byte, 192)
+	input[0], input[32], input[64] = 0x01, 0x02, 0x03
+	tracer.CaptureStart(env, common.Address{}, common.Address{}, false, []byte{}, 1000, big.NewInt(0))
+	tracer.CaptureEnter(vm.STATICCALL, common.Address{}, vm.PointEvaluationAddress, input, 100, nil)
+	tracer.CaptureExit(nil, 50, nil)
+	tracer.CaptureEnter(vm.STATICCALL, common.Address{}, vm.PointEvaluationAddress, input[:100], 100, nil)
+	tracer.CaptureExit(nil, 50, nil)
+	tracer.CaptureEnter(vm.CALL, common.Address{}, common.Address{0xaa}, input, 100, nil)
+	tracer.CaptureExit(nil, 50, nil)
+	tracer.CaptureEnd(nil, 100, 1, nil)
+
+	have, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	word := func(b byte) string { return "0x" + fmt.Sprintf("%02x", b) + strings.Repeat("0", 62) }
+	want := fmt.Sprintf(`{"dataHashes":["0x01aa%s","0x01bb%s"],"dataGasUsed":%d,"evals":["%s.%s.%s.98.98",null,null]}`,
+		strings.Repeat("0", 60), strings.Repeat("0", 60), 2*params.DataGasPerBlob, word(1), word(2), word(3))
+	if string(have) != want {
+		t.Errorf("point evaluation mismatch.\nhave %s\nwant %s", have, want)
+	}
+}
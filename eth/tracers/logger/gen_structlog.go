@@ -17,20 +17,21 @@ var _ = (*structLogMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (s StructLog) MarshalJSON() ([]byte, error) {
 	type StructLog struct {
-		Pc            uint64                      `json:"pc"`
-		Op            vm.OpCode                   `json:"op"`
-		Gas           math.HexOrDecimal64         `json:"gas"`
-		GasCost       math.HexOrDecimal64         `json:"gasCost"`
-		Memory        hexutil.Bytes               `json:"memory"`
-		MemorySize    int                         `json:"memSize"`
-		Stack         []uint256.Int               `json:"stack"`
-		ReturnData    hexutil.Bytes               `json:"returnData"`
-		Storage       map[common.Hash]common.Hash `json:"-"`
-		Depth         int                         `json:"depth"`
-		RefundCounter uint64                      `json:"refund"`
-		Err           error                       `json:"-"`
-		OpName        string                      `json:"opName"`
-		ErrorString   string                      `json:"error"`
+		Pc              uint64                      `json:"pc"`
+		Op              vm.OpCode                   `json:"op"`
+		Gas             math.HexOrDecimal64         `json:"gas"`
+		GasCost         math.HexOrDecimal64         `json:"gasCost"`
+		Memory          hexutil.Bytes               `json:"memory"`
+		MemorySize      int                         `json:"memSize"`
+		Stack           []uint256.Int               `json:"stack"`
+		ReturnData      hexutil.Bytes               `json:"returnData"`
+		Storage         map[common.Hash]common.Hash `json:"-"`
+		Depth           int                         `json:"depth"`
+		RefundCounter   uint64                      `json:"refund"`
+		Err             error                       `json:"-"`
+		PointEvaluation *vm.PointEvaluationInput    `json:"pointEvaluation,omitempty"`
+		OpName          string                      `json:"opName"`
+		ErrorString     string                      `json:"error"`
 	}
 	var enc StructLog
 	enc.Pc = s.Pc
@@ -45,6 +46,7 @@ func (s StructLog) MarshalJSON() ([]byte, error) {
 	enc.Depth = s.Depth
 	enc.RefundCounter = s.RefundCounter
 	enc.Err = s.Err
+	enc.PointEvaluation = s.PointEvaluation
 	enc.OpName = s.OpName()
 	enc.ErrorString = s.ErrorString()
 	return json.Marshal(&enc)
@@ -53,18 +55,19 @@ func (s StructLog) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (s *StructLog) UnmarshalJSON(input []byte) error {
 	type StructLog struct {
-		Pc            *uint64                     `json:"pc"`
-		Op            *vm.OpCode                  `json:"op"`
-		Gas           *math.HexOrDecimal64        `json:"gas"`
-		GasCost       *math.HexOrDecimal64        `json:"gasCost"`
-		Memory        *hexutil.Bytes              `json:"memory"`
-		MemorySize    *int                        `json:"memSize"`
-		Stack         []uint256.Int               `json:"stack"`
-		ReturnData    *hexutil.Bytes              `json:"returnData"`
-		Storage       map[common.Hash]common.Hash `json:"-"`
-		Depth         *int                        `json:"depth"`
-		RefundCounter *uint64                     `json:"refund"`
-		Err           error                       `json:"-"`
+		Pc              *uint64                     `json:"pc"`
+		Op              *vm.OpCode                  `json:"op"`
+		Gas             *math.HexOrDecimal64        `json:"gas"`
+		GasCost         *math.HexOrDecimal64        `json:"gasCost"`
+		Memory          *hexutil.Bytes              `json:"memory"`
+		MemorySize      *int                        `json:"memSize"`
+		Stack           []uint256.Int               `json:"stack"`
+		ReturnData      *hexutil.Bytes              `json:"returnData"`
+		Storage         map[common.Hash]common.Hash `json:"-"`
+		Depth           *int                        `json:"depth"`
+		RefundCounter   *uint64                     `json:"refund"`
+		Err             error                       `json:"-"`
+		PointEvaluation *vm.PointEvaluationInput    `json:"pointEvaluation,omitempty"`
 	}
 	var dec StructLog
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -106,5 +109,8 @@ func (s *StructLog) UnmarshalJSON(input []byte) error {
 	if dec.Err != nil {
 		s.Err = dec.Err
 	}
+	if dec.PointEvaluation != nil {
+		s.PointEvaluation = dec.PointEvaluation
+	}
 	return nil
 }
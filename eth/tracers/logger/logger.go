@@ -74,6 +74,10 @@ type StructLog struct {
 	Depth         int                         `json:"depth"`
 	RefundCounter uint64                      `json:"refund"`
 	Err           error                       `json:"-"`
+
+	// PointEvaluation is the decoded input of the EIP-4844 point evaluation
+	// precompile, set on the operation calling into it.
+	PointEvaluation *vm.PointEvaluationInput `json:"pointEvaluation,omitempty"`
 }
 
 // overrides for gencodec
@@ -108,10 +112,11 @@ type StructLogger struct {
 	cfg Config
 	env *vm.EVM
 
-	storage map[common.Address]Storage
-	logs    []StructLog
-	output  []byte
-	err     error
+	dataHashes []common.Hash // Blob versioned hashes of the traced transaction
+	storage    map[common.Address]Storage
+	logs       []StructLog
+	output     []byte
+	err        error
 }
 
 // NewStructLogger returns a new logger
@@ -130,12 +135,14 @@ func (l *StructLogger) Reset() {
 	l.storage = make(map[common.Address]Storage)
 	l.output = make([]byte, 0)
 	l.logs = l.logs[:0]
+	l.dataHashes = nil
 	l.err = nil
 }
 
 // CaptureStart implements the EVMLogger interface to initialize the tracing operation.
 func (l *StructLogger) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
 	l.env = env
+	l.dataHashes = env.TxContext.DataHashes
 }
 
 // CaptureState logs a new structured log message and pushes it out to the environment
@@ -197,7 +204,7 @@ func (l *StructLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, s
 		copy(rdata, rData)
 	}
 	// create a new snapshot of the EVM.
-	log := StructLog{pc, op, gas, cost, mem, memory.Len(), stck, rdata, storage, depth, l.env.StateDB.GetRefund(), err}
+	log := StructLog{pc, op, gas, cost, mem, memory.Len(), stck, rdata, storage, depth, l.env.StateDB.GetRefund(), err, nil}
 	l.logs = append(l.logs, log)
 }
 
@@ -218,7 +225,15 @@ func (l *StructLogger) CaptureEnd(output []byte, gasUsed uint64, t time.Duration
 	}
 }
 
+// CaptureEnter labels calls into the point evaluation precompile with their
+// decoded input, attaching it to the operation that made the call.
 func (l *StructLogger) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if to != vm.PointEvaluationAddress || len(l.logs) == 0 {
+		return
+	}
+	if log := &l.logs[len(l.logs)-1]; log.Op == typ {
+		log.PointEvaluation, _ = vm.ParsePointEvaluationInput(input)
+	}
 }
 
 func (l *StructLogger) CaptureExit(output []byte, gasUsed uint64, err error) {}
@@ -226,6 +241,9 @@ func (l *StructLogger) CaptureExit(output []byte, gasUsed uint64, err error) {}
 // StructLogs returns the captured log entries.
 func (l *StructLogger) StructLogs() []StructLog { return l.logs }
 
+// DataHashes returns the blob versioned hashes of the traced transaction.
+func (l *StructLogger) DataHashes() []common.Hash { return l.dataHashes }
+
 // Error returns the VM error captured by the trace.
 func (l *StructLogger) Error() error { return l.err }
 
@@ -72,3 +72,34 @@ func TestStoreCapture(t *testing.T) {
 		t.Errorf("expected %x, got %x", exp, logger.storage[contract.Address()][index])
 	}
 }
+
+func TestPointEvaluationCapture(t *testing.T) {
+	var (
+		logger   = NewStructLogger(nil)
+		hashes   = []common.Hash{{0x01, 0xaa}}
+		env      = vm.NewEVM(vm.BlockContext{}, vm.TxContext{DataHashes: hashes}, &dummyStatedb{}, params.TestChainConfig, vm.Config{Debug: true, Tracer: logger})
+		contract = vm.NewContract(&dummyContractRef{}, &dummyContractRef{}, new(big.Int), 100000)
+	)
+	contract.Code = []byte{byte(vm.PUSH1), 0x1, byte(vm.STATICCALL)}
+	logger.CaptureStart(env, common.Address{}, contract.Address(), false, nil, 0, nil)
+	env.Interpreter().Run(contract, []byte{}, false)
+
+	if have := logger.DataHashes(); len(have) != 1 || have[0] != hashes[0] {
+		t.Fatalf("data hashes mismatch: have %x, want %x", have, hashes)
+	}
+	input := make([]byte, 192)
+	input[0], input[32], input[64], input[96], input[144] = 0x01, 0x02, 0x03, 0x04, 0x05
+
+	logger.CaptureEnter(vm.STATICCALL, contract.Address(), vm.PointEvaluationAddress, input, 0, nil)
+	logs := logger.StructLogs()
+	if logs[0].PointEvaluation != nil {
+		t.Fatalf("point evaluation attached to wrong operation")
+	}
+	eval := logs[1].PointEvaluation
+	if eval == nil {
+		t.Fatalf("point evaluation not captured")
+	}
+	if eval.VersionedHash[0] != 0x01 || eval.Z[0] != 0x02 || eval.Y[0] != 0x03 || eval.Commitment[0] != 0x04 || eval.Proof[0] != 0x05 {
+		t.Errorf("point evaluation input mis-decoded: %+v", eval)
+	}
+}
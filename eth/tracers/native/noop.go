@@ -64,6 +64,16 @@ func (t *noopTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.
 func (t *noopTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
 }
 
+// CaptureBlobTxStart implements the BlobTxLogger interface to account for the
+// blobs of a transaction before it is executed.
+func (t *noopTracer) CaptureBlobTxStart(hashes []common.Hash, dataGas uint64, dataGasPrice *big.Int) {
+}
+
+// CaptureBlobTxEnd implements the BlobTxLogger interface, it is called after the
+// blob transaction was executed.
+func (t *noopTracer) CaptureBlobTxEnd(err error) {
+}
+
 // GetResult returns an empty json object.
 func (t *noopTracer) GetResult() (json.RawMessage, error) {
 	return json.RawMessage(`{}`), nil
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -523,6 +524,36 @@ func (ec *Client) SendTransaction(ctx context.Context, tx *types.Transaction) er
 	return ec.c.CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Encode(data))
 }
 
+// SendBlobTransaction injects a signed blob transaction into the pending pool for
+// execution, together with the blobs it references.
+//
+// The KZG commitments and proofs of the blobs are computed locally. The versioned
+// hashes of the commitments have to match the ones signed over in the transaction,
+// which can be derived up front via types.NewBlobTxSidecar.
+func (ec *Client) SendBlobTransaction(ctx context.Context, tx *types.Transaction, blobs []kzg.Blob) error {
+	if tx.Type() != types.BlobTxType {
+		return errors.New("not a blob transaction")
+	}
+	sidecar, err := types.NewBlobTxSidecar(blobs)
+	if err != nil {
+		return err
+	}
+	have, want := sidecar.BlobHashes(), tx.DataHashes()
+	if len(have) != len(want) {
+		return fmt.Errorf("blob count mismatch: have %d, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			return fmt.Errorf("blob %d: versioned hash mismatch: have %x, want %x", i, have[i], want[i])
+		}
+	}
+	data, err := tx.WithBlobTxSidecar(sidecar).MarshalNetwork()
+	if err != nil {
+		return err
+	}
+	return ec.c.CallContext(ctx, nil, "eth_sendRawBlobTransaction", hexutil.Encode(data))
+}
+
 func toBlockNumArg(number *big.Int) string {
 	if number == nil {
 		return "latest"
@@ -28,11 +28,13 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/node"
@@ -195,6 +197,17 @@ var genesis = &core.Genesis{
 	BaseFee:   big.NewInt(params.InitialBaseFee),
 }
 
+// shardingGenesis is the test genesis with the sharding fork active, for tests
+// exercising blob transactions.
+var shardingGenesis = func() *core.Genesis {
+	config := *params.AllEthashProtocolChanges
+	config.ShardingForkBlock = common.Big0
+
+	g := *genesis
+	g.Config = &config
+	return &g
+}()
+
 var testTx1 = types.MustSignNewTx(testKey, types.LatestSigner(genesis.Config), &types.LegacyTx{
 	Nonce:    0,
 	Value:    big.NewInt(12),
@@ -212,8 +225,12 @@ var testTx2 = types.MustSignNewTx(testKey, types.LatestSigner(genesis.Config), &
 })
 
 func newTestBackend(t *testing.T) (*node.Node, []*types.Block) {
+	return newTestBackendWithGenesis(t, genesis)
+}
+
+func newTestBackendWithGenesis(t *testing.T, genesis *core.Genesis) (*node.Node, []*types.Block) {
 	// Generate test chain.
-	blocks := generateTestChain()
+	blocks := generateTestChain(genesis)
 
 	// Create node
 	n, err := node.New(&node.Config{})
@@ -237,7 +254,7 @@ func newTestBackend(t *testing.T) (*node.Node, []*types.Block) {
 	return n, blocks
 }
 
-func generateTestChain() []*types.Block {
+func generateTestChain(genesis *core.Genesis) []*types.Block {
 	db := rawdb.NewMemoryDatabase()
 	generate := func(i int, g *core.BlockGen) {
 		g.OffsetTime(5)
@@ -669,6 +686,158 @@ func testTransactionSender(t *testing.T, client *rpc.Client) {
 	}
 }
 
+func TestSendBlobTransaction(t *testing.T) {
+	backend, _ := newTestBackendWithGenesis(t, shardingGenesis)
+	client, _ := backend.Attach()
+	defer backend.Close()
+	defer client.Close()
+
+	ec := NewClient(client)
+	ctx := context.Background()
+
+	blobs := []kzg.Blob{{}}
+	sidecar, err := types.NewBlobTxSidecar(blobs)
+	if err != nil {
+		t.Fatalf("failed to create sidecar: %v", err)
+	}
+	nonce, err := ec.PendingNonceAt(ctx, testAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := types.MustSignNewTx(testKey, types.LatestSigner(shardingGenesis.Config), &types.BlobTx{
+		ChainID:             shardingGenesis.Config.ChainID,
+		Nonce:               nonce,
+		To:                  &common.Address{2},
+		Value:               big.NewInt(1),
+		Gas:                 params.TxGas,
+		GasTipCap:           big.NewInt(params.InitialBaseFee),
+		GasFeeCap:           big.NewInt(2 * params.InitialBaseFee),
+		MaxFeePerDataGas:    big.NewInt(params.GWei),
+		BlobVersionedHashes: sidecar.BlobHashes(),
+	})
+	// Blobs not matching the signed versioned hashes must be rejected locally
+	if err := ec.SendBlobTransaction(ctx, tx, []kzg.Blob{{}, {}}); err == nil {
+		t.Fatal("blob transaction sent with mismatching blobs")
+	}
+	if err := ec.SendBlobTransaction(ctx, tx, blobs); err != nil {
+		t.Fatalf("failed to send blob transaction: %v", err)
+	}
+	// The blob fields must survive the round trip through the node
+	pending, isPending, err := ec.TransactionByHash(ctx, tx.Hash())
+	if err != nil {
+		t.Fatalf("failed to retrieve blob transaction: %v", err)
+	}
+	if !isPending {
+		t.Fatal("blob transaction not pending")
+	}
+	if pending.Type() != types.BlobTxType {
+		t.Fatalf("transaction type mismatch: have %d, want %d", pending.Type(), types.BlobTxType)
+	}
+	if pending.MaxFeePerDataGas().Cmp(tx.MaxFeePerDataGas()) != 0 {
+		t.Fatalf("data gas fee cap mismatch: have %v, want %v", pending.MaxFeePerDataGas(), tx.MaxFeePerDataGas())
+	}
+	if !reflect.DeepEqual(pending.DataHashes(), tx.DataHashes()) {
+		t.Fatalf("versioned hashes mismatch: have %x, want %x", pending.DataHashes(), tx.DataHashes())
+	}
+	if pending.Hash() != tx.Hash() {
+		t.Fatalf("transaction hash mismatch: have %x, want %x", pending.Hash(), tx.Hash())
+	}
+	// The pooled transaction must be retrievable along with its blobs
+	var raw hexutil.Bytes
+	if err := client.CallContext(ctx, &raw, "debug_getRawBlobTransaction", tx.Hash()); err != nil {
+		t.Fatalf("failed to retrieve raw blob transaction: %v", err)
+	}
+	want, err := tx.WithBlobTxSidecar(sidecar).MarshalNetwork()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, want) {
+		t.Fatalf("raw blob transaction mismatch: have %x, want %x", raw, want)
+	}
+	if err := client.CallContext(ctx, &raw, "debug_getRawBlobTransaction", common.Hash{}); err == nil {
+		t.Fatal("unknown blob transaction retrieved")
+	}
+}
+
+// Tests that blocks including blob transactions are rendered with the typed
+// blob fields and the block level data gas fields in full transaction mode.
+func TestBlobTxBlock(t *testing.T) {
+	// Generate a chain with a blob transaction in its only block
+	db := rawdb.NewMemoryDatabase()
+	tx := types.MustSignNewTx(testKey, types.LatestSigner(shardingGenesis.Config), &types.BlobTx{
+		ChainID:             shardingGenesis.Config.ChainID,
+		To:                  &common.Address{2},
+		Value:               big.NewInt(1),
+		Gas:                 params.TxGas,
+		GasTipCap:           big.NewInt(params.InitialBaseFee),
+		GasFeeCap:           big.NewInt(2 * params.InitialBaseFee),
+		MaxFeePerDataGas:    big.NewInt(params.GWei),
+		BlobVersionedHashes: []common.Hash{{0x01}, {0x01}},
+	})
+	blocks, _ := core.GenerateChain(shardingGenesis.Config, shardingGenesis.ToBlock(db), ethash.NewFaker(), db, 1, func(i int, g *core.BlockGen) {
+		g.AddTx(tx)
+	})
+	// Start a node serving the chain
+	n, err := node.New(&node.Config{})
+	if err != nil {
+		t.Fatalf("can't create new node: %v", err)
+	}
+	config := &ethconfig.Config{Genesis: shardingGenesis}
+	config.Ethash.PowMode = ethash.ModeFake
+	ethservice, err := eth.New(n, config)
+	if err != nil {
+		t.Fatalf("can't create new ethereum service: %v", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatalf("can't start test node: %v", err)
+	}
+	defer n.Close()
+	if _, err := ethservice.BlockChain().InsertChain(blocks); err != nil {
+		t.Fatalf("can't import test blocks: %v", err)
+	}
+	client, _ := n.Attach()
+	defer client.Close()
+
+	// Check the raw block response for the blob fields
+	var raw struct {
+		DataGasUsed   *hexutil.Uint64 `json:"dataGasUsed"`
+		ExcessDataGas *hexutil.Big    `json:"excessDataGas"`
+		Transactions  []struct {
+			Type             hexutil.Uint64 `json:"type"`
+			MaxFeePerDataGas *hexutil.Big   `json:"maxFeePerDataGas"`
+			BlobHashes       []common.Hash  `json:"blobVersionedHashes"`
+		} `json:"transactions"`
+	}
+	if err := client.Call(&raw, "eth_getBlockByNumber", "0x1", true); err != nil {
+		t.Fatalf("failed to retrieve block: %v", err)
+	}
+	if raw.DataGasUsed == nil || uint64(*raw.DataGasUsed) != 2*params.DataGasPerBlob {
+		t.Errorf("data gas used mismatch: have %v, want %d", raw.DataGasUsed, 2*params.DataGasPerBlob)
+	}
+	if raw.ExcessDataGas == nil || raw.ExcessDataGas.ToInt().Cmp(blocks[0].ExcessDataGas()) != 0 {
+		t.Errorf("excess data gas mismatch: have %v, want %v", raw.ExcessDataGas, blocks[0].ExcessDataGas())
+	}
+	if len(raw.Transactions) != 1 {
+		t.Fatalf("transaction count mismatch: have %d, want 1", len(raw.Transactions))
+	}
+	if have := raw.Transactions[0]; uint64(have.Type) != types.BlobTxType ||
+		have.MaxFeePerDataGas == nil || have.MaxFeePerDataGas.ToInt().Cmp(tx.MaxFeePerDataGas()) != 0 ||
+		!reflect.DeepEqual(have.BlobHashes, tx.DataHashes()) {
+		t.Errorf("blob transaction fields mismatch: have %+v", have)
+	}
+	// The block must also be decodable by the client
+	block, err := NewClient(client).BlockByNumber(context.Background(), big.NewInt(1))
+	if err != nil {
+		t.Fatalf("failed to retrieve block: %v", err)
+	}
+	if block.Hash() != blocks[0].Hash() {
+		t.Errorf("block hash mismatch: have %x, want %x", block.Hash(), blocks[0].Hash())
+	}
+	if have := block.Transactions()[0]; have.Hash() != tx.Hash() {
+		t.Errorf("transaction hash mismatch: have %x, want %x", have.Hash(), tx.Hash())
+	}
+}
+
 func sendTransaction(ec *Client) error {
 	chainID, err := ec.ChainID(context.Background())
 	if err != nil {
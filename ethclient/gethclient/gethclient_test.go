@@ -20,10 +20,12 @@ import (
 	"bytes"
 	"context"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -306,3 +308,69 @@ func testCallContract(t *testing.T, client *rpc.Client) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+// Tests that access lists of blob transactions cover the state accessed through
+// their versioned hashes, and that the data gas is reported apart from the gas.
+func TestAccessListBlobs(t *testing.T) {
+	// Deploy a contract loading the storage slot named by its first blob hash
+	contract := common.Address{0xaa}
+	genesis := &core.Genesis{
+		Config:  params.TestShardingChainConfig,
+		BaseFee: big.NewInt(params.InitialBaseFee),
+		Alloc: core.GenesisAlloc{
+			testAddr: {Balance: testBalance},
+			contract: {Code: common.FromHex("0x6000495400"), Balance: new(big.Int)}, // SLOAD(DATAHASH(0))
+		},
+	}
+	n, err := node.New(&node.Config{})
+	if err != nil {
+		t.Fatalf("can't create new node: %v", err)
+	}
+	config := &ethconfig.Config{Genesis: genesis}
+	config.Ethash.PowMode = ethash.ModeFake
+	if _, err := eth.New(n, config); err != nil {
+		t.Fatalf("can't create new ethereum service: %v", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatalf("can't start test node: %v", err)
+	}
+	defer n.Close()
+
+	client, err := n.Attach()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	hash := common.Hash{0x01, 0xff}
+	var result struct {
+		Accesslist  *types.AccessList `json:"accessList"`
+		Error       string            `json:"error,omitempty"`
+		GasUsed     hexutil.Uint64    `json:"gasUsed"`
+		DataGasUsed hexutil.Uint64    `json:"dataGasUsed"`
+	}
+	arg := map[string]interface{}{
+		"from":                testAddr,
+		"to":                  contract,
+		"blobVersionedHashes": []common.Hash{hash},
+	}
+	if err := client.CallContext(context.Background(), &result, "eth_createAccessList", arg, "latest"); err != nil {
+		t.Fatalf("failed to create access list: %v", err)
+	}
+	if result.Error != "" {
+		t.Fatalf("unexpected vm error: %v", result.Error)
+	}
+	want := types.AccessList{{Address: contract, StorageKeys: []common.Hash{hash}}}
+	if !reflect.DeepEqual(*result.Accesslist, want) {
+		t.Fatalf("access list mismatch: have %v, want %v", *result.Accesslist, want)
+	}
+	// The slot is warm, so the execution costs the intrinsic gas, the access
+	// list, two pushes, DATAHASH and a warm SLOAD
+	execution := params.TxGas + params.TxAccessListAddressGas + params.TxAccessListStorageKeyGas + 3 + 3 + params.WarmStorageReadCostEIP2929
+	if uint64(result.GasUsed) != execution {
+		t.Errorf("gas used mismatch: have %d, want %d", result.GasUsed, execution)
+	}
+	if result.DataGasUsed != params.DataGasPerBlob {
+		t.Errorf("data gas used mismatch: have %d, want %d", result.DataGasUsed, params.DataGasPerBlob)
+	}
+}
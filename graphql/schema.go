@@ -98,6 +98,9 @@ const schema string = `
 		maxFeePerGas: BigInt
         # MaxPriorityFeePerGas is the maximum miner tip per gas offered to include a transaction, in wei. 
 		maxPriorityFeePerGas: BigInt
+        # MaxFeePerDataGas is the maximum fee per data gas offered to include the
+        # blobs of a transaction, in wei. This is null for non-blob transactions.
+        maxFeePerDataGas: BigInt
         # Gas is the maximum amount of gas this transaction can consume.
         gas: Long!
         # InputData is the data supplied to the target of the transaction.
@@ -138,6 +141,9 @@ const schema string = `
         #Envelope transaction support
         type: Int
         accessList: [AccessTuple!]
+        # BlobVersionedHashes is the list of versioned hashes of the blobs
+        # referenced by a blob transaction. This is null for non-blob transactions.
+        blobVersionedHashes: [Bytes32!]
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -189,6 +195,13 @@ const schema string = `
         gasUsed: Long!
         # BaseFeePerGas is the fee perunit of gas burned by the protocol in this block.
 		baseFeePerGas: BigInt
+        # DataGasUsed is the amount of data gas consumed by the blobs of the
+        # transactions in this block. This is null before the sharding fork.
+        dataGasUsed: Long
+        # ExcessDataGas is the running excess of data gas consumed over the
+        # target, determining the data gas price. This is null before the
+        # sharding fork.
+        excessDataGas: BigInt
         # Timestamp is the unix timestamp at which this block was mined.
         timestamp: Long!
         # LogsBloom is a bloom filter that can be used to check if a block may
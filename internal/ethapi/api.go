@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +41,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -81,15 +83,139 @@ func (s *PublicEthereumAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.
 	return (*hexutil.Big)(tipcap), err
 }
 
+// BlobGasPrice returns the price per data gas for blob transactions in the
+// next block, derived from the excess data gas of the head block.
+func (s *PublicEthereumAPI) BlobGasPrice(ctx context.Context) (*hexutil.Big, error) {
+	return (*hexutil.Big)(misc.GetDataGasPrice(s.b.ChainConfig(), s.b.CurrentHeader().ExcessDataGas)), nil
+}
+
+// MaxFeePerDataGas returns a suggestion for a data gas fee cap for blob transactions.
+func (s *PublicEthereumAPI) MaxFeePerDataGas(ctx context.Context) (*hexutil.Big, error) {
+	feeCap, err := s.b.SuggestDataGasFeeCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(feeCap), err
+}
+
+// feeSuggestion is the fee recommendation for blob transactions.
+type feeSuggestion struct {
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas"`
+	MaxFeePerDataGas     *hexutil.Big `json:"maxFeePerDataGas"`
+}
+
+// SuggestFees returns a suggestion for all fee caps of blob transactions, based
+// on the gas and blob usage of the recent blocks.
+func (s *PublicEthereumAPI) SuggestFees(ctx context.Context) (*feeSuggestion, error) {
+	gasFeeCap, gasTipCap, dataGasFeeCap, err := s.b.SuggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &feeSuggestion{
+		MaxFeePerGas:         (*hexutil.Big)(gasFeeCap),
+		MaxPriorityFeePerGas: (*hexutil.Big)(gasTipCap),
+		MaxFeePerDataGas:     (*hexutil.Big)(dataGasFeeCap),
+	}, nil
+}
+
+// dataGasForecast is the projection of data gas prices over the next blocks.
+type dataGasForecast struct {
+	FirstBlock    *hexutil.Big   `json:"firstBlock"`
+	BlobsPerBlock float64        `json:"blobsPerBlock"`
+	DataGasPrice  []*hexutil.Big `json:"dataGasPrice"`
+}
+
+// DataGasPriceForecast projects the price of data gas over the given number of
+// blocks after the head, assuming the blob demand of the recent blocks persists,
+// so that blob submissions can be scheduled into cheaper blocks.
+func (s *PublicEthereumAPI) DataGasPriceForecast(ctx context.Context, blockCount rpc.DecimalOrHex) (*dataGasForecast, error) {
+	first, blobs, prices, err := s.b.ForecastDataGasPrices(ctx, int(blockCount))
+	if err != nil {
+		return nil, err
+	}
+	results := &dataGasForecast{
+		FirstBlock:    (*hexutil.Big)(first),
+		BlobsPerBlock: blobs,
+		DataGasPrice:  make([]*hexutil.Big, len(prices)),
+	}
+	for i, price := range prices {
+		results.DataGasPrice[i] = (*hexutil.Big)(price)
+	}
+	return results, nil
+}
+
+// postingCost is the cost of posting a payload either as calldata or in blobs.
+type postingCost struct {
+	Transactions hexutil.Uint64 `json:"transactions"`
+	Blobs        hexutil.Uint64 `json:"blobs,omitempty"`
+	Gas          hexutil.Uint64 `json:"gas"`
+	DataGas      hexutil.Uint64 `json:"dataGas,omitempty"`
+	Fee          *hexutil.Big   `json:"fee"`
+}
+
+// postingCosts compares the costs of posting a payload as calldata and in blobs.
+type postingCosts struct {
+	Calldata *postingCost `json:"calldata"`
+	Blobs    *postingCost `json:"blobs,omitempty"`
+	Cheaper  string       `json:"cheaper"`
+}
+
+func newPostingCost(cost *misc.PostingCost) *postingCost {
+	return &postingCost{
+		Transactions: hexutil.Uint64(cost.Transactions),
+		Blobs:        hexutil.Uint64(cost.Blobs),
+		Gas:          hexutil.Uint64(cost.Gas),
+		DataGas:      hexutil.Uint64(cost.DataGas),
+		Fee:          (*hexutil.Big)(cost.Fee),
+	}
+}
+
+// PostingCost returns the costs of posting a payload of the given size in the
+// next block as the calldata of a transaction and in the blobs of blob
+// transactions, at the next base fee, the suggested tip and the current data
+// gas price. Blob costs are omitted if blob transactions are not yet enabled.
+func (s *PublicEthereumAPI) PostingCost(ctx context.Context, size hexutil.Uint64) (*postingCosts, error) {
+	tip, err := s.b.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		config  = s.b.ChainConfig()
+		head    = s.b.CurrentHeader()
+		next    = new(big.Int).Add(head.Number, common.Big1)
+		baseFee = new(big.Int)
+	)
+	if config.IsLondon(next) {
+		baseFee = misc.CalcBaseFee(config, head)
+	}
+	calldata := misc.CalldataPostingCost(uint64(size), baseFee, tip)
+	result := &postingCosts{
+		Calldata: newPostingCost(calldata),
+		Cheaper:  "calldata",
+	}
+	if config.IsSharding(next, head.Time+1) {
+		blobs := misc.BlobPostingCost(config, head.Time+1, uint64(size), baseFee, tip, misc.GetDataGasPrice(config, head.ExcessDataGas))
+		result.Blobs = newPostingCost(blobs)
+		if blobs.Fee.Cmp(calldata.Fee) < 0 {
+			result.Cheaper = "blobs"
+		}
+	}
+	return result, nil
+}
+
 type feeHistoryResult struct {
-	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
-	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
-	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
-	GasUsedRatio []float64        `json:"gasUsedRatio"`
+	OldestBlock   *hexutil.Big     `json:"oldestBlock"`
+	Reward        [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee       []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio  []float64        `json:"gasUsedRatio"`
+	DataGasUsed   []hexutil.Uint64 `json:"dataGasUsed,omitempty"`
+	ExcessDataGas []*hexutil.Big   `json:"excessDataGas,omitempty"`
+	DataGasPrice  []*hexutil.Big   `json:"dataGasPrice,omitempty"`
 }
 
 func (s *PublicEthereumAPI) FeeHistory(ctx context.Context, blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*feeHistoryResult, error) {
-	oldest, reward, baseFee, gasUsed, err := s.b.FeeHistory(ctx, int(blockCount), lastBlock, rewardPercentiles)
+	oldest, reward, baseFee, gasUsed, dataGasUsed, excessDataGas, dataGasPrice, err := s.b.FeeHistory(ctx, int(blockCount), lastBlock, rewardPercentiles)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +238,24 @@ func (s *PublicEthereumAPI) FeeHistory(ctx context.Context, blockCount rpc.Decim
 			results.BaseFee[i] = (*hexutil.Big)(v)
 		}
 	}
+	if dataGasUsed != nil {
+		results.DataGasUsed = make([]hexutil.Uint64, len(dataGasUsed))
+		for i, v := range dataGasUsed {
+			results.DataGasUsed[i] = hexutil.Uint64(v)
+		}
+	}
+	if excessDataGas != nil {
+		results.ExcessDataGas = make([]*hexutil.Big, len(excessDataGas))
+		for i, v := range excessDataGas {
+			results.ExcessDataGas[i] = (*hexutil.Big)(v)
+		}
+	}
+	if dataGasPrice != nil {
+		results.DataGasPrice = make([]*hexutil.Big, len(dataGasPrice))
+		for i, v := range dataGasPrice {
+			results.DataGasPrice[i] = (*hexutil.Big)(v)
+		}
+	}
 	return results, nil
 }
 
@@ -815,6 +959,168 @@ func (s *PublicBlockChainAPI) GetUncleCountByBlockHash(ctx context.Context, bloc
 	return nil
 }
 
+// BlockBlobs holds the blobs carried by the blob transactions of a block, along
+// with their KZG commitments, in the order of their versioned hashes.
+type BlockBlobs struct {
+	BlockHash   common.Hash         `json:"blockHash"`
+	BlockNumber hexutil.Uint64      `json:"blockNumber"`
+	Blobs       []kzg.Blob          `json:"blobs"`
+	Commitments []kzg.KZGCommitment `json:"commitments"`
+}
+
+// BeaconBlobSidecar is a blob along with its KZG commitment and proof, shaped
+// like the blob sidecars of the beacon API. Fields only known to the consensus
+// layer (slot, proposer, beacon block root) are left out.
+type BeaconBlobSidecar struct {
+	BlockHash     common.Hash       `json:"block_hash"`
+	BlockNumber   string            `json:"block_number"`
+	Index         string            `json:"index"`
+	Blob          kzg.Blob          `json:"blob"`
+	KZGCommitment kzg.KZGCommitment `json:"kzg_commitment"`
+	KZGProof      kzg.KZGProof      `json:"kzg_proof"`
+}
+
+// BeaconBlobSidecars is the envelope of blob sidecar responses in the beacon API
+// format.
+type BeaconBlobSidecars struct {
+	Data []*BeaconBlobSidecar `json:"data"`
+}
+
+// newBeaconBlobSidecars flattens the sidecars of a block into beacon API shaped
+// sidecars, indexed by the position of the blob within the block.
+func newBeaconBlobSidecars(block *types.Block, sidecars []*types.BlobTxSidecar) *BeaconBlobSidecars {
+	result := &BeaconBlobSidecars{Data: []*BeaconBlobSidecar{}}
+	for _, sidecar := range sidecars {
+		for i := range sidecar.Blobs {
+			result.Data = append(result.Data, &BeaconBlobSidecar{
+				BlockHash:     block.Hash(),
+				BlockNumber:   strconv.FormatUint(block.NumberU64(), 10),
+				Index:         strconv.Itoa(len(result.Data)),
+				Blob:          sidecar.Blobs[i],
+				KZGCommitment: sidecar.Commitments[i],
+				KZGProof:      sidecar.Proofs[i],
+			})
+		}
+	}
+	return result
+}
+
+// GetBlobsByBlock returns the blobs and KZG commitments of the blob transactions
+// included in the given block. Blobs are not part of blocks, they are only stored
+// if known locally when the block was imported (built by this node, or imported
+// via the engine API with blobs from the transaction pool) and are only retained
+// for recent blocks, unless retrieved from other nodes with --blobhistory. An
+// error is returned if the blobs are not available.
+//
+// With --rpc.beaconblobs, the blobs are returned as beacon API shaped sidecars
+// instead, so consumers integrated with consensus clients can read them as is.
+func (s *PublicBlockChainAPI) GetBlobsByBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (interface{}, error) {
+	block, err := s.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	var hashes []common.Hash
+	for _, tx := range block.Transactions() {
+		hashes = append(hashes, tx.DataHashes()...)
+	}
+	var sidecars []*types.BlobTxSidecar
+	if len(hashes) > 0 {
+		if sidecars, err = s.b.GetBlobSidecars(ctx, block.Hash()); err != nil {
+			return nil, err
+		}
+	}
+	result := &BlockBlobs{
+		BlockHash:   block.Hash(),
+		BlockNumber: hexutil.Uint64(block.NumberU64()),
+		Blobs:       []kzg.Blob{},
+		Commitments: []kzg.KZGCommitment{},
+	}
+	for _, sidecar := range sidecars {
+		result.Blobs = append(result.Blobs, sidecar.Blobs...)
+		result.Commitments = append(result.Commitments, sidecar.Commitments...)
+	}
+	if len(result.Blobs) != len(hashes) {
+		return nil, fmt.Errorf("blobs of block %#x not available (pruned or never known locally)", block.Hash())
+	}
+	if s.b.RPCBeaconBlobs() {
+		return newBeaconBlobSidecars(block, sidecars), nil
+	}
+	return result, nil
+}
+
+// VersionedBlob is a blob looked up by its versioned hash, along with its KZG
+// commitment and proof and the position of the transaction carrying it.
+type VersionedBlob struct {
+	BlockHash        common.Hash       `json:"blockHash"`
+	BlockNumber      hexutil.Uint64    `json:"blockNumber"`
+	TransactionHash  common.Hash       `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64    `json:"transactionIndex"`
+	Blob             kzg.Blob          `json:"blob"`
+	Commitment       kzg.KZGCommitment `json:"commitment"`
+	Proof            kzg.KZGProof      `json:"proof"`
+}
+
+// GetBlobByVersionedHash returns the blob with the given versioned hash from the
+// canonical chain, along with its KZG commitment and proof. Blobs are located
+// through an index maintained for the blocks whose sidecars are retained, so
+// nil is returned for blobs that were pruned or never known locally.
+//
+// With --rpc.beaconblobs, the blob is returned as a beacon API shaped sidecar
+// instead, indexed by the position of the blob within the block.
+func (s *PublicBlockChainAPI) GetBlobByVersionedHash(ctx context.Context, versionedHash common.Hash) (interface{}, error) {
+	hash, err := s.b.GetBlobBlockHash(ctx, versionedHash)
+	if hash == (common.Hash{}) || err != nil {
+		return nil, err
+	}
+	block, err := s.b.BlockByHash(ctx, hash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	sidecars, err := s.b.GetBlobSidecars(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	// Sidecars are stored in the order of the blob transactions of the block
+	var blobTxs, blobs int
+	for i, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		for j, h := range tx.DataHashes() {
+			if h != versionedHash {
+				continue
+			}
+			if blobTxs >= len(sidecars) || j >= len(sidecars[blobTxs].Blobs) {
+				return nil, fmt.Errorf("blobs of block %#x not available (pruned or never known locally)", hash)
+			}
+			sidecar := sidecars[blobTxs]
+			if s.b.RPCBeaconBlobs() {
+				return &BeaconBlobSidecar{
+					BlockHash:     hash,
+					BlockNumber:   strconv.FormatUint(block.NumberU64(), 10),
+					Index:         strconv.Itoa(blobs + j),
+					Blob:          sidecar.Blobs[j],
+					KZGCommitment: sidecar.Commitments[j],
+					KZGProof:      sidecar.Proofs[j],
+				}, nil
+			}
+			return &VersionedBlob{
+				BlockHash:        hash,
+				BlockNumber:      hexutil.Uint64(block.NumberU64()),
+				TransactionHash:  tx.Hash(),
+				TransactionIndex: hexutil.Uint64(i),
+				Blob:             sidecar.Blobs[j],
+				Commitment:       sidecar.Commitments[j],
+				Proof:            sidecar.Proofs[j],
+			}, nil
+		}
+		blobTxs++
+		blobs += len(tx.DataHashes())
+	}
+	// The block carrying the blob was reorged out since the lookup
+	return nil, nil
+}
+
 // GetCode returns the code stored at the given address in the state for the given block number.
 func (s *PublicBlockChainAPI) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
 	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
@@ -1042,6 +1348,15 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 			}
 			available.Sub(available, args.Value.ToInt())
 		}
+		// Blob transactions pay for their data gas upfront, on top of the execution
+		if dataFeeCap := args.dataFeeCap(); dataFeeCap != nil && args.dataGas() > 0 {
+			dataCost := new(big.Int).SetUint64(args.dataGas())
+			dataCost.Mul(dataCost, dataFeeCap)
+			if dataCost.Cmp(available) >= 0 {
+				return 0, errors.New("insufficient funds for data gas")
+			}
+			available.Sub(available, dataCost)
+		}
 		allowance := new(big.Int).Div(available, feeCap)
 
 		// If the allowance is larger than maximum uint64, skip checking
@@ -1122,14 +1437,35 @@ func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args TransactionA
 	return DoEstimateGas(ctx, s.b, args, bNrOrHash, s.b.RPCGasCap())
 }
 
+// DataGasEstimate is the gas estimate of a blob transaction, split into the gas
+// needed by its execution and the data gas consumed by its blobs.
+type DataGasEstimate struct {
+	Gas     hexutil.Uint64 `json:"gas"`
+	DataGas hexutil.Uint64 `json:"dataGas"`
+}
+
+// EstimateDataGas returns an estimate of the amount of gas needed to execute the
+// given blob transaction against the current pending block, along with the fixed
+// amount of data gas its blobs consume.
+func (s *PublicBlockChainAPI) EstimateDataGas(ctx context.Context, args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (*DataGasEstimate, error) {
+	gas, err := s.EstimateGas(ctx, args, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return &DataGasEstimate{Gas: gas, DataGas: hexutil.Uint64(args.dataGas())}, nil
+}
+
 // ExecutionResult groups all structured logs emitted by the EVM
 // while replaying a transaction in debug mode as well as transaction
 // execution status, the amount of gas used and the return value
 type ExecutionResult struct {
-	Gas         uint64         `json:"gas"`
-	Failed      bool           `json:"failed"`
-	ReturnValue string         `json:"returnValue"`
-	StructLogs  []StructLogRes `json:"structLogs"`
+	Gas          uint64         `json:"gas"`
+	Failed       bool           `json:"failed"`
+	ReturnValue  string         `json:"returnValue"`
+	StructLogs   []StructLogRes `json:"structLogs"`
+	DataHashes   []common.Hash  `json:"dataHashes,omitempty"`
+	DataGasUsed  uint64         `json:"dataGasUsed,omitempty"`
+	DataGasPrice *hexutil.Big   `json:"dataGasPrice,omitempty"`
 }
 
 // StructLogRes stores a structured log emitted by the EVM while replaying a
@@ -1144,6 +1480,8 @@ type StructLogRes struct {
 	Stack   *[]string          `json:"stack,omitempty"`
 	Memory  *[]string          `json:"memory,omitempty"`
 	Storage *map[string]string `json:"storage,omitempty"`
+
+	PointEvaluation *vm.PointEvaluationInput `json:"pointEvaluation,omitempty"`
 }
 
 // FormatLogs formats EVM returned structured logs for json output
@@ -1157,6 +1495,8 @@ func FormatLogs(logs []logger.StructLog) []StructLogRes {
 			GasCost: trace.GasCost,
 			Depth:   trace.Depth,
 			Error:   trace.ErrorString(),
+
+			PointEvaluation: trace.PointEvaluation,
 		}
 		if trace.Stack != nil {
 			stack := make([]string, len(trace.Stack))
@@ -1209,6 +1549,10 @@ func RPCMarshalHeader(head *types.Header) map[string]interface{} {
 		result["baseFeePerGas"] = (*hexutil.Big)(head.BaseFee)
 	}
 
+	if head.ExcessDataGas != nil {
+		result["excessDataGas"] = (*hexutil.Big)(head.ExcessDataGas)
+	}
+
 	return result
 }
 
@@ -1219,20 +1563,24 @@ func RPCMarshalBlock(block *types.Block, inclTx bool, fullTx bool, config *param
 	fields := RPCMarshalHeader(block.Header())
 	fields["size"] = hexutil.Uint64(block.Size())
 
+	// Headers don't carry the data gas used, derive it from the blobs of the block
+	if block.ExcessDataGas() != nil {
+		fields["dataGasUsed"] = hexutil.Uint64(block.DataGasUsed())
+	}
 	if inclTx {
-		formatTx := func(tx *types.Transaction) (interface{}, error) {
+		formatTx := func(idx int, tx *types.Transaction) (interface{}, error) {
 			return tx.Hash(), nil
 		}
 		if fullTx {
-			formatTx = func(tx *types.Transaction) (interface{}, error) {
-				return newRPCTransactionFromBlockHash(block, tx.Hash(), config), nil
+			formatTx = func(idx int, tx *types.Transaction) (interface{}, error) {
+				return newRPCTransactionFromBlockIndex(block, uint64(idx), config), nil
 			}
 		}
 		txs := block.Transactions()
 		transactions := make([]interface{}, len(txs))
 		var err error
 		for i, tx := range txs {
-			if transactions[i], err = formatTx(tx); err != nil {
+			if transactions[i], err = formatTx(i, tx); err != nil {
 				return nil, err
 			}
 		}
@@ -1287,6 +1635,8 @@ type RPCTransaction struct {
 	Type             hexutil.Uint64    `json:"type"`
 	Accesses         *types.AccessList `json:"accessList,omitempty"`
 	ChainID          *hexutil.Big      `json:"chainId,omitempty"`
+	MaxFeePerDataGas *hexutil.Big      `json:"maxFeePerDataGas,omitempty"`
+	BlobHashes       []common.Hash     `json:"blobVersionedHashes,omitempty"`
 	V                *hexutil.Big      `json:"v"`
 	R                *hexutil.Big      `json:"r"`
 	S                *hexutil.Big      `json:"s"`
@@ -1294,8 +1644,8 @@ type RPCTransaction struct {
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
 // representation, with the given location metadata set (if available).
-func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber uint64, index uint64, baseFee *big.Int, config *params.ChainConfig) *RPCTransaction {
-	signer := types.MakeSigner(config, big.NewInt(0).SetUint64(blockNumber))
+func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber uint64, blockTime uint64, index uint64, baseFee *big.Int, config *params.ChainConfig) *RPCTransaction {
+	signer := types.MakeSigner(config, big.NewInt(0).SetUint64(blockNumber), blockTime)
 	from, _ := types.Sender(signer, tx)
 	v, r, s := tx.RawSignatureValues()
 	result := &RPCTransaction{
@@ -1322,7 +1672,7 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		al := tx.AccessList()
 		result.Accesses = &al
 		result.ChainID = (*hexutil.Big)(tx.ChainId())
-	case types.DynamicFeeTxType:
+	case types.DynamicFeeTxType, types.BlobTxType:
 		al := tx.AccessList()
 		result.Accesses = &al
 		result.ChainID = (*hexutil.Big)(tx.ChainId())
@@ -1336,19 +1686,27 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		} else {
 			result.GasPrice = (*hexutil.Big)(tx.GasFeeCap())
 		}
+		if tx.Type() == types.BlobTxType {
+			result.MaxFeePerDataGas = (*hexutil.Big)(tx.MaxFeePerDataGas())
+			result.BlobHashes = tx.DataHashes()
+		}
 	}
 	return result
 }
 
 // newRPCPendingTransaction returns a pending transaction that will serialize to the RPC representation
 func newRPCPendingTransaction(tx *types.Transaction, current *types.Header, config *params.ChainConfig) *RPCTransaction {
-	var baseFee *big.Int
-	blockNumber := uint64(0)
+	var (
+		baseFee     *big.Int
+		blockNumber = uint64(0)
+		blockTime   = uint64(0)
+	)
 	if current != nil {
 		baseFee = misc.CalcBaseFee(config, current)
 		blockNumber = current.Number.Uint64()
+		blockTime = current.Time
 	}
-	return newRPCTransaction(tx, common.Hash{}, blockNumber, 0, baseFee, config)
+	return newRPCTransaction(tx, common.Hash{}, blockNumber, blockTime, 0, baseFee, config)
 }
 
 // newRPCTransactionFromBlockIndex returns a transaction that will serialize to the RPC representation.
@@ -1357,7 +1715,7 @@ func newRPCTransactionFromBlockIndex(b *types.Block, index uint64, config *param
 	if index >= uint64(len(txs)) {
 		return nil
 	}
-	return newRPCTransaction(txs[index], b.Hash(), b.NumberU64(), index, b.BaseFee(), config)
+	return newRPCTransaction(txs[index], b.Hash(), b.NumberU64(), b.Time(), index, b.BaseFee(), config)
 }
 
 // newRPCRawTransactionFromBlockIndex returns the bytes of a transaction given a block and a transaction index.
@@ -1384,13 +1742,19 @@ func newRPCTransactionFromBlockHash(b *types.Block, hash common.Hash, config *pa
 // Its the result of the `debug_createAccessList` RPC call.
 // It contains an error if the transaction itself failed.
 type accessListResult struct {
-	Accesslist *types.AccessList `json:"accessList"`
-	Error      string            `json:"error,omitempty"`
-	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+	Accesslist  *types.AccessList `json:"accessList"`
+	Error       string            `json:"error,omitempty"`
+	GasUsed     hexutil.Uint64    `json:"gasUsed"`
+	DataGasUsed hexutil.Uint64    `json:"dataGasUsed,omitempty"`
 }
 
 // CreateAccessList creates a EIP-2930 type AccessList for the given transaction.
 // Reexec and BlockNrOrHash can be specified to create the accessList on top of a certain state.
+//
+// Blob transactions are executed with their versioned hashes exposed to DATAHASH,
+// so the state accessed through them is part of the access list. The gas used
+// only covers the execution, the data gas consumed by the blobs is reported
+// separately.
 func (s *PublicBlockChainAPI) CreateAccessList(ctx context.Context, args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (*accessListResult, error) {
 	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
 	if blockNrOrHash != nil {
@@ -1400,7 +1764,7 @@ func (s *PublicBlockChainAPI) CreateAccessList(ctx context.Context, args Transac
 	if err != nil {
 		return nil, err
 	}
-	result := &accessListResult{Accesslist: &acl, GasUsed: hexutil.Uint64(gasUsed)}
+	result := &accessListResult{Accesslist: &acl, GasUsed: hexutil.Uint64(gasUsed), DataGasUsed: hexutil.Uint64(args.dataGas())}
 	if vmerr != nil {
 		result.Error = vmerr.Error()
 	}
@@ -1432,7 +1796,7 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 	}
 	isPostMerge := header.Difficulty.Cmp(common.Big0) == 0
 	// Retrieve the precompiles since they don't need to be added to the access list
-	precompiles := vm.ActivePrecompiles(b.ChainConfig().Rules(header.Number, isPostMerge))
+	precompiles := vm.ActivePrecompiles(b.ChainConfig().Rules(header.Number, isPostMerge, header.Time))
 
 	// Create an initial tracer
 	prevTracer := logger.NewAccessListTracer(nil, args.from(), to, precompiles)
@@ -1444,6 +1808,9 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 		accessList := prevTracer.AccessList()
 		log.Trace("Creating access list", "input", accessList)
 
+		// Set the accesslist to the last al
+		args.AccessList = &accessList
+
 		// If no gas amount was specified, each unique access list needs it's own
 		// gas calculation. This is quite expensive, but we need to be accurate
 		// and it's convered by the sender only anyway.
@@ -1455,8 +1822,6 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 		}
 		// Copy the original db so we don't modify it
 		statedb := db.Copy()
-		// Set the accesslist to the last al
-		args.AccessList = &accessList
 		msg, err := args.ToMessage(b.RPCGasCap(), header.BaseFee)
 		if err != nil {
 			return nil, 0, nil, err
@@ -1576,7 +1941,7 @@ func (s *PublicTransactionPoolAPI) GetTransactionByHash(ctx context.Context, has
 		if err != nil {
 			return nil, err
 		}
-		return newRPCTransaction(tx, blockHash, blockNumber, index, header.BaseFee, s.b.ChainConfig()), nil
+		return newRPCTransaction(tx, blockHash, blockNumber, header.Time, index, header.BaseFee, s.b.ChainConfig()), nil
 	}
 	// No finalized transaction, try to retrieve it from the pool
 	if tx := s.b.GetPoolTransaction(hash); tx != nil {
@@ -1619,9 +1984,16 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 	}
 	receipt := receipts[index]
 
+	header, err := s.b.HeaderByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("header %#x not found", blockHash)
+	}
 	// Derive the sender.
 	bigblock := new(big.Int).SetUint64(blockNumber)
-	signer := types.MakeSigner(s.b.ChainConfig(), bigblock)
+	signer := types.MakeSigner(s.b.ChainConfig(), bigblock, header.Time)
 	from, _ := types.Sender(signer, tx)
 
 	fields := map[string]interface{}{
@@ -1642,13 +2014,26 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 	if !s.b.ChainConfig().IsLondon(bigblock) {
 		fields["effectiveGasPrice"] = hexutil.Uint64(tx.GasPrice().Uint64())
 	} else {
-		header, err := s.b.HeaderByHash(ctx, blockHash)
-		if err != nil {
-			return nil, err
-		}
 		gasPrice := new(big.Int).Add(header.BaseFee, tx.EffectiveGasTipValue(header.BaseFee))
 		fields["effectiveGasPrice"] = hexutil.Uint64(gasPrice.Uint64())
 	}
+	// Assign the data gas paid by blob transactions. Receipts not processed locally
+	// lack the price, derive it from the parent header in that case.
+	if tx.Type() == types.BlobTxType {
+		dataGasPrice := receipt.DataGasPrice
+		if dataGasPrice == nil {
+			parent, err := s.b.HeaderByHash(ctx, header.ParentHash)
+			if err != nil {
+				return nil, err
+			}
+			if parent == nil {
+				return nil, fmt.Errorf("parent header %#x not found", header.ParentHash)
+			}
+			dataGasPrice = misc.GetDataGasPrice(s.b.ChainConfig(), parent.ExcessDataGas)
+		}
+		fields["dataGasUsed"] = hexutil.Uint64(tx.DataGas())
+		fields["dataGasPrice"] = (*hexutil.Big)(dataGasPrice)
+	}
 	// Assign receipt status or post state.
 	if len(receipt.PostState) > 0 {
 		fields["root"] = hexutil.Bytes(receipt.PostState)
@@ -1693,7 +2078,7 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		return common.Hash{}, err
 	}
 	// Print a log with full tx details for manual investigations and interventions
-	signer := types.MakeSigner(b.ChainConfig(), b.CurrentBlock().Number())
+	signer := types.MakeSigner(b.ChainConfig(), b.CurrentBlock().Number(), b.CurrentBlock().Time())
 	from, err := types.Sender(signer, tx)
 	if err != nil {
 		return common.Hash{}, err
@@ -1767,6 +2152,21 @@ func (s *PublicTransactionPoolAPI) SendRawTransaction(ctx context.Context, input
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
+// SendRawBlobTransaction will add the signed blob transaction, wrapped together
+// with its blobs, KZG commitments and proofs, to the transaction pool. The pool
+// verifies the blobs against the versioned hashes of the transaction, and the
+// canonical transaction hash is returned.
+func (s *PublicTransactionPoolAPI) SendRawBlobTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalNetwork(input); err != nil {
+		return common.Hash{}, err
+	}
+	if tx.Type() != types.BlobTxType || tx.BlobTxSidecar() == nil {
+		return common.Hash{}, errors.New("not a blob transaction wrapped with its blobs")
+	}
+	return SubmitTransaction(ctx, s.b, tx)
+}
+
 // Sign calculates an ECDSA signature for:
 // keccack256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
@@ -1936,6 +2336,20 @@ func (api *PublicDebugAPI) GetBlockRlp(ctx context.Context, number uint64) (hexu
 	return rlp.EncodeToBytes(block)
 }
 
+// GetRawBlobTransaction retrieves the network encoding of a pooled blob transaction,
+// wrapped together with its blobs, KZG commitments and proofs. The result can be
+// resubmitted through eth_sendRawBlobTransaction.
+func (api *PublicDebugAPI) GetRawBlobTransaction(hash common.Hash) (hexutil.Bytes, error) {
+	tx := api.b.GetPoolTransactionWithBlobs(hash)
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %#x not found in pool", hash)
+	}
+	if tx.BlobTxSidecar() == nil {
+		return nil, fmt.Errorf("transaction %#x is not a blob transaction", hash)
+	}
+	return tx.MarshalNetwork()
+}
+
 // TestSignCliqueBlock fetches the given block number, and attempts to sign it as a clique header with the
 // given address, returning the address of the recovered signature
 //
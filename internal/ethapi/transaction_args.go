@@ -27,7 +27,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -52,6 +54,10 @@ type TransactionArgs struct {
 	// Introduced by AccessListTxType transaction.
 	AccessList *types.AccessList `json:"accessList,omitempty"`
 	ChainID    *hexutil.Big      `json:"chainId,omitempty"`
+
+	// Introduced by BlobTxType transaction.
+	BlobVersionedHashes []common.Hash `json:"blobVersionedHashes,omitempty"`
+	MaxFeePerDataGas    *hexutil.Big  `json:"maxFeePerDataGas,omitempty"`
 }
 
 // from retrieves the transaction sender address.
@@ -73,6 +79,21 @@ func (args *TransactionArgs) data() []byte {
 	return nil
 }
 
+// dataGas returns the amount of data gas consumed by the blobs referenced by the
+// transaction.
+func (args *TransactionArgs) dataGas() uint64 {
+	return uint64(len(args.BlobVersionedHashes)) * params.DataGasPerBlob
+}
+
+// dataFeeCap retrieves the data gas fee cap of the transaction, or nil if none
+// was specified.
+func (args *TransactionArgs) dataFeeCap() *big.Int {
+	if args.MaxFeePerDataGas == nil {
+		return nil
+	}
+	return args.MaxFeePerDataGas.ToInt()
+}
+
 // setDefaults fills in default values for unspecified tx fields.
 func (args *TransactionArgs) setDefaults(ctx context.Context, b Backend) error {
 	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
@@ -156,6 +177,8 @@ func (args *TransactionArgs) setDefaults(ctx context.Context, b Backend) error {
 			Value:                args.Value,
 			Data:                 (*hexutil.Bytes)(&data),
 			AccessList:           args.AccessList,
+			BlobVersionedHashes:  args.BlobVersionedHashes,
+			MaxFeePerDataGas:     args.MaxFeePerDataGas,
 		}
 		pendingBlockNr := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
 		estimated, err := DoEstimateGas(ctx, b, callArgs, pendingBlockNr, b.RPCGasCap())
@@ -240,6 +263,19 @@ func (args *TransactionArgs) ToMessage(globalGasCap uint64, baseFee *big.Int) (t
 		accessList = *args.AccessList
 	}
 	msg := types.NewMessage(addr, args.To, 0, value, gas, gasPrice, gasFeeCap, gasTipCap, data, accessList, true)
+	if len(args.BlobVersionedHashes) > 0 {
+		// Blob hashes are exposed to the EVM via DATAHASH, so only accept ones a
+		// real blob transaction could carry on any chain
+		if len(args.BlobVersionedHashes) > params.MaxBlobsPerBlockLimit {
+			return types.Message{}, fmt.Errorf("too many blob versioned hashes: have %d, max %d", len(args.BlobVersionedHashes), params.MaxBlobsPerBlockLimit)
+		}
+		for i, hash := range args.BlobVersionedHashes {
+			if hash[0] != kzg.BlobCommitmentVersionKZG {
+				return types.Message{}, fmt.Errorf("blob versioned hash %d has invalid version %#x", i, hash[0])
+			}
+		}
+		msg = msg.WithDataHashes(args.BlobVersionedHashes, args.dataFeeCap())
+	}
 	return msg, nil
 }
 
@@ -248,6 +284,24 @@ func (args *TransactionArgs) ToMessage(globalGasCap uint64, baseFee *big.Int) (t
 func (args *TransactionArgs) toTransaction() *types.Transaction {
 	var data types.TxData
 	switch {
+	case args.BlobVersionedHashes != nil && args.MaxFeePerGas != nil:
+		al := types.AccessList{}
+		if args.AccessList != nil {
+			al = *args.AccessList
+		}
+		data = &types.BlobTx{
+			To:                  args.To,
+			ChainID:             (*big.Int)(args.ChainID),
+			Nonce:               uint64(*args.Nonce),
+			Gas:                 uint64(*args.Gas),
+			GasFeeCap:           (*big.Int)(args.MaxFeePerGas),
+			GasTipCap:           (*big.Int)(args.MaxPriorityFeePerGas),
+			Value:               (*big.Int)(args.Value),
+			Data:                args.data(),
+			AccessList:          al,
+			MaxFeePerDataGas:    args.dataFeeCap(),
+			BlobVersionedHashes: args.BlobVersionedHashes,
+		}
 	case args.MaxFeePerGas != nil:
 		al := types.AccessList{}
 		if args.AccessList != nil {
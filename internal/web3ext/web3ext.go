@@ -233,6 +233,11 @@ web3._extend({
 			call: 'debug_getBlockRlp',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'getRawBlobTransaction',
+			call: 'debug_getRawBlobTransaction',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'testSignCliqueBlock',
 			call: 'debug_testSignCliqueBlock',
@@ -420,6 +425,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceBlockTimings',
+			call: 'debug_traceBlockTimings',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
 		new web3._extend.Method({
 			name: 'traceTransaction',
 			call: 'debug_traceTransaction',
@@ -510,12 +521,23 @@ web3._extend({
 			inputFormatter: [web3._extend.formatters.inputCallFormatter, web3._extend.formatters.inputBlockNumberFormatter],
 			outputFormatter: web3._extend.utils.toDecimal
 		}),
+		new web3._extend.Method({
+			name: 'estimateDataGas',
+			call: 'eth_estimateDataGas',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputCallFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'submitTransaction',
 			call: 'eth_submitTransaction',
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'sendRawBlobTransaction',
+			call: 'eth_sendRawBlobTransaction',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'fillTransaction',
 			call: 'eth_fillTransaction',
@@ -545,6 +567,17 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, function (val) { return !!val; }]
 		}),
+		new web3._extend.Method({
+			name: 'getBlobsByBlock',
+			call: 'eth_getBlobsByBlock',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getBlobByVersionedHash',
+			call: 'eth_getBlobByVersionedHash',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransaction',
 			call: 'eth_getRawTransactionByHash',
@@ -576,6 +609,22 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'suggestFees',
+			call: 'eth_suggestFees',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'dataGasPriceForecast',
+			call: 'eth_dataGasPriceForecast',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'postingCost',
+			call: 'eth_postingCost',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal],
+		}),
 		new web3._extend.Method({
 			name: 'getLogs',
 			call: 'eth_getLogs',
@@ -600,6 +649,16 @@ web3._extend({
 			getter: 'eth_maxPriorityFeePerGas',
 			outputFormatter: web3._extend.utils.toBigNumber
 		}),
+		new web3._extend.Property({
+			name: 'blobGasPrice',
+			getter: 'eth_blobGasPrice',
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+		new web3._extend.Property({
+			name: 'maxFeePerDataGas',
+			getter: 'eth_maxFeePerDataGas',
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
 	]
 });
 `
@@ -168,6 +168,16 @@ func (b *LesApiBackend) GetReceipts(ctx context.Context, hash common.Hash) (type
 	return nil, nil
 }
 
+// GetBlobSidecars always returns nil, light clients do not retain blobs.
+func (b *LesApiBackend) GetBlobSidecars(ctx context.Context, hash common.Hash) ([]*types.BlobTxSidecar, error) {
+	return nil, nil
+}
+
+// GetBlobBlockHash always returns the empty hash, light clients do not index blobs.
+func (b *LesApiBackend) GetBlobBlockHash(ctx context.Context, versionedHash common.Hash) (common.Hash, error) {
+	return common.Hash{}, nil
+}
+
 func (b *LesApiBackend) GetLogs(ctx context.Context, hash common.Hash) ([][]*types.Log, error) {
 	if number := rawdb.ReadHeaderNumber(b.eth.chainDb, hash); number != nil {
 		return light.GetBlockLogs(ctx, b.eth.odr, hash, *number)
@@ -207,6 +217,10 @@ func (b *LesApiBackend) GetPoolTransaction(txHash common.Hash) *types.Transactio
 	return b.eth.txPool.GetTransaction(txHash)
 }
 
+func (b *LesApiBackend) GetPoolTransactionWithBlobs(txHash common.Hash) *types.Transaction {
+	return b.eth.txPool.GetTransaction(txHash)
+}
+
 func (b *LesApiBackend) GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
 	return light.GetTransaction(ctx, b.eth.odr, txHash)
 }
@@ -270,7 +284,19 @@ func (b *LesApiBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	return b.gpo.SuggestTipCap(ctx)
 }
 
-func (b *LesApiBackend) FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, err error) {
+func (b *LesApiBackend) SuggestDataGasFeeCap(ctx context.Context) (*big.Int, error) {
+	return b.gpo.SuggestDataGasFeeCap(ctx)
+}
+
+func (b *LesApiBackend) SuggestFees(ctx context.Context) (gasFeeCap, gasTipCap, dataGasFeeCap *big.Int, err error) {
+	return b.gpo.SuggestFees(ctx)
+}
+
+func (b *LesApiBackend) ForecastDataGasPrices(ctx context.Context, blocks int) (firstBlock *big.Int, blobsPerBlock float64, dataGasPrice []*big.Int, err error) {
+	return b.gpo.ForecastDataGasPrices(ctx, blocks)
+}
+
+func (b *LesApiBackend) FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, dataGasUsed []uint64, excessDataGas []*big.Int, dataGasPrice []*big.Int, err error) {
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
 
@@ -302,6 +328,10 @@ func (b *LesApiBackend) RPCTxFeeCap() float64 {
 	return b.eth.config.RPCTxFeeCap
 }
 
+func (b *LesApiBackend) RPCBeaconBlobs() bool {
+	return b.eth.config.RPCBeaconBlobs
+}
+
 func (b *LesApiBackend) BloomStatus() (uint64, uint64) {
 	if b.eth.bloomIndexer == nil {
 		return 0, 0
@@ -46,8 +46,11 @@ var (
 )
 
 func generatePreMergeChain(n int) (*core.Genesis, []*types.Header, []*types.Block) {
+	return generatePreMergeChainWithConfig(params.AllEthashProtocolChanges, n)
+}
+
+func generatePreMergeChainWithConfig(config *params.ChainConfig, n int) (*core.Genesis, []*types.Header, []*types.Block) {
 	db := rawdb.NewMemoryDatabase()
-	config := params.AllEthashProtocolChanges
 	genesis := &core.Genesis{
 		Config:    config,
 		Alloc:     core.GenesisAlloc{testAddr: {Balance: testBalance}},
@@ -159,6 +162,74 @@ func TestExecutePayloadV1(t *testing.T) {
 	}
 }
 
+// Tests that payloads are rejected if their excess data gas does not account for
+// the blobs they carry, even if the header alone could have reached it.
+func TestExecutePayloadV1ExcessDataGas(t *testing.T) {
+	config := *params.TestShardingChainConfig
+	genesis, headers, blocks := generatePreMergeChainWithConfig(&config, 10)
+	n, lesService := startLesService(t, genesis, headers[:9])
+	lesService.Merger().ReachTTD()
+	defer n.Close()
+
+	api := NewConsensusAPI(lesService)
+	fcState := beacon.ForkchoiceStateV1{
+		HeadBlockHash:      blocks[8].Hash(),
+		SafeBlockHash:      common.Hash{},
+		FinalizedBlockHash: common.Hash{},
+	}
+	if _, err := api.ForkchoiceUpdatedV1(fcState, nil); err != nil {
+		t.Fatalf("Failed to update head %v", err)
+	}
+	block := blocks[9]
+
+	execute := func(excessDataGas *big.Int) error {
+		fakeBlock := types.NewBlock(&types.Header{
+			ParentHash:    block.ParentHash(),
+			UncleHash:     crypto.Keccak256Hash(nil),
+			Coinbase:      block.Coinbase(),
+			Root:          block.Root(),
+			TxHash:        crypto.Keccak256Hash(nil),
+			ReceiptHash:   crypto.Keccak256Hash(nil),
+			Bloom:         block.Bloom(),
+			Difficulty:    big.NewInt(0),
+			Number:        block.Number(),
+			GasLimit:      block.GasLimit(),
+			GasUsed:       block.GasUsed(),
+			Time:          block.Time(),
+			Extra:         block.Extra(),
+			BaseFee:       block.BaseFee(),
+			ExcessDataGas: excessDataGas,
+		}, nil, nil, nil, trie.NewStackTrie(nil))
+
+		_, err := api.ExecutePayloadV1(beacon.ExecutableDataV1{
+			ParentHash:    fakeBlock.ParentHash(),
+			FeeRecipient:  fakeBlock.Coinbase(),
+			StateRoot:     fakeBlock.Root(),
+			ReceiptsRoot:  fakeBlock.ReceiptHash(),
+			LogsBloom:     fakeBlock.Bloom().Bytes(),
+			Random:        fakeBlock.MixDigest(),
+			Number:        fakeBlock.NumberU64(),
+			GasLimit:      fakeBlock.GasLimit(),
+			GasUsed:       fakeBlock.GasUsed(),
+			Timestamp:     fakeBlock.Time(),
+			ExtraData:     fakeBlock.Extra(),
+			BaseFeePerGas: fakeBlock.BaseFee(),
+			ExcessDataGas: fakeBlock.ExcessDataGas(),
+			BlockHash:     fakeBlock.Hash(),
+			Transactions:  encodeTransactions(fakeBlock.Transactions()),
+		})
+		return err
+	}
+	// An excess of one blob above the target is reachable from the parent, but
+	// not by a block without any blobs
+	if err := execute(big.NewInt(params.DataGasPerBlob)); err == nil {
+		t.Fatal("Payload with unaccounted excess data gas accepted")
+	}
+	if err := execute(new(big.Int)); err != nil {
+		t.Fatalf("Failed to execute payload %v", err)
+	}
+}
+
 func TestEth2DeepReorg(t *testing.T) {
 	// TODO (MariusVanDerWijden) TestEth2DeepReorg is currently broken, because it tries to reorg
 	// before the totalTerminalDifficulty threshold
@@ -93,6 +93,7 @@ const (
 	MsgProofsV2
 	MsgHelperTrieProofs
 	MsgTxStatus
+	MsgBlobCommitments
 )
 
 // Msg encodes a LES message that delivers reply data for a request
@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/light"
 	"github.com/ethereum/go-ethereum/log"
@@ -43,6 +44,9 @@ var (
 	errCHTHashMismatch     = errors.New("cht hash mismatch")
 	errCHTNumberMismatch   = errors.New("cht number mismatch")
 	errUselessNodes        = errors.New("useless nodes in merkle proof nodeset")
+	errBlobTxOrder         = errors.New("blob transactions out of order")
+	errNotBlobTx           = errors.New("not a blob transaction")
+	errBlobHashMismatch    = errors.New("blob versioned hash mismatch")
 )
 
 type LesOdrRequest interface {
@@ -68,6 +72,8 @@ func LesRequest(req light.OdrRequest) LesOdrRequest {
 		return (*BloomRequest)(r)
 	case *light.TxStatusRequest:
 		return (*TxStatusRequest)(r)
+	case *light.BlobCommitmentsRequest:
+		return (*BlobCommitmentsRequest)(r)
 	default:
 		return nil
 	}
@@ -513,6 +519,110 @@ func (r *TxStatusRequest) Validate(db ethdb.Database, msg *Msg) error {
 	return nil
 }
 
+// BlobTxCommitments contains the KZG commitments to the blobs of a single blob
+// transaction, identified by its index within the block.
+type BlobTxCommitments struct {
+	Index       uint64
+	Commitments []kzg.KZGCommitment
+}
+
+type BlobCommitmentsResps struct { // describes all responses, not just a single one
+	Blocks [][]BlobTxCommitments
+	Proofs light.NodeList
+}
+
+// BlobCommitmentsRequest is the ODR request type for blob commitments, see LesOdrRequest interface
+type BlobCommitmentsRequest light.BlobCommitmentsRequest
+
+// GetCost returns the cost of the given ODR request according to the serving
+// peer's cost table (implementation of LesOdrRequest)
+func (r *BlobCommitmentsRequest) GetCost(peer *serverPeer) uint64 {
+	return peer.getRequestCost(GetBlobCommitmentsMsg, 1)
+}
+
+// CanSend tells if a certain peer is suitable for serving the given request
+func (r *BlobCommitmentsRequest) CanSend(peer *serverPeer) bool {
+	if peer.version < lpv5 {
+		return false
+	}
+	return peer.HasBlock(r.Hash, r.Number, false)
+}
+
+// Request sends an ODR request to the LES network (implementation of LesOdrRequest)
+func (r *BlobCommitmentsRequest) Request(reqID uint64, peer *serverPeer) error {
+	peer.Log().Debug("Requesting blob commitments", "hash", r.Hash)
+	return peer.requestBlobCommitments(reqID, []common.Hash{r.Hash})
+}
+
+// Validate processes an ODR request reply message from the LES network
+// returns true and stores results in memory if the message was a valid reply
+// to the request (implementation of LesOdrRequest)
+func (r *BlobCommitmentsRequest) Validate(db ethdb.Database, msg *Msg) error {
+	log.Debug("Validating blob commitments", "hash", r.Hash)
+
+	// Ensure we have a correct message with a single block's commitments
+	if msg.MsgType != MsgBlobCommitments {
+		return errInvalidMessageType
+	}
+	resp := msg.Obj.(BlobCommitmentsResps)
+	if len(resp.Blocks) != 1 {
+		return errInvalidEntryCount
+	}
+	entries := resp.Blocks[0]
+
+	// Retrieve our stored header and validate the transactions against it
+	if r.Header == nil {
+		r.Header = rawdb.ReadHeader(db, r.Hash, r.Number)
+	}
+	if r.Header == nil {
+		return errHeaderUnavailable
+	}
+	var (
+		nodeSet     = resp.Proofs.NodeSet()
+		reads       = &readTraceDB{db: nodeSet}
+		txs         = make([]*types.Transaction, 0, len(entries))
+		commitments = make([][]kzg.KZGCommitment, 0, len(entries))
+	)
+	for i, entry := range entries {
+		if i > 0 && entry.Index <= entries[i-1].Index {
+			return errBlobTxOrder
+		}
+		key, _ := rlp.EncodeToBytes(entry.Index)
+		value, err := trie.VerifyProof(r.Header.TxHash, key, reads)
+		if err != nil {
+			return fmt.Errorf("merkle proof verification failed: %v", err)
+		}
+		if value == nil {
+			return errNotBlobTx
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(value); err != nil {
+			return err
+		}
+		if tx.Type() != types.BlobTxType {
+			return errNotBlobTx
+		}
+		// Ensure the commitments are the ones referenced by the transaction
+		hashes := tx.DataHashes()
+		if len(hashes) != len(entry.Commitments) {
+			return errBlobHashMismatch
+		}
+		for j, commitment := range entry.Commitments {
+			if commitment.ComputeVersionedHash() != hashes[j] {
+				return errBlobHashMismatch
+			}
+		}
+		txs = append(txs, tx)
+		commitments = append(commitments, entry.Commitments)
+	}
+	// check if all nodes have been read by VerifyProof
+	if len(reads.reads) != nodeSet.KeyCount() {
+		return errUselessNodes
+	}
+	r.Txs, r.Commitments = txs, commitments
+	return nil
+}
+
 // readTraceDB stores the keys of database reads. We use this to check that received node
 // sets contain only the trie nodes necessary to make proofs pass.
 type readTraceDB struct {
@@ -33,10 +33,14 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/light"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 )
 
 type odrTestFn func(ctx context.Context, db ethdb.Database, config *params.ChainConfig, bc *core.BlockChain, lc *light.LightChain, bhash common.Hash) []byte
@@ -436,3 +440,78 @@ func randomHash() common.Hash {
 	}
 	return hash
 }
+
+// Tests that blob commitments proven by the server are accepted by the client,
+// and that tampered responses are rejected.
+func TestBlobCommitmentsValidation(t *testing.T) {
+	signer := types.LatestSigner(params.TestShardingChainConfig)
+
+	plain, _ := types.SignTx(types.NewTransaction(0, userAddr1, big.NewInt(1), params.TxGas, big.NewInt(params.InitialBaseFee), nil), signer, bankKey)
+	commitments := []kzg.KZGCommitment{{0xc0}, {0xc0, 0x01}}
+	blobtx, _ := types.SignNewTx(bankKey, signer, &types.BlobTx{
+		ChainID:             params.TestShardingChainConfig.ChainID,
+		Nonce:               1,
+		GasTipCap:           common.Big0,
+		GasFeeCap:           big.NewInt(params.InitialBaseFee),
+		Gas:                 params.TxGas,
+		To:                  &userAddr1,
+		MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+		BlobVersionedHashes: []common.Hash{commitments[0].ComputeVersionedHash(), commitments[1].ComputeVersionedHash()},
+	})
+	txs := types.Transactions{plain, blobtx}
+	sidecars := []*types.BlobTxSidecar{{Commitments: commitments}}
+	header := &types.Header{Number: big.NewInt(1), TxHash: types.DeriveSha(txs, trie.NewStackTrie(nil))}
+
+	validate := func(entries []BlobTxCommitments, nodes *light.NodeSet) (*BlobCommitmentsRequest, error) {
+		req := &BlobCommitmentsRequest{Hash: header.Hash(), Number: 1, Header: header}
+		msg := &Msg{
+			MsgType: MsgBlobCommitments,
+			Obj:     BlobCommitmentsResps{Blocks: [][]BlobTxCommitments{entries}, Proofs: nodes.NodeList()},
+		}
+		return req, req.Validate(rawdb.NewMemoryDatabase(), msg)
+	}
+	prove := func() ([]BlobTxCommitments, *light.NodeSet) {
+		nodes := light.NewNodeSet()
+		entries, err := proveBlobCommitments(txs, sidecars, nodes)
+		if err != nil {
+			t.Fatalf("failed to prove blob commitments: %v", err)
+		}
+		return entries, nodes
+	}
+	// Check that a valid response is accepted
+	entries, nodes := prove()
+	req, err := validate(entries, nodes)
+	if err != nil {
+		t.Fatalf("valid response rejected: %v", err)
+	}
+	if len(req.Txs) != 1 || req.Txs[0].Hash() != blobtx.Hash() {
+		t.Fatalf("blob transactions mismatch: have %v, want [%x]", req.Txs, blobtx.Hash())
+	}
+	if !reflect.DeepEqual(req.Commitments, [][]kzg.KZGCommitment{commitments}) {
+		t.Fatalf("commitments mismatch: have %x, want %x", req.Commitments, commitments)
+	}
+	// Check that swapped commitments are rejected
+	entries, nodes = prove()
+	entries[0].Commitments = []kzg.KZGCommitment{commitments[1], commitments[0]}
+	if _, err := validate(entries, nodes); err != errBlobHashMismatch {
+		t.Fatalf("swapped commitments error mismatch: have %v, want %v", err, errBlobHashMismatch)
+	}
+	// Check that commitments attached to a non-blob transaction are rejected
+	entries, _ = prove()
+	entries[0].Index = 0
+
+	txTrie, _ := trie.New(common.Hash{}, trie.NewDatabase(memorydb.New()))
+	types.DeriveSha(txs, txTrie)
+	key, _ := rlp.EncodeToBytes(uint64(0))
+	nodes = light.NewNodeSet()
+	txTrie.Prove(key, 0, nodes)
+	if _, err := validate(entries, nodes); err != errNotBlobTx {
+		t.Fatalf("non-blob transaction error mismatch: have %v, want %v", err, errNotBlobTx)
+	}
+	// Check that superfluous proof nodes are rejected
+	entries, nodes = prove()
+	nodes.Put(crypto.Keccak256([]byte{0x01}), []byte{0x01})
+	if _, err := validate(entries, nodes); err != errUselessNodes {
+		t.Fatalf("useless nodes error mismatch: have %v, want %v", err, errUselessNodes)
+	}
+}
@@ -481,6 +481,13 @@ func (p *serverPeer) requestTxStatus(reqID uint64, txHashes []common.Hash) error
 	return p.sendRequest(GetTxStatusMsg, reqID, txHashes, len(txHashes))
 }
 
+// requestBlobCommitments fetches a batch of blob commitments, along with their
+// transaction inclusion proofs, of the blocks corresponding to the hashes specified.
+func (p *serverPeer) requestBlobCommitments(reqID uint64, hashes []common.Hash) error {
+	p.Log().Debug("Fetching batch of blob commitments", "count", len(hashes))
+	return p.sendRequest(GetBlobCommitmentsMsg, reqID, hashes, len(hashes))
+}
+
 // sendTxs creates a reply with a batch of transactions to be added to the remote transaction pool.
 func (p *serverPeer) sendTxs(reqID uint64, amount int, txs rlp.RawValue) error {
 	p.Log().Debug("Sending batch of transactions", "amount", amount, "size", len(txs))
@@ -666,6 +673,9 @@ func (p *serverPeer) Handshake(genesis common.Hash, forkid forkid.ID, forkFilter
 
 		if !p.onlyAnnounce {
 			for msgCode := range reqAvgTimeCost {
+				if msgCode >= ProtocolLengths[uint(p.version)] {
+					continue // message introduced in a later protocol version
+				}
 				if p.fcCosts[msgCode] == nil {
 					return errResp(ErrUselessPeer, "peer does not support message %d", msgCode)
 				}
@@ -924,6 +934,13 @@ func (p *clientPeer) replyTxStatus(reqID uint64, stats []light.TxStatus) *reply
 	return &reply{p.rw, TxStatusMsg, reqID, data}
 }
 
+// replyBlobCommitments creates a reply with a batch of blob commitments and their
+// transaction inclusion proofs, corresponding to the ones requested.
+func (p *clientPeer) replyBlobCommitments(reqID uint64, resp BlobCommitmentsResps) *reply {
+	data, _ := rlp.EncodeToBytes(resp)
+	return &reply{p.rw, BlobCommitmentsMsg, reqID, data}
+}
+
 // sendAnnounce announces the availability of a number of blocks through
 // a hash notification.
 func (p *clientPeer) sendAnnounce(request announceData) error {
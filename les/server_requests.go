@@ -19,11 +19,13 @@ package les
 import (
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/light"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
@@ -145,6 +147,16 @@ var Les3 = map[uint64]RequestType{
 		ServingTimeMeter: miscServingTimeTxStatusTimer,
 		Handle:           handleGetTxStatus,
 	},
+	GetBlobCommitmentsMsg: {
+		Name:             "blob commitments request",
+		MaxCount:         MaxBlobCommitmentsFetch,
+		InPacketsMeter:   miscInBlobPacketsMeter,
+		InTrafficMeter:   miscInBlobTrafficMeter,
+		OutPacketsMeter:  miscOutBlobPacketsMeter,
+		OutTrafficMeter:  miscOutBlobTrafficMeter,
+		ServingTimeMeter: miscServingTimeBlobTimer,
+		Handle:           handleGetBlobCommitments,
+	},
 }
 
 // handleGetBlockHeaders handles a block header request
@@ -447,6 +459,78 @@ func handleGetProofs(msg Decoder) (serveRequestFn, uint64, uint64, error) {
 	}, r.ReqID, uint64(len(r.Reqs)), nil
 }
 
+// handleGetBlobCommitments handles a blob commitments request
+func handleGetBlobCommitments(msg Decoder) (serveRequestFn, uint64, uint64, error) {
+	var r GetBlobCommitmentsPacket
+	if err := msg.Decode(&r); err != nil {
+		return nil, 0, 0, err
+	}
+	return func(backend serverBackend, p *clientPeer, waitOrStop func() bool) *reply {
+		var (
+			blocks [][]BlobTxCommitments
+			nodes  = light.NewNodeSet()
+		)
+		bc := backend.BlockChain()
+		for i, hash := range r.Hashes {
+			if i != 0 && !waitOrStop() {
+				return nil
+			}
+			if nodes.DataSize() >= softResponseLimit {
+				break
+			}
+			// Retrieve the requested block, stopping at the first unknown one to
+			// keep the results aligned with the requested hashes
+			block := bc.GetBlockByHash(hash)
+			if block == nil {
+				p.bumpInvalid()
+				break
+			}
+			commitments, err := proveBlobCommitments(block.Transactions(), bc.GetBlobSidecarsByHash(hash), nodes)
+			if err != nil {
+				p.Log().Debug("Failed to prove blob commitments", "number", block.Number(), "hash", hash, "err", err)
+				break
+			}
+			blocks = append(blocks, commitments)
+		}
+		return p.replyBlobCommitments(r.ReqID, BlobCommitmentsResps{Blocks: blocks, Proofs: nodes.NodeList()})
+	}, r.ReqID, uint64(len(r.Hashes)), nil
+}
+
+// proveBlobCommitments gathers the KZG commitments of the blob transactions in
+// a block from their sidecars, and adds the merkle proofs of the transactions
+// against the block's transaction trie into the given node set.
+func proveBlobCommitments(txs types.Transactions, sidecars []*types.BlobTxSidecar, nodes *light.NodeSet) ([]BlobTxCommitments, error) {
+	var commitments []BlobTxCommitments
+	for i, tx := range txs {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		if len(commitments) >= len(sidecars) {
+			return nil, fmt.Errorf("missing sidecar of blob transaction %d", i)
+		}
+		commitments = append(commitments, BlobTxCommitments{
+			Index:       uint64(i),
+			Commitments: sidecars[len(commitments)].Commitments,
+		})
+	}
+	if len(commitments) == 0 {
+		return nil, nil
+	}
+	txTrie, err := trie.New(common.Hash{}, trie.NewDatabase(memorydb.New()))
+	if err != nil {
+		return nil, err
+	}
+	types.DeriveSha(txs, txTrie)
+
+	for _, c := range commitments {
+		key, _ := rlp.EncodeToBytes(c.Index)
+		if err := txTrie.Prove(key, 0, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return commitments, nil
+}
+
 // handleGetHelperTrieProofs handles a helper trie proof request
 func handleGetHelperTrieProofs(msg Decoder) (serveRequestFn, uint64, uint64, error) {
 	var r GetHelperTrieProofsPacket
@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/ethdb"
 )
 
@@ -176,6 +177,20 @@ func (req *BloomRequest) StoreResult(db ethdb.Database) {
 	}
 }
 
+// BlobCommitmentsRequest is the ODR request type for retrieving the KZG commitments
+// of the blob transactions in a block. The transactions are proven against the
+// block's transaction trie, but the proofs cannot attest that none were left out.
+type BlobCommitmentsRequest struct {
+	Hash        common.Hash
+	Number      uint64
+	Header      *types.Header
+	Txs         []*types.Transaction
+	Commitments [][]kzg.KZGCommitment
+}
+
+// StoreResult stores the retrieved data in local database
+func (req *BlobCommitmentsRequest) StoreResult(db ethdb.Database) {}
+
 // TxStatus describes the status of a transaction
 type TxStatus struct {
 	Status core.TxStatus
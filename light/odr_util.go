@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -175,7 +176,7 @@ func GetBlockReceipts(ctx context.Context, odr OdrBackend, hash common.Hash, num
 		genesis := rawdb.ReadCanonicalHash(odr.Database(), 0)
 		config := rawdb.ReadChainConfig(odr.Database(), genesis)
 
-		if err := receipts.DeriveFields(config, block.Hash(), block.NumberU64(), block.Transactions()); err != nil {
+		if err := receipts.DeriveFields(config, block.Hash(), block.NumberU64(), block.Time(), block.Transactions()); err != nil {
 			return nil, err
 		}
 		rawdb.WriteReceipts(odr.Database(), hash, number, receipts)
@@ -183,6 +184,23 @@ func GetBlockReceipts(ctx context.Context, odr OdrBackend, hash common.Hash, num
 	return receipts, nil
 }
 
+// GetBlobCommitments retrieves the blob transactions of a block, along with the
+// KZG commitments to their blobs, from the network.
+func GetBlobCommitments(ctx context.Context, odr OdrBackend, hash common.Hash, number uint64) ([]*types.Transaction, [][]kzg.KZGCommitment, error) {
+	header, err := GetHeaderByNumber(ctx, odr, number)
+	if err != nil {
+		return nil, nil, errNoHeader
+	}
+	if header.Hash() != hash {
+		return nil, nil, errNonCanonicalHash
+	}
+	r := &BlobCommitmentsRequest{Hash: hash, Number: number, Header: header}
+	if err := odr.Retrieve(ctx, r); err != nil {
+		return nil, nil, err
+	}
+	return r.Txs, r.Commitments, nil
+}
+
 // GetBlockLogs retrieves the logs generated by the transactions included in a
 // block given by its hash.
 func GetBlockLogs(ctx context.Context, odr OdrBackend, hash common.Hash, number uint64) ([][]*types.Log, error) {
@@ -86,9 +86,11 @@ type environment struct {
 	ancestors mapset.Set     // ancestor set (used for checking uncle parent validity)
 	family    mapset.Set     // family set (used for checking uncle invalidity)
 	tcount    int            // tx count in cycle
+	blobs     int            // blob count in cycle
 	gasPool   *core.GasPool  // available gas used to pack transactions
 	coinbase  common.Address
 
+	parent   *types.Header
 	header   *types.Header
 	txs      []*types.Transaction
 	receipts []*types.Receipt
@@ -103,7 +105,9 @@ func (env *environment) copy() *environment {
 		ancestors: env.ancestors.Clone(),
 		family:    env.family.Clone(),
 		tcount:    env.tcount,
+		blobs:     env.blobs,
 		coinbase:  env.coinbase,
+		parent:    env.parent,
 		header:    types.CopyHeader(env.header),
 		receipts:  copyReceipts(env.receipts),
 	}
@@ -768,11 +772,12 @@ func (w *worker) makeEnv(parent *types.Block, header *types.Header, coinbase com
 
 	// Note the passed coinbase may be different with header.Coinbase.
 	env := &environment{
-		signer:    types.MakeSigner(w.chainConfig, header.Number),
+		signer:    types.MakeSigner(w.chainConfig, header.Number, header.Time),
 		state:     state,
 		coinbase:  coinbase,
 		ancestors: mapset.NewSet(),
 		family:    mapset.NewSet(),
+		parent:    parent.Header(),
 		header:    header,
 		uncles:    make(map[common.Hash]*types.Header),
 	}
@@ -846,6 +851,9 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
 	}
+	dataGasPrice := misc.GetDataGasPrice(w.chainConfig, env.parent.ExcessDataGas)
+	maxBlobs := w.chainConfig.ShardingParamsAt(env.header.Time).MaxBlobsPerBlock
+
 	var coalescedLogs []*types.Log
 
 	for {
@@ -892,6 +900,30 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 			txs.Pop()
 			continue
 		}
+		// Blob transactions need enough data gas left in the block, pay for it,
+		// and have their blobs included alongside the block.
+		blobs := len(tx.DataHashes())
+		if blobs > 0 {
+			if uint64(env.blobs+blobs) > maxBlobs {
+				log.Trace("Not enough data gas for blob transaction", "sender", from, "blobs", blobs, "have", env.blobs)
+				txs.Pop()
+				continue
+			}
+			if tx.MaxFeePerDataGas().Cmp(dataGasPrice) < 0 {
+				log.Trace("Skipping underpriced blob transaction", "sender", from, "maxFeePerDataGas", tx.MaxFeePerDataGas(), "dataGasPrice", dataGasPrice)
+				txs.Pop()
+				continue
+			}
+			if tx.BlobTxSidecar() == nil {
+				pooled := w.eth.TxPool().GetWithBlobs(tx.Hash())
+				if pooled == nil || pooled.BlobTxSidecar() == nil {
+					log.Trace("Skipping blob transaction without blobs", "sender", from, "hash", tx.Hash())
+					txs.Pop()
+					continue
+				}
+				tx = pooled
+			}
+		}
 		// Start executing the transaction
 		env.state.Prepare(tx.Hash(), env.tcount)
 
@@ -916,6 +948,10 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 			// Everything ok, collect the logs and shift in the next transaction from the same account
 			coalescedLogs = append(coalescedLogs, logs...)
 			env.tcount++
+			if blobs > 0 {
+				env.blobs += blobs
+				env.header.ExcessDataGas = misc.CalcExcessDataGas(w.chainConfig, env.parent, env.header.Time, env.blobs)
+			}
 			txs.Shift()
 
 		case errors.Is(err, core.ErrTxTypeNotSupported):
@@ -1013,6 +1049,10 @@ func (w *worker) prepareWork(genParams *generateParams) (*environment, error) {
 			header.GasLimit = core.CalcGasLimit(parentGasLimit, w.config.GasCeil)
 		}
 	}
+	// Track the excess data gas after the sharding fork
+	if w.chainConfig.IsSharding(header.Number, header.Time) {
+		header.ExcessDataGas = misc.CalcExcessDataGas(w.chainConfig, parent.Header(), header.Time, 0)
+	}
 	// Run the consensus preparation with the default or customized consensus engine.
 	if err := w.engine.Prepare(w.chain, header); err != nil {
 		log.Error("Failed to prepare header for sealing", "err", err)
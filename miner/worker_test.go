@@ -29,12 +29,14 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
@@ -76,6 +78,7 @@ var (
 func init() {
 	testTxPoolConfig = core.DefaultTxPoolConfig
 	testTxPoolConfig.Journal = ""
+	testTxPoolConfig.BlobStore = ""
 	ethashChainConfig = new(params.ChainConfig)
 	*ethashChainConfig = *params.TestChainConfig
 	cliqueChainConfig = new(params.ChainConfig)
@@ -667,3 +670,61 @@ func testGetSealingWork(t *testing.T, chainConfig *params.ChainConfig, engine co
 		}
 	}
 }
+
+func TestBlobTransactionPacking(t *testing.T) {
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	w, b := newTestWorker(t, params.TestShardingChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+	defer w.close()
+
+	w.skipSealHook = func(task *task) bool {
+		return true
+	}
+	// Add more blobs than fit into a single block, the excess should be left
+	// in the pool for a later block.
+	var commitment kzg.KZGCommitment
+	commitment[0] = 0xc0 // point at infinity, committing to the empty blob
+
+	signer := types.LatestSigner(params.TestShardingChainConfig)
+	for i := 0; i < 3; i++ {
+		sidecar := &types.BlobTxSidecar{
+			Blobs:       []kzg.Blob{{}, {}},
+			Commitments: []kzg.KZGCommitment{commitment, commitment},
+			Proofs:      []kzg.KZGProof{{0xc0}, {0xc0}},
+		}
+		tx := types.MustSignNewTx(testBankKey, signer, &types.BlobTx{
+			ChainID:             params.TestShardingChainConfig.ChainID,
+			Nonce:               uint64(i + 1),
+			GasTipCap:           big.NewInt(params.InitialBaseFee),
+			GasFeeCap:           big.NewInt(2 * params.InitialBaseFee),
+			Gas:                 params.TxGas,
+			To:                  &testUserAddress,
+			MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+			BlobVersionedHashes: []common.Hash{commitment.ComputeVersionedHash(), commitment.ComputeVersionedHash()},
+		})
+		if err := b.txPool.AddLocal(tx.WithBlobTxSidecar(sidecar)); err != nil {
+			t.Fatalf("failed to add blob transaction %d: %v", i, err)
+		}
+	}
+	block, err := w.getSealingBlock(b.chain.CurrentBlock().Hash(), uint64(time.Now().Unix()), testBankAddress, common.Hash{})
+	if err != nil {
+		t.Fatalf("failed to generate block: %v", err)
+	}
+	var blobs int
+	for _, tx := range block.Transactions() {
+		if len(tx.DataHashes()) == 0 {
+			continue
+		}
+		if tx.BlobTxSidecar() == nil {
+			t.Errorf("blob transaction %x packed without its blobs", tx.Hash())
+		}
+		blobs += len(tx.DataHashes())
+	}
+	if blobs != params.MaxBlobsPerBlock {
+		t.Errorf("blob count mismatch: have %d, want %d", blobs, params.MaxBlobsPerBlock)
+	}
+	if have, want := block.Header().ExcessDataGas, misc.CalcExcessDataGas(b.chain.Config(), b.chain.CurrentBlock().Header(), block.Time(), blobs); have == nil || have.Cmp(want) != 0 {
+		t.Errorf("excess data gas mismatch: have %v, want %v", have, want)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// jwtExpiryTimeout is the maximum allowed difference between the issuance time
+// of a token and the local time.
+const jwtExpiryTimeout = 60 * time.Second
+
+var (
+	errMissingToken     = errors.New("missing token")
+	errMalformedToken   = errors.New("malformed token")
+	errInvalidSignature = errors.New("signature invalid")
+	errMissingIssuedAt  = errors.New("missing issued-at")
+	errStaleToken       = errors.New("stale token")
+	errFutureToken      = errors.New("future token")
+)
+
+// jwtHandler guards an http handler with HS256 signed JSON web tokens.
+type jwtHandler struct {
+	secret []byte
+	next   http.Handler
+}
+
+// newJWTHandler creates a http.Handler which only passes on requests carrying
+// a fresh token signed with the given secret.
+func newJWTHandler(secret []byte, next http.Handler) http.Handler {
+	return &jwtHandler{
+		secret: secret,
+		next:   next,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
+	var token string
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if err := verifyJWT(handler.secret, token, time.Now()); err != nil {
+		http.Error(out, err.Error(), http.StatusForbidden)
+		return
+	}
+	handler.next.ServeHTTP(out, r)
+}
+
+// verifyJWT checks that a token is signed with the secret using HS256, and was
+// issued within jwtExpiryTimeout of now.
+func verifyJWT(secret []byte, token string, now time.Time) error {
+	if token == "" {
+		return errMissingToken
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errMalformedToken
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != "HS256" {
+		return fmt.Errorf("unsupported signing method %q", header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errMalformedToken
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+	var claims struct {
+		IssuedAt *float64 `json:"iat"`
+	}
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return err
+	}
+	if claims.IssuedAt == nil {
+		return errMissingIssuedAt
+	}
+	issued := time.Unix(int64(*claims.IssuedAt), 0)
+	if issued.Before(now.Add(-jwtExpiryTimeout)) {
+		return errStaleToken
+	}
+	if issued.After(now.Add(jwtExpiryTimeout)) {
+		return errFutureToken
+	}
+	return nil
+}
+
+// decodeJWTSegment decodes a base64url encoded JSON segment of a token.
+func decodeJWTSegment(segment string, v interface{}) error {
+	blob, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errMalformedToken
+	}
+	if err := json.Unmarshal(blob, v); err != nil {
+		return errMalformedToken
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+// makeJWT creates a token with the given header and claims, signed with secret.
+func makeJWT(secret []byte, header, claims string) string {
+	signed := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	var (
+		secret = []byte("0123456789abcdef0123456789abcdef")
+		now    = time.Unix(1650000000, 0)
+		header = `{"alg":"HS256","typ":"JWT"}`
+	)
+	tests := []struct {
+		token string
+		err   error
+	}{
+		{makeJWT(secret, header, `{"iat":1650000000}`), nil},
+		{makeJWT(secret, header, `{"iat":1649999950}`), nil},
+		{makeJWT(secret, header, `{"iat":1650000050}`), nil},
+		{makeJWT(secret, header, `{"iat":1649999900}`), errStaleToken},
+		{makeJWT(secret, header, `{"iat":1650000100}`), errFutureToken},
+		{makeJWT(secret, header, `{}`), errMissingIssuedAt},
+		{makeJWT([]byte("wrong secret"), header, `{"iat":1650000000}`), errInvalidSignature},
+		{makeJWT(secret, header, `{"iat":1650000000}`)[1:], errMalformedToken},
+		{makeJWT(secret, header, `not json`), errMalformedToken},
+		{"", errMissingToken},
+		{"a.b", errMalformedToken},
+	}
+	for i, tt := range tests {
+		if err := verifyJWT(secret, tt.token, now); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+	// Tokens not signed with HS256 must be rejected, even if the signature matches
+	if err := verifyJWT(secret, makeJWT(secret, `{"alg":"none"}`, `{"iat":1650000000}`), now); err == nil {
+		t.Errorf("token with unsupported signing method accepted")
+	}
+}
@@ -24,9 +24,11 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -591,6 +593,68 @@ func (test rpcPrefixTest) check(t *testing.T, node *Node) {
 	}
 }
 
+// authTestService is an API exposed on the authenticated endpoint.
+type authTestService struct{}
+
+func (authTestService) Ping() string { return "pong" }
+
+// Tests that the authenticated endpoint only serves the authenticated APIs, and
+// only to requests carrying a valid token.
+func TestAuthenticatedRPC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	node, err := New(&Config{
+		AuthAddr:         "127.0.0.1",
+		AuthVirtualHosts: []string{"localhost"},
+		JWTSecret:        filepath.Join(dir, "jwtsecret"),
+	})
+	if err != nil {
+		t.Fatalf("could not create node: %v", err)
+	}
+	defer node.Close()
+
+	node.RegisterAPIs([]rpc.API{
+		{Namespace: "auth", Service: authTestService{}, Authenticated: true},
+		{Namespace: "open", Service: authTestService{}, Public: true},
+	})
+	if err := node.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	// The secret was generated on startup, sign a token with it
+	secret, err := obtainJWTSecret(node.config.JWTSecret)
+	if err != nil {
+		t.Fatalf("could not load generated secret: %v", err)
+	}
+	url := "http://" + node.httpAuth.listenAddr()
+
+	token := makeJWT(secret, `{"alg":"HS256","typ":"JWT"}`, fmt.Sprintf(`{"iat":%d}`, time.Now().Unix()))
+	resp := rpcRequest(t, url, "Authorization", "Bearer "+token)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("authenticated request failed: %d %s", resp.StatusCode, body)
+	}
+	if !strings.Contains(string(body), `"auth"`) || strings.Contains(string(body), `"open"`) {
+		t.Fatalf("authenticated endpoint module mismatch: %s", body)
+	}
+	// Requests without a valid token must be rejected
+	resp = rpcRequest(t, url)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("unauthenticated request status mismatch: have %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+	stale := makeJWT(secret, `{"alg":"HS256","typ":"JWT"}`, fmt.Sprintf(`{"iat":%d}`, time.Now().Add(-time.Hour).Unix()))
+	resp = rpcRequest(t, url, "Authorization", "Bearer "+stale)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("stale token status mismatch: have %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
+
 func createNode(t *testing.T, httpPort, wsPort int) *Node {
 	conf := &Config{
 		HTTPHost: "127.0.0.1",
@@ -125,5 +125,12 @@ compile_fuzzer tests/fuzzers/snap  FuzzSRange fuzz_storage_range
 compile_fuzzer tests/fuzzers/snap  FuzzByteCodes fuzz_byte_codes
 compile_fuzzer tests/fuzzers/snap  FuzzTrieNodes fuzz_trie_nodes
 
+compile_fuzzer tests/fuzzers/blobs  FuzzWrapper fuzz_blob_wrapper
+compile_fuzzer tests/fuzzers/blobs  FuzzHash fuzz_blob_hash
+compile_fuzzer tests/fuzzers/blobs  FuzzCommitments fuzz_blob_commitments
+compile_fuzzer tests/fuzzers/blobs  FuzzPoint fuzz_kzg_point
+compile_fuzzer tests/fuzzers/blobs  FuzzBlob fuzz_blob
+compile_fuzzer tests/fuzzers/blobs  FuzzVerify fuzz_kzg_verify
+
 #TODO: move this to tests/fuzzers, if possible
 compile_fuzzer crypto/blake2b  Fuzz      fuzzBlake2b
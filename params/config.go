@@ -257,17 +257,21 @@ var (
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil}
+	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, new(EthashConfig), nil}
 
 	// AllCliqueProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Ethereum core developers into the Clique consensus.
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}}
+	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}}
 
-	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil}
-	TestRules       = TestChainConfig.Rules(new(big.Int), false)
+	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, new(EthashConfig), nil}
+	TestRules       = TestChainConfig.Rules(new(big.Int), false, 0)
+
+	// TestShardingChainConfig is TestChainConfig with the sharding fork active
+	// from genesis, for tests exercising blob transactions.
+	TestShardingChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, big.NewInt(0), nil, nil, nil, nil, new(EthashConfig), nil}
 )
 
 // TrustedCheckpoint represents a set of post-processed trie roots (CHT and
@@ -347,16 +351,134 @@ type ChainConfig struct {
 	LondonBlock         *big.Int `json:"londonBlock,omitempty"`         // London switch block (nil = no fork, 0 = already on london)
 	ArrowGlacierBlock   *big.Int `json:"arrowGlacierBlock,omitempty"`   // Eip-4345 (bomb delay) switch block (nil = no fork, 0 = already activated)
 	MergeForkBlock      *big.Int `json:"mergeForkBlock,omitempty"`      // EIP-3675 (TheMerge) switch block (nil = no fork, 0 = already in merge proceedings)
+	ShardingForkBlock   *big.Int `json:"shardingForkBlock,omitempty"`   // EIP-4844 (sharding) switch block (nil = no fork, 0 = already activated)
+
+	// ShardingForkTime schedules the sharding fork by block timestamp instead of
+	// block number, as needed post-merge (nil = no fork, 0 = already activated).
+	ShardingForkTime *uint64 `json:"shardingForkTime,omitempty"`
 
 	// TerminalTotalDifficulty is the amount of total difficulty reached by
 	// the network that triggers the consensus upgrade.
 	TerminalTotalDifficulty *big.Int `json:"terminalTotalDifficulty,omitempty"`
 
+	// Sharding overrides the blob parameters of the sharding fork, defaulting to
+	// the mainnet values if unset.
+	Sharding *ShardingConfig `json:"sharding,omitempty"`
+
+	// ShardingSchedule lists later changes of the blob counts of the sharding
+	// fork, each activated at a block timestamp. Updates are ordered by time.
+	ShardingSchedule []*ShardingUpdate `json:"shardingSchedule,omitempty"`
+
 	// Various consensus engines
 	Ethash *EthashConfig `json:"ethash,omitempty"`
 	Clique *CliqueConfig `json:"clique,omitempty"`
 }
 
+// ShardingConfig is the set of blob parameters of the sharding (EIP-4844) fork,
+// allowing devnets to experiment with different blob counts. Fields left zero
+// fall back to their defaults. The data gas consumed by a single blob is tied to
+// the size of a blob, so it is not configurable.
+type ShardingConfig struct {
+	MaxBlobsPerTx              uint64 `json:"maxBlobsPerTx,omitempty"`              // Maximum number of blobs a single transaction may carry
+	MaxBlobsPerBlock           uint64 `json:"maxBlobsPerBlock,omitempty"`           // Maximum number of blobs a single block may carry
+	TargetBlobsPerBlock        uint64 `json:"targetBlobsPerBlock,omitempty"`        // Number of blobs per block the data gas price targets
+	MinDataGasPrice            uint64 `json:"minDataGasPrice,omitempty"`            // Minimum price of a unit of data gas
+	DataGasPriceUpdateFraction uint64 `json:"dataGasPriceUpdateFraction,omitempty"` // Controls the maximum rate of change of the data gas price
+}
+
+// DefaultShardingConfig contains the default blob parameters of the sharding fork.
+var DefaultShardingConfig = &ShardingConfig{
+	MaxBlobsPerTx:              MaxBlobsPerBlock,
+	MaxBlobsPerBlock:           MaxBlobsPerBlock,
+	TargetBlobsPerBlock:        TargetDataGasPerBlock / DataGasPerBlob,
+	MinDataGasPrice:            MinDataGasPrice,
+	DataGasPriceUpdateFraction: DataGasPriceUpdateFraction,
+}
+
+// ShardingUpdate changes the blob counts of the sharding fork from a given block
+// timestamp on, e.g. to raise the number of blobs per block. Fields left zero
+// keep their previous values, the blobs per transaction following the blobs per
+// block unless set.
+type ShardingUpdate struct {
+	Time                uint64 `json:"time"`                          // Block timestamp from which on the update is active
+	MaxBlobsPerTx       uint64 `json:"maxBlobsPerTx,omitempty"`       // Maximum number of blobs a single transaction may carry
+	MaxBlobsPerBlock    uint64 `json:"maxBlobsPerBlock,omitempty"`    // Maximum number of blobs a single block may carry
+	TargetBlobsPerBlock uint64 `json:"targetBlobsPerBlock,omitempty"` // Number of blobs per block the data gas price targets
+}
+
+// TargetDataGasPerBlock returns the data gas consumption per block targeted by
+// the data gas price.
+func (c *ShardingConfig) TargetDataGasPerBlock() uint64 {
+	return c.TargetBlobsPerBlock * DataGasPerBlob
+}
+
+// MaxDataGasPerBlock returns the maximum data gas a single block may consume.
+func (c *ShardingConfig) MaxDataGasPerBlock() uint64 {
+	return c.MaxBlobsPerBlock * DataGasPerBlob
+}
+
+// check verifies that the blob parameters are consistent with each other.
+func (c *ShardingConfig) check() error {
+	switch {
+	case c.MaxBlobsPerBlock > MaxBlobsPerBlockLimit:
+		return fmt.Errorf("too many blobs per block: have %d, max %d", c.MaxBlobsPerBlock, MaxBlobsPerBlockLimit)
+	case c.MaxBlobsPerTx > c.MaxBlobsPerBlock:
+		return fmt.Errorf("blobs per transaction (%d) above blobs per block (%d)", c.MaxBlobsPerTx, c.MaxBlobsPerBlock)
+	case c.TargetBlobsPerBlock > c.MaxBlobsPerBlock:
+		return fmt.Errorf("target blobs per block (%d) above maximum (%d)", c.TargetBlobsPerBlock, c.MaxBlobsPerBlock)
+	}
+	return nil
+}
+
+// ShardingParams returns the blob parameters of the sharding fork, filling in
+// the defaults for anything not configured. It may be called on a nil config to
+// retrieve the defaults.
+func (c *ChainConfig) ShardingParams() *ShardingConfig {
+	params := *DefaultShardingConfig
+	if c == nil || c.Sharding == nil {
+		return &params
+	}
+	params.setBlobs(c.Sharding.MaxBlobsPerTx, c.Sharding.MaxBlobsPerBlock, c.Sharding.TargetBlobsPerBlock)
+	if c.Sharding.MinDataGasPrice != 0 {
+		params.MinDataGasPrice = c.Sharding.MinDataGasPrice
+	}
+	if c.Sharding.DataGasPriceUpdateFraction != 0 {
+		params.DataGasPriceUpdateFraction = c.Sharding.DataGasPriceUpdateFraction
+	}
+	return &params
+}
+
+// ShardingParamsAt returns the blob parameters of the sharding fork active at the
+// given block timestamp, applying the scheduled updates on top of ShardingParams.
+func (c *ChainConfig) ShardingParamsAt(time uint64) *ShardingConfig {
+	params := c.ShardingParams()
+	if c == nil {
+		return params
+	}
+	for _, update := range c.ShardingSchedule {
+		if update.Time > time {
+			break
+		}
+		params.setBlobs(update.MaxBlobsPerTx, update.MaxBlobsPerBlock, update.TargetBlobsPerBlock)
+	}
+	return params
+}
+
+// setBlobs overrides the blob counts that are set, the blobs per transaction
+// following the blobs per block unless set explicitly.
+func (c *ShardingConfig) setBlobs(maxPerTx, maxPerBlock, target uint64) {
+	if maxPerBlock != 0 {
+		c.MaxBlobsPerBlock = maxPerBlock
+		c.MaxBlobsPerTx = maxPerBlock
+	}
+	if maxPerTx != 0 {
+		c.MaxBlobsPerTx = maxPerTx
+	}
+	if target != 0 {
+		c.TargetBlobsPerBlock = target
+	}
+}
+
 // EthashConfig is the consensus engine configs for proof-of-work based sealing.
 type EthashConfig struct{}
 
@@ -387,7 +509,11 @@ func (c *ChainConfig) String() string {
 	default:
 		engine = "unknown"
 	}
-	return fmt.Sprintf("{ChainID: %v Homestead: %v DAO: %v DAOSupport: %v EIP150: %v EIP155: %v EIP158: %v Byzantium: %v Constantinople: %v Petersburg: %v Istanbul: %v, Muir Glacier: %v, Berlin: %v, London: %v, Arrow Glacier: %v, MergeFork: %v, Engine: %v}",
+	var shardingTime interface{} = "<nil>"
+	if c.ShardingForkTime != nil {
+		shardingTime = *c.ShardingForkTime
+	}
+	return fmt.Sprintf("{ChainID: %v Homestead: %v DAO: %v DAOSupport: %v EIP150: %v EIP155: %v EIP158: %v Byzantium: %v Constantinople: %v Petersburg: %v Istanbul: %v, Muir Glacier: %v, Berlin: %v, London: %v, Arrow Glacier: %v, MergeFork: %v, Sharding: %v, Sharding Time: %v, Engine: %v}",
 		c.ChainID,
 		c.HomesteadBlock,
 		c.DAOForkBlock,
@@ -404,6 +530,8 @@ func (c *ChainConfig) String() string {
 		c.LondonBlock,
 		c.ArrowGlacierBlock,
 		c.MergeForkBlock,
+		c.ShardingForkBlock,
+		shardingTime,
 		engine,
 	)
 }
@@ -475,6 +603,12 @@ func (c *ChainConfig) IsArrowGlacier(num *big.Int) bool {
 	return isForked(c.ArrowGlacierBlock, num)
 }
 
+// IsSharding returns whether the sharding (EIP-4844) fork is active at the given
+// block number or timestamp, depending on which of the two schedules it.
+func (c *ChainConfig) IsSharding(num *big.Int, time uint64) bool {
+	return isForked(c.ShardingForkBlock, num) || isTimestampForked(c.ShardingForkTime, time)
+}
+
 // IsTerminalPoWBlock returns whether the given block is the last block of PoW stage.
 func (c *ChainConfig) IsTerminalPoWBlock(parentTotalDiff *big.Int, totalDiff *big.Int) bool {
 	if c.TerminalTotalDifficulty == nil {
@@ -484,19 +618,27 @@ func (c *ChainConfig) IsTerminalPoWBlock(parentTotalDiff *big.Int, totalDiff *bi
 }
 
 // CheckCompatible checks whether scheduled fork transitions have been imported
-// with a mismatching chain configuration.
-func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64) *ConfigCompatError {
-	bhead := new(big.Int).SetUint64(height)
-
+// with a mismatching chain configuration. Forks scheduled by block are checked
+// against the given head number, forks scheduled by time against the head time.
+func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64, time uint64) *ConfigCompatError {
+	var (
+		bhead = new(big.Int).SetUint64(height)
+		btime = time
+	)
 	// Iterate checkCompatible to find the lowest conflict.
 	var lasterr *ConfigCompatError
 	for {
-		err := c.checkCompatible(newcfg, bhead)
-		if err == nil || (lasterr != nil && err.RewindTo == lasterr.RewindTo) {
+		err := c.checkCompatible(newcfg, bhead, btime)
+		if err == nil || (lasterr != nil && err.RewindTo == lasterr.RewindTo && err.RewindToTime == lasterr.RewindToTime) {
 			break
 		}
 		lasterr = err
-		bhead.SetUint64(err.RewindTo)
+
+		if err.isTimestamp() {
+			btime = err.RewindToTime
+		} else {
+			bhead.SetUint64(err.RewindTo)
+		}
 	}
 	return lasterr
 }
@@ -525,6 +667,7 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 		{name: "londonBlock", block: c.LondonBlock},
 		{name: "arrowGlacierBlock", block: c.ArrowGlacierBlock, optional: true},
 		{name: "mergeStartBlock", block: c.MergeForkBlock, optional: true},
+		{name: "shardingForkBlock", block: c.ShardingForkBlock, optional: true},
 	} {
 		if lastFork.name != "" {
 			// Next one must be higher number
@@ -544,10 +687,26 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	// The sharding fork may be scheduled by block number or timestamp, not both
+	if c.ShardingForkBlock != nil && c.ShardingForkTime != nil {
+		return fmt.Errorf("unsupported fork scheduling: shardingForkBlock enabled at %v, but shardingForkTime also enabled at %v",
+			c.ShardingForkBlock, *c.ShardingForkTime)
+	}
+	if err := c.ShardingParams().check(); err != nil {
+		return fmt.Errorf("invalid sharding config: %v", err)
+	}
+	for i, update := range c.ShardingSchedule {
+		if i > 0 && update.Time <= c.ShardingSchedule[i-1].Time {
+			return fmt.Errorf("unsupported sharding schedule ordering: update at %d follows update at %d", update.Time, c.ShardingSchedule[i-1].Time)
+		}
+		if err := c.ShardingParamsAt(update.Time).check(); err != nil {
+			return fmt.Errorf("invalid sharding schedule update at %d: %v", update.Time, err)
+		}
+	}
 	return nil
 }
 
-func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *ConfigCompatError {
+func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int, headTime uint64) *ConfigCompatError {
 	if isForkIncompatible(c.HomesteadBlock, newcfg.HomesteadBlock, head) {
 		return newCompatError("Homestead fork block", c.HomesteadBlock, newcfg.HomesteadBlock)
 	}
@@ -600,15 +759,59 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.MergeForkBlock, newcfg.MergeForkBlock, head) {
 		return newCompatError("Merge Start fork block", c.MergeForkBlock, newcfg.MergeForkBlock)
 	}
+	if isForkIncompatible(c.ShardingForkBlock, newcfg.ShardingForkBlock, head) {
+		return newCompatError("Sharding fork block", c.ShardingForkBlock, newcfg.ShardingForkBlock)
+	}
+	if isForkTimestampIncompatible(c.ShardingForkTime, newcfg.ShardingForkTime, headTime) {
+		return newTimestampCompatError("Sharding fork timestamp", c.ShardingForkTime, newcfg.ShardingForkTime)
+	}
+	if *c.ShardingParams() != *newcfg.ShardingParams() {
+		if isForked(c.ShardingForkBlock, head) {
+			return newCompatError("Sharding config", c.ShardingForkBlock, newcfg.ShardingForkBlock)
+		}
+		if isTimestampForked(c.ShardingForkTime, headTime) {
+			return newTimestampCompatError("Sharding config", c.ShardingForkTime, newcfg.ShardingForkTime)
+		}
+	}
+	if isForked(c.ShardingForkBlock, head) || isTimestampForked(c.ShardingForkTime, headTime) {
+		if time := shardingScheduleChange(c, newcfg, headTime); time != nil {
+			return newTimestampCompatError("Sharding schedule", time, time)
+		}
+	}
 	return nil
 }
 
+// shardingScheduleChange returns the timestamp of the first scheduled blob
+// parameter update up to head at which the two configs disagree, or nil if they
+// agree on the entire history.
+func shardingScheduleChange(c, newcfg *ChainConfig, head uint64) *uint64 {
+	var first *uint64
+	for _, schedule := range [][]*ShardingUpdate{c.ShardingSchedule, newcfg.ShardingSchedule} {
+		for _, update := range schedule {
+			time := update.Time
+			if time > head || (first != nil && *first <= time) {
+				break
+			}
+			if *c.ShardingParamsAt(time) != *newcfg.ShardingParamsAt(time) {
+				first = &time
+			}
+		}
+	}
+	return first
+}
+
 // isForkIncompatible returns true if a fork scheduled at s1 cannot be rescheduled to
 // block s2 because head is already past the fork.
 func isForkIncompatible(s1, s2, head *big.Int) bool {
 	return (isForked(s1, head) || isForked(s2, head)) && !configNumEqual(s1, s2)
 }
 
+// isForkTimestampIncompatible returns true if a fork scheduled at timestamp s1
+// cannot be rescheduled to timestamp s2 because head is already past the fork.
+func isForkTimestampIncompatible(s1, s2 *uint64, head uint64) bool {
+	return (isTimestampForked(s1, head) || isTimestampForked(s2, head)) && !configTimestampEqual(s1, s2)
+}
+
 // isForked returns whether a fork scheduled at block s is active at the given head block.
 func isForked(s, head *big.Int) bool {
 	if s == nil || head == nil {
@@ -617,6 +820,15 @@ func isForked(s, head *big.Int) bool {
 	return s.Cmp(head) <= 0
 }
 
+// isTimestampForked returns whether a fork scheduled at timestamp s is active at
+// the given head timestamp.
+func isTimestampForked(s *uint64, head uint64) bool {
+	if s == nil {
+		return false
+	}
+	return *s <= head
+}
+
 func configNumEqual(x, y *big.Int) bool {
 	if x == nil {
 		return y == nil
@@ -627,14 +839,29 @@ func configNumEqual(x, y *big.Int) bool {
 	return x.Cmp(y) == 0
 }
 
+func configTimestampEqual(x, y *uint64) bool {
+	if x == nil {
+		return y == nil
+	}
+	if y == nil {
+		return x == nil
+	}
+	return *x == *y
+}
+
 // ConfigCompatError is raised if the locally-stored blockchain is initialised with a
 // ChainConfig that would alter the past.
 type ConfigCompatError struct {
 	What string
-	// block numbers of the stored and new configurations
+	// block numbers of the stored and new configurations if block based forking
 	StoredConfig, NewConfig *big.Int
+	// timestamps of the stored and new configurations if time based forking
+	StoredTime, NewTime *uint64
 	// the block number to which the local chain must be rewound to correct the error
 	RewindTo uint64
+	// the timestamp to which the local chain must be rewound to correct the error,
+	// only set for time based forks
+	RewindToTime uint64
 }
 
 func newCompatError(what string, storedblock, newblock *big.Int) *ConfigCompatError {
@@ -647,17 +874,51 @@ func newCompatError(what string, storedblock, newblock *big.Int) *ConfigCompatEr
 	default:
 		rew = newblock
 	}
-	err := &ConfigCompatError{what, storedblock, newblock, 0}
+	err := &ConfigCompatError{What: what, StoredConfig: storedblock, NewConfig: newblock}
 	if rew != nil && rew.Sign() > 0 {
 		err.RewindTo = rew.Uint64() - 1
 	}
 	return err
 }
 
+func newTimestampCompatError(what string, storedtime, newtime *uint64) *ConfigCompatError {
+	var rew *uint64
+	switch {
+	case storedtime == nil:
+		rew = newtime
+	case newtime == nil || *storedtime < *newtime:
+		rew = storedtime
+	default:
+		rew = newtime
+	}
+	err := &ConfigCompatError{What: what, StoredTime: storedtime, NewTime: newtime}
+	if rew != nil && *rew > 0 {
+		err.RewindToTime = *rew - 1
+	}
+	return err
+}
+
+// isTimestamp returns whether the incompatibility is with a time based fork, in
+// which case the chain must be rewound to RewindToTime.
+func (err *ConfigCompatError) isTimestamp() bool {
+	return err.StoredTime != nil || err.NewTime != nil
+}
+
 func (err *ConfigCompatError) Error() string {
+	if err.isTimestamp() {
+		return fmt.Sprintf("mismatching %s in database (have timestamp %s, want timestamp %s, rewindto timestamp %d)", err.What, timestampString(err.StoredTime), timestampString(err.NewTime), err.RewindToTime)
+	}
 	return fmt.Sprintf("mismatching %s in database (have %d, want %d, rewindto %d)", err.What, err.StoredConfig, err.NewConfig, err.RewindTo)
 }
 
+// timestampString formats an optional fork timestamp for error messages.
+func timestampString(t *uint64) string {
+	if t == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%d", *t)
+}
+
 // Rules wraps ChainConfig and is merely syntactic sugar or can be used for functions
 // that do not have or require information about the block.
 //
@@ -668,11 +929,11 @@ type Rules struct {
 	IsHomestead, IsEIP150, IsEIP155, IsEIP158               bool
 	IsByzantium, IsConstantinople, IsPetersburg, IsIstanbul bool
 	IsBerlin, IsLondon                                      bool
-	IsMerge                                                 bool
+	IsMerge, IsSharding                                     bool
 }
 
 // Rules ensures c's ChainID is not nil.
-func (c *ChainConfig) Rules(num *big.Int, isMerge bool) Rules {
+func (c *ChainConfig) Rules(num *big.Int, isMerge bool, time uint64) Rules {
 	chainID := c.ChainID
 	if chainID == nil {
 		chainID = new(big.Int)
@@ -690,5 +951,6 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool) Rules {
 		IsBerlin:         c.IsBerlin(num),
 		IsLondon:         c.IsLondon(num),
 		IsMerge:          isMerge,
+		IsSharding:       c.IsSharding(num, time),
 	}
 }
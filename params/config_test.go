@@ -17,6 +17,7 @@
 package params
 
 import (
+	"encoding/json"
 	"math/big"
 	"reflect"
 	"testing"
@@ -24,9 +25,10 @@ import (
 
 func TestCheckCompatible(t *testing.T) {
 	type test struct {
-		stored, new *ChainConfig
-		head        uint64
-		wantErr     *ConfigCompatError
+		stored, new   *ChainConfig
+		head          uint64
+		headTimestamp uint64
+		wantErr       *ConfigCompatError
 	}
 	tests := []test{
 		{stored: AllEthashProtocolChanges, new: AllEthashProtocolChanges, head: 0, wantErr: nil},
@@ -87,12 +89,187 @@ func TestCheckCompatible(t *testing.T) {
 				RewindTo:     30,
 			},
 		},
+		{
+			stored:        &ChainConfig{ShardingForkTime: newUint64(10)},
+			new:           &ChainConfig{ShardingForkTime: newUint64(20)},
+			headTimestamp: 9,
+			wantErr:       nil,
+		},
+		{
+			stored:        &ChainConfig{ShardingForkTime: newUint64(10)},
+			new:           &ChainConfig{ShardingForkTime: newUint64(20)},
+			head:          100,
+			headTimestamp: 25,
+			wantErr: &ConfigCompatError{
+				What:         "Sharding fork timestamp",
+				StoredTime:   newUint64(10),
+				NewTime:      newUint64(20),
+				RewindToTime: 9,
+			},
+		},
+		{
+			stored:        &ChainConfig{ShardingForkTime: newUint64(10)},
+			new:           &ChainConfig{},
+			headTimestamp: 15,
+			wantErr: &ConfigCompatError{
+				What:         "Sharding fork timestamp",
+				StoredTime:   newUint64(10),
+				NewTime:      nil,
+				RewindToTime: 9,
+			},
+		},
 	}
 
 	for _, test := range tests {
-		err := test.stored.CheckCompatible(test.new, test.head)
+		err := test.stored.CheckCompatible(test.new, test.head, test.headTimestamp)
 		if !reflect.DeepEqual(err, test.wantErr) {
-			t.Errorf("error mismatch:\nstored: %v\nnew: %v\nhead: %v\nerr: %v\nwant: %v", test.stored, test.new, test.head, err, test.wantErr)
+			t.Errorf("error mismatch:\nstored: %v\nnew: %v\nhead: %v\nheadTimestamp: %v\nerr: %v\nwant: %v", test.stored, test.new, test.head, test.headTimestamp, err, test.wantErr)
+		}
+	}
+}
+
+func TestShardingFork(t *testing.T) {
+	var byBlock, byTime ChainConfig
+	if err := json.Unmarshal([]byte(`{"shardingForkBlock": 10}`), &byBlock); err != nil {
+		t.Fatalf("failed to parse block scheduled fork: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"shardingForkTime": 1000}`), &byTime); err != nil {
+		t.Fatalf("failed to parse time scheduled fork: %v", err)
+	}
+	tests := []struct {
+		config *ChainConfig
+		num    int64
+		time   uint64
+		want   bool
+	}{
+		{&byBlock, 9, 2000, false},
+		{&byBlock, 10, 0, true},
+		{&byTime, 100, 999, false},
+		{&byTime, 0, 1000, true},
+		{&ChainConfig{}, 100, 2000, false},
+	}
+	for i, test := range tests {
+		if have := test.config.IsSharding(big.NewInt(test.num), test.time); have != test.want {
+			t.Errorf("test %d: fork activation mismatch: have %v, want %v", i, have, test.want)
+		}
+	}
+	both := byTime
+	both.ShardingForkBlock = big.NewInt(10)
+	if err := both.CheckConfigForkOrder(); err == nil {
+		t.Errorf("sharding fork scheduled by both block and time accepted")
+	}
+}
+
+func TestShardingConfig(t *testing.T) {
+	// Unconfigured chains use the default blob parameters
+	if have := AllEthashProtocolChanges.ShardingParams(); *have != *DefaultShardingConfig {
+		t.Errorf("default sharding params mismatch: have %+v, want %+v", have, DefaultShardingConfig)
+	}
+	if have := (*ChainConfig)(nil).ShardingParams(); *have != *DefaultShardingConfig {
+		t.Errorf("nil config sharding params mismatch: have %+v, want %+v", have, DefaultShardingConfig)
+	}
+	// Configured parameters override the defaults, the blobs per transaction
+	// following the blobs per block unless set
+	var config ChainConfig
+	if err := json.Unmarshal([]byte(`{"sharding": {"maxBlobsPerBlock": 8, "targetBlobsPerBlock": 4}}`), &config); err != nil {
+		t.Fatalf("failed to parse sharding config: %v", err)
+	}
+	want := *DefaultShardingConfig
+	want.MaxBlobsPerTx, want.MaxBlobsPerBlock, want.TargetBlobsPerBlock = 8, 8, 4
+	if have := config.ShardingParams(); *have != want {
+		t.Errorf("configured sharding params mismatch: have %+v, want %+v", have, want)
+	}
+	if have, want := config.ShardingParams().MaxDataGasPerBlock(), uint64(8*DataGasPerBlob); have != want {
+		t.Errorf("max data gas mismatch: have %d, want %d", have, want)
+	}
+	if err := config.CheckConfigForkOrder(); err != nil {
+		t.Errorf("valid sharding config rejected: %v", err)
+	}
+	// Inconsistent parameters are rejected
+	for i, invalid := range []*ShardingConfig{
+		{MaxBlobsPerBlock: MaxBlobsPerBlockLimit + 1},
+		{MaxBlobsPerTx: MaxBlobsPerBlock + 1},
+		{MaxBlobsPerBlock: 1, TargetBlobsPerBlock: 2},
+	} {
+		if err := (&ChainConfig{Sharding: invalid}).CheckConfigForkOrder(); err == nil {
+			t.Errorf("test %d: invalid sharding config accepted: %+v", i, invalid)
 		}
 	}
+	// Parameters may only change before the fork
+	stored := &ChainConfig{ShardingForkBlock: big.NewInt(10)}
+	changed := &ChainConfig{ShardingForkBlock: big.NewInt(10), Sharding: &ShardingConfig{MaxBlobsPerBlock: 8}}
+	if err := stored.CheckCompatible(changed, 9, 0); err != nil {
+		t.Errorf("sharding config change before fork rejected: %v", err)
+	}
+	if err := stored.CheckCompatible(changed, 10, 0); err == nil || err.RewindTo != 9 {
+		t.Errorf("sharding config change after fork mismatch: have %v, want rewind to 9", err)
+	}
+	// Same for time scheduled forks
+	stored = &ChainConfig{ShardingForkTime: newUint64(1000)}
+	changed = &ChainConfig{ShardingForkTime: newUint64(1000), Sharding: &ShardingConfig{MaxBlobsPerBlock: 8}}
+	if err := stored.CheckCompatible(changed, 100, 999); err != nil {
+		t.Errorf("sharding config change before fork time rejected: %v", err)
+	}
+	if err := stored.CheckCompatible(changed, 100, 1000); err == nil || err.RewindToTime != 999 {
+		t.Errorf("sharding config change after fork time mismatch: have %v, want rewind to time 999", err)
+	}
 }
+
+func TestShardingSchedule(t *testing.T) {
+	var config ChainConfig
+	if err := json.Unmarshal([]byte(`{"sharding": {"maxBlobsPerBlock": 4, "targetBlobsPerBlock": 2}, "shardingSchedule": [{"time": 100, "maxBlobsPerBlock": 8, "targetBlobsPerBlock": 4}, {"time": 200, "maxBlobsPerTx": 2}]}`), &config); err != nil {
+		t.Fatalf("failed to parse sharding schedule: %v", err)
+	}
+	if err := config.CheckConfigForkOrder(); err != nil {
+		t.Errorf("valid sharding schedule rejected: %v", err)
+	}
+	// Updates apply from their timestamp on, on top of the earlier ones
+	for _, tt := range []struct {
+		time                 uint64
+		perTx, perBlock, tgt uint64
+	}{
+		{0, 4, 4, 2},
+		{99, 4, 4, 2},
+		{100, 8, 8, 4},
+		{199, 8, 8, 4},
+		{200, 2, 8, 4},
+	} {
+		have := config.ShardingParamsAt(tt.time)
+		if have.MaxBlobsPerTx != tt.perTx || have.MaxBlobsPerBlock != tt.perBlock || have.TargetBlobsPerBlock != tt.tgt {
+			t.Errorf("time %d: blob params mismatch: have %d/%d/%d, want %d/%d/%d", tt.time,
+				have.MaxBlobsPerTx, have.MaxBlobsPerBlock, have.TargetBlobsPerBlock, tt.perTx, tt.perBlock, tt.tgt)
+		}
+	}
+	if have := config.ShardingParams(); have.MaxBlobsPerBlock != 4 {
+		t.Errorf("fork blob params changed by schedule: have %d blobs per block, want 4", have.MaxBlobsPerBlock)
+	}
+	// Unordered or inconsistent schedules are rejected
+	for i, invalid := range [][]*ShardingUpdate{
+		{{Time: 200, MaxBlobsPerBlock: 8}, {Time: 100, MaxBlobsPerBlock: 16}},
+		{{Time: 100, MaxBlobsPerBlock: 8}, {Time: 100, MaxBlobsPerBlock: 16}},
+		{{Time: 100, MaxBlobsPerBlock: MaxBlobsPerBlockLimit + 1}},
+		{{Time: 100, MaxBlobsPerBlock: 1}},
+	} {
+		if err := (&ChainConfig{ShardingSchedule: invalid}).CheckConfigForkOrder(); err == nil {
+			t.Errorf("test %d: invalid sharding schedule accepted", i)
+		}
+	}
+	// Updates may only change while not yet active
+	stored := &ChainConfig{ShardingForkTime: newUint64(0), ShardingSchedule: []*ShardingUpdate{{Time: 100, MaxBlobsPerBlock: 8}}}
+	changed := &ChainConfig{ShardingForkTime: newUint64(0), ShardingSchedule: []*ShardingUpdate{{Time: 100, MaxBlobsPerBlock: 16}}}
+	if err := stored.CheckCompatible(changed, 10, 99); err != nil {
+		t.Errorf("sharding schedule change before update rejected: %v", err)
+	}
+	if err := stored.CheckCompatible(changed, 10, 100); err == nil || err.RewindToTime != 99 {
+		t.Errorf("sharding schedule change after update mismatch: have %v, want rewind to time 99", err)
+	}
+	added := &ChainConfig{ShardingForkTime: newUint64(0), ShardingSchedule: []*ShardingUpdate{{Time: 50, MaxBlobsPerBlock: 3}, {Time: 100, MaxBlobsPerBlock: 8}}}
+	if err := stored.CheckCompatible(added, 10, 100); err == nil || err.RewindToTime != 49 {
+		t.Errorf("sharding schedule insertion mismatch: have %v, want rewind to time 49", err)
+	}
+	if err := stored.CheckCompatible(stored, 10, 1000); err != nil {
+		t.Errorf("unchanged sharding schedule rejected: %v", err)
+	}
+}
+
+func newUint64(val uint64) *uint64 { return &val }
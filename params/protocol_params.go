@@ -123,6 +123,16 @@ const (
 	ElasticityMultiplier     = 2          // Bounds the maximum gas limit an EIP-1559 block may have.
 	InitialBaseFee           = 1000000000 // Initial base fee for EIP-1559 blocks.
 
+	// EIP-4844 data gas defaults, chains may override all but the per-blob data
+	// gas through ShardingConfig.
+	DataGasPerBlob             = 1 << 17 // Data gas consumed by a single EIP-4844 blob.
+	TargetDataGasPerBlock      = 1 << 18 // Target data gas consumption per block, twice the per-blob amount.
+	MaxDataGasPerBlock         = 1 << 19 // Maximum data gas a single block may consume.
+	MaxBlobsPerBlock           = MaxDataGasPerBlock / DataGasPerBlob
+	MinDataGasPrice            = 1       // Minimum price of a unit of data gas.
+	DataGasPriceUpdateFraction = 2225652 // Controls the maximum rate of change of the data gas price.
+	MaxBlobsPerBlockLimit      = 64      // Upper bound of configurable blobs per block, keeping blob transactions within network message limits.
+
 	MaxCodeSize = 24576 // Maximum bytecode to permit for a contract
 
 	// Precompiled contract gas prices
@@ -153,6 +163,8 @@ const (
 	Bls12381MapG1Gas          uint64 = 5500   // Gas price for BLS12-381 mapping field element to G1 operation
 	Bls12381MapG2Gas          uint64 = 110000 // Gas price for BLS12-381 mapping field element to G2 operation
 
+	PointEvaluationGas uint64 = 50000 // Gas price for the EIP-4844 point evaluation precompile
+
 	// The Refund Quotient is the cap on how much of the used gas can be refunded. Before EIP-3529,
 	// up to half the consumed gas could be refunded. Redefined as 1/5th in EIP-3529
 	RefundQuotient        uint64 = 2
@@ -516,6 +516,14 @@ func logDiff(original *SignTxRequest, new *SignTxResponse) bool {
 		modified = true
 		log.Info("Nonce changed by UI", "was", n0, "is", n1)
 	}
+	if a, b := original.Transaction.MaxFeePerDataGas, new.Transaction.MaxFeePerDataGas; intPtrModified(a, b) {
+		log.Info("maxFeePerDataGas changed by UI", "was", a, "is", b)
+		modified = true
+	}
+	if h0, h1 := original.Transaction.BlobVersionedHashes, new.Transaction.BlobVersionedHashes; !reflect.DeepEqual(h0, h1) {
+		log.Info("Blob versioned hashes changed by UI", "was", h0, "is", h1)
+		modified = true
+	}
 	return modified
 }
 
@@ -589,6 +597,13 @@ func (api *SignerAPI) SignTransaction(ctx context.Context, args apitypes.SendTxA
 	}
 	// Convert fields into a real transaction
 	var unsignedTx = result.Transaction.ToTransaction()
+
+	// The blobs might have been modified by the UI, verify them once more
+	if sidecar := unsignedTx.BlobTxSidecar(); sidecar != nil {
+		if err := sidecar.Verify(unsignedTx.DataHashes()); err != nil {
+			return nil, fmt.Errorf("blobs do not match the versioned hashes: %v", err)
+		}
+	}
 	// Get the password for the transaction
 	pw, err := api.lookupOrQueryPassword(acc.Address, "Account password",
 		fmt.Sprintf("Please enter the password for account %s", acc.Address.String()))
@@ -602,7 +617,8 @@ func (api *SignerAPI) SignTransaction(ctx context.Context, args apitypes.SendTxA
 		return nil, err
 	}
 
-	data, err := signedTx.MarshalBinary()
+	// Blob transactions carrying their blobs are returned wrapped together
+	data, err := signedTx.MarshalNetwork()
 	if err != nil {
 		return nil, err
 	}
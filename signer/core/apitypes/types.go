@@ -36,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 )
 
 var typedDataReferenceTypeRegexp = regexp.MustCompile(`^[A-Z](\w*)(\[\])?$`)
@@ -100,6 +101,15 @@ type SendTxArgs struct {
 	// For non-legacy transactions
 	AccessList *types.AccessList `json:"accessList,omitempty"`
 	ChainID    *hexutil.Big      `json:"chainId,omitempty"`
+
+	// For blob transactions
+	MaxFeePerDataGas    *hexutil.Big  `json:"maxFeePerDataGas,omitempty"`
+	BlobVersionedHashes []common.Hash `json:"blobVersionedHashes,omitempty"`
+
+	// Optional sidecar of blob transactions, verified against the versioned hashes
+	Blobs       []kzg.Blob          `json:"blobs,omitempty"`
+	Commitments []kzg.KZGCommitment `json:"commitments,omitempty"`
+	Proofs      []kzg.KZGProof      `json:"proofs,omitempty"`
 }
 
 func (args SendTxArgs) String() string {
@@ -128,6 +138,27 @@ func (args *SendTxArgs) ToTransaction() *types.Transaction {
 
 	var data types.TxData
 	switch {
+	case args.MaxFeePerDataGas != nil || args.BlobVersionedHashes != nil:
+		al := types.AccessList{}
+		if args.AccessList != nil {
+			al = *args.AccessList
+		}
+		data = &types.BlobTx{
+			To:                  to,
+			ChainID:             (*big.Int)(args.ChainID),
+			Nonce:               uint64(args.Nonce),
+			Gas:                 uint64(args.Gas),
+			GasFeeCap:           (*big.Int)(args.MaxFeePerGas),
+			GasTipCap:           (*big.Int)(args.MaxPriorityFeePerGas),
+			Value:               (*big.Int)(&args.Value),
+			Data:                input,
+			AccessList:          al,
+			MaxFeePerDataGas:    (*big.Int)(args.MaxFeePerDataGas),
+			BlobVersionedHashes: args.BlobVersionedHashes,
+		}
+		if sidecar := args.BlobTxSidecar(); sidecar != nil {
+			return types.NewTx(data).WithBlobTxSidecar(sidecar)
+		}
 	case args.MaxFeePerGas != nil:
 		al := types.AccessList{}
 		if args.AccessList != nil {
@@ -168,6 +199,19 @@ func (args *SendTxArgs) ToTransaction() *types.Transaction {
 	return types.NewTx(data)
 }
 
+// BlobTxSidecar returns the blobs, commitments and proofs of a blob transaction,
+// or nil if none were supplied.
+func (args *SendTxArgs) BlobTxSidecar() *types.BlobTxSidecar {
+	if args.Blobs == nil && args.Commitments == nil && args.Proofs == nil {
+		return nil
+	}
+	return &types.BlobTxSidecar{
+		Blobs:       args.Blobs,
+		Commitments: args.Commitments,
+		Proofs:      args.Proofs,
+	}
+}
+
 type SigFormat struct {
 	Mime        string
 	ByteVersion byte
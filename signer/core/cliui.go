@@ -119,6 +119,9 @@ func (ui *CommandlineUI) ApproveTx(request *SignTxRequest) (SignTxResponse, erro
 	if request.Transaction.MaxFeePerGas != nil {
 		fmt.Printf("maxFeePerGas:          %v wei\n", request.Transaction.MaxFeePerGas.ToInt())
 		fmt.Printf("maxPriorityFeePerGas:  %v wei\n", request.Transaction.MaxPriorityFeePerGas.ToInt())
+		if request.Transaction.MaxFeePerDataGas != nil {
+			fmt.Printf("maxFeePerDataGas:      %v wei\n", request.Transaction.MaxFeePerDataGas.ToInt())
+		}
 	} else {
 		fmt.Printf("gasprice: %v wei\n", request.Transaction.GasPrice.ToInt())
 	}
@@ -135,6 +138,15 @@ func (ui *CommandlineUI) ApproveTx(request *SignTxRequest) (SignTxResponse, erro
 			}
 		}
 	}
+	if hashes := request.Transaction.BlobVersionedHashes; hashes != nil {
+		fmt.Printf("Blob versioned hashes\n")
+		for i, hash := range hashes {
+			fmt.Printf(" %d. %v\n", i, hash)
+		}
+		if blobs := request.Transaction.Blobs; blobs != nil {
+			fmt.Printf("blobs:    %d attached\n", len(blobs))
+		}
+	}
 	if request.Transaction.Data != nil {
 		d := *request.Transaction.Data
 		if len(d) > 0 {
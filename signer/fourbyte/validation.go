@@ -45,6 +45,12 @@ func (db *Database) ValidateTransaction(selector *string, tx *apitypes.SendTxArg
 	if tx.Data != nil {
 		data = *tx.Data
 	}
+	// Blobs must match the versioned hashes being signed (show stopper)
+	if sidecar := tx.BlobTxSidecar(); sidecar != nil {
+		if err := sidecar.Verify(tx.BlobVersionedHashes); err != nil {
+			return nil, fmt.Errorf("blobs do not match the versioned hashes: %v", err)
+		}
+	}
 	// Contract creation doesn't validate call data, handle first
 	if tx.To == nil {
 		// Contract creation should contain sufficient data to deploy a contract. A
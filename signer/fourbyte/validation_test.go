@@ -22,6 +22,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
@@ -135,3 +137,38 @@ func TestTransactionValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestBlobTransactionValidation(t *testing.T) {
+	var (
+		db     = newEmpty()
+		feeCap = hexutil.Big(*big.NewInt(1))
+	)
+	sidecar, err := types.NewBlobTxSidecar([]kzg.Blob{{}})
+	if err != nil {
+		t.Fatalf("failed to create sidecar: %v", err)
+	}
+	blobArgs := func(hashes []common.Hash) *apitypes.SendTxArgs {
+		args := dummyTxArgs(txtestcase{from: "000000000000000000000000000000000000dead", to: "0x000000000000000000000000000000000000dEaD",
+			n: "0x01", g: "0x20", gp: "0x40", value: "0x01"})
+		args.GasPrice = nil
+		args.MaxFeePerGas, args.MaxPriorityFeePerGas = &feeCap, &feeCap
+		args.MaxFeePerDataGas = &feeCap
+		args.BlobVersionedHashes = hashes
+		args.Blobs, args.Commitments, args.Proofs = sidecar.Blobs, sidecar.Commitments, sidecar.Proofs
+		return args
+	}
+	// Blobs matching the versioned hashes are accepted
+	if _, err := db.ValidateTransaction(nil, blobArgs(sidecar.BlobHashes())); err != nil {
+		t.Errorf("matching sidecar rejected: %v", err)
+	}
+	if tx := blobArgs(sidecar.BlobHashes()).ToTransaction(); tx.Type() != types.BlobTxType || tx.BlobTxSidecar() == nil {
+		t.Errorf("expected blob transaction with sidecar, got type %d", tx.Type())
+	}
+	// Blobs not matching the versioned hashes are a show stopper
+	if _, err := db.ValidateTransaction(nil, blobArgs([]common.Hash{{0x01}})); err == nil {
+		t.Errorf("mismatching versioned hashes accepted")
+	}
+	if _, err := db.ValidateTransaction(nil, blobArgs(nil)); err == nil {
+		t.Errorf("missing versioned hashes accepted")
+	}
+}
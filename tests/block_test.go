@@ -17,7 +17,21 @@
 package tests
 
 import (
+	"encoding/json"
+	"math/big"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 func TestBlockchain(t *testing.T) {
@@ -58,3 +72,114 @@ func TestBlockchain(t *testing.T) {
 	// prior to Istanbul. However, they are all derived from GeneralStateTests,
 	// which run natively, so there's no reason to run them here.
 }
+
+// Tests that blockchain tests of the sharding fork, carrying blob transactions
+// and excess data gas, can be executed.
+func TestBlockchainSharding(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		config = Forks["Sharding"]
+		gspec  = &core.Genesis{
+			Config:        config,
+			GasLimit:      params.GenesisGasLimit,
+			Difficulty:    common.Big0,
+			Alloc:         core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee:       big.NewInt(params.InitialBaseFee),
+			ExcessDataGas: new(big.Int),
+		}
+		db      = rawdb.NewMemoryDatabase()
+		genesis = gspec.MustCommit(db)
+	)
+	blocks, _ := core.GenerateChain(config, genesis, beacon.New(ethash.NewFaker()), db, 2, func(i int, b *core.BlockGen) {
+		var hashes []common.Hash
+		for j := 0; j < i+3; j++ {
+			hashes = append(hashes, kzg.KZGCommitment{0xc0}.ComputeVersionedHash())
+		}
+		tx, err := types.SignNewTx(key, types.NewShardingSigner(config.ChainID), &types.BlobTx{
+			ChainID:             config.ChainID,
+			Nonce:               uint64(i),
+			GasTipCap:           common.Big1,
+			GasFeeCap:           big.NewInt(2 * params.InitialBaseFee),
+			Gas:                 params.TxGas,
+			To:                  &common.Address{0xaa},
+			Value:               big.NewInt(100),
+			MaxFeePerDataGas:    big.NewInt(params.MinDataGasPrice),
+			BlobVersionedHashes: hashes,
+		})
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		b.AddTx(tx)
+	})
+	if blocks[1].ExcessDataGas().Sign() == 0 {
+		t.Fatalf("test chain does not accumulate excess data gas")
+	}
+	newTest := func() *BlockTest {
+		test := &BlockTest{json: btJSON{
+			Genesis:    *newBtHeader(genesis.Header()),
+			Pre:        gspec.Alloc,
+			Post:       core.GenesisAlloc{common.Address{0xaa}: {Balance: big.NewInt(200)}},
+			BestBlock:  common.UnprefixedHash(blocks[1].Hash()),
+			Network:    "Sharding",
+			SealEngine: "NoProof",
+		}}
+		for _, block := range blocks {
+			enc, _ := rlp.EncodeToBytes(block)
+			test.json.Blocks = append(test.json.Blocks, btBlock{BlockHeader: newBtHeader(block.Header()), Rlp: hexutil.Encode(enc)})
+		}
+		// Round trip through JSON to exercise the fixture decoding
+		enc, err := json.Marshal(test.json)
+		if err != nil {
+			t.Fatalf("failed to encode test: %v", err)
+		}
+		test = new(BlockTest)
+		if err := json.Unmarshal(enc, test); err != nil {
+			t.Fatalf("failed to decode test: %v", err)
+		}
+		return test
+	}
+	if err := newTest().Run(false); err != nil {
+		t.Fatalf("valid test failed: %v", err)
+	}
+	// Blocks with an excess data gas not matching their blobs must be rejected
+	header := blocks[1].Header()
+	header.ExcessDataGas = new(big.Int).Add(header.ExcessDataGas, big.NewInt(params.DataGasPerBlob))
+	enc, _ := rlp.EncodeToBytes(blocks[1].WithSeal(header))
+
+	test := newTest()
+	test.json.Blocks = append(test.json.Blocks, btBlock{Rlp: hexutil.Encode(enc), ExpectException: "invalid excessDataGas"})
+	if err := test.Run(false); err != nil {
+		t.Fatalf("test with invalid block failed: %v", err)
+	}
+	// Mismatching excess data gas in the fixture headers must be detected
+	test = newTest()
+	test.json.Blocks[1].BlockHeader.ExcessDataGas = header.ExcessDataGas
+	if err := test.Run(false); err == nil {
+		t.Fatalf("excess data gas mismatch not detected")
+	}
+}
+
+// newBtHeader converts a header into its blockchain test representation.
+func newBtHeader(h *types.Header) *btHeader {
+	return &btHeader{
+		Bloom:            h.Bloom,
+		Coinbase:         h.Coinbase,
+		MixHash:          h.MixDigest,
+		Nonce:            h.Nonce,
+		Number:           h.Number,
+		Hash:             h.Hash(),
+		ParentHash:       h.ParentHash,
+		ReceiptTrie:      h.ReceiptHash,
+		StateRoot:        h.Root,
+		TransactionsTrie: h.TxHash,
+		UncleHash:        h.UncleHash,
+		ExtraData:        h.Extra,
+		Difficulty:       h.Difficulty,
+		GasLimit:         h.GasLimit,
+		GasUsed:          h.GasUsed,
+		Timestamp:        h.Time,
+		BaseFeePerGas:    h.BaseFee,
+		ExcessDataGas:    h.ExcessDataGas,
+	}
+}
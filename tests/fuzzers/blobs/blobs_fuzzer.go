@@ -0,0 +1,216 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobs
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// FuzzWrapper decodes the input as the network encoding of a transaction,
+// possibly a blob transaction wrapped together with its sidecar, and checks
+// that anything accepted encodes back into the exact same bytes.
+func FuzzWrapper(input []byte) int {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalNetwork(input); err != nil {
+		return 0
+	}
+	if sidecar := tx.BlobTxSidecar(); sidecar != nil {
+		hashes := tx.DataHashes()
+		if len(sidecar.Blobs) != len(hashes) || len(sidecar.Commitments) != len(hashes) || len(sidecar.Proofs) != len(hashes) {
+			panic(fmt.Sprintf("sidecar size mismatch: %d hashes, %d blobs, %d commitments, %d proofs",
+				len(hashes), len(sidecar.Blobs), len(sidecar.Commitments), len(sidecar.Proofs)))
+		}
+	}
+	output, err := tx.MarshalNetwork()
+	if err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(input, output) {
+		panic(fmt.Sprintf("decode-encode is not equal, \ninput : %x\noutput: %x", input, output))
+	}
+	return 1
+}
+
+// FuzzHash decodes the input as the network encoding of a transaction and checks
+// that its hash doesn't depend on the sidecar: it must match the hash of the
+// transaction stripped of its sidecar, and that of the canonical encoding
+// decoded anew.
+func FuzzHash(input []byte) int {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalNetwork(input); err != nil {
+		return 0
+	}
+	// Strip the sidecar before hashing, as copies inherit the cached hash
+	stripped := tx.WithoutBlobTxSidecar()
+	hash := tx.Hash()
+	if stripped.Hash() != hash {
+		panic(fmt.Sprintf("sidecar changes hash: wrapped %x, stripped %x", hash, stripped.Hash()))
+	}
+	minimal, err := tx.MarshalMinimal()
+	if err != nil {
+		panic(err)
+	}
+	dec := new(types.Transaction)
+	if err := dec.UnmarshalBinary(minimal); err != nil {
+		panic(fmt.Sprintf("failed to decode canonical encoding %x: %v", minimal, err))
+	}
+	if dec.Hash() != hash {
+		panic(fmt.Sprintf("hash mismatch: network %x, canonical %x", hash, dec.Hash()))
+	}
+	if tx.BlobTxSidecar() == nil {
+		return 0
+	}
+	return 1
+}
+
+// FuzzCommitments decodes the input as a list of KZG commitments, as carried by
+// blob transaction sidecars, and decodes every commitment into a curve point.
+func FuzzCommitments(input []byte) int {
+	var commitments []kzg.KZGCommitment
+	if err := rlp.DecodeBytes(input, &commitments); err != nil {
+		return 0
+	}
+	output, err := rlp.EncodeToBytes(commitments)
+	if err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(input, output) {
+		panic(fmt.Sprintf("decode-encode is not equal, \ninput : %x\noutput: %x", input, output))
+	}
+	for _, commitment := range commitments {
+		checkPoint(commitment)
+	}
+	return 1
+}
+
+// FuzzPoint decodes the input as a compressed G1 point through the commitment
+// and proof types, checking that accepted points are encoded canonically.
+func FuzzPoint(input []byte) int {
+	if len(input) != 48 {
+		return 0
+	}
+	var commitment kzg.KZGCommitment
+	copy(commitment[:], input)
+	if !checkPoint(commitment) {
+		return 0
+	}
+	if _, err := kzg.KZGProof(commitment).Point(); err != nil {
+		panic(fmt.Sprintf("proof rejected valid point %x: %v", input, err))
+	}
+	return 1
+}
+
+// checkPoint decodes the commitment into a curve point, and if it is accepted,
+// ensures it compresses back into the same bytes.
+func checkPoint(commitment kzg.KZGCommitment) bool {
+	p, err := commitment.Point()
+	if err != nil {
+		return false
+	}
+	if enc := bls12381.NewG1().ToCompressed(p); !bytes.Equal(enc, commitment[:]) {
+		panic(fmt.Sprintf("non-canonical point accepted, \ninput : %x\noutput: %x", commitment, enc))
+	}
+	return true
+}
+
+// FuzzBlob packs the input into a blob and unpacks it again, and also treats
+// the input as the blob contents, checking that any payload extracted from it
+// packs back into the same blob.
+func FuzzBlob(input []byte) int {
+	var blob kzg.Blob
+	if err := blob.EncodeData(input); err == nil {
+		data, err := blob.DecodeData()
+		if err != nil {
+			panic(fmt.Sprintf("failed to decode encoded blob: %v", err))
+		}
+		if !bytes.Equal(input, data) {
+			panic(fmt.Sprintf("encode-decode is not equal, \ninput : %x\noutput: %x", input, data))
+		}
+	}
+	blob = kzg.Blob{}
+	copy(blob[:], input)
+
+	data, err := blob.DecodeData()
+	if err != nil {
+		return 0
+	}
+	var output kzg.Blob
+	if err := output.EncodeData(data); err != nil {
+		panic(fmt.Sprintf("failed to encode decoded blob: %v", err))
+	}
+	if blob != output {
+		panic("decode-encode is not equal")
+	}
+	return 1
+}
+
+// FuzzVerify builds blobs, commitments and proofs of possibly mismatching counts
+// from the input, with possibly corrupted points and non-canonical field elements,
+// and verifies them both in a batch and one by one. Malformed inputs must be
+// rejected with an error, and both ways of verifying must agree.
+//
+// The first byte of the input holds the number of blobs, commitments and proofs
+// (two bits each), the rest fills the commitments, proofs and blobs in order.
+func FuzzVerify(input []byte) int {
+	if len(input) < 1 {
+		return 0
+	}
+	var (
+		blobs       = make([]kzg.Blob, input[0]&0x3)
+		commitments = make([]kzg.KZGCommitment, (input[0]>>2)&0x3)
+		proofs      = make([]kzg.KZGProof, (input[0]>>4)&0x3)
+		rest        = input[1:]
+	)
+	for i := range commitments {
+		rest = rest[copy(commitments[i][:], rest):]
+	}
+	for i := range proofs {
+		rest = rest[copy(proofs[i][:], rest):]
+	}
+	for i := range blobs {
+		rest = rest[copy(blobs[i][:], rest):]
+	}
+	err := kzg.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+		if err != kzg.ErrBatchLengthMismatch {
+			panic(fmt.Sprintf("mismatching lengths accepted: %d blobs, %d commitments, %d proofs", len(blobs), len(commitments), len(proofs)))
+		}
+		return 0
+	}
+	for i := range blobs {
+		if single := kzg.VerifyBlobKZGProof(&blobs[i], commitments[i], proofs[i]); err == nil && single != nil {
+			panic(fmt.Sprintf("batch accepted blob %d rejected on its own: %v", i, single))
+		}
+	}
+	// Reuse the leading blob bytes as evaluation point and claimed value
+	if len(blobs) > 0 {
+		var z, y [32]byte
+		copy(z[:], blobs[0][:32])
+		copy(y[:], blobs[0][32:64])
+		kzg.VerifyKZGProof(commitments[0], z, y, proofs[0])
+	}
+	if err != nil {
+		return 0
+	}
+	return 1
+}
@@ -129,6 +129,9 @@ type fuzzer struct {
 }
 
 func newFuzzer(input []byte) *fuzzer {
+	txconfig := core.DefaultTxPoolConfig
+	txconfig.BlobStore = "" // Don't litter the disk with blobs
+
 	return &fuzzer{
 		chain:     chain,
 		chainLen:  testChainLen,
@@ -139,7 +142,7 @@ func newFuzzer(input []byte) *fuzzer {
 		chtKeys:   chtKeys,
 		bloomKeys: bloomKeys,
 		nonce:     uint64(len(txHashes)),
-		pool:      core.NewTxPool(core.DefaultTxPoolConfig, params.TestChainConfig, chain),
+		pool:      core.NewTxPool(txconfig, params.TestChainConfig, chain),
 		input:     bytes.NewReader(input),
 	}
 }
@@ -34,6 +34,7 @@ func (b btHeader) MarshalJSON() ([]byte, error) {
 		GasUsed          math.HexOrDecimal64
 		Timestamp        math.HexOrDecimal64
 		BaseFeePerGas    *math.HexOrDecimal256
+		ExcessDataGas    *math.HexOrDecimal256
 	}
 	var enc btHeader
 	enc.Bloom = b.Bloom
@@ -53,6 +54,7 @@ func (b btHeader) MarshalJSON() ([]byte, error) {
 	enc.GasUsed = math.HexOrDecimal64(b.GasUsed)
 	enc.Timestamp = math.HexOrDecimal64(b.Timestamp)
 	enc.BaseFeePerGas = (*math.HexOrDecimal256)(b.BaseFeePerGas)
+	enc.ExcessDataGas = (*math.HexOrDecimal256)(b.ExcessDataGas)
 	return json.Marshal(&enc)
 }
 
@@ -76,6 +78,7 @@ func (b *btHeader) UnmarshalJSON(input []byte) error {
 		GasUsed          *math.HexOrDecimal64
 		Timestamp        *math.HexOrDecimal64
 		BaseFeePerGas    *math.HexOrDecimal256
+		ExcessDataGas    *math.HexOrDecimal256
 	}
 	var dec btHeader
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -132,5 +135,8 @@ func (b *btHeader) UnmarshalJSON(input []byte) error {
 	if dec.BaseFeePerGas != nil {
 		b.BaseFeePerGas = (*big.Int)(dec.BaseFeePerGas)
 	}
+	if dec.ExcessDataGas != nil {
+		b.ExcessDataGas = (*big.Int)(dec.ExcessDataGas)
+	}
 	return nil
 }
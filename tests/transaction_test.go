@@ -19,6 +19,10 @@ package tests
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -52,3 +56,45 @@ func TestTransaction(t *testing.T) {
 		}
 	})
 }
+
+// Tests that blob transactions, wrapped together with their sidecar, are only
+// accepted by the sharding fork.
+func TestTransactionSharding(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	cfg := params.MainnetChainConfig
+
+	commitment := kzg.KZGCommitment{0xc0}
+	tx, err := types.SignNewTx(key, types.NewShardingSigner(cfg.ChainID), &types.BlobTx{
+		ChainID:             cfg.ChainID,
+		GasTipCap:           common.Big1,
+		GasFeeCap:           common.Big1,
+		Gas:                 params.TxGas,
+		To:                  &common.Address{0xaa},
+		MaxFeePerDataGas:    common.Big1,
+		BlobVersionedHashes: []common.Hash{commitment.ComputeVersionedHash()},
+	})
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	tx = tx.WithBlobTxSidecar(&types.BlobTxSidecar{
+		Blobs:       []kzg.Blob{{}},
+		Commitments: []kzg.KZGCommitment{commitment},
+		Proofs:      []kzg.KZGProof{{0xc0}},
+	})
+	enc, err := tx.MarshalNetwork()
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %v", err)
+	}
+	test := &TransactionTest{
+		RLP:      enc,
+		London:   &ttFork{},
+		Sharding: &ttFork{Sender: common.UnprefixedAddress(crypto.PubkeyToAddress(key.PublicKey)), Hash: common.UnprefixedHash(tx.Hash())},
+	}
+	if err := test.Run(cfg); err != nil {
+		t.Fatalf("valid test failed: %v", err)
+	}
+	test.Sharding.Hash = common.UnprefixedHash{}
+	if err := test.Run(cfg); err == nil {
+		t.Fatalf("hash mismatch not detected")
+	}
+}
@@ -37,6 +37,11 @@ type TransactionTest struct {
 	EIP158         ttFork
 	Frontier       ttFork
 	Homestead      ttFork
+
+	// Forks introducing new transaction types are optional, as older fixtures
+	// predate them and are not expected to fail there.
+	London   *ttFork
+	Sharding *ttFork
 }
 
 type ttFork struct {
@@ -47,7 +52,13 @@ type ttFork struct {
 func (tt *TransactionTest) Run(config *params.ChainConfig) error {
 	validateTx := func(rlpData hexutil.Bytes, signer types.Signer, isHomestead bool, isIstanbul bool) (*common.Address, *common.Hash, error) {
 		tx := new(types.Transaction)
-		if err := rlp.DecodeBytes(rlpData, tx); err != nil {
+		if len(rlpData) > 0 && rlpData[0] <= 0x7f {
+			// Typed transactions are given as raw envelopes, blob transactions
+			// possibly wrapped together with their sidecar
+			if err := tx.UnmarshalNetwork(rlpData); err != nil {
+				return nil, nil, err
+			}
+		} else if err := rlp.DecodeBytes(rlpData, tx); err != nil {
 			return nil, nil, err
 		}
 		sender, err := types.Sender(signer, tx)
@@ -66,13 +77,14 @@ func (tt *TransactionTest) Run(config *params.ChainConfig) error {
 		return &sender, &h, nil
 	}
 
-	for _, testcase := range []struct {
+	type forkCase struct {
 		name        string
 		signer      types.Signer
 		fork        ttFork
 		isHomestead bool
 		isIstanbul  bool
-	}{
+	}
+	testcases := []forkCase{
 		{"Frontier", types.FrontierSigner{}, tt.Frontier, false, false},
 		{"Homestead", types.HomesteadSigner{}, tt.Homestead, true, false},
 		{"EIP150", types.HomesteadSigner{}, tt.EIP150, true, false},
@@ -80,7 +92,14 @@ func (tt *TransactionTest) Run(config *params.ChainConfig) error {
 		{"Byzantium", types.NewEIP155Signer(config.ChainID), tt.Byzantium, true, false},
 		{"Constantinople", types.NewEIP155Signer(config.ChainID), tt.Constantinople, true, false},
 		{"Istanbul", types.NewEIP155Signer(config.ChainID), tt.Istanbul, true, true},
-	} {
+	}
+	if tt.London != nil {
+		testcases = append(testcases, forkCase{"London", types.NewLondonSigner(config.ChainID), *tt.London, true, true})
+	}
+	if tt.Sharding != nil {
+		testcases = append(testcases, forkCase{"Sharding", types.NewShardingSigner(config.ChainID), *tt.Sharding, true, true})
+	}
+	for _, testcase := range testcases {
 		sender, txhash, err := validateTx(tt.RLP, testcase.signer, testcase.isHomestead, testcase.isIstanbul)
 
 		if testcase.fork.Sender == (common.UnprefixedAddress{}) {